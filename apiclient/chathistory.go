@@ -0,0 +1,39 @@
+package apiclient
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+type SearchChatHistoryOptions struct {
+	ProjectID string
+	ThreadID  string
+	Limit     int
+}
+
+// SearchChatHistory searches the messages and tool calls of the user's chat threads.
+// This handles the GET /api/chat-history/search API endpoint
+func (c *Client) SearchChatHistory(ctx context.Context, q string, opts SearchChatHistoryOptions) (types.ChatHistorySearchResultList, error) {
+	query := url.Values{"q": {q}}
+	if opts.ProjectID != "" {
+		query.Set("projectID", opts.ProjectID)
+	}
+	if opts.ThreadID != "" {
+		query.Set("threadID", opts.ThreadID)
+	}
+	if opts.Limit > 0 {
+		query.Set("limit", strconv.Itoa(opts.Limit))
+	}
+
+	_, resp, err := c.doRequest(ctx, http.MethodGet, "/chat-history/search?"+query.Encode(), nil)
+	if err != nil {
+		return types.ChatHistorySearchResultList{}, err
+	}
+	var result types.ChatHistorySearchResultList
+	_, err = toObject(resp, &result)
+	return result, err
+}
@@ -1,8 +1,11 @@
 package apiclient
 
 import (
+	"bytes"
 	"context"
+	"io"
 	"net/http"
+	"net/url"
 
 	"github.com/obot-platform/obot/apiclient/types"
 )
@@ -107,3 +110,36 @@ func (c *Client) DeleteProject(ctx context.Context, assistantID, projectID strin
 	_, _, err := c.doRequest(ctx, http.MethodDelete, url, nil)
 	return err
 }
+
+// ExportProject exports a project as a project archive
+// This handles the GET /api/assistants/{assistant_id}/projects/{project_id}/export API endpoint
+func (c *Client) ExportProject(ctx context.Context, assistantID, projectID string) ([]byte, error) {
+	url := "/assistants/" + assistantID + "/projects/" + projectID + "/export"
+	_, resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	return io.ReadAll(resp.Body)
+}
+
+// ImportProject creates a project from a project archive. The mcpServers map the MCP server placeholders of the
+// archive to MCP server IDs.
+// This handles the POST /api/assistants/{assistant_id}/projects/import API endpoint
+func (c *Client) ImportProject(ctx context.Context, assistantID string, archive []byte, mcpServers map[string]string) (*types.ProjectImportResult, error) {
+	query := url.Values{}
+	for placeholder, mcpID := range mcpServers {
+		query.Add("mcpServer", placeholder+"="+mcpID)
+	}
+	path := "/assistants/" + assistantID + "/projects/import"
+	if len(query) > 0 {
+		path += "?" + query.Encode()
+	}
+	_, resp, err := c.doRequest(ctx, http.MethodPost, path, bytes.NewReader(archive), "Content-Type", "application/zip")
+	if err != nil {
+		return nil, err
+	}
+	var result types.ProjectImportResult
+	_, err = toObject(resp, &result)
+	return &result, err
+}
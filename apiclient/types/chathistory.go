@@ -0,0 +1,19 @@
+package types
+
+type ChatHistorySearchResult struct {
+	AssistantID string `json:"assistantID"`
+	ProjectID   string `json:"projectID"`
+	ThreadID    string `json:"threadID"`
+	ThreadName  string `json:"threadName,omitempty"`
+	RunID       string `json:"runID"`
+	// Role is user, assistant or tool.
+	Role     string `json:"role"`
+	ToolName string `json:"toolName,omitempty"`
+	// Snippet is HTML-escaped text of the message around the matching terms, which are wrapped in <mark> elements.
+	Snippet   string `json:"snippet"`
+	CreatedAt Time   `json:"createdAt"`
+	// EventsURL replays the conversation starting at the matching run.
+	EventsURL string `json:"eventsURL"`
+}
+
+type ChatHistorySearchResultList List[ChatHistorySearchResult]
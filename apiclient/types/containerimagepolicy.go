@@ -0,0 +1,23 @@
+package types
+
+// ContainerImagePolicy restricts the images that containerized MCP servers can run.
+type ContainerImagePolicy struct {
+	ContainerImagePolicyManifest
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+type ContainerImagePolicyManifest struct {
+	// AllowedImages are the registries and repositories that images can be pulled from. An entry can be a registry
+	// (e.g. "ghcr.io"), a repository (e.g. "ghcr.io/obot-platform/mcp"), or a repository prefix ending in "/*"
+	// (e.g. "ghcr.io/obot-platform/*"). Images on Docker Hub can be referenced by their short names (e.g. "nginx").
+	// If empty, images from any registry are allowed.
+	AllowedImages []string `json:"allowedImages,omitempty"`
+	// RequireDigest requires images to be pinned by digest (e.g. "ghcr.io/org/image@sha256:...").
+	RequireDigest bool `json:"requireDigest,omitempty"`
+	// RequireSignature requires images to have a cosign signature that can be verified by one of SignaturePublicKeys.
+	// Signatures are fetched from the image's registry without credentials, so they must be publicly readable.
+	// Images referenced by tag are deployed by the digest that was verified.
+	RequireSignature bool `json:"requireSignature,omitempty"`
+	// SignaturePublicKeys are the PEM encoded public keys that are trusted to sign images.
+	SignaturePublicKeys []string `json:"signaturePublicKeys,omitempty"`
+}
@@ -0,0 +1,58 @@
+package types
+
+import "fmt"
+
+// LegalHold preserves the data of users, projects and MCP servers. While a hold is active, retention, audit log
+// cleanup and user deletion don't delete the threads, runs, files and audit logs it covers.
+type LegalHold struct {
+	Metadata          `json:",inline"`
+	LegalHoldManifest `json:",inline"`
+	// Released is true once the hold has been released. Released holds no longer preserve data, but are kept as a record.
+	Released bool `json:"released,omitempty"`
+	// History records who created, changed and released the hold.
+	History []LegalHoldEvent `json:"history,omitempty"`
+}
+
+type LegalHoldManifest struct {
+	DisplayName string `json:"displayName,omitempty"`
+	// Reason describes why the data is preserved, such as a matter or case number.
+	Reason string `json:"reason,omitempty"`
+	// UserIDs are the users whose threads and audit logs are preserved.
+	UserIDs []string `json:"userIDs,omitempty"`
+	// ProjectIDs are the projects whose threads are preserved.
+	ProjectIDs []string `json:"projectIDs,omitempty"`
+	// MCPServerIDs are the MCP servers whose audit logs are preserved.
+	MCPServerIDs []string `json:"mcpServerIDs,omitempty"`
+}
+
+func (m LegalHoldManifest) Validate() error {
+	if m.Reason == "" {
+		return fmt.Errorf("reason is required")
+	}
+	if len(m.UserIDs) == 0 && len(m.ProjectIDs) == 0 && len(m.MCPServerIDs) == 0 {
+		return fmt.Errorf("at least one user, project or MCP server is required")
+	}
+	return nil
+}
+
+type LegalHoldEventType string
+
+const (
+	LegalHoldEventCreated  LegalHoldEventType = "created"
+	LegalHoldEventUpdated  LegalHoldEventType = "updated"
+	LegalHoldEventReleased LegalHoldEventType = "released"
+)
+
+type LegalHoldEvent struct {
+	Type   LegalHoldEventType `json:"type"`
+	UserID string             `json:"userID"`
+	Time   Time               `json:"time"`
+	// Reason is given when the hold is released.
+	Reason string `json:"reason,omitempty"`
+}
+
+type LegalHoldRelease struct {
+	Reason string `json:"reason"`
+}
+
+type LegalHoldList List[LegalHold]
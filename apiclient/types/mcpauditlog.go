@@ -103,3 +103,42 @@ type MCPPromptReadStats struct {
 
 // MCPUsageStatsList represents a list of MCP usage statistics
 type MCPUsageStatsList List[MCPUsageStatItem]
+
+// MCPUsageTimeSeries represents MCP usage per interval, with a series per group
+type MCPUsageTimeSeries struct {
+	Interval  string           `json:"interval"`
+	GroupBy   string           `json:"groupBy,omitempty"`
+	TimeStart Time             `json:"timeStart"`
+	TimeEnd   Time             `json:"timeEnd"`
+	Series    []MCPUsageSeries `json:"series"`
+}
+
+// MCPUsageSeries represents the usage of a group over time. Only the fields of the grouping are set.
+type MCPUsageSeries struct {
+	MCPID                string          `json:"mcpID,omitempty"`
+	MCPServerDisplayName string          `json:"mcpServerDisplayName,omitempty"`
+	ToolName             string          `json:"toolName,omitempty"`
+	UserID               string          `json:"userID,omitempty"`
+	ClientName           string          `json:"clientName,omitempty"`
+	APIKey               string          `json:"apiKey,omitempty"`
+	Points               []MCPUsagePoint `json:"points"`
+}
+
+// MCPUsagePoint represents the usage of an interval. Processing time percentiles are estimated from a histogram.
+type MCPUsagePoint struct {
+	Time                Time                  `json:"time"`
+	CallCount           int64                 `json:"callCount"`
+	ErrorCount          int64                 `json:"errorCount"`
+	ErrorRate           float64               `json:"errorRate"`
+	AvgProcessingTimeMs float64               `json:"avgProcessingTimeMs"`
+	P50ProcessingTimeMs float64               `json:"p50ProcessingTimeMs"`
+	P95ProcessingTimeMs float64               `json:"p95ProcessingTimeMs"`
+	P99ProcessingTimeMs float64               `json:"p99ProcessingTimeMs"`
+	ResponseStatuses    []MCPUsageStatusCount `json:"responseStatuses,omitempty"`
+}
+
+// MCPUsageStatusCount represents the number of calls of an interval with a response status
+type MCPUsageStatusCount struct {
+	ResponseStatus int   `json:"responseStatus"`
+	Count          int64 `json:"count"`
+}
@@ -0,0 +1,55 @@
+package types
+
+import "fmt"
+
+// MCPAuditLogPolicy controls how long the audit logs of MCP servers are kept and how much of each call is captured.
+type MCPAuditLogPolicy struct {
+	Metadata                  `json:",inline"`
+	MCPAuditLogPolicyManifest `json:",inline"`
+}
+
+type MCPAuditLogPolicyManifest struct {
+	DisplayName string `json:"displayName,omitempty"`
+	// Resources are the MCP servers, catalog entries and catalogs the policy applies to. A selector with the ID "*"
+	// applies the policy to every server.
+	Resources []Resource `json:"resources,omitempty"`
+	// PowerUserWorkspaceIDs are the workspaces whose MCP servers the policy applies to.
+	PowerUserWorkspaceIDs []string `json:"powerUserWorkspaceIDs,omitempty"`
+	// RetentionDays is the number of days audit logs are kept. If zero, the server's default retention is used.
+	RetentionDays int `json:"retentionDays,omitempty"`
+	// DisableBodyCapture stops request and response bodies from being stored.
+	DisableBodyCapture bool `json:"disableBodyCapture,omitempty"`
+	// MaxBodyBytes truncates request and response bodies that are larger. If zero, bodies are stored in full.
+	MaxBodyBytes int `json:"maxBodyBytes,omitempty"`
+	// RedactHeaders are the names of request and response headers whose values are replaced before storing.
+	RedactHeaders []string `json:"redactHeaders,omitempty"`
+}
+
+func (m MCPAuditLogPolicyManifest) Validate() error {
+	if len(m.Resources) == 0 && len(m.PowerUserWorkspaceIDs) == 0 {
+		return fmt.Errorf("at least one resource or workspace is required")
+	}
+
+	for _, resource := range m.Resources {
+		if err := resource.Validate(); err != nil {
+			return fmt.Errorf("invalid resource: %v", err)
+		}
+	}
+
+	if m.RetentionDays < 0 {
+		return fmt.Errorf("retention days must not be negative")
+	}
+	if m.MaxBodyBytes < 0 {
+		return fmt.Errorf("max body bytes must not be negative")
+	}
+
+	for _, header := range m.RedactHeaders {
+		if header == "" {
+			return fmt.Errorf("header names must not be empty")
+		}
+	}
+
+	return nil
+}
+
+type MCPAuditLogPolicyList List[MCPAuditLogPolicy]
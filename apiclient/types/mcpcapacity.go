@@ -6,6 +6,7 @@ type CapacitySource string
 const (
 	CapacitySourceResourceQuota CapacitySource = "resourceQuota"
 	CapacitySourceDeployments   CapacitySource = "deployments"
+	CapacitySourceDocker        CapacitySource = "docker"
 )
 
 // MCPCapacityInfo represents MCP namespace capacity information
@@ -13,14 +14,14 @@ type MCPCapacityInfo struct {
 	// Source indicates where the capacity data comes from (graceful degradation)
 	Source CapacitySource `json:"source"`
 
-	// CPURequested is the total CPU requested by MCP deployments
+	// CPURequested is the total CPU requested by MCP deployments, or the total CPU limit of the Docker containers
 	CPURequested string `json:"cpuRequested,omitempty"`
-	// CPULimit is the CPU limit from ResourceQuota
+	// CPULimit is the CPU limit from ResourceQuota, or the CPU budget of the Docker backend
 	CPULimit string `json:"cpuLimit,omitempty"`
 
-	// MemoryRequested is the total memory requested by MCP deployments
+	// MemoryRequested is the total memory requested by MCP deployments, or the total memory limit of the Docker containers
 	MemoryRequested string `json:"memoryRequested,omitempty"`
-	// MemoryLimit is the memory limit from ResourceQuota
+	// MemoryLimit is the memory limit from ResourceQuota, or the memory budget of the Docker backend
 	MemoryLimit string `json:"memoryLimit,omitempty"`
 
 	// ActiveDeployments is the number of active MCP server deployments
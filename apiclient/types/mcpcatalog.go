@@ -11,6 +11,10 @@ type MCPCatalog struct {
 type MCPCatalogManifest struct {
 	DisplayName string   `json:"displayName"`
 	SourceURLs  []string `json:"sourceURLs"`
+	// ExposePackages includes package descriptors for the catalog's npx, uvx, and containerized entries in the
+	// registry API, so that registry clients can install them locally. Entries not allowed by the package or
+	// container image policies are never exposed. When it isn't set on an update, the current setting is kept.
+	ExposePackages *bool `json:"exposePackages,omitempty"`
 }
 
 type MCPCatalogList List[MCPCatalog]
@@ -0,0 +1,79 @@
+package types
+
+import (
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+type MCPEgressMode string
+
+const (
+	// MCPEgressModeAllow allows all outbound network access, other than to loopback and link-local addresses, where cloud
+	// metadata endpoints are served.
+	MCPEgressModeAllow MCPEgressMode = "allow"
+	// MCPEgressModeDeny only allows outbound network access to Obot, DNS and the hosts allowed by the policy.
+	MCPEgressModeDeny MCPEgressMode = "deny"
+)
+
+// MCPEgressPolicy restricts the outbound network access of an MCP server's deployment.
+type MCPEgressPolicy struct {
+	Mode  MCPEgressMode   `json:"mode"`
+	Allow []MCPEgressRule `json:"allow,omitempty"`
+}
+
+// MCPEgressRule allows access to a hostname or a CIDR range. Exactly one of Hostname and CIDR must be set.
+type MCPEgressRule struct {
+	// Hostname is an exact hostname, such as api.example.com.
+	Hostname string `json:"hostname,omitempty"`
+	// CIDR is an IPv4 or IPv6 range, such as 203.0.113.0/24.
+	CIDR string `json:"cidr,omitempty"`
+	// Ports are the TCP ports that are allowed. If empty, all ports are allowed.
+	Ports []int `json:"ports,omitempty"`
+}
+
+func (p MCPEgressPolicy) Validate() error {
+	switch p.Mode {
+	case MCPEgressModeAllow:
+		if len(p.Allow) > 0 {
+			return fmt.Errorf("allow rules can only be used with the %q mode", MCPEgressModeDeny)
+		}
+	case MCPEgressModeDeny:
+	default:
+		return fmt.Errorf("mode must be %q or %q", MCPEgressModeAllow, MCPEgressModeDeny)
+	}
+
+	for i, rule := range p.Allow {
+		if err := rule.Validate(); err != nil {
+			return fmt.Errorf("invalid allow rule %d: %v", i, err)
+		}
+	}
+
+	return nil
+}
+
+func (r MCPEgressRule) Validate() error {
+	switch {
+	case r.Hostname == "" && r.CIDR == "", r.Hostname != "" && r.CIDR != "":
+		return fmt.Errorf("exactly one of hostname and cidr must be set")
+	case r.Hostname != "":
+		if strings.ContainsAny(r.Hostname, ":/*@ ") {
+			return fmt.Errorf("hostname %q must be a plain hostname without a scheme, port, path or wildcard", r.Hostname)
+		}
+		if _, err := netip.ParseAddr(r.Hostname); err == nil {
+			return fmt.Errorf("use cidr instead of hostname for the IP address %s", r.Hostname)
+		}
+	default:
+		if _, err := netip.ParsePrefix(r.CIDR); err != nil {
+			return fmt.Errorf("invalid cidr %q: %v", r.CIDR, err)
+		}
+	}
+
+	for _, port := range r.Ports {
+		if port < 1 || port > 65535 {
+			return fmt.Errorf("port %d must be between 1 and 65535", port)
+		}
+	}
+
+	return nil
+}
@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -73,6 +74,13 @@ type CatalogComponentServer struct {
 	Manifest MCPServerCatalogEntryManifest `json:"manifest,omitempty"`
 	// ToolOverrides restrict the tools exposed by the component server
 	ToolOverrides []ToolOverride `json:"toolOverrides,omitempty"`
+	// PromptOverrides restrict the prompts exposed by the component server
+	PromptOverrides []PromptOverride `json:"promptOverrides,omitempty"`
+	// ResourceOverrides restrict the resources and resource templates exposed by the component server
+	ResourceOverrides []ResourceOverride `json:"resourceOverrides,omitempty"`
+	// Namespace, if set, prefixes the names of the prompts and the URIs of the resources exposed by the component
+	// server that aren't overridden, so that they don't conflict with those of other component servers
+	Namespace string `json:"namespace,omitempty"`
 }
 
 // ComponentID returns the ID of the component server.
@@ -98,6 +106,13 @@ type ComponentServer struct {
 	Manifest MCPServerManifest `json:"manifest,omitempty"`
 	// ToolOverrides restrict the tools exposed by the component server
 	ToolOverrides []ToolOverride `json:"toolOverrides,omitempty"`
+	// PromptOverrides restrict the prompts exposed by the component server
+	PromptOverrides []PromptOverride `json:"promptOverrides,omitempty"`
+	// ResourceOverrides restrict the resources and resource templates exposed by the component server
+	ResourceOverrides []ResourceOverride `json:"resourceOverrides,omitempty"`
+	// Namespace, if set, prefixes the names of the prompts and the URIs of the resources exposed by the component
+	// server that aren't overridden, so that they don't conflict with those of other component servers
+	Namespace string `json:"namespace,omitempty"`
 	// Disabled indicates whether the component server should be included in the composite server at runtime
 	Disabled bool `json:"disabled,omitempty"`
 }
@@ -147,9 +162,17 @@ type MCPServerCatalogEntryManifest struct {
 	CompositeConfig     *CompositeCatalogConfig     `json:"compositeConfig,omitempty"`
 
 	Env []MCPEnv `json:"env,omitempty"`
+
+	// EgressPolicy restricts the outbound network access of servers deployed from this entry.
+	// If it is not set, the platform's default egress mode is used.
+	EgressPolicy *MCPEgressPolicy `json:"egressPolicy,omitempty"`
+
+	// ToolOverrides restrict and rewrite the tools exposed by servers deployed from this entry.
+	// Composite servers set them on their component servers instead.
+	ToolOverrides []ToolOverride `json:"toolOverrides,omitempty"`
 }
 
-// ToolOverride defines how a single component tool is exposed by the composite server
+// ToolOverride defines how a single tool is exposed by a server, or a component tool by the composite server
 type ToolOverride struct {
 	// Name is the original tool name as returned by the component server
 	Name string `json:"name"`
@@ -168,6 +191,157 @@ type ToolOverride struct {
 
 	// Enabled indicates if the tool should be included in the tool allowlist.
 	Enabled bool `json:"enabled,omitempty"`
+
+	// Arguments pin, default, narrow or remove the arguments of the tool.
+	// They are applied by the MCP gateway to the published input schema and to the tool calls.
+	Arguments []ToolArgumentOverride `json:"arguments,omitempty"`
+}
+
+// PromptOverride defines how a single component prompt is exposed by the composite server
+type PromptOverride struct {
+	// Name is the original prompt name as returned by the component server
+	Name string `json:"name"`
+
+	// OverrideName is the prompt name exposed by the composite server.
+	// An empty string denotes that the prompt name, prefixed with the component's namespace if any, should be used.
+	OverrideName string `json:"overrideName,omitempty"`
+
+	// OverrideDescription is optional and will override the prompt description returned by the component server
+	OverrideDescription string `json:"overrideDescription,omitempty"`
+
+	// Enabled indicates if the prompt should be included in the prompt allowlist.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// ExposedName returns the name of the prompt exposed by the composite server for a component with the namespace.
+func (o PromptOverride) ExposedName(namespace string) string {
+	if o.OverrideName != "" {
+		return o.OverrideName
+	}
+	return NamespacedPromptName(namespace, o.Name)
+}
+
+// ResourceOverride defines how a single component resource or resource template is exposed by the composite server
+type ResourceOverride struct {
+	// URI is the original URI of the resource, or URI template of the resource template, as returned by the component
+	// server
+	URI string `json:"uri"`
+
+	// OverrideURI is the resource URI exposed by the composite server.
+	// An empty string denotes that the URI, prefixed with the component's namespace if any, should be used.
+	// Resource templates can't be given a different URI template.
+	OverrideURI string `json:"overrideURI,omitempty"`
+
+	// OverrideDescription is optional and will override the resource description returned by the component server
+	OverrideDescription string `json:"overrideDescription,omitempty"`
+
+	// Enabled indicates if the resource should be included in the resource allowlist.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
+// IsTemplate returns whether the override is for a resource template.
+func (o ResourceOverride) IsTemplate() bool {
+	return strings.Contains(o.URI, "{")
+}
+
+// ExposedURI returns the URI, or URI template, exposed by the composite server for a component with the namespace.
+func (o ResourceOverride) ExposedURI(namespace string) string {
+	if o.OverrideURI != "" {
+		return o.OverrideURI
+	}
+	return NamespacedResourceURI(namespace, o.URI)
+}
+
+// NamespacedPromptName returns the name of a component's prompt prefixed with the component's namespace.
+func NamespacedPromptName(namespace, name string) string {
+	if namespace == "" {
+		return name
+	}
+	return namespace + "_" + name
+}
+
+// NamespacedResourceURI returns the URI of a component's resource prefixed with the component's namespace.
+// The namespace becomes part of the URI's scheme, for example "runbooks+file:///restart.md".
+func NamespacedResourceURI(namespace, uri string) string {
+	if namespace == "" {
+		return uri
+	}
+	return namespace + "+" + uri
+}
+
+// ToolArgumentOverride defines how a single argument of a tool is exposed to clients
+type ToolArgumentOverride struct {
+	// Name is the name of the argument in the tool's input schema
+	Name string `json:"name"`
+
+	// Value, if set, is always sent as the argument's value.
+	// The argument is removed from the published input schema, and any value sent by the client is replaced.
+	Value json.RawMessage `json:"value,omitempty"`
+
+	// Default, if set, is sent as the argument's value when the client doesn't send one.
+	Default json.RawMessage `json:"default,omitempty"`
+
+	// Enum, if set, restricts the values that the client can send for the argument.
+	// Tool calls with other values are rejected.
+	Enum []json.RawMessage `json:"enum,omitempty"`
+
+	// Removed indicates that the argument is removed from the published input schema.
+	// Any value sent by the client is dropped.
+	Removed bool `json:"removed,omitempty"`
+}
+
+// Validate checks that the argument override sets valid JSON values and doesn't combine conflicting options.
+func (a ToolArgumentOverride) Validate() error {
+	if a.Name == "" {
+		return fmt.Errorf("argument name is required")
+	}
+	if len(a.Value) > 0 {
+		if !json.Valid(a.Value) {
+			return fmt.Errorf("value of argument %s is not valid JSON", a.Name)
+		}
+		if len(a.Default) > 0 || len(a.Enum) > 0 || a.Removed {
+			return fmt.Errorf("argument %s has a fixed value and can't also set a default, enum, or be removed", a.Name)
+		}
+	}
+	if a.Removed && (len(a.Default) > 0 || len(a.Enum) > 0) {
+		return fmt.Errorf("argument %s is removed and can't also set a default or enum", a.Name)
+	}
+	if len(a.Default) > 0 && !json.Valid(a.Default) {
+		return fmt.Errorf("default of argument %s is not valid JSON", a.Name)
+	}
+	for _, value := range a.Enum {
+		if !json.Valid(value) {
+			return fmt.Errorf("enum of argument %s has a value that is not valid JSON", a.Name)
+		}
+	}
+	if len(a.Default) > 0 && len(a.Enum) > 0 && !containsJSON(a.Enum, a.Default) {
+		return fmt.Errorf("default of argument %s is not in its enum", a.Name)
+	}
+	return nil
+}
+
+// Allows returns whether the argument's enum, if any, allows the value.
+func (a ToolArgumentOverride) Allows(value json.RawMessage) bool {
+	return len(a.Enum) == 0 || containsJSON(a.Enum, value)
+}
+
+// containsJSON returns whether values has a value that is equal to value, ignoring formatting and key order.
+func containsJSON(values []json.RawMessage, value json.RawMessage) bool {
+	var v any
+	if err := json.Unmarshal(value, &v); err != nil {
+		return false
+	}
+	normalized, _ := json.Marshal(v)
+	for _, candidate := range values {
+		var c any
+		if err := json.Unmarshal(candidate, &c); err != nil {
+			continue
+		}
+		if b, _ := json.Marshal(c); string(b) == string(normalized) {
+			return true
+		}
+	}
+	return false
 }
 
 type MCPHeader struct {
@@ -216,6 +390,10 @@ type MCPServerManifest struct {
 
 	Env []MCPEnv `json:"env,omitempty"`
 
+	// ToolOverrides restrict and rewrite the tools exposed by the server.
+	// Composite servers set them on their component servers instead.
+	ToolOverrides []ToolOverride `json:"toolOverrides,omitempty"`
+
 	// Legacy fields that are deprecated, used only for cleaning up old servers
 	Command string      `json:"command,omitempty"`
 	Args    []string    `json:"args,omitempty"`
@@ -223,6 +401,8 @@ type MCPServerManifest struct {
 	Headers []MCPHeader `json:"headers,omitempty"`
 
 	IdleShutdownIntervalHours int `json:"idleShutdownIntervalHours,omitempty"`
+	// IdleShutdownIntervalMinutes overrides IdleShutdownIntervalHours when it is set.
+	IdleShutdownIntervalMinutes int `json:"idleShutdownIntervalMinutes,omitempty"`
 }
 
 type MCPServer struct {
@@ -283,6 +463,48 @@ type MCPServer struct {
 
 	// CompositeName is the name of the composite server that this MCP server is a component of, if there is one.
 	CompositeName string `json:"compositeName,omitempty"`
+
+	// Health contains the results of the periodic health probes of this server's deployment.
+	Health *MCPServerHealth `json:"health,omitempty"`
+}
+
+type MCPServerHealthState string
+
+const (
+	MCPServerHealthStateHealthy    MCPServerHealthState = "healthy"
+	MCPServerHealthStateUnhealthy  MCPServerHealthState = "unhealthy"
+	MCPServerHealthStateNotRunning MCPServerHealthState = "notRunning"
+)
+
+type MCPServerHealthEventType string
+
+const (
+	MCPServerHealthEventProbeFailed   MCPServerHealthEventType = "ProbeFailed"
+	MCPServerHealthEventRecovered     MCPServerHealthEventType = "Recovered"
+	MCPServerHealthEventRestarted     MCPServerHealthEventType = "Restarted"
+	MCPServerHealthEventRestartFailed MCPServerHealthEventType = "RestartFailed"
+)
+
+type MCPServerHealth struct {
+	// State is the result of the most recent health probe.
+	State MCPServerHealthState `json:"state,omitempty"`
+	// LastTransitionTime is the last time the state changed.
+	LastTransitionTime *Time `json:"lastTransitionTime,omitempty"`
+	// ConsecutiveFailures is the number of failed probes since the server was last healthy or restarted.
+	ConsecutiveFailures int `json:"consecutiveFailures,omitempty"`
+	// AutoRestarts is the number of automatic restarts since the server was last healthy.
+	AutoRestarts int `json:"autoRestarts,omitempty"`
+	// LastAutoRestartTime is the last time the server was automatically restarted.
+	LastAutoRestartTime *Time `json:"lastAutoRestartTime,omitempty"`
+	// History contains the most recent health events, newest last. Health events are only recorded here, and aren't
+	// published as Kubernetes events.
+	History []MCPServerHealthEvent `json:"history,omitempty"`
+}
+
+type MCPServerHealthEvent struct {
+	Time    Time                     `json:"time"`
+	Type    MCPServerHealthEventType `json:"type"`
+	Message string                   `json:"message,omitempty"`
 }
 
 type DeploymentCondition struct {
@@ -394,6 +616,7 @@ func MapCatalogEntryToServer(catalogEntry MCPServerCatalogEntryManifest, userURL
 		ToolPreview:      catalogEntry.ToolPreview,
 		Runtime:          catalogEntry.Runtime,
 		Env:              catalogEntry.Env,
+		ToolOverrides:    catalogEntry.ToolOverrides,
 	}
 
 	// Handle runtime-specific mapping
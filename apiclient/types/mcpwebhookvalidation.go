@@ -1,10 +1,38 @@
 package types
 
 import (
+	"encoding/json"
 	"fmt"
+	"regexp"
 	"slices"
 )
 
+// MCPWebhookValidationType is the type of validation that a MCPWebhookValidation does.
+// The built-in types run in-process in Obot and don't need a webhook to be hosted.
+type MCPWebhookValidationType string
+
+const (
+	MCPWebhookValidationTypeWebhook           MCPWebhookValidationType = "webhook"
+	MCPWebhookValidationTypeArgumentSchema    MCPWebhookValidationType = "argumentSchema"
+	MCPWebhookValidationTypeArgumentValues    MCPWebhookValidationType = "argumentValues"
+	MCPWebhookValidationTypeResponseRedaction MCPWebhookValidationType = "responseRedaction"
+	MCPWebhookValidationTypeRateLimit         MCPWebhookValidationType = "rateLimit"
+)
+
+// MCPWebhookDecision is the decision of a validation on a MCP request or response.
+type MCPWebhookDecision string
+
+const (
+	MCPWebhookDecisionAccepted MCPWebhookDecision = "accepted"
+	MCPWebhookDecisionRejected MCPWebhookDecision = "rejected"
+	MCPWebhookDecisionRedacted MCPWebhookDecision = "redacted"
+)
+
+// IsBuiltin returns whether the validation runs in-process instead of calling a webhook.
+func (t MCPWebhookValidationType) IsBuiltin() bool {
+	return t != "" && t != MCPWebhookValidationTypeWebhook
+}
+
 type MCPWebhookValidation struct {
 	Metadata                     `json:",inline"`
 	MCPWebhookValidationManifest `json:",inline"`
@@ -18,11 +46,43 @@ type MCPWebhookValidationManifest struct {
 	Secret    string       `json:"secret,omitempty"`
 	Selectors MCPSelectors `json:"selectors,omitempty"`
 	Disabled  bool         `json:"disabled,omitempty"`
+	// ShadowMode records the decisions of the validation without enforcing them.
+	ShadowMode bool `json:"shadowMode,omitempty"`
+
+	// ValidationType is the type of the validation. It defaults to calling the webhook at URL.
+	ValidationType    MCPWebhookValidationType `json:"validationType,omitempty"`
+	ArgumentSchema    json.RawMessage          `json:"argumentSchema,omitempty"`
+	ArgumentValues    []MCPArgumentValueRule   `json:"argumentValues,omitempty"`
+	ResponseRedaction *MCPResponseRedaction    `json:"responseRedaction,omitempty"`
+	RateLimit         *MCPRateLimit            `json:"rateLimit,omitempty"`
+}
+
+// MCPArgumentValueRule restricts the values of a tool call argument. Allowed and Denied are regular expressions
+// that must match the whole value. A value is rejected if it matches a denied pattern, or if there are allowed patterns
+// and it matches none of them.
+type MCPArgumentValueRule struct {
+	Argument string   `json:"argument"`
+	Allowed  []string `json:"allowed,omitempty"`
+	Denied   []string `json:"denied,omitempty"`
+}
+
+// MCPResponseRedaction replaces the matches of the patterns in responses with "[REDACTED]".
+type MCPResponseRedaction struct {
+	Patterns []string `json:"patterns,omitempty"`
+	// SecretScanning adds built-in patterns for common secrets like API keys, tokens and private keys.
+	SecretScanning bool `json:"secretScanning,omitempty"`
+}
+
+// MCPRateLimit caps the number of calls per tool.
+type MCPRateLimit struct {
+	CallsPerMinute int `json:"callsPerMinute"`
+	// PerUser applies the cap to each user separately instead of to all users together.
+	PerUser bool `json:"perUser,omitempty"`
 }
 
 func (m *MCPWebhookValidationManifest) Validate() error {
-	if m.URL == "" {
-		return fmt.Errorf("webhook URL is required")
+	if err := m.validateType(); err != nil {
+		return err
 	}
 
 	for _, resource := range m.Resources {
@@ -44,8 +104,109 @@ func (m *MCPWebhookValidationManifest) Validate() error {
 	return nil
 }
 
+// RunsInGateway returns whether the validation is run by the MCP gateway instead of through a webhook validation server.
+// Webhooks in shadow mode are called by the gateway so that their decisions can be recorded instead of enforced.
+func (m *MCPWebhookValidationManifest) RunsInGateway() bool {
+	return m.ValidationType.IsBuiltin() || m.ShadowMode
+}
+
+func (m *MCPWebhookValidationManifest) validateType() error {
+	switch m.ValidationType {
+	case "", MCPWebhookValidationTypeWebhook:
+		if m.URL == "" {
+			return fmt.Errorf("webhook URL is required")
+		}
+		return nil
+	case MCPWebhookValidationTypeArgumentSchema:
+		if len(m.ArgumentSchema) == 0 {
+			return fmt.Errorf("argument schema is required")
+		}
+		if !json.Valid(m.ArgumentSchema) {
+			return fmt.Errorf("argument schema is not valid JSON")
+		}
+	case MCPWebhookValidationTypeArgumentValues:
+		if len(m.ArgumentValues) == 0 {
+			return fmt.Errorf("at least one argument value rule is required")
+		}
+		for _, rule := range m.ArgumentValues {
+			if rule.Argument == "" {
+				return fmt.Errorf("argument value rules must have an argument")
+			}
+			if len(rule.Allowed) == 0 && len(rule.Denied) == 0 {
+				return fmt.Errorf("argument value rule for %q must have allowed or denied values", rule.Argument)
+			}
+			if err := validatePatterns(append(slices.Clone(rule.Allowed), rule.Denied...)); err != nil {
+				return err
+			}
+		}
+	case MCPWebhookValidationTypeResponseRedaction:
+		if m.ResponseRedaction == nil || len(m.ResponseRedaction.Patterns) == 0 && !m.ResponseRedaction.SecretScanning {
+			return fmt.Errorf("response redaction requires patterns or secret scanning")
+		}
+		if err := validatePatterns(m.ResponseRedaction.Patterns); err != nil {
+			return err
+		}
+	case MCPWebhookValidationTypeRateLimit:
+		if m.RateLimit == nil || m.RateLimit.CallsPerMinute <= 0 {
+			return fmt.Errorf("rate limit requires a positive number of calls per minute")
+		}
+	default:
+		return fmt.Errorf("unknown validation type %q", m.ValidationType)
+	}
+
+	if m.URL != "" || m.Secret != "" {
+		return fmt.Errorf("url and secret can only be set for webhook validations")
+	}
+
+	return nil
+}
+
+func validatePatterns(patterns []string) error {
+	for _, pattern := range patterns {
+		if _, err := regexp.Compile(pattern); err != nil {
+			return fmt.Errorf("invalid pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
 type MCPWebhookValidationList List[MCPWebhookValidation]
 
+// MCPWebhookValidationTestRequest is a tools/call request to send through a validation without enforcing its decision.
+// Either the request or the ID of the MCP audit log to replay the request of is required.
+type MCPWebhookValidationTestRequest struct {
+	Request    json.RawMessage `json:"request,omitempty"`
+	AuditLogID uint            `json:"auditLogID,omitempty"`
+}
+
+// MCPWebhookValidationTestResult is the result of sending a request through a validation.
+type MCPWebhookValidationTestResult struct {
+	Decision  MCPWebhookDecision `json:"decision"`
+	Message   string             `json:"message,omitempty"`
+	LatencyMs int64              `json:"latencyMs"`
+	// StatusCode and Response are the HTTP status code and raw body of the webhook's response.
+	StatusCode int             `json:"statusCode,omitempty"`
+	Response   string          `json:"response,omitempty"`
+	Request    json.RawMessage `json:"request"`
+}
+
+// MCPWebhookShadowDecision is a decision of a validation in shadow mode that wasn't enforced.
+type MCPWebhookShadowDecision struct {
+	ID                string             `json:"id"`
+	Created           Time               `json:"created"`
+	ValidationName    string             `json:"validationName"`
+	MCPID             string             `json:"mcpID"`
+	UserID            string             `json:"userID"`
+	CallType          string             `json:"callType"`
+	CallIdentifier    string             `json:"callIdentifier,omitempty"`
+	Decision          MCPWebhookDecision `json:"decision"`
+	Message           string             `json:"message,omitempty"`
+	LatencyMs         int64              `json:"latencyMs"`
+	WebhookStatusCode int                `json:"webhookStatusCode,omitempty"`
+}
+
+type MCPWebhookShadowDecisionList List[MCPWebhookShadowDecision]
+
 type MCPSelectors []MCPSelector
 
 func (f MCPSelectors) Matches(method, identifier string) bool {
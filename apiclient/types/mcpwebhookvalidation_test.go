@@ -419,3 +419,88 @@ func TestMCPFilter_Matches(t *testing.T) {
 		})
 	}
 }
+
+func TestMCPWebhookValidationManifest_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		manifest MCPWebhookValidationManifest
+		wantErr  bool
+	}{
+		{
+			name:     "webhook requires url",
+			manifest: MCPWebhookValidationManifest{},
+			wantErr:  true,
+		},
+		{
+			name:     "webhook with url",
+			manifest: MCPWebhookValidationManifest{URL: "https://example.com"},
+		},
+		{
+			name:     "argument schema",
+			manifest: MCPWebhookValidationManifest{ValidationType: MCPWebhookValidationTypeArgumentSchema, ArgumentSchema: []byte(`{"type":"object"}`)},
+		},
+		{
+			name:     "argument schema must be valid json",
+			manifest: MCPWebhookValidationManifest{ValidationType: MCPWebhookValidationTypeArgumentSchema, ArgumentSchema: []byte(`{`)},
+			wantErr:  true,
+		},
+		{
+			name: "built-in types can't have a url",
+			manifest: MCPWebhookValidationManifest{
+				ValidationType: MCPWebhookValidationTypeArgumentSchema,
+				ArgumentSchema: []byte(`{"type":"object"}`),
+				URL:            "https://example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name: "argument values with invalid pattern",
+			manifest: MCPWebhookValidationManifest{
+				ValidationType: MCPWebhookValidationTypeArgumentValues,
+				ArgumentValues: []MCPArgumentValueRule{{Argument: "path", Denied: []string{"("}}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "argument values require allowed or denied",
+			manifest: MCPWebhookValidationManifest{
+				ValidationType: MCPWebhookValidationTypeArgumentValues,
+				ArgumentValues: []MCPArgumentValueRule{{Argument: "path"}},
+			},
+			wantErr: true,
+		},
+		{
+			name: "response redaction with secret scanning",
+			manifest: MCPWebhookValidationManifest{
+				ValidationType:    MCPWebhookValidationTypeResponseRedaction,
+				ResponseRedaction: &MCPResponseRedaction{SecretScanning: true},
+			},
+		},
+		{
+			name:     "response redaction requires config",
+			manifest: MCPWebhookValidationManifest{ValidationType: MCPWebhookValidationTypeResponseRedaction},
+			wantErr:  true,
+		},
+		{
+			name: "rate limit must be positive",
+			manifest: MCPWebhookValidationManifest{
+				ValidationType: MCPWebhookValidationTypeRateLimit,
+				RateLimit:      &MCPRateLimit{},
+			},
+			wantErr: true,
+		},
+		{
+			name:     "unknown type",
+			manifest: MCPWebhookValidationManifest{ValidationType: "unknown"},
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.manifest.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
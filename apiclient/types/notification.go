@@ -0,0 +1,146 @@
+package types
+
+import (
+	"fmt"
+	"net/mail"
+	"net/url"
+	"slices"
+)
+
+type NotificationChannelType string
+
+const (
+	// NotificationChannelTypeEmail sends notifications through an SMTP server.
+	NotificationChannelTypeEmail NotificationChannelType = "email"
+	// NotificationChannelTypeWebhook posts notifications to Slack or Teams compatible incoming webhooks.
+	NotificationChannelTypeWebhook NotificationChannelType = "webhook"
+	// NotificationChannelTypeHTTP posts a JSON payload, signed with the channel secret, to an arbitrary URL.
+	NotificationChannelTypeHTTP NotificationChannelType = "http"
+)
+
+type NotificationEvent string
+
+const (
+	NotificationEventSuccess NotificationEvent = "success"
+	NotificationEventFailure NotificationEvent = "failure"
+	NotificationEventWarning NotificationEvent = "warning"
+)
+
+func (e NotificationEvent) Validate() error {
+	switch e {
+	case NotificationEventSuccess, NotificationEventFailure, NotificationEventWarning:
+		return nil
+	default:
+		return fmt.Errorf("invalid notification event %q", e)
+	}
+}
+
+type NotificationChannel struct {
+	Metadata                    `json:",inline"`
+	NotificationChannelManifest `json:",inline"`
+	HasSecret                   bool `json:"hasSecret,omitempty"`
+}
+
+type NotificationChannelList List[NotificationChannel]
+
+type NotificationChannelManifest struct {
+	DisplayName string                  `json:"displayName,omitempty"`
+	ChannelType NotificationChannelType `json:"channelType"`
+	// URL is the destination for webhook and http channels.
+	URL string `json:"url,omitempty"`
+	// Email is the configuration for email channels.
+	Email *EmailNotificationConfig `json:"email,omitempty"`
+	// Secret is the SMTP password for email channels or the signing secret for http channels.
+	// It is never returned by the API.
+	Secret   string `json:"secret,omitempty"`
+	Disabled bool   `json:"disabled,omitempty"`
+}
+
+type EmailNotificationConfig struct {
+	Host     string   `json:"host"`
+	Port     int      `json:"port,omitempty"`
+	Username string   `json:"username,omitempty"`
+	From     string   `json:"from"`
+	To       []string `json:"to"`
+}
+
+func (m NotificationChannelManifest) Validate() error {
+	switch m.ChannelType {
+	case NotificationChannelTypeEmail:
+		if m.Email == nil {
+			return fmt.Errorf("email configuration is required for email channels")
+		}
+		if m.Email.Host == "" {
+			return fmt.Errorf("SMTP host is required")
+		}
+		if m.Email.Port < 0 || m.Email.Port > 65535 {
+			return fmt.Errorf("invalid SMTP port %d", m.Email.Port)
+		}
+		if _, err := mail.ParseAddress(m.Email.From); err != nil {
+			return fmt.Errorf("invalid from address %q: %w", m.Email.From, err)
+		}
+		if len(m.Email.To) == 0 {
+			return fmt.Errorf("at least one recipient is required")
+		}
+		for _, to := range m.Email.To {
+			if _, err := mail.ParseAddress(to); err != nil {
+				return fmt.Errorf("invalid recipient address %q: %w", to, err)
+			}
+		}
+	case NotificationChannelTypeWebhook, NotificationChannelTypeHTTP:
+		if m.URL == "" {
+			return fmt.Errorf("URL is required for %s channels", m.ChannelType)
+		}
+		u, err := url.Parse(m.URL)
+		if err != nil {
+			return fmt.Errorf("invalid URL: %w", err)
+		}
+		if u.Scheme != "http" && u.Scheme != "https" {
+			return fmt.Errorf("URL must use http or https")
+		}
+	default:
+		return fmt.Errorf("invalid notification channel type %q", m.ChannelType)
+	}
+
+	return nil
+}
+
+// NotificationSubscription subscribes a notification channel to the outcomes of a task.
+type NotificationSubscription struct {
+	ChannelID string              `json:"channelID"`
+	Events    []NotificationEvent `json:"events"`
+}
+
+func (s NotificationSubscription) Validate() error {
+	if s.ChannelID == "" {
+		return fmt.Errorf("channel ID is required")
+	}
+	if len(s.Events) == 0 {
+		return fmt.Errorf("at least one event is required for channel %s", s.ChannelID)
+	}
+	for _, e := range s.Events {
+		if err := e.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Matches returns true if the subscription should be notified for the given events.
+func (s NotificationSubscription) Matches(events ...NotificationEvent) bool {
+	for _, e := range events {
+		if slices.Contains(s.Events, e) {
+			return true
+		}
+	}
+	return false
+}
+
+// NotificationDelivery records the delivery of a notification to a channel.
+type NotificationDelivery struct {
+	ChannelID string            `json:"channelID"`
+	Event     NotificationEvent `json:"event"`
+	Attempts  int               `json:"attempts,omitempty"`
+	SentAt    *Time             `json:"sentAt,omitempty"`
+	Error     string            `json:"error,omitempty"`
+}
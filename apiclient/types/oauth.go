@@ -82,6 +82,11 @@ type OAuthClientManifest struct {
 	// SoftwareVersion is a version identifier string for the client software identified by "software_id".
 	// Optional.
 	SoftwareVersion string `json:"software_version,omitempty"`
+
+	// ServiceAccountID is the ID of the service account that the client_credentials grant issues tokens for.
+	// It can only be set by admins on static clients, and is required for clients that use the client_credentials grant.
+	// Optional.
+	ServiceAccountID string `json:"service_account_id,omitempty"`
 }
 
 type OAuthClient struct {
@@ -104,7 +109,7 @@ type OAuthClientList List[OAuthClient]
 
 type OAuthToken struct {
 	AccessToken  string `json:"access_token"`
-	RefreshToken string `json:"refresh_token"`
+	RefreshToken string `json:"refresh_token,omitempty"`
 	ExpiresIn    int    `json:"expires_in"`
 	TokenType    string `json:"token_type"`
 }
@@ -0,0 +1,29 @@
+package types
+
+// PackagePolicy restricts the npm and PyPI packages that npx and uvx MCP servers can run.
+type PackagePolicy struct {
+	PackagePolicyManifest
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+type PackagePolicyManifest struct {
+	// NPM is the policy for packages run by npx MCP servers.
+	NPM PackageRegistryPolicy `json:"npm,omitempty"`
+	// PyPI is the policy for packages run by uvx MCP servers.
+	PyPI PackageRegistryPolicy `json:"pypi,omitempty"`
+}
+
+type PackageRegistryPolicy struct {
+	// AllowedPackages are the names of the packages that can be run. A "*" matches any characters except "/", so
+	// "@modelcontextprotocol/*" allows every package in an npm scope. PyPI names are compared after normalization,
+	// so "mcp_server" and "MCP-Server" are the same package. If empty, any package is allowed.
+	AllowedPackages []string `json:"allowedPackages,omitempty"`
+	// RequireExactVersion requires packages to be pinned to an exact version, like "package@1.2.3" for npm
+	// or "package==1.2.3" for PyPI.
+	RequireExactVersion bool `json:"requireExactVersion,omitempty"`
+	// MirrorURL is the URL of the registry that packages are installed from instead of the public registry.
+	// It is set as NPM_CONFIG_REGISTRY for npx servers and UV_DEFAULT_INDEX for uvx servers.
+	// When a mirror or allowed packages are set, registries and indexes configured by a server's environment, like
+	// npm_config_registry, UV_INDEX or PIP_INDEX_URL, are removed.
+	MirrorURL string `json:"mirrorURL,omitempty"`
+}
@@ -0,0 +1,65 @@
+package types
+
+// ProjectArchiveVersion is the version of the project archive format written by this version of obot.
+const ProjectArchiveVersion = 1
+
+// ProjectArchive is the manifest of a project export archive. The archive is a zip file with the manifest at
+// project.json, knowledge files under knowledge/ and chat threads under threads/.
+type ProjectArchive struct {
+	Version    int             `json:"version"`
+	ExportedAt Time            `json:"exportedAt"`
+	Project    ProjectManifest `json:"project"`
+	// Env is the environment variables the project expects. Their values are never exported.
+	Env            []EnvVar                  `json:"env,omitempty"`
+	Tasks          []ProjectArchiveTask      `json:"tasks,omitempty"`
+	Memories       []Memory                  `json:"memories,omitempty"`
+	KnowledgeFiles []string                  `json:"knowledgeFiles,omitempty"`
+	MCPServers     []ProjectArchiveMCPServer `json:"mcpServers,omitempty"`
+	Threads        []string                  `json:"threads,omitempty"`
+}
+
+type ProjectArchiveTask struct {
+	// ID is the ID of the task in the exported project, so that shared tasks can be re-mapped on import.
+	ID       string           `json:"id"`
+	Manifest WorkflowManifest `json:"manifest"`
+}
+
+// ProjectArchiveMCPServer is a placeholder for an MCP server the project was connected to. Connections and their
+// credentials are specific to an obot instance, so on import each placeholder is mapped to an MCP server of the
+// importing instance, or left out.
+type ProjectArchiveMCPServer struct {
+	Placeholder string `json:"placeholder"`
+	Alias       string `json:"alias,omitempty"`
+	Name        string `json:"name,omitempty"`
+	// MCPID is the ID of the MCP server or MCP server instance in the exported project's obot instance.
+	MCPID string `json:"mcpID"`
+	// CatalogEntryID is the ID of the catalog entry the MCP server was created from, if there was one.
+	CatalogEntryID string `json:"catalogEntryID,omitempty"`
+}
+
+// ProjectArchiveThread is a chat thread of an exported project, stored at threads/<id>.json.
+type ProjectArchiveThread struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name,omitempty"`
+	Description string              `json:"description,omitempty"`
+	Runs        []ProjectArchiveRun `json:"runs,omitempty"`
+}
+
+// ProjectArchiveRun is a message of a chat thread and its response, with the run state needed to show it and to
+// continue the conversation.
+type ProjectArchiveRun struct {
+	Input     string `json:"input"`
+	Output    string `json:"output,omitempty"`
+	Error     string `json:"error,omitempty"`
+	CreatedAt Time   `json:"createdAt"`
+	Program   []byte `json:"program,omitempty"`
+	ChatState []byte `json:"chatState,omitempty"`
+	CallFrame []byte `json:"callFrame,omitempty"`
+}
+
+type ProjectImportResult struct {
+	Project Project `json:"project"`
+	// UnmappedMCPServers are the MCP server placeholders of the archive that were not mapped to an MCP server, and so
+	// were not added to the project.
+	UnmappedMCPServers []ProjectArchiveMCPServer `json:"unmappedMCPServers,omitempty"`
+}
@@ -32,6 +32,7 @@ type PublishedArtifactVersionSummary struct {
 	Description string    `json:"description,omitempty"`
 	CreatedAt   Time      `json:"createdAt"`
 	Subjects    []Subject `json:"subjects,omitempty"`
+	PublishedArtifactVersionState
 }
 
 // PublishedArtifactList is a list of published artifacts.
@@ -42,6 +43,87 @@ type PublishedArtifactVersionEntry struct {
 	Version     int       `json:"version"`
 	BlobKey     string    `json:"blobKey"`
 	Description string    `json:"description,omitempty"`
+	AuthorEmail string    `json:"authorEmail,omitempty"`
+	Body        string    `json:"body,omitempty"`
 	CreatedAt   Time      `json:"createdAt"`
 	Subjects    []Subject `json:"subjects,omitempty"`
+	PublishedArtifactVersionState
+}
+
+// PublishedArtifactReviewState is the review state of a version of an artifact.
+type PublishedArtifactReviewState string
+
+const (
+	PublishedArtifactReviewStatePending  PublishedArtifactReviewState = "pending"
+	PublishedArtifactReviewStateApproved PublishedArtifactReviewState = "approved"
+	PublishedArtifactReviewStateRejected PublishedArtifactReviewState = "rejected"
+)
+
+// PublishedArtifactVersionState is the review, deprecation, and yank state of a version of an artifact.
+type PublishedArtifactVersionState struct {
+	// ReviewState is empty for versions that were published without review. Only versions without a review state
+	// or that have been approved are visible to the version's subjects.
+	ReviewState PublishedArtifactReviewState `json:"reviewState,omitempty"`
+	// Reviews are the decisions recorded by reviewers, oldest first.
+	Reviews []PublishedArtifactReview `json:"reviews,omitempty"`
+	// Deprecated versions can still be downloaded, but clients should warn about them.
+	Deprecated         bool   `json:"deprecated,omitempty"`
+	DeprecationMessage string `json:"deprecationMessage,omitempty"`
+	// Yanked versions are never downloaded by default, but can still be downloaded by their version number.
+	Yanked     bool   `json:"yanked,omitempty"`
+	YankReason string `json:"yankReason,omitempty"`
+}
+
+// IsPublished returns whether the version is visible to its subjects, because it was published without review or has
+// been approved.
+func (s PublishedArtifactVersionState) IsPublished() bool {
+	return s.ReviewState == "" || s.ReviewState == PublishedArtifactReviewStateApproved
+}
+
+// PublishedArtifactReview is a reviewer's decision on a pending version of an artifact.
+type PublishedArtifactReview struct {
+	ReviewerID string                       `json:"reviewerID"`
+	Decision   PublishedArtifactReviewState `json:"decision"`
+	Comment    string                       `json:"comment,omitempty"`
+	CreatedAt  Time                         `json:"createdAt"`
+}
+
+// PublishedArtifactReviewRequest is the body of a request to approve or reject a pending version.
+type PublishedArtifactReviewRequest struct {
+	// Decision is either "approved" or "rejected".
+	Decision PublishedArtifactReviewState `json:"decision"`
+	Comment  string                       `json:"comment,omitempty"`
+}
+
+// PublishedArtifactVersionDiff is the difference between a version of an artifact and the previous published version.
+type PublishedArtifactVersionDiff struct {
+	Version int `json:"version"`
+	// BaseVersion is the version compared against, or 0 if there is no previous published version.
+	BaseVersion int                         `json:"baseVersion,omitempty"`
+	Files       []PublishedArtifactFileDiff `json:"files"`
+}
+
+// PublishedArtifactFileDiff is the difference of a single file between two versions of an artifact.
+type PublishedArtifactFileDiff struct {
+	Path string `json:"path"`
+	// Status is "added", "removed", or "modified".
+	Status string `json:"status"`
+	// Diff is a unified diff of the file. It is empty for binary files.
+	Diff   string `json:"diff,omitempty"`
+	Binary bool   `json:"binary,omitempty"`
+}
+
+// PublishedArtifactReviewPolicy requires new versions of published artifacts to be approved before they are
+// visible to their subjects.
+type PublishedArtifactReviewPolicy struct {
+	PublishedArtifactReviewPolicyManifest
+	Metadata Metadata `json:"metadata,omitempty"`
+}
+
+type PublishedArtifactReviewPolicyManifest struct {
+	// RequireReview makes new versions start as pending until a reviewer approves them.
+	RequireReview bool `json:"requireReview,omitempty"`
+	// Reviewers are the users and groups that can approve or reject pending versions. Authors can't review their
+	// own versions.
+	Reviewers []Subject `json:"reviewers,omitempty"`
 }
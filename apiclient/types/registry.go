@@ -22,7 +22,7 @@ type RegistryServerResponse struct {
 }
 
 // RegistryServerDetail matches the Registry API RegistryServerDetail schema
-// For Obot, configured servers always use Remotes (never Packages)
+// For Obot, configured servers always use Remotes. Catalog entries also use Packages if their catalog exposes them.
 type RegistryServerDetail struct {
 	Name        string                    `json:"name"`
 	Description string                    `json:"description"`
@@ -30,6 +30,7 @@ type RegistryServerDetail struct {
 	Version     string                    `json:"version"`
 	WebsiteURL  string                    `json:"websiteUrl,omitempty"`
 	Icons       []RegistryServerIcon      `json:"icons,omitempty"`
+	Packages    []RegistryServerPackage   `json:"packages,omitempty"`
 	Remotes     []RegistryServerRemote    `json:"remotes,omitempty"`
 	Repository  *RegistryServerRepository `json:"repository,omitempty"`
 	Schema      string                    `json:"$schema,omitempty"`
@@ -47,8 +48,53 @@ type RegistryServerIcon struct {
 // RegistryServerRemote represents a remote server configuration
 // All Obot servers are exposed as streamable-http remotes via mcp-connect
 type RegistryServerRemote struct {
-	Type string `json:"type"` // Always "streamable-http" for configured Obot servers
-	URL  string `json:"url"`  // The mcp-connect URL
+	Type    string                  `json:"type"` // Always "streamable-http" for configured Obot servers
+	URL     string                  `json:"url"`  // The mcp-connect URL
+	Headers []RegistryKeyValueInput `json:"headers,omitempty"`
+}
+
+// RegistryServerPackage represents a package that the server can be installed and run from
+type RegistryServerPackage struct {
+	RegistryType         string                  `json:"registryType"` // "npm", "pypi", "oci", etc.
+	RegistryBaseURL      string                  `json:"registryBaseUrl,omitempty"`
+	Identifier           string                  `json:"identifier"`
+	Version              string                  `json:"version,omitempty"`
+	RuntimeHint          string                  `json:"runtimeHint,omitempty"`
+	Transport            RegistryServerTransport `json:"transport"`
+	RuntimeArguments     []RegistryArgument      `json:"runtimeArguments,omitempty"`
+	PackageArguments     []RegistryArgument      `json:"packageArguments,omitempty"`
+	EnvironmentVariables []RegistryKeyValueInput `json:"environmentVariables,omitempty"`
+}
+
+// RegistryServerTransport represents how a client connects to a server run from a package
+type RegistryServerTransport struct {
+	Type    string                  `json:"type"`          // "stdio", "streamable-http", or "sse"
+	URL     string                  `json:"url,omitempty"` // Only for streamable-http and sse, and may contain {variables}
+	Headers []RegistryKeyValueInput `json:"headers,omitempty"`
+}
+
+// RegistryKeyValueInput represents an environment variable or header that the server needs
+type RegistryKeyValueInput struct {
+	Name        string `json:"name,omitempty"` // Omitted for argument variables, which are named by their keys
+	Description string `json:"description,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Default     string `json:"default,omitempty"`
+	IsRequired  bool   `json:"isRequired,omitempty"`
+	IsSecret    bool   `json:"isSecret,omitempty"`
+}
+
+// RegistryArgument represents a command line argument passed to the server or its runtime
+type RegistryArgument struct {
+	Type        string `json:"type"`           // "positional" or "named"
+	Name        string `json:"name,omitempty"` // The flag for named arguments, like "--port"
+	ValueHint   string `json:"valueHint,omitempty"`
+	Value       string `json:"value,omitempty"`
+	Default     string `json:"default,omitempty"`
+	Description string `json:"description,omitempty"`
+	IsRequired  bool   `json:"isRequired,omitempty"`
+	IsSecret    bool   `json:"isSecret,omitempty"`
+	// Variables are the inputs substituted for {name} placeholders in Value
+	Variables map[string]RegistryKeyValueInput `json:"variables,omitempty"`
 }
 
 // RegistryServerRepository represents repository metadata
@@ -0,0 +1,28 @@
+package types
+
+// ServiceAccountManifest describes a non-human user that integrations authenticate as with the client_credentials
+// grant of the OAuth clients that are bound to it.
+type ServiceAccountManifest struct {
+	// Name is the unique name of the service account. The service account's username is the name prefixed with
+	// "serviceaccount:". It can't be changed after the service account is created.
+	Name string `json:"name"`
+
+	// DisplayName is the name that is shown for the service account in audit logs and usage.
+	DisplayName string `json:"displayName,omitempty"`
+
+	// Role is the role of the service account. Service accounts can't be owners or admins, and only owners can give them
+	// the auditor role. Defaults to Basic(4).
+	Role Role `json:"role,omitempty"`
+
+	// GroupIDs are the IDs of the auth provider groups that the service account is a member of, which access control
+	// rules and group role assignments apply to.
+	GroupIDs []string `json:"groupIDs,omitempty"`
+}
+
+type ServiceAccount struct {
+	Metadata
+	ServiceAccountManifest
+	Username string `json:"username"`
+}
+
+type ServiceAccountList List[ServiceAccount]
@@ -16,6 +16,9 @@ type TaskManifest struct {
 	Steps       []TaskStep    `json:"steps"`
 	Schedule    *Schedule     `json:"schedule"`
 	OnDemand    *TaskOnDemand `json:"onDemand"`
+
+	// Notifications are the channels notified when a run of this task finishes.
+	Notifications []NotificationSubscription `json:"notifications,omitempty"`
 }
 
 type TaskOnDemand struct {
@@ -30,8 +33,28 @@ type Schedule struct {
 	Day      int    `json:"day"`
 	Weekday  int    `json:"weekday"`
 	TimeZone string `json:"timezone"`
+
+	// Cron is a five field cron expression. When set, Interval, Hour, Minute, Day, and Weekday are ignored.
+	Cron string `json:"cron,omitempty"`
+	// Paused stops the schedule from triggering runs. Runs missed while paused are never caught up.
+	Paused bool `json:"paused,omitempty"`
+	// BlackoutDates are dates, formatted as YYYY-MM-DD in the schedule's time zone, on which no runs are triggered.
+	BlackoutDates []string `json:"blackoutDates,omitempty"`
+	// CatchUp determines what happens to runs that were missed because the controller was not running.
+	CatchUp CatchUpPolicy `json:"catchUp,omitempty"`
 }
 
+type CatchUpPolicy string
+
+const (
+	// CatchUpPolicySkip drops missed runs and waits for the next scheduled time.
+	CatchUpPolicySkip CatchUpPolicy = "skip"
+	// CatchUpPolicyRunOnce triggers a single run for any number of missed runs. This is the default.
+	CatchUpPolicyRunOnce CatchUpPolicy = "run-once"
+	// CatchUpPolicyRunAll triggers a run for every missed run, up to a limit.
+	CatchUpPolicyRunAll CatchUpPolicy = "run-all"
+)
+
 type TaskStep struct {
 	ID   string   `json:"id,omitempty"`
 	Step string   `json:"step,omitempty"`
@@ -49,6 +72,8 @@ type TaskRun struct {
 	EndTime   *Time        `json:"endTime,omitempty"`
 	Error     string       `json:"error,omitempty"`
 	Warning   string       `json:"warning,omitempty"`
+
+	Notifications []NotificationDelivery `json:"notifications,omitempty"`
 }
 
 type TaskRunList List[TaskRun]
@@ -135,6 +135,7 @@ type User struct {
 	OriginalEmail              string   `json:"originalEmail,omitempty"`
 	OriginalUsername           string   `json:"originalUsername,omitempty"`
 	AutonomousToolUseEnabled   *bool    `json:"autonomousToolUseEnabled,omitempty"`
+	ServiceAccount             bool     `json:"serviceAccount,omitempty"`
 }
 
 type UserList List[User]
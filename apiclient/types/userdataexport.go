@@ -0,0 +1,28 @@
+package types
+
+// UserDataExport is an archive of everything the platform stores about a user, for data subject access requests.
+type UserDataExport struct {
+	Metadata `json:",inline"`
+	// UserID is the user whose data is exported.
+	UserID string `json:"userID"`
+	// RequestedBy is the user that requested the export. It is the exported user for self-service exports.
+	RequestedBy string              `json:"requestedBy"`
+	State       UserDataExportState `json:"state,omitempty"`
+	Error       string              `json:"error,omitempty"`
+	// Size is the size of the archive in bytes, once the export has completed.
+	Size        int64 `json:"size,omitempty"`
+	CompletedAt *Time `json:"completedAt,omitempty"`
+	// ExpiresAt is when the archive is deleted.
+	ExpiresAt *Time `json:"expiresAt,omitempty"`
+}
+
+type UserDataExportState string
+
+const (
+	UserDataExportStatePending   UserDataExportState = "pending"
+	UserDataExportStateRunning   UserDataExportState = "running"
+	UserDataExportStateCompleted UserDataExportState = "completed"
+	UserDataExportStateFailed    UserDataExportState = "failed"
+)
+
+type UserDataExportList List[UserDataExport]
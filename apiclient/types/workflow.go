@@ -17,6 +17,8 @@ type WorkflowManifest struct {
 	Output      string            `json:"output"`
 	Name        string            `json:"name,omitempty"`
 	Description string            `json:"description,omitempty"`
+
+	Notifications []NotificationSubscription `json:"notifications,omitempty"`
 }
 
 type EnvVar struct {
@@ -624,6 +624,18 @@ func (in *CatalogComponentServer) DeepCopyInto(out *CatalogComponentServer) {
 	if in.ToolOverrides != nil {
 		in, out := &in.ToolOverrides, &out.ToolOverrides
 		*out = make([]ToolOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PromptOverrides != nil {
+		in, out := &in.PromptOverrides, &out.PromptOverrides
+		*out = make([]PromptOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceOverrides != nil {
+		in, out := &in.ResourceOverrides, &out.ResourceOverrides
+		*out = make([]ResourceOverride, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -638,6 +650,44 @@ func (in *CatalogComponentServer) DeepCopy() *CatalogComponentServer {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatHistorySearchResult) DeepCopyInto(out *ChatHistorySearchResult) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatHistorySearchResult.
+func (in *ChatHistorySearchResult) DeepCopy() *ChatHistorySearchResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatHistorySearchResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ChatHistorySearchResultList) DeepCopyInto(out *ChatHistorySearchResultList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ChatHistorySearchResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ChatHistorySearchResultList.
+func (in *ChatHistorySearchResultList) DeepCopy() *ChatHistorySearchResultList {
+	if in == nil {
+		return nil
+	}
+	out := new(ChatHistorySearchResultList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClientInfo) DeepCopyInto(out *ClientInfo) {
 	*out = *in
@@ -706,6 +756,18 @@ func (in *ComponentServer) DeepCopyInto(out *ComponentServer) {
 	if in.ToolOverrides != nil {
 		in, out := &in.ToolOverrides, &out.ToolOverrides
 		*out = make([]ToolOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PromptOverrides != nil {
+		in, out := &in.PromptOverrides, &out.PromptOverrides
+		*out = make([]PromptOverride, len(*in))
+		copy(*out, *in)
+	}
+	if in.ResourceOverrides != nil {
+		in, out := &in.ResourceOverrides, &out.ResourceOverrides
+		*out = make([]ResourceOverride, len(*in))
 		copy(*out, *in)
 	}
 }
@@ -764,6 +826,48 @@ func (in *CompositeRuntimeConfig) DeepCopy() *CompositeRuntimeConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicy) DeepCopyInto(out *ContainerImagePolicy) {
+	*out = *in
+	in.ContainerImagePolicyManifest.DeepCopyInto(&out.ContainerImagePolicyManifest)
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicy.
+func (in *ContainerImagePolicy) DeepCopy() *ContainerImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicyManifest) DeepCopyInto(out *ContainerImagePolicyManifest) {
+	*out = *in
+	if in.AllowedImages != nil {
+		in, out := &in.AllowedImages, &out.AllowedImages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.SignaturePublicKeys != nil {
+		in, out := &in.SignaturePublicKeys, &out.SignaturePublicKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicyManifest.
+func (in *ContainerImagePolicyManifest) DeepCopy() *ContainerImagePolicyManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicyManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ContainerizedRuntimeConfig) DeepCopyInto(out *ContainerizedRuntimeConfig) {
 	*out = *in
@@ -887,7 +991,7 @@ func (in *CronJobManifest) DeepCopyInto(out *CronJobManifest) {
 	if in.TaskSchedule != nil {
 		in, out := &in.TaskSchedule, &out.TaskSchedule
 		*out = new(Schedule)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 }
 
@@ -984,6 +1088,26 @@ func (in *DeploymentCondition) DeepCopy() *DeploymentCondition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmailNotificationConfig) DeepCopyInto(out *EmailNotificationConfig) {
+	*out = *in
+	if in.To != nil {
+		in, out := &in.To, &out.To
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmailNotificationConfig.
+func (in *EmailNotificationConfig) DeepCopy() *EmailNotificationConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EmailNotificationConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *EmailReceiver) DeepCopyInto(out *EmailReceiver) {
 	*out = *in
@@ -1541,6 +1665,113 @@ func (in *KnowledgeSourceManifest) DeepCopy() *KnowledgeSourceManifest {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHold) DeepCopyInto(out *LegalHold) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.LegalHoldManifest.DeepCopyInto(&out.LegalHoldManifest)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]LegalHoldEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHold.
+func (in *LegalHold) DeepCopy() *LegalHold {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldEvent) DeepCopyInto(out *LegalHoldEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldEvent.
+func (in *LegalHoldEvent) DeepCopy() *LegalHoldEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldList) DeepCopyInto(out *LegalHoldList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LegalHold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldList.
+func (in *LegalHoldList) DeepCopy() *LegalHoldList {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldManifest) DeepCopyInto(out *LegalHoldManifest) {
+	*out = *in
+	if in.UserIDs != nil {
+		in, out := &in.UserIDs, &out.UserIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ProjectIDs != nil {
+		in, out := &in.ProjectIDs, &out.ProjectIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MCPServerIDs != nil {
+		in, out := &in.MCPServerIDs, &out.MCPServerIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldManifest.
+func (in *LegalHoldManifest) DeepCopy() *LegalHoldManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldRelease) DeepCopyInto(out *LegalHoldRelease) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldRelease.
+func (in *LegalHoldRelease) DeepCopy() *LegalHoldRelease {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldRelease)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *LogoPreferences) DeepCopyInto(out *LogoPreferences) {
 	*out = *in
@@ -1556,6 +1787,31 @@ func (in *LogoPreferences) DeepCopy() *LogoPreferences {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPArgumentValueRule) DeepCopyInto(out *MCPArgumentValueRule) {
+	*out = *in
+	if in.Allowed != nil {
+		in, out := &in.Allowed, &out.Allowed
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Denied != nil {
+		in, out := &in.Denied, &out.Denied
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPArgumentValueRule.
+func (in *MCPArgumentValueRule) DeepCopy() *MCPArgumentValueRule {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPArgumentValueRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPAuditLog) DeepCopyInto(out *MCPAuditLog) {
 	*out = *in
@@ -1620,6 +1876,75 @@ func (in *MCPAuditLogList) DeepCopy() *MCPAuditLogList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicy) DeepCopyInto(out *MCPAuditLogPolicy) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.MCPAuditLogPolicyManifest.DeepCopyInto(&out.MCPAuditLogPolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicy.
+func (in *MCPAuditLogPolicy) DeepCopy() *MCPAuditLogPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicyList) DeepCopyInto(out *MCPAuditLogPolicyList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPAuditLogPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicyList.
+func (in *MCPAuditLogPolicyList) DeepCopy() *MCPAuditLogPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicyManifest) DeepCopyInto(out *MCPAuditLogPolicyManifest) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]Resource, len(*in))
+		copy(*out, *in)
+	}
+	if in.PowerUserWorkspaceIDs != nil {
+		in, out := &in.PowerUserWorkspaceIDs, &out.PowerUserWorkspaceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RedactHeaders != nil {
+		in, out := &in.RedactHeaders, &out.RedactHeaders
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicyManifest.
+func (in *MCPAuditLogPolicyManifest) DeepCopy() *MCPAuditLogPolicyManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicyManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPAuditLogResponse) DeepCopyInto(out *MCPAuditLogResponse) {
 	*out = *in
@@ -1706,6 +2031,11 @@ func (in *MCPCatalogManifest) DeepCopyInto(out *MCPCatalogManifest) {
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
+	if in.ExposePackages != nil {
+		in, out := &in.ExposePackages, &out.ExposePackages
+		*out = new(bool)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPCatalogManifest.
@@ -1719,44 +2049,86 @@ func (in *MCPCatalogManifest) DeepCopy() *MCPCatalogManifest {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPEnv) DeepCopyInto(out *MCPEnv) {
+func (in *MCPEgressPolicy) DeepCopyInto(out *MCPEgressPolicy) {
 	*out = *in
-	out.MCPHeader = in.MCPHeader
+	if in.Allow != nil {
+		in, out := &in.Allow, &out.Allow
+		*out = make([]MCPEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEnv.
-func (in *MCPEnv) DeepCopy() *MCPEnv {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEgressPolicy.
+func (in *MCPEgressPolicy) DeepCopy() *MCPEgressPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPEnv)
+	out := new(MCPEgressPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPHeader) DeepCopyInto(out *MCPHeader) {
+func (in *MCPEgressRule) DeepCopyInto(out *MCPEgressRule) {
 	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeader.
-func (in *MCPHeader) DeepCopy() *MCPHeader {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEgressRule.
+func (in *MCPEgressRule) DeepCopy() *MCPEgressRule {
 	if in == nil {
 		return nil
 	}
-	out := new(MCPHeader)
+	out := new(MCPEgressRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MCPPromptReadStats) DeepCopyInto(out *MCPPromptReadStats) {
+func (in *MCPEnv) DeepCopyInto(out *MCPEnv) {
 	*out = *in
+	out.MCPHeader = in.MCPHeader
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptReadStats.
-func (in *MCPPromptReadStats) DeepCopy() *MCPPromptReadStats {
-	if in == nil {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPEnv.
+func (in *MCPEnv) DeepCopy() *MCPEnv {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPEnv)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPHeader) DeepCopyInto(out *MCPHeader) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPHeader.
+func (in *MCPHeader) DeepCopy() *MCPHeader {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPHeader)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPPromptReadStats) DeepCopyInto(out *MCPPromptReadStats) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPPromptReadStats.
+func (in *MCPPromptReadStats) DeepCopy() *MCPPromptReadStats {
+	if in == nil {
 		return nil
 	}
 	out := new(MCPPromptReadStats)
@@ -1764,6 +2136,21 @@ func (in *MCPPromptReadStats) DeepCopy() *MCPPromptReadStats {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPRateLimit) DeepCopyInto(out *MCPRateLimit) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPRateLimit.
+func (in *MCPRateLimit) DeepCopy() *MCPRateLimit {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPRateLimit)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPResourceReadStats) DeepCopyInto(out *MCPResourceReadStats) {
 	*out = *in
@@ -1794,6 +2181,26 @@ func (in *MCPResourceRequests) DeepCopy() *MCPResourceRequests {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPResponseRedaction) DeepCopyInto(out *MCPResponseRedaction) {
+	*out = *in
+	if in.Patterns != nil {
+		in, out := &in.Patterns, &out.Patterns
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPResponseRedaction.
+func (in *MCPResponseRedaction) DeepCopy() *MCPResponseRedaction {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPResponseRedaction)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPSelector) DeepCopyInto(out *MCPSelector) {
 	*out = *in
@@ -1877,6 +2284,11 @@ func (in *MCPServer) DeepCopyInto(out *MCPServer) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(MCPServerHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServer.
@@ -1983,6 +2395,18 @@ func (in *MCPServerCatalogEntryManifest) DeepCopyInto(out *MCPServerCatalogEntry
 		*out = make([]MCPEnv, len(*in))
 		copy(*out, *in)
 	}
+	if in.EgressPolicy != nil {
+		in, out := &in.EgressPolicy, &out.EgressPolicy
+		*out = new(MCPEgressPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ToolOverrides != nil {
+		in, out := &in.ToolOverrides, &out.ToolOverrides
+		*out = make([]ToolOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerCatalogEntryManifest.
@@ -2034,6 +2458,52 @@ func (in *MCPServerEvent) DeepCopy() *MCPServerEvent {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealth) DeepCopyInto(out *MCPServerHealth) {
+	*out = *in
+	if in.LastTransitionTime != nil {
+		in, out := &in.LastTransitionTime, &out.LastTransitionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.LastAutoRestartTime != nil {
+		in, out := &in.LastAutoRestartTime, &out.LastAutoRestartTime
+		*out = (*in).DeepCopy()
+	}
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]MCPServerHealthEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealth.
+func (in *MCPServerHealth) DeepCopy() *MCPServerHealth {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerHealth)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPServerHealthEvent) DeepCopyInto(out *MCPServerHealthEvent) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerHealthEvent.
+func (in *MCPServerHealthEvent) DeepCopy() *MCPServerHealthEvent {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPServerHealthEvent)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPServerInstance) DeepCopyInto(out *MCPServerInstance) {
 	*out = *in
@@ -2141,6 +2611,13 @@ func (in *MCPServerManifest) DeepCopyInto(out *MCPServerManifest) {
 		*out = make([]MCPEnv, len(*in))
 		copy(*out, *in)
 	}
+	if in.ToolOverrides != nil {
+		in, out := &in.ToolOverrides, &out.ToolOverrides
+		*out = make([]ToolOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Args != nil {
 		in, out := &in.Args, &out.Args
 		*out = make([]string, len(*in))
@@ -2293,6 +2770,49 @@ func (in *MCPToolCallStatsItem) DeepCopy() *MCPToolCallStatsItem {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPUsagePoint) DeepCopyInto(out *MCPUsagePoint) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+	if in.ResponseStatuses != nil {
+		in, out := &in.ResponseStatuses, &out.ResponseStatuses
+		*out = make([]MCPUsageStatusCount, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPUsagePoint.
+func (in *MCPUsagePoint) DeepCopy() *MCPUsagePoint {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPUsagePoint)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPUsageSeries) DeepCopyInto(out *MCPUsageSeries) {
+	*out = *in
+	if in.Points != nil {
+		in, out := &in.Points, &out.Points
+		*out = make([]MCPUsagePoint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPUsageSeries.
+func (in *MCPUsageSeries) DeepCopy() *MCPUsageSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPUsageSeries)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPUsageStatItem) DeepCopyInto(out *MCPUsageStatItem) {
 	*out = *in
@@ -2371,6 +2891,83 @@ func (in *MCPUsageStatsList) DeepCopy() *MCPUsageStatsList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPUsageStatusCount) DeepCopyInto(out *MCPUsageStatusCount) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPUsageStatusCount.
+func (in *MCPUsageStatusCount) DeepCopy() *MCPUsageStatusCount {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPUsageStatusCount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPUsageTimeSeries) DeepCopyInto(out *MCPUsageTimeSeries) {
+	*out = *in
+	in.TimeStart.DeepCopyInto(&out.TimeStart)
+	in.TimeEnd.DeepCopyInto(&out.TimeEnd)
+	if in.Series != nil {
+		in, out := &in.Series, &out.Series
+		*out = make([]MCPUsageSeries, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPUsageTimeSeries.
+func (in *MCPUsageTimeSeries) DeepCopy() *MCPUsageTimeSeries {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPUsageTimeSeries)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPWebhookShadowDecision) DeepCopyInto(out *MCPWebhookShadowDecision) {
+	*out = *in
+	in.Created.DeepCopyInto(&out.Created)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPWebhookShadowDecision.
+func (in *MCPWebhookShadowDecision) DeepCopy() *MCPWebhookShadowDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPWebhookShadowDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPWebhookShadowDecisionList) DeepCopyInto(out *MCPWebhookShadowDecisionList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPWebhookShadowDecision, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPWebhookShadowDecisionList.
+func (in *MCPWebhookShadowDecisionList) DeepCopy() *MCPWebhookShadowDecisionList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPWebhookShadowDecisionList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPWebhookValidation) DeepCopyInto(out *MCPWebhookValidation) {
 	*out = *in
@@ -2425,6 +3022,28 @@ func (in *MCPWebhookValidationManifest) DeepCopyInto(out *MCPWebhookValidationMa
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.ArgumentSchema != nil {
+		in, out := &in.ArgumentSchema, &out.ArgumentSchema
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.ArgumentValues != nil {
+		in, out := &in.ArgumentValues, &out.ArgumentValues
+		*out = make([]MCPArgumentValueRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ResponseRedaction != nil {
+		in, out := &in.ResponseRedaction, &out.ResponseRedaction
+		*out = new(MCPResponseRedaction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RateLimit != nil {
+		in, out := &in.RateLimit, &out.RateLimit
+		*out = new(MCPRateLimit)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPWebhookValidationManifest.
@@ -2437,6 +3056,46 @@ func (in *MCPWebhookValidationManifest) DeepCopy() *MCPWebhookValidationManifest
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPWebhookValidationTestRequest) DeepCopyInto(out *MCPWebhookValidationTestRequest) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPWebhookValidationTestRequest.
+func (in *MCPWebhookValidationTestRequest) DeepCopy() *MCPWebhookValidationTestRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPWebhookValidationTestRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPWebhookValidationTestResult) DeepCopyInto(out *MCPWebhookValidationTestResult) {
+	*out = *in
+	if in.Request != nil {
+		in, out := &in.Request, &out.Request
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPWebhookValidationTestResult.
+func (in *MCPWebhookValidationTestResult) DeepCopy() *MCPWebhookValidationTestResult {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPWebhookValidationTestResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Memory) DeepCopyInto(out *Memory) {
 	*out = *in
@@ -3027,44 +3686,142 @@ func (in *NanobotAgentManifest) DeepCopy() *NanobotAgentManifest {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *NotionConfig) DeepCopyInto(out *NotionConfig) {
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
 	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.NotificationChannelManifest.DeepCopyInto(&out.NotificationChannelManifest)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotionConfig.
-func (in *NotionConfig) DeepCopy() *NotionConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
 	if in == nil {
 		return nil
 	}
-	out := new(NotionConfig)
+	out := new(NotificationChannel)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OAuthApp) DeepCopyInto(out *OAuthApp) {
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	in.OAuthAppManifest.DeepCopyInto(&out.OAuthAppManifest)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthApp.
-func (in *OAuthApp) DeepCopy() *OAuthApp {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelList.
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
 	if in == nil {
 		return nil
 	}
-	out := new(OAuthApp)
+	out := new(NotificationChannelList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *OAuthAppList) DeepCopyInto(out *OAuthAppList) {
+func (in *NotificationChannelManifest) DeepCopyInto(out *NotificationChannelManifest) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]OAuthApp, len(*in))
-		for i := range *in {
+	if in.Email != nil {
+		in, out := &in.Email, &out.Email
+		*out = new(EmailNotificationConfig)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelManifest.
+func (in *NotificationChannelManifest) DeepCopy() *NotificationChannelManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationDelivery) DeepCopyInto(out *NotificationDelivery) {
+	*out = *in
+	if in.SentAt != nil {
+		in, out := &in.SentAt, &out.SentAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationDelivery.
+func (in *NotificationDelivery) DeepCopy() *NotificationDelivery {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationDelivery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationSubscription) DeepCopyInto(out *NotificationSubscription) {
+	*out = *in
+	if in.Events != nil {
+		in, out := &in.Events, &out.Events
+		*out = make([]NotificationEvent, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationSubscription.
+func (in *NotificationSubscription) DeepCopy() *NotificationSubscription {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationSubscription)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotionConfig) DeepCopyInto(out *NotionConfig) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotionConfig.
+func (in *NotionConfig) DeepCopy() *NotionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NotionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthApp) DeepCopyInto(out *OAuthApp) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.OAuthAppManifest.DeepCopyInto(&out.OAuthAppManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthApp.
+func (in *OAuthApp) DeepCopy() *OAuthApp {
+	if in == nil {
+		return nil
+	}
+	out := new(OAuthApp)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OAuthAppList) DeepCopyInto(out *OAuthAppList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]OAuthApp, len(*in))
+		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
@@ -3266,6 +4023,60 @@ func (in *OneDriveConfig) DeepCopy() *OneDriveConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicy) DeepCopyInto(out *PackagePolicy) {
+	*out = *in
+	in.PackagePolicyManifest.DeepCopyInto(&out.PackagePolicyManifest)
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicy.
+func (in *PackagePolicy) DeepCopy() *PackagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicyManifest) DeepCopyInto(out *PackagePolicyManifest) {
+	*out = *in
+	in.NPM.DeepCopyInto(&out.NPM)
+	in.PyPI.DeepCopyInto(&out.PyPI)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicyManifest.
+func (in *PackagePolicyManifest) DeepCopy() *PackagePolicyManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicyManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackageRegistryPolicy) DeepCopyInto(out *PackageRegistryPolicy) {
+	*out = *in
+	if in.AllowedPackages != nil {
+		in, out := &in.AllowedPackages, &out.AllowedPackages
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackageRegistryPolicy.
+func (in *PackageRegistryPolicy) DeepCopy() *PackageRegistryPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackageRegistryPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurityAdmissionSettings) DeepCopyInto(out *PodSecurityAdmissionSettings) {
 	*out = *in
@@ -3394,6 +4205,141 @@ func (in *Project) DeepCopy() *Project {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectArchive) DeepCopyInto(out *ProjectArchive) {
+	*out = *in
+	in.ExportedAt.DeepCopyInto(&out.ExportedAt)
+	in.Project.DeepCopyInto(&out.Project)
+	if in.Env != nil {
+		in, out := &in.Env, &out.Env
+		*out = make([]EnvVar, len(*in))
+		copy(*out, *in)
+	}
+	if in.Tasks != nil {
+		in, out := &in.Tasks, &out.Tasks
+		*out = make([]ProjectArchiveTask, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Memories != nil {
+		in, out := &in.Memories, &out.Memories
+		*out = make([]Memory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.KnowledgeFiles != nil {
+		in, out := &in.KnowledgeFiles, &out.KnowledgeFiles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.MCPServers != nil {
+		in, out := &in.MCPServers, &out.MCPServers
+		*out = make([]ProjectArchiveMCPServer, len(*in))
+		copy(*out, *in)
+	}
+	if in.Threads != nil {
+		in, out := &in.Threads, &out.Threads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectArchive.
+func (in *ProjectArchive) DeepCopy() *ProjectArchive {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectArchive)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectArchiveMCPServer) DeepCopyInto(out *ProjectArchiveMCPServer) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectArchiveMCPServer.
+func (in *ProjectArchiveMCPServer) DeepCopy() *ProjectArchiveMCPServer {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectArchiveMCPServer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectArchiveRun) DeepCopyInto(out *ProjectArchiveRun) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+	if in.Program != nil {
+		in, out := &in.Program, &out.Program
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.ChatState != nil {
+		in, out := &in.ChatState, &out.ChatState
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	if in.CallFrame != nil {
+		in, out := &in.CallFrame, &out.CallFrame
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectArchiveRun.
+func (in *ProjectArchiveRun) DeepCopy() *ProjectArchiveRun {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectArchiveRun)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectArchiveTask) DeepCopyInto(out *ProjectArchiveTask) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectArchiveTask.
+func (in *ProjectArchiveTask) DeepCopy() *ProjectArchiveTask {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectArchiveTask)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectArchiveThread) DeepCopyInto(out *ProjectArchiveThread) {
+	*out = *in
+	if in.Runs != nil {
+		in, out := &in.Runs, &out.Runs
+		*out = make([]ProjectArchiveRun, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectArchiveThread.
+func (in *ProjectArchiveThread) DeepCopy() *ProjectArchiveThread {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectArchiveThread)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectCapabilities) DeepCopyInto(out *ProjectCapabilities) {
 	*out = *in
@@ -3454,6 +4400,27 @@ func (in *ProjectCredentialList) DeepCopy() *ProjectCredentialList {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProjectImportResult) DeepCopyInto(out *ProjectImportResult) {
+	*out = *in
+	in.Project.DeepCopyInto(&out.Project)
+	if in.UnmappedMCPServers != nil {
+		in, out := &in.UnmappedMCPServers, &out.UnmappedMCPServers
+		*out = make([]ProjectArchiveMCPServer, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProjectImportResult.
+func (in *ProjectImportResult) DeepCopy() *ProjectImportResult {
+	if in == nil {
+		return nil
+	}
+	out := new(ProjectImportResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ProjectInvitationManifest) DeepCopyInto(out *ProjectInvitationManifest) {
 	*out = *in
@@ -3806,6 +4773,21 @@ func (in *Prompt) DeepCopy() *Prompt {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PromptOverride) DeepCopyInto(out *PromptOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PromptOverride.
+func (in *PromptOverride) DeepCopy() *PromptOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(PromptOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PromptResponse) DeepCopyInto(out *PromptResponse) {
 	*out = *in
@@ -3838,68 +4820,171 @@ func (in *ProviderConfigurationParameter) DeepCopy() *ProviderConfigurationParam
 	if in == nil {
 		return nil
 	}
-	out := new(ProviderConfigurationParameter)
+	out := new(ProviderConfigurationParameter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifact) DeepCopyInto(out *PublishedArtifact) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	out.PublishedArtifactManifest = in.PublishedArtifactManifest
+	if in.Versions != nil {
+		in, out := &in.Versions, &out.Versions
+		*out = make([]PublishedArtifactVersionSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifact.
+func (in *PublishedArtifact) DeepCopy() *PublishedArtifact {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifact)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactFileDiff) DeepCopyInto(out *PublishedArtifactFileDiff) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactFileDiff.
+func (in *PublishedArtifactFileDiff) DeepCopy() *PublishedArtifactFileDiff {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactFileDiff)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactList) DeepCopyInto(out *PublishedArtifactList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PublishedArtifact, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactList.
+func (in *PublishedArtifactList) DeepCopy() *PublishedArtifactList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactManifest) DeepCopyInto(out *PublishedArtifactManifest) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactManifest.
+func (in *PublishedArtifactManifest) DeepCopy() *PublishedArtifactManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReview) DeepCopyInto(out *PublishedArtifactReview) {
+	*out = *in
+	in.CreatedAt.DeepCopyInto(&out.CreatedAt)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReview.
+func (in *PublishedArtifactReview) DeepCopy() *PublishedArtifactReview {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReview)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReviewPolicy) DeepCopyInto(out *PublishedArtifactReviewPolicy) {
+	*out = *in
+	in.PublishedArtifactReviewPolicyManifest.DeepCopyInto(&out.PublishedArtifactReviewPolicyManifest)
+	in.Metadata.DeepCopyInto(&out.Metadata)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicy.
+func (in *PublishedArtifactReviewPolicy) DeepCopy() *PublishedArtifactReviewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReviewPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedArtifact) DeepCopyInto(out *PublishedArtifact) {
+func (in *PublishedArtifactReviewPolicyManifest) DeepCopyInto(out *PublishedArtifactReviewPolicyManifest) {
 	*out = *in
-	in.Metadata.DeepCopyInto(&out.Metadata)
-	out.PublishedArtifactManifest = in.PublishedArtifactManifest
-	if in.Versions != nil {
-		in, out := &in.Versions, &out.Versions
-		*out = make([]PublishedArtifactVersionSummary, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.Reviewers != nil {
+		in, out := &in.Reviewers, &out.Reviewers
+		*out = make([]Subject, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifact.
-func (in *PublishedArtifact) DeepCopy() *PublishedArtifact {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicyManifest.
+func (in *PublishedArtifactReviewPolicyManifest) DeepCopy() *PublishedArtifactReviewPolicyManifest {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedArtifact)
+	out := new(PublishedArtifactReviewPolicyManifest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedArtifactList) DeepCopyInto(out *PublishedArtifactList) {
+func (in *PublishedArtifactReviewRequest) DeepCopyInto(out *PublishedArtifactReviewRequest) {
 	*out = *in
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]PublishedArtifact, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactList.
-func (in *PublishedArtifactList) DeepCopy() *PublishedArtifactList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewRequest.
+func (in *PublishedArtifactReviewRequest) DeepCopy() *PublishedArtifactReviewRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedArtifactList)
+	out := new(PublishedArtifactReviewRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PublishedArtifactManifest) DeepCopyInto(out *PublishedArtifactManifest) {
+func (in *PublishedArtifactVersionDiff) DeepCopyInto(out *PublishedArtifactVersionDiff) {
 	*out = *in
+	if in.Files != nil {
+		in, out := &in.Files, &out.Files
+		*out = make([]PublishedArtifactFileDiff, len(*in))
+		copy(*out, *in)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactManifest.
-func (in *PublishedArtifactManifest) DeepCopy() *PublishedArtifactManifest {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactVersionDiff.
+func (in *PublishedArtifactVersionDiff) DeepCopy() *PublishedArtifactVersionDiff {
 	if in == nil {
 		return nil
 	}
-	out := new(PublishedArtifactManifest)
+	out := new(PublishedArtifactVersionDiff)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -3913,6 +4998,7 @@ func (in *PublishedArtifactVersionEntry) DeepCopyInto(out *PublishedArtifactVers
 		*out = make([]Subject, len(*in))
 		copy(*out, *in)
 	}
+	in.PublishedArtifactVersionState.DeepCopyInto(&out.PublishedArtifactVersionState)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactVersionEntry.
@@ -3925,6 +5011,28 @@ func (in *PublishedArtifactVersionEntry) DeepCopy() *PublishedArtifactVersionEnt
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactVersionState) DeepCopyInto(out *PublishedArtifactVersionState) {
+	*out = *in
+	if in.Reviews != nil {
+		in, out := &in.Reviews, &out.Reviews
+		*out = make([]PublishedArtifactReview, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactVersionState.
+func (in *PublishedArtifactVersionState) DeepCopy() *PublishedArtifactVersionState {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactVersionState)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishedArtifactVersionSummary) DeepCopyInto(out *PublishedArtifactVersionSummary) {
 	*out = *in
@@ -3934,6 +5042,7 @@ func (in *PublishedArtifactVersionSummary) DeepCopyInto(out *PublishedArtifactVe
 		*out = make([]Subject, len(*in))
 		copy(*out, *in)
 	}
+	in.PublishedArtifactVersionState.DeepCopyInto(&out.PublishedArtifactVersionState)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactVersionSummary.
@@ -3946,6 +5055,28 @@ func (in *PublishedArtifactVersionSummary) DeepCopy() *PublishedArtifactVersionS
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryArgument) DeepCopyInto(out *RegistryArgument) {
+	*out = *in
+	if in.Variables != nil {
+		in, out := &in.Variables, &out.Variables
+		*out = make(map[string]RegistryKeyValueInput, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryArgument.
+func (in *RegistryArgument) DeepCopy() *RegistryArgument {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryArgument)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryGitHubMeta) DeepCopyInto(out *RegistryGitHubMeta) {
 	*out = *in
@@ -3961,6 +5092,21 @@ func (in *RegistryGitHubMeta) DeepCopy() *RegistryGitHubMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryKeyValueInput) DeepCopyInto(out *RegistryKeyValueInput) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryKeyValueInput.
+func (in *RegistryKeyValueInput) DeepCopy() *RegistryKeyValueInput {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryKeyValueInput)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryMeta) DeepCopyInto(out *RegistryMeta) {
 	*out = *in
@@ -4042,10 +5188,19 @@ func (in *RegistryServerDetail) DeepCopyInto(out *RegistryServerDetail) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.Packages != nil {
+		in, out := &in.Packages, &out.Packages
+		*out = make([]RegistryServerPackage, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Remotes != nil {
 		in, out := &in.Remotes, &out.Remotes
 		*out = make([]RegistryServerRemote, len(*in))
-		copy(*out, *in)
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
 	}
 	if in.Repository != nil {
 		in, out := &in.Repository, &out.Repository
@@ -4147,9 +5302,49 @@ func (in *RegistryServerMeta) DeepCopy() *RegistryServerMeta {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryServerPackage) DeepCopyInto(out *RegistryServerPackage) {
+	*out = *in
+	in.Transport.DeepCopyInto(&out.Transport)
+	if in.RuntimeArguments != nil {
+		in, out := &in.RuntimeArguments, &out.RuntimeArguments
+		*out = make([]RegistryArgument, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.PackageArguments != nil {
+		in, out := &in.PackageArguments, &out.PackageArguments
+		*out = make([]RegistryArgument, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.EnvironmentVariables != nil {
+		in, out := &in.EnvironmentVariables, &out.EnvironmentVariables
+		*out = make([]RegistryKeyValueInput, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryServerPackage.
+func (in *RegistryServerPackage) DeepCopy() *RegistryServerPackage {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryServerPackage)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RegistryServerRemote) DeepCopyInto(out *RegistryServerRemote) {
 	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]RegistryKeyValueInput, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryServerRemote.
@@ -4194,6 +5389,26 @@ func (in *RegistryServerResponse) DeepCopy() *RegistryServerResponse {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RegistryServerTransport) DeepCopyInto(out *RegistryServerTransport) {
+	*out = *in
+	if in.Headers != nil {
+		in, out := &in.Headers, &out.Headers
+		*out = make([]RegistryKeyValueInput, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RegistryServerTransport.
+func (in *RegistryServerTransport) DeepCopy() *RegistryServerTransport {
+	if in == nil {
+		return nil
+	}
+	out := new(RegistryServerTransport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *RemainingTokenUsage) DeepCopyInto(out *RemainingTokenUsage) {
 	*out = *in
@@ -4284,6 +5499,21 @@ func (in *Resource) DeepCopy() *Resource {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ResourceOverride) DeepCopyInto(out *ResourceOverride) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ResourceOverride.
+func (in *ResourceOverride) DeepCopy() *ResourceOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ResourceOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Run) DeepCopyInto(out *Run) {
 	*out = *in
@@ -4355,6 +5585,11 @@ func (in *S3Config) DeepCopy() *S3Config {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Schedule) DeepCopyInto(out *Schedule) {
 	*out = *in
+	if in.BlackoutDates != nil {
+		in, out := &in.BlackoutDates, &out.BlackoutDates
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Schedule.
@@ -4370,7 +5605,7 @@ func (in *Schedule) DeepCopy() *Schedule {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledAuditLogExportCreateRequest) DeepCopyInto(out *ScheduledAuditLogExportCreateRequest) {
 	*out = *in
-	out.Schedule = in.Schedule
+	in.Schedule.DeepCopyInto(&out.Schedule)
 	in.Filters.DeepCopyInto(&out.Filters)
 }
 
@@ -4409,7 +5644,7 @@ func (in *ScheduledAuditLogExportListResponse) DeepCopy() *ScheduledAuditLogExpo
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ScheduledAuditLogExportResponse) DeepCopyInto(out *ScheduledAuditLogExportResponse) {
 	*out = *in
-	out.Schedule = in.Schedule
+	in.Schedule.DeepCopyInto(&out.Schedule)
 	in.Filters.DeepCopyInto(&out.Filters)
 	in.LastRunAt.DeepCopyInto(&out.LastRunAt)
 }
@@ -4440,7 +5675,7 @@ func (in *ScheduledAuditLogExportUpdateRequest) DeepCopyInto(out *ScheduledAudit
 	if in.Schedule != nil {
 		in, out := &in.Schedule, &out.Schedule
 		*out = new(Schedule)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.RetentionPeriodInDays != nil {
 		in, out := &in.RetentionPeriodInDays, &out.RetentionPeriodInDays
@@ -4474,6 +5709,65 @@ func (in *ScheduledAuditLogExportUpdateRequest) DeepCopy() *ScheduledAuditLogExp
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccount) DeepCopyInto(out *ServiceAccount) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	in.ServiceAccountManifest.DeepCopyInto(&out.ServiceAccountManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccount.
+func (in *ServiceAccount) DeepCopy() *ServiceAccount {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccount)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountList) DeepCopyInto(out *ServiceAccountList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ServiceAccount, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountList.
+func (in *ServiceAccountList) DeepCopy() *ServiceAccountList {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ServiceAccountManifest) DeepCopyInto(out *ServiceAccountManifest) {
+	*out = *in
+	if in.GroupIDs != nil {
+		in, out := &in.GroupIDs, &out.GroupIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ServiceAccountManifest.
+func (in *ServiceAccountManifest) DeepCopy() *ServiceAccountManifest {
+	if in == nil {
+		return nil
+	}
+	out := new(ServiceAccountManifest)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Skill) DeepCopyInto(out *Skill) {
 	*out = *in
@@ -4980,13 +6274,20 @@ func (in *TaskManifest) DeepCopyInto(out *TaskManifest) {
 	if in.Schedule != nil {
 		in, out := &in.Schedule, &out.Schedule
 		*out = new(Schedule)
-		**out = **in
+		(*in).DeepCopyInto(*out)
 	}
 	if in.OnDemand != nil {
 		in, out := &in.OnDemand, &out.OnDemand
 		*out = new(TaskOnDemand)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskManifest.
@@ -5034,6 +6335,13 @@ func (in *TaskRun) DeepCopyInto(out *TaskRun) {
 		in, out := &in.EndTime, &out.EndTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRun.
@@ -5412,6 +6720,42 @@ func (in TokenUsageSeries) DeepCopy() TokenUsageSeries {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ToolArgumentOverride) DeepCopyInto(out *ToolArgumentOverride) {
+	*out = *in
+	if in.Value != nil {
+		in, out := &in.Value, &out.Value
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = make(json.RawMessage, len(*in))
+		copy(*out, *in)
+	}
+	if in.Enum != nil {
+		in, out := &in.Enum, &out.Enum
+		*out = make([]json.RawMessage, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = make(json.RawMessage, len(*in))
+				copy(*out, *in)
+			}
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolArgumentOverride.
+func (in *ToolArgumentOverride) DeepCopy() *ToolArgumentOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(ToolArgumentOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolCall) DeepCopyInto(out *ToolCall) {
 	*out = *in
@@ -5531,6 +6875,13 @@ func (in *ToolManifest) DeepCopy() *ToolManifest {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ToolOverride) DeepCopyInto(out *ToolOverride) {
 	*out = *in
+	if in.Arguments != nil {
+		in, out := &in.Arguments, &out.Arguments
+		*out = make([]ToolArgumentOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ToolOverride.
@@ -5660,6 +7011,52 @@ func (in *User) DeepCopy() *User {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExport) DeepCopyInto(out *UserDataExport) {
+	*out = *in
+	in.Metadata.DeepCopyInto(&out.Metadata)
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ExpiresAt != nil {
+		in, out := &in.ExpiresAt, &out.ExpiresAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExport.
+func (in *UserDataExport) DeepCopy() *UserDataExport {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExportList) DeepCopyInto(out *UserDataExportList) {
+	*out = *in
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UserDataExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExportList.
+func (in *UserDataExportList) DeepCopy() *UserDataExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserDefaultRoleSetting) DeepCopyInto(out *UserDefaultRoleSetting) {
 	*out = *in
@@ -5935,6 +7332,13 @@ func (in *WorkflowManifest) DeepCopyInto(out *WorkflowManifest) {
 			(*out)[key] = val
 		}
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]NotificationSubscription, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowManifest.
@@ -61,6 +61,12 @@ var (
 		"/api/workspaces/",
 		"/api/mcp-webhook-validations",
 		"/api/mcp-webhook-validations/",
+		"/api/notification-channels",
+		"/api/notification-channels/",
+		"/api/mcp-audit-log-policies",
+		"/api/mcp-audit-log-policies/",
+		"/api/legal-holds",
+		"/api/legal-holds/",
 		"/api/system-mcp-servers",
 		"/api/system-mcp-servers/",
 		"GET /api/mcp-audit-logs",
@@ -69,6 +75,7 @@ var (
 		"GET /api/mcp-audit-logs/{mcp_id}",
 		"GET /api/mcp-stats",
 		"GET /api/mcp-stats/{mcp_id}",
+		"GET /api/mcp-usage-timeseries",
 		"GET /debug/pprof/",
 		"GET /debug/triggers",
 		"GET /debug/metrics",
@@ -101,6 +108,8 @@ var (
 		"POST /api/encrypt-all-users",
 		"/api/users/",
 		"GET /api/active-users",
+		"/api/service-accounts",
+		"/api/service-accounts/",
 		"GET /api/token-usage",
 		"GET /api/total-token-usage",
 		"GET /api/tokens",
@@ -111,6 +120,9 @@ var (
 		"/api/user-default-role-settings",
 		"/api/setup/",
 		"/api/k8s-settings",
+		"/api/container-image-policy",
+		"/api/package-policy",
+		"/api/published-artifact-review-policy",
 		"/api/mcp-capacity",
 		"/api/audit-log-exports",
 		"/api/audit-log-exports/{id}",
@@ -163,6 +175,7 @@ var (
 			"GET /api/mcp-audit-logs/{mcp_id}",
 			"GET /api/mcp-stats",
 			"GET /api/mcp-stats/{mcp_id}",
+			"GET /api/mcp-usage-timeseries",
 			"GET /api/mcp-capacity",
 			"GET /api/threads",
 			"GET /api/threads/",
@@ -172,11 +185,19 @@ var (
 			"GET /api/users/",
 			"GET /api/groups",
 			"GET /api/groups/",
+			"GET /api/service-accounts",
+			"GET /api/service-accounts/",
 			"GET /api/group-role-assignments",
 			"GET /api/group-role-assignments/",
 			"GET /api/mcp-catalogs/",
 			"GET /api/mcp-webhook-validations",
 			"GET /api/mcp-webhook-validations/",
+			"GET /api/notification-channels",
+			"GET /api/notification-channels/",
+			"GET /api/mcp-audit-log-policies",
+			"GET /api/mcp-audit-log-policies/",
+			"GET /api/legal-holds",
+			"GET /api/legal-holds/",
 			"GET /api/mcp-servers/",
 			"GET /api/tasks",
 			"GET /api/tasks/",
@@ -187,6 +208,9 @@ var (
 			"GET /api/message-policies/",
 			"GET /api/user-default-role-settings",
 			"GET /api/k8s-settings",
+			"GET /api/container-image-policy",
+			"GET /api/package-policy",
+			"GET /api/published-artifact-review-policy",
 			"POST /api/auth-providers/",
 			"GET /api/workspaces/",
 			"GET /api/projects/",
@@ -258,6 +282,10 @@ var (
 			"GET /oauth/authorize",
 			"POST /oauth/token/{mcp_id}",
 			"POST /oauth/token",
+			"POST /oauth/device_authorization/{mcp_id}",
+			"POST /oauth/device_authorization",
+			"POST /oauth/revoke",
+			"POST /oauth/introspect",
 			"GET /oauth/jwks.json",
 
 			"/mcp-connect/",
@@ -284,6 +312,10 @@ var (
 			"GET /api/users",
 			"GET /api/groups",
 
+			// Allow authenticated users to list notification channels so they can subscribe their tasks.
+			// Channel configuration is only returned to admins.
+			"GET /api/notification-channels",
+
 			// Allow authenticated users to read and accept/reject project invitations.
 			// The security depends on the code being an unguessable UUID string,
 			// which is the project owner shares with the user that they are inviting.
@@ -305,14 +337,17 @@ var (
 			"GET /api/mcp-audit-logs/{mcp_id}",
 			"GET /api/mcp-stats",
 			"GET /api/mcp-stats/{mcp_id}",
+			"GET /api/mcp-usage-timeseries",
 
 			// Published artifacts — any authenticated user can publish, search, and download.
-			// Ownership checks for update/delete are enforced in the handler.
+			// Ownership and reviewer checks for update/delete/review are enforced in the handler.
 			"POST /api/published-artifacts",
 			"GET /api/published-artifacts",
 			"GET /api/published-artifacts/{id}",
 			"GET /api/published-artifacts/{id}/download",
 			"GET /api/published-artifacts/{id}/{version}/skill",
+			"GET /api/published-artifacts/{id}/{version}/diff",
+			"POST /api/published-artifacts/{id}/{version}/reviews",
 			"PUT /api/published-artifacts/{id}",
 			"DELETE /api/published-artifacts/{id}",
 
@@ -340,6 +375,7 @@ var (
 			"GET /api/mcp-audit-logs/{mcp_id}",
 			"GET /api/mcp-stats",
 			"GET /api/mcp-stats/{mcp_id}",
+			"GET /api/mcp-usage-timeseries",
 		},
 
 		types.GroupAuthenticated: {
@@ -360,6 +396,13 @@ var (
 			"GET /api/api-keys",
 			"GET /api/api-keys/{id}",
 			"DELETE /api/api-keys/{id}",
+
+			// Exports of the user's own data
+			"POST /api/user-data-exports",
+			"GET /api/user-data-exports",
+			"GET /api/user-data-exports/{export_id}",
+			"GET /api/user-data-exports/{export_id}/download",
+			"DELETE /api/user-data-exports/{export_id}",
 		},
 
 		// API key users have restricted access - they can only access MCP-connect routes and /api/me
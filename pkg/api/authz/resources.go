@@ -19,19 +19,24 @@ var apiResources = map[string][]string{
 		"GET    /oauth/mcp/callback",
 		"GET    /auth/mcp/composite/{mcp_id}",
 		"GET    /api/oauth/composite/{mcp_id}",
+		"GET    /api/oauth/device/{user_code}",
+		"POST   /api/oauth/device/{user_code}",
 		"GET    /mcp-connect/{mcp_id}",
 		"POST   /mcp-connect/{mcp_id}",
 		"DELETE /mcp-connect/{mcp_id}",
 		"GET    /api/mcp-stats/{mcp_id}",
 		"GET    /api/mcp-audit-logs/{mcp_id}",
+		"GET    /api/chat-history/search",
 		"GET    /api/assistants",
 		"GET    /api/assistants/{assistant_id}",
 		"GET    /api/assistants/{assistant_id}/projects",
 		"POST   /api/assistants/{assistant_id}/projects",
+		"POST   /api/assistants/{assistant_id}/projects/import",
 		"DELETE /api/assistants/{assistant_id}/projects/{project_id}",
 		"GET    /api/assistants/{assistant_id}/projects/{project_id}",
 		"PUT    /api/assistants/{assistant_id}/projects/{project_id}",
 		"POST   /api/assistants/{assistant_id}/projects/{project_id}/copy",
+		"GET    /api/assistants/{assistant_id}/projects/{project_id}/export",
 		"GET    /api/assistants/{assistant_id}/projects/{project_id}/credentials",
 		"DELETE /api/assistants/{assistant_id}/projects/{project_id}/credentials/{credential_id}",
 		"GET    /api/assistants/{assistant_id}/projects/{project_id}/default-model",
@@ -350,6 +350,10 @@ func (a *AssistantHandler) DeleteKnowledge(req api.Context) error {
 		return types.NewErrHTTP(http.StatusTooEarly, "knowledge set is not created yet")
 	}
 
+	if err := checkLegalHolds(req, thread); err != nil {
+		return err
+	}
+
 	return deleteKnowledge(req, req.PathValue("file"), thread.Status.KnowledgeSetNames[0])
 }
 
@@ -436,19 +440,8 @@ func (a *AssistantHandler) SetTools(req api.Context) error {
 		return a.Tools(req)
 	}
 
-	for _, tool := range toolList {
-		if !slices.Contains(agent.Spec.Manifest.DefaultThreadTools, tool) && !slices.Contains(agent.Spec.Manifest.AvailableThreadTools, tool) {
-			return types.NewErrBadRequest("tool %s is not available for this agent", tool)
-		}
-	}
-
-	maxThreadTools := DefaultMaxUserThreadTools
-	if agent.Spec.Manifest.MaxThreadTools > 0 {
-		maxThreadTools = agent.Spec.Manifest.MaxThreadTools
-	}
-
-	if len(toolList) > maxThreadTools {
-		return types.NewErrBadRequest("too many tools for this agent")
+	if err := validateThreadTools(&agent, toolList); err != nil {
+		return err
 	}
 
 	toolList = slices.DeleteFunc(toolList, func(s string) bool {
@@ -479,6 +472,27 @@ func (a *AssistantHandler) SetTools(req api.Context) error {
 	return a.Tools(req)
 }
 
+// validateThreadTools returns an error if any of the tools isn't available to the threads of the agent, or if there are
+// more tools than the agent allows.
+func validateThreadTools(agent *v1.Agent, tools []string) error {
+	for _, tool := range tools {
+		if !slices.Contains(agent.Spec.Manifest.DefaultThreadTools, tool) && !slices.Contains(agent.Spec.Manifest.AvailableThreadTools, tool) {
+			return types.NewErrBadRequest("tool %s is not available for this agent", tool)
+		}
+	}
+
+	maxThreadTools := DefaultMaxUserThreadTools
+	if agent.Spec.Manifest.MaxThreadTools > 0 {
+		maxThreadTools = agent.Spec.Manifest.MaxThreadTools
+	}
+
+	if len(tools) > maxThreadTools {
+		return types.NewErrBadRequest("too many tools for this agent")
+	}
+
+	return nil
+}
+
 func (a *AssistantHandler) Tools(req api.Context) error {
 	var (
 		id     = req.PathValue("assistant_id")
@@ -220,6 +220,9 @@ func (h *AuditLogExportHandler) UpdateScheduledAuditLogExport(req api.Context) e
 		scheduledExport.Spec.Enabled = *updateReq.Enabled
 	}
 	if updateReq.Schedule != nil {
+		if err := validateExportSchedule(*updateReq.Schedule); err != nil {
+			return types.NewErrBadRequest("validation failed: %v", err)
+		}
 		scheduledExport.Spec.Schedule = h.convertSchedule(*updateReq.Schedule)
 	}
 	if updateReq.RetentionPeriodInDays != nil {
@@ -399,6 +402,14 @@ func (h *AuditLogExportHandler) validateScheduledExportRequest(req *types.Schedu
 	if req.Name == "" {
 		return fmt.Errorf("name is required")
 	}
+	return validateExportSchedule(req.Schedule)
+}
+
+// validateExportSchedule rejects the schedule settings that only tasks support, so that they aren't silently dropped.
+func validateExportSchedule(schedule types.Schedule) error {
+	if schedule.Cron != "" || schedule.Paused || len(schedule.BlackoutDates) > 0 || schedule.CatchUp != "" {
+		return fmt.Errorf("cron, paused, blackoutDates and catchUp are not supported for scheduled audit log exports")
+	}
 	return nil
 }
 
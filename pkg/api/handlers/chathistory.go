@@ -0,0 +1,175 @@
+package handlers
+
+import (
+	"fmt"
+	"html"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	gtypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const maxChatHistorySearchLimit = 200
+
+type ChatHistoryHandler struct{}
+
+func NewChatHistoryHandler() *ChatHistoryHandler {
+	return &ChatHistoryHandler{}
+}
+
+// Search handles GET /api/chat-history/search. Only the chat threads of projects the user owns or is a member of are
+// searched. Users who copied a shared project only see their own copy's threads.
+func (*ChatHistoryHandler) Search(req api.Context) error {
+	var (
+		query     = req.URL.Query()
+		q         = strings.TrimSpace(query.Get("q"))
+		projectID = query.Get("projectID")
+		threadID  = query.Get("threadID")
+		limit     int
+	)
+
+	if q == "" {
+		return types.NewErrBadRequest("missing search query")
+	}
+
+	if l := query.Get("limit"); l != "" {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil || limit < 1 {
+			return types.NewErrBadRequest("invalid limit %q", l)
+		}
+		limit = min(limit, maxChatHistorySearchLimit)
+	}
+
+	projects, err := chatHistoryProjects(req)
+	if err != nil {
+		return err
+	}
+
+	if projectID != "" {
+		projectName := strings.Replace(projectID, system.ProjectPrefix, system.ThreadPrefix, 1)
+		project, ok := projects[projectName]
+		if !ok {
+			return types.NewErrNotFound("project %s not found", projectID)
+		}
+		projects = map[string]*v1.Thread{projectName: project}
+	}
+
+	projectNames := make([]string, 0, len(projects))
+	for name := range projects {
+		projectNames = append(projectNames, name)
+	}
+
+	messages, err := req.GatewayClient.SearchChatMessages(req.Context(), gateway.ChatMessageSearchOptions{
+		Query:        q,
+		ProjectNames: projectNames,
+		ThreadName:   threadID,
+		Limit:        limit,
+	})
+	if err != nil {
+		return err
+	}
+
+	var (
+		threads = make(map[string]*v1.Thread)
+		result  = types.ChatHistorySearchResultList{Items: make([]types.ChatHistorySearchResult, 0, len(messages))}
+	)
+	for _, message := range messages {
+		thread, ok := threads[message.ThreadName]
+		if !ok {
+			thread = new(v1.Thread)
+			if err := req.Get(thread, message.ThreadName); apierrors.IsNotFound(err) {
+				thread = nil
+			} else if err != nil {
+				return err
+			}
+			threads[message.ThreadName] = thread
+		}
+		if thread == nil || !thread.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		var (
+			assistantID = projects[message.ProjectName].Spec.AgentName
+			projectID   = strings.Replace(message.ProjectName, system.ThreadPrefix, system.ProjectPrefix, 1)
+		)
+		result.Items = append(result.Items, types.ChatHistorySearchResult{
+			AssistantID: assistantID,
+			ProjectID:   projectID,
+			ThreadID:    message.ThreadName,
+			ThreadName:  thread.Spec.Manifest.Name,
+			RunID:       message.RunName,
+			Role:        message.Role,
+			ToolName:    message.ToolName,
+			Snippet:     highlightChatHistorySnippet(message.Snippet),
+			CreatedAt:   *types.NewTime(message.CreatedAt),
+			EventsURL: fmt.Sprintf("/api/assistants/%s/projects/%s/threads/%s/events?runID=%s",
+				assistantID, projectID, message.ThreadName, url.QueryEscape(message.RunName)),
+		})
+	}
+
+	return req.Write(result)
+}
+
+// chatHistoryProjects returns the project threads, keyed by name, whose chat history the user can search.
+func chatHistoryProjects(req api.Context) (map[string]*v1.Thread, error) {
+	var (
+		threads  v1.ThreadList
+		auths    v1.ThreadAuthorizationList
+		projects = make(map[string]*v1.Thread)
+	)
+
+	if err := req.List(&threads, kclient.MatchingFields{
+		"spec.project":  "true",
+		"spec.template": "false",
+		"spec.userUID":  req.User.GetUID(),
+	}); err != nil {
+		return nil, err
+	}
+
+	for i := range threads.Items {
+		if threads.Items[i].DeletionTimestamp.IsZero() {
+			projects[threads.Items[i].Name] = &threads.Items[i]
+		}
+	}
+
+	if err := req.List(&auths, kclient.MatchingFields{
+		"spec.userID": req.User.GetUID(),
+	}); err != nil {
+		return nil, err
+	}
+
+	for _, auth := range auths.Items {
+		if _, ok := projects[auth.Spec.ThreadID]; ok {
+			continue
+		}
+
+		var thread v1.Thread
+		if err := req.Get(&thread, auth.Spec.ThreadID); apierrors.IsNotFound(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		if thread.Spec.Project && !thread.Spec.Template && thread.DeletionTimestamp.IsZero() {
+			projects[thread.Name] = &thread
+		}
+	}
+
+	return projects, nil
+}
+
+// highlightChatHistorySnippet escapes a snippet for HTML and replaces the database's highlight markers with <mark> elements.
+func highlightChatHistorySnippet(snippet string) string {
+	return strings.NewReplacer(
+		gtypes.ChatMessageHighlightStart, "<mark>",
+		gtypes.ChatMessageHighlightEnd, "</mark>",
+	).Replace(html.EscapeString(snippet))
+}
@@ -0,0 +1,14 @@
+package handlers
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestHighlightChatHistorySnippet(t *testing.T) {
+	assert.Equal(t,
+		"Use <mark>SELECT</mark> * FROM users WHERE name = &#39;&lt;b&gt;&#39;",
+		highlightChatHistorySnippet("Use \x02SELECT\x03 * FROM users WHERE name = '<b>'"),
+	)
+}
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/imagepolicy"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type ContainerImagePolicyHandler struct{}
+
+func NewContainerImagePolicyHandler() *ContainerImagePolicyHandler {
+	return &ContainerImagePolicyHandler{}
+}
+
+func (h *ContainerImagePolicyHandler) Get(req api.Context) error {
+	var policy v1.ContainerImagePolicy
+	err := req.Storage.Get(req.Context(), client.ObjectKey{
+		Namespace: req.Namespace(),
+		Name:      system.ContainerImagePolicyName,
+	}, &policy)
+
+	if apierrors.IsNotFound(err) {
+		// Return an empty policy if not yet configured
+		return req.Write(types.ContainerImagePolicy{})
+	}
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertContainerImagePolicy(policy))
+}
+
+func (h *ContainerImagePolicyHandler) Update(req api.Context) error {
+	var input types.ContainerImagePolicyManifest
+	if err := req.Read(&input); err != nil {
+		return err
+	}
+
+	if err := imagepolicy.Validate(input); err != nil {
+		return types.NewErrBadRequest("invalid container image policy: %v", err)
+	}
+
+	var policy v1.ContainerImagePolicy
+	err := req.Get(&policy, system.ContainerImagePolicyName)
+
+	if apierrors.IsNotFound(err) {
+		policy = v1.ContainerImagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.ContainerImagePolicyName,
+				Namespace: req.Namespace(),
+			},
+			Spec: v1.ContainerImagePolicySpec{
+				ContainerImagePolicyManifest: input,
+			},
+		}
+
+		if err := req.Create(&policy); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		policy.Spec.ContainerImagePolicyManifest = input
+		if err := req.Update(&policy); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(convertContainerImagePolicy(policy))
+}
+
+func convertContainerImagePolicy(policy v1.ContainerImagePolicy) types.ContainerImagePolicy {
+	return types.ContainerImagePolicy{
+		ContainerImagePolicyManifest: policy.Spec.ContainerImagePolicyManifest,
+		Metadata:                     MetadataFrom(&policy),
+	}
+}
+
+// checkContainerImagePolicy returns a bad request error if any of the server's images are not allowed by the container image policy.
+func checkContainerImagePolicy(req api.Context, manifest types.MCPServerManifest) error {
+	policy, err := imagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		return err
+	}
+
+	return imagePolicyError(imagepolicy.CheckServerManifest(policy, manifest))
+}
+
+// checkCatalogEntryContainerImagePolicy returns a bad request error if any of the entry's images are not allowed by the container image policy.
+func checkCatalogEntryContainerImagePolicy(req api.Context, manifest types.MCPServerCatalogEntryManifest) error {
+	policy, err := imagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		return err
+	}
+
+	return imagePolicyError(imagepolicy.CheckCatalogEntryManifest(policy, manifest))
+}
+
+func imagePolicyError(err error) error {
+	if violation := (*imagepolicy.ViolationError)(nil); errors.As(err, &violation) {
+		return types.NewErrBadRequest("%v", violation)
+	}
+	return err
+}
@@ -0,0 +1,165 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/legalhold"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type LegalHoldHandler struct{}
+
+func NewLegalHoldHandler() *LegalHoldHandler {
+	return &LegalHoldHandler{}
+}
+
+func (*LegalHoldHandler) List(req api.Context) error {
+	var list v1.LegalHoldList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	items := make([]types.LegalHold, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertLegalHold(item))
+	}
+
+	return req.Write(types.LegalHoldList{Items: items})
+}
+
+func (*LegalHoldHandler) Get(req api.Context) error {
+	var hold v1.LegalHold
+	if err := req.Get(&hold, req.PathValue("legal_hold_id")); err != nil {
+		return err
+	}
+
+	return req.Write(convertLegalHold(hold))
+}
+
+func (*LegalHoldHandler) Create(req api.Context) error {
+	var manifest types.LegalHoldManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	hold := v1.LegalHold{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.LegalHoldPrefix,
+			Namespace:    req.Namespace(),
+		},
+		Spec: v1.LegalHoldSpec{
+			Manifest: manifest,
+			History:  []types.LegalHoldEvent{legalHoldEvent(req, types.LegalHoldEventCreated, "")},
+		},
+	}
+
+	if err := req.Create(&hold); err != nil {
+		return fmt.Errorf("failed to create legal hold: %w", err)
+	}
+
+	log.Infof("Created legal hold: id=%s userID=%s users=%d projects=%d mcpServers=%d", hold.Name, req.User.GetUID(), len(manifest.UserIDs), len(manifest.ProjectIDs), len(manifest.MCPServerIDs))
+	return req.WriteCreated(convertLegalHold(hold))
+}
+
+func (*LegalHoldHandler) Update(req api.Context) error {
+	var hold v1.LegalHold
+	if err := req.Get(&hold, req.PathValue("legal_hold_id")); err != nil {
+		return err
+	}
+
+	if hold.Spec.Released {
+		return types.NewErrBadRequest("legal hold %s has been released and can't be changed", hold.Name)
+	}
+
+	var manifest types.LegalHoldManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	hold.Spec.Manifest = manifest
+	hold.Spec.History = append(hold.Spec.History, legalHoldEvent(req, types.LegalHoldEventUpdated, ""))
+	if err := req.Update(&hold); err != nil {
+		return fmt.Errorf("failed to update legal hold: %w", err)
+	}
+
+	log.Infof("Updated legal hold: id=%s userID=%s users=%d projects=%d mcpServers=%d", hold.Name, req.User.GetUID(), len(manifest.UserIDs), len(manifest.ProjectIDs), len(manifest.MCPServerIDs))
+	return req.Write(convertLegalHold(hold))
+}
+
+// Release ends the legal hold, so that the data it preserved is deleted by retention again. Released holds are kept
+// as a record, so there is no way to delete a hold.
+func (*LegalHoldHandler) Release(req api.Context) error {
+	var hold v1.LegalHold
+	if err := req.Get(&hold, req.PathValue("legal_hold_id")); err != nil {
+		return err
+	}
+
+	if hold.Spec.Released {
+		return types.NewErrBadRequest("legal hold %s has already been released", hold.Name)
+	}
+
+	var release types.LegalHoldRelease
+	if err := req.Read(&release); err != nil {
+		return types.NewErrBadRequest("failed to read release: %v", err)
+	}
+	if release.Reason == "" {
+		return types.NewErrBadRequest("reason is required")
+	}
+
+	hold.Spec.Released = true
+	hold.Spec.History = append(hold.Spec.History, legalHoldEvent(req, types.LegalHoldEventReleased, release.Reason))
+	if err := req.Update(&hold); err != nil {
+		return fmt.Errorf("failed to release legal hold: %w", err)
+	}
+
+	log.Infof("Released legal hold: id=%s userID=%s", hold.Name, req.User.GetUID())
+	return req.Write(convertLegalHold(hold))
+}
+
+func legalHoldEvent(req api.Context, eventType types.LegalHoldEventType, reason string) types.LegalHoldEvent {
+	return types.LegalHoldEvent{
+		Type:   eventType,
+		UserID: req.User.GetUID(),
+		Time:   *types.NewTime(time.Now()),
+		Reason: reason,
+	}
+}
+
+func convertLegalHold(hold v1.LegalHold) types.LegalHold {
+	return types.LegalHold{
+		Metadata:          MetadataFrom(&hold),
+		LegalHoldManifest: hold.Spec.Manifest,
+		Released:          hold.Spec.Released,
+		History:           hold.Spec.History,
+	}
+}
+
+// checkLegalHolds returns a conflict error if deleting the thread, or data in it, would delete data preserved by an
+// active legal hold.
+func checkLegalHolds(req api.Context, thread *v1.Thread) error {
+	holds, err := legalhold.Active(req.Context(), req.Storage)
+	if err != nil {
+		return fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	if held, err := holds.PreventsDeletion(req.Context(), req.Storage, thread); err != nil {
+		return err
+	} else if held {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("%s is under legal hold and can't be deleted", thread.Name))
+	}
+	return nil
+}
@@ -0,0 +1,62 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestThreadDeleteRejectsHeldThreads(t *testing.T) {
+	storage := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithObjects(
+			&v1.LegalHold{
+				ObjectMeta: metav1.ObjectMeta{Name: "lh1held", Namespace: system.DefaultNamespace},
+				Spec: v1.LegalHoldSpec{Manifest: types.LegalHoldManifest{
+					Reason:  "Litigation",
+					UserIDs: []string{"held-user"},
+				}},
+			},
+			&v1.Thread{
+				ObjectMeta: metav1.ObjectMeta{Name: "t1held", Namespace: system.DefaultNamespace},
+				Spec:       v1.ThreadSpec{UserID: "held-user", ParentThreadName: "t1project"},
+			},
+			&v1.Thread{
+				ObjectMeta: metav1.ObjectMeta{Name: "t1other", Namespace: system.DefaultNamespace},
+				Spec:       v1.ThreadSpec{UserID: "other-user", ParentThreadName: "t1project"},
+			},
+		).
+		Build()
+
+	deleteThread := func(id string) error {
+		req := httptest.NewRequest(http.MethodDelete, "/api/threads/"+id, nil)
+		req.SetPathValue("id", id)
+		return (&ThreadHandler{}).Delete(api.Context{
+			ResponseWriter: httptest.NewRecorder(),
+			Request:        req,
+			Storage:        storage,
+			User:           &kuser.DefaultInfo{UID: "admin", Groups: []string{types.GroupAdmin}},
+		})
+	}
+
+	err := deleteThread("t1held")
+	var httpErr *types.ErrHTTP
+	require.ErrorAs(t, err, &httpErr)
+	assert.Equal(t, http.StatusConflict, httpErr.Code)
+	require.NoError(t, storage.Get(context.Background(), kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: "t1held"}, &v1.Thread{}))
+
+	require.NoError(t, deleteThread("t1other"))
+}
@@ -35,7 +35,14 @@ import (
 
 var envVarRegex = regexp.MustCompile(`\${([^}]+)}`)
 
-const requestTimeUpdateInterval = 15 * time.Minute
+// requestTimeUpdateInterval returns how often the last request time of the server is updated. Idle shutdown intervals
+// set in minutes need the time in one minute granularity, others make do with fewer writes to storage.
+func requestTimeUpdateInterval(server v1.MCPServer) time.Duration {
+	if interval := server.Status.IdleShutdownInterval.Duration; interval > 0 && interval%time.Hour != 0 {
+		return time.Minute
+	}
+	return 15 * time.Minute
+}
 
 // MCPOAuthChecker will check the OAuth status for an MCP server. This interface breaks an import cycle.
 type MCPOAuthChecker interface {
@@ -567,6 +574,9 @@ func (m *MCPHandler) LaunchServer(req api.Context) error {
 				if errors.Is(err, mcp.ErrInsufficientCapacity) {
 					return types.NewErrHTTP(http.StatusServiceUnavailable, "Insufficient capacity to deploy MCP server. Please contact your administrator.")
 				}
+				if errors.Is(err, mcp.ErrServerQuotaExceeded) {
+					return types.NewErrHTTP(http.StatusTooManyRequests, "Maximum number of running MCP servers reached. Please contact your administrator.")
+				}
 				if nse := (*mcp.ErrNotSupportedByBackend)(nil); errors.As(err, &nse) {
 					return types.NewErrHTTP(http.StatusBadRequest, nse.Error())
 				}
@@ -594,6 +604,9 @@ func (m *MCPHandler) LaunchServer(req api.Context) error {
 		if errors.Is(err, mcp.ErrInsufficientCapacity) {
 			return types.NewErrHTTP(http.StatusServiceUnavailable, "Insufficient capacity to deploy MCP server. Please contact your administrator.")
 		}
+		if errors.Is(err, mcp.ErrServerQuotaExceeded) {
+			return types.NewErrHTTP(http.StatusTooManyRequests, "Maximum number of running MCP servers reached. Please contact your administrator.")
+		}
 		if nse := (*mcp.ErrNotSupportedByBackend)(nil); errors.As(err, &nse) {
 			return types.NewErrHTTP(http.StatusBadRequest, nse.Error())
 		}
@@ -1259,7 +1272,7 @@ func serverFromMCPServerInstance(req api.Context, instance v1.MCPServerInstance)
 
 	// Best effort to update the last request time.
 	// Don't update on every request, only if it's been a while since the last update, to avoid excessive writes to storage.
-	if time.Since(server.Status.LastRequestTime.Time) > requestTimeUpdateInterval {
+	if time.Since(server.Status.LastRequestTime.Time) > requestTimeUpdateInterval(server) {
 		server.Status.LastRequestTime = metav1.Now()
 		if err := req.Storage.Status().Update(req.Context(), &server); err != nil {
 			log.Warnf("failed to update mcp server status: %v", err)
@@ -1411,7 +1424,7 @@ func serverConfigForAction(req api.Context, server v1.MCPServer) (mcp.ServerConf
 
 	// Best effort to update the last request time.
 	// Don't update on every request, only if it's been a while since the last update, to avoid excessive writes to storage.
-	if time.Since(server.Status.LastRequestTime.Time) > requestTimeUpdateInterval {
+	if time.Since(server.Status.LastRequestTime.Time) > requestTimeUpdateInterval(server) {
 		server.Status.LastRequestTime = metav1.Now()
 		if err := req.Storage.Status().Update(req.Context(), &server); err != nil {
 			log.Warnf("failed to update mcp server status: %v", err)
@@ -1520,11 +1533,14 @@ func serverManifestFromCatalogEntryManifest(
 			}
 
 			result.CompositeConfig.ComponentServers = append(result.CompositeConfig.ComponentServers, types.ComponentServer{
-				MCPServerID:    entryComponent.MCPServerID,
-				CatalogEntryID: entryComponent.CatalogEntryID,
-				ToolOverrides:  entryComponent.ToolOverrides,
-				Disabled:       inputComponent.Disabled,
-				Manifest:       resultComponentManifest,
+				MCPServerID:       entryComponent.MCPServerID,
+				CatalogEntryID:    entryComponent.CatalogEntryID,
+				ToolOverrides:     entryComponent.ToolOverrides,
+				PromptOverrides:   entryComponent.PromptOverrides,
+				ResourceOverrides: entryComponent.ResourceOverrides,
+				Namespace:         entryComponent.Namespace,
+				Disabled:          inputComponent.Disabled,
+				Manifest:          resultComponentManifest,
 			})
 		}
 	} else {
@@ -1571,6 +1587,9 @@ func mergeMCPServerManifests(existing, override types.MCPServerManifest) types.M
 	if override.Runtime != "" {
 		existing.Runtime = override.Runtime
 	}
+	if len(override.ToolOverrides) > 0 {
+		existing.ToolOverrides = override.ToolOverrides
+	}
 
 	// Merge runtime-specific configurations
 	if override.UVXConfig != nil {
@@ -1689,6 +1708,14 @@ func (m *MCPHandler) CreateServer(req api.Context) error {
 		return types.NewErrBadRequest("validation failed: %v", err)
 	}
 
+	if err := checkContainerImagePolicy(req, server.Spec.Manifest); err != nil {
+		return err
+	}
+
+	if err := checkPackagePolicy(req, server.Spec.Manifest); err != nil {
+		return err
+	}
+
 	addExtractedEnvVars(&server)
 	if err := req.Create(&server); err != nil {
 		return err
@@ -1777,6 +1804,14 @@ func (m *MCPHandler) UpdateServer(req api.Context) error {
 		return types.NewErrBadRequest("validation failed: %v", err)
 	}
 
+	if err := checkContainerImagePolicy(req, updated); err != nil {
+		return err
+	}
+
+	if err := checkPackagePolicy(req, updated); err != nil {
+		return err
+	}
+
 	// Use retry.RetryOnConflict because controllers (e.g. DetectK8sSettingsDrift,
 	// UpdateMCPServerStatus) can update this MCPServer concurrently, bumping the
 	// ResourceVersion between our read and write.
@@ -2346,7 +2381,17 @@ func toolsForServer(ctx context.Context, mcpSessionManager *mcp.SessionManager,
 		return nil, err
 	}
 
-	return mcp.ConvertTools(gTools, allowedTools, server.Spec.UnsupportedTools)
+	tools, err := mcp.ConvertTools(gTools, allowedTools, server.Spec.UnsupportedTools)
+	if err != nil {
+		return nil, err
+	}
+
+	// Show the tools as they are published by the MCP gateway.
+	if server.Spec.Manifest.Runtime != types.RuntimeComposite && len(server.Spec.Manifest.ToolOverrides) > 0 {
+		tools = mcp.ApplyToolOverrides(tools, server.Spec.Manifest.ToolOverrides)
+	}
+
+	return tools, nil
 }
 
 func (m *MCPHandler) removeMCPServer(ctx context.Context, mcpServer v1.MCPServer) error {
@@ -2589,6 +2634,7 @@ func ConvertMCPServer(server v1.MCPServer, credEnv map[string]string, serverURL,
 		Template:                    server.Spec.Template,
 		CompositeName:               server.Spec.CompositeName,
 		NanobotAgentID:              server.Spec.NanobotAgentID,
+		Health:                      server.Status.Health,
 	}
 
 	// For composite servers, also consider component configuration if provided
@@ -4,6 +4,9 @@ import (
 	"fmt"
 	"testing"
 	"time"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // Test functions for applyURLTemplate
@@ -277,3 +280,26 @@ func TestApplyURLTemplateRealWorldExamples(t *testing.T) {
 		})
 	}
 }
+
+func TestRequestTimeUpdateInterval(t *testing.T) {
+	tests := []struct {
+		name         string
+		idleInterval time.Duration
+		expected     time.Duration
+	}{
+		{name: "unknown idle interval", expected: 15 * time.Minute},
+		{name: "idle interval in hours", idleInterval: 24 * time.Hour, expected: 15 * time.Minute},
+		{name: "idle interval in minutes", idleInterval: 90 * time.Minute, expected: time.Minute},
+		{name: "idle shutdown disabled", idleInterval: -time.Minute, expected: 15 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var server v1.MCPServer
+			server.Status.IdleShutdownInterval = metav1.Duration{Duration: tt.idleInterval}
+			if got := requestTimeUpdateInterval(server); got != tt.expected {
+				t.Errorf("requestTimeUpdateInterval() = %v, want %v", got, tt.expected)
+			}
+		})
+	}
+}
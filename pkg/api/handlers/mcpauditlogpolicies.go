@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type MCPAuditLogPolicyHandler struct{}
+
+func NewMCPAuditLogPolicyHandler() *MCPAuditLogPolicyHandler {
+	return &MCPAuditLogPolicyHandler{}
+}
+
+func (*MCPAuditLogPolicyHandler) List(req api.Context) error {
+	var list v1.MCPAuditLogPolicyList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list audit log policies: %w", err)
+	}
+
+	items := make([]types.MCPAuditLogPolicy, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertMCPAuditLogPolicy(item))
+	}
+
+	return req.Write(types.MCPAuditLogPolicyList{Items: items})
+}
+
+func (*MCPAuditLogPolicyHandler) Get(req api.Context) error {
+	var policy v1.MCPAuditLogPolicy
+	if err := req.Get(&policy, req.PathValue("policy_id")); err != nil {
+		return err
+	}
+
+	return req.Write(convertMCPAuditLogPolicy(policy))
+}
+
+func (*MCPAuditLogPolicyHandler) Create(req api.Context) error {
+	var manifest types.MCPAuditLogPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	policy := v1.MCPAuditLogPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.MCPAuditLogPolicyPrefix,
+			Namespace:    req.Namespace(),
+		},
+		Spec: v1.MCPAuditLogPolicySpec{
+			Manifest: manifest,
+		},
+	}
+
+	if err := req.Create(&policy); err != nil {
+		return fmt.Errorf("failed to create audit log policy: %w", err)
+	}
+
+	return req.WriteCreated(convertMCPAuditLogPolicy(policy))
+}
+
+func (*MCPAuditLogPolicyHandler) Update(req api.Context) error {
+	var policy v1.MCPAuditLogPolicy
+	if err := req.Get(&policy, req.PathValue("policy_id")); err != nil {
+		return err
+	}
+
+	var manifest types.MCPAuditLogPolicyManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	policy.Spec.Manifest = manifest
+	if err := req.Update(&policy); err != nil {
+		return fmt.Errorf("failed to update audit log policy: %w", err)
+	}
+
+	return req.Write(convertMCPAuditLogPolicy(policy))
+}
+
+func (*MCPAuditLogPolicyHandler) Delete(req api.Context) error {
+	var policy v1.MCPAuditLogPolicy
+	if err := req.Get(&policy, req.PathValue("policy_id")); err != nil {
+		return err
+	}
+
+	if err := req.Delete(&policy); err != nil {
+		return fmt.Errorf("failed to delete audit log policy: %w", err)
+	}
+
+	return req.Write(convertMCPAuditLogPolicy(policy))
+}
+
+func convertMCPAuditLogPolicy(policy v1.MCPAuditLogPolicy) types.MCPAuditLogPolicy {
+	return types.MCPAuditLogPolicy{
+		Metadata:                  MetadataFrom(&policy),
+		MCPAuditLogPolicyManifest: policy.Spec.Manifest,
+	}
+}
@@ -109,7 +109,7 @@ func (h *MCPCatalogHandler) Update(req api.Context) error {
 		return fmt.Errorf("failed to get catalog: %w", err)
 	}
 
-	// The only field that can be updated is the source URLs.
+	// The only fields that can be updated are the source URLs and whether packages are exposed.
 	for _, urlStr := range manifest.SourceURLs {
 		if urlStr != "" && urlStr != h.defaultCatalogPath {
 			u, err := url.Parse(urlStr)
@@ -117,7 +117,8 @@ func (h *MCPCatalogHandler) Update(req api.Context) error {
 				return types.NewErrBadRequest("invalid URL: %v", err)
 			}
 
-			if u.Scheme != "https" {
+			// MCP Registry API servers are marked with a "registry+" prefix.
+			if u.Scheme != "https" && u.Scheme != "registry+https" {
 				return types.NewErrBadRequest("only HTTPS URLs are supported")
 			}
 		}
@@ -135,6 +136,9 @@ func (h *MCPCatalogHandler) Update(req api.Context) error {
 	}
 
 	catalog.Spec.SourceURLs = manifest.SourceURLs
+	if manifest.ExposePackages != nil {
+		catalog.Spec.ExposePackages = *manifest.ExposePackages
+	}
 
 	if err := req.Update(&catalog); err != nil {
 		return fmt.Errorf("failed to update catalog: %w", err)
@@ -295,6 +299,14 @@ func (h *MCPCatalogHandler) CreateEntry(req api.Context) error {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 
+	if err := checkCatalogEntryContainerImagePolicy(req, manifest); err != nil {
+		return err
+	}
+
+	if err := checkCatalogEntryPackagePolicy(req, manifest); err != nil {
+		return err
+	}
+
 	cleanName := normalizeMCPCatalogEntryName(manifest.Name)
 
 	entry := v1.MCPServerCatalogEntry{
@@ -367,6 +379,14 @@ func (h *MCPCatalogHandler) UpdateEntry(req api.Context) error {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 
+	if err := checkCatalogEntryContainerImagePolicy(req, manifest); err != nil {
+		return err
+	}
+
+	if err := checkCatalogEntryPackagePolicy(req, manifest); err != nil {
+		return err
+	}
+
 	// Copy the tool previews over so that they don't get wiped out when updating the manifest
 	manifest.ToolPreview = entry.Spec.Manifest.ToolPreview
 
@@ -1447,8 +1467,9 @@ func convertMCPCatalog(catalog v1.MCPCatalog) types.MCPCatalog {
 	return types.MCPCatalog{
 		Metadata: MetadataFrom(&catalog),
 		MCPCatalogManifest: types.MCPCatalogManifest{
-			DisplayName: catalog.Spec.DisplayName,
-			SourceURLs:  catalog.Spec.SourceURLs,
+			DisplayName:    catalog.Spec.DisplayName,
+			SourceURLs:     catalog.Spec.SourceURLs,
+			ExposePackages: &catalog.Spec.ExposePackages,
 		},
 		LastSynced: *types.NewTime(catalog.Status.LastSyncTime.Time),
 		SyncErrors: catalog.Status.SyncErrors,
@@ -1567,6 +1588,7 @@ func convertServerManifestToCatalogManifest(serverManifest types.MCPServerManife
 		Runtime:          serverManifest.Runtime,
 		Env:              serverManifest.Env,
 		ToolPreview:      serverManifest.ToolPreview,
+		ToolOverrides:    serverManifest.ToolOverrides,
 	}
 
 	// Convert runtime-specific configs
@@ -1591,10 +1613,13 @@ func convertServerManifestToCatalogManifest(serverManifest types.MCPServerManife
 			componentServers := make([]types.CatalogComponentServer, len(serverManifest.CompositeConfig.ComponentServers))
 			for i, comp := range serverManifest.CompositeConfig.ComponentServers {
 				componentServers[i] = types.CatalogComponentServer{
-					CatalogEntryID: comp.CatalogEntryID,
-					MCPServerID:    comp.MCPServerID,
-					Manifest:       convertServerManifestToCatalogManifest(comp.Manifest),
-					ToolOverrides:  comp.ToolOverrides,
+					CatalogEntryID:    comp.CatalogEntryID,
+					MCPServerID:       comp.MCPServerID,
+					Manifest:          convertServerManifestToCatalogManifest(comp.Manifest),
+					ToolOverrides:     comp.ToolOverrides,
+					PromptOverrides:   comp.PromptOverrides,
+					ResourceOverrides: comp.ResourceOverrides,
+					Namespace:         comp.Namespace,
 				}
 			}
 			catalogManifest.CompositeConfig = &types.CompositeCatalogConfig{
@@ -1651,6 +1676,14 @@ func (h *MCPCatalogHandler) RefreshCompositeComponents(req api.Context) error {
 		return types.NewErrBadRequest("failed to validate entry manifest: %v", err)
 	}
 
+	if err := checkCatalogEntryContainerImagePolicy(req, entry.Spec.Manifest); err != nil {
+		return err
+	}
+
+	if err := checkCatalogEntryPackagePolicy(req, entry.Spec.Manifest); err != nil {
+		return err
+	}
+
 	// Update the entry
 	if err := req.Update(&entry); err != nil {
 		return fmt.Errorf("failed to update entry: %w", err)
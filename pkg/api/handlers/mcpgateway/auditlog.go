@@ -163,11 +163,21 @@ func (h *AuditLogHandler) SubmitAuditLogs(req api.Context) error {
 		mcpServerName  string
 		nanobotAgentID string
 		userID         string
+		policyTarget   auditLogPolicyTarget
 	)
 	if len(mcpServers.Items) == 1 {
-		mcpServerName = mcpServers.Items[0].Name
-		nanobotAgentID = mcpServers.Items[0].Spec.NanobotAgentID
-		userID = mcpServers.Items[0].Spec.UserID
+		server := mcpServers.Items[0]
+		mcpServerName = server.Name
+		nanobotAgentID = server.Spec.NanobotAgentID
+		userID = server.Spec.UserID
+		policyTarget = auditLogPolicyTarget{
+			catalogEntryName:     server.Spec.MCPServerCatalogEntryName,
+			catalogName:          server.Spec.MCPCatalogID,
+			powerUserWorkspaceID: server.Spec.PowerUserWorkspaceID,
+		}
+		if policyTarget.catalogName == "" {
+			policyTarget.catalogName = server.Status.MCPCatalogID
+		}
 	} else {
 		// Also check SystemMCPServer resources (e.g. obot-mcp-server)
 		var systemServers v1.SystemMCPServerList
@@ -181,6 +191,13 @@ func (h *AuditLogHandler) SubmitAuditLogs(req api.Context) error {
 		}
 		mcpServerName = systemServers.Items[0].Name
 	}
+	policyTarget.mcpServerName = mcpServerName
+
+	var policies v1.MCPAuditLogPolicyList
+	if err := req.List(&policies); err != nil {
+		return fmt.Errorf("failed to list audit log policies: %w", err)
+	}
+	policy := resolveAuditLogPolicy(policies.Items, policyTarget)
 
 	var auditLogs []auditLogInput
 	if err := req.Read(&auditLogs); err != nil {
@@ -212,6 +229,7 @@ func (h *AuditLogHandler) SubmitAuditLogs(req api.Context) error {
 			auditLog.MCPServerDisplayName = auditLog.Metadata["mcpServerDisplayName"]
 		}
 
+		applyAuditLogPolicy(policy, &auditLog.MCPAuditLog)
 		req.GatewayClient.LogMCPAuditEntry(auditLog.MCPAuditLog)
 	}
 
@@ -518,3 +536,126 @@ func (h *AuditLogHandler) GetUsageStats(req api.Context) error {
 		Items:       result,
 	})
 }
+
+// GetUsageTimeSeries handles GET /api/mcp-usage-timeseries
+func (h *AuditLogHandler) GetUsageTimeSeries(req api.Context) error {
+	query := req.URL.Query()
+
+	opts := gateway.MCPUsageTimeSeriesOptions{
+		Interval:                   query.Get("interval"),
+		GroupBy:                    query.Get("group_by"),
+		MCPID:                      query.Get("mcp_id"),
+		UserIDs:                    parseMultiValueParam(query, "user_ids"),
+		MCPServerDisplayNames:      parseMultiValueParam(query, "mcp_server_display_names"),
+		MCPServerCatalogEntryNames: parseMultiValueParam(query, "mcp_server_catalog_entry_names"),
+		CallTypes:                  parseMultiValueParam(query, "call_types"),
+		CallIdentifiers:            parseMultiValueParam(query, "call_identifiers"),
+		ClientNames:                parseMultiValueParam(query, "client_names"),
+	}
+
+	if opts.Interval == "" {
+		opts.Interval = gateway.MCPUsageIntervalDay
+	}
+	if !slices.Contains([]string{gateway.MCPUsageIntervalHour, gateway.MCPUsageIntervalDay, gateway.MCPUsageIntervalWeek}, opts.Interval) {
+		return types.NewErrBadRequest("invalid interval %q, expected hour, day or week", opts.Interval)
+	}
+	if !slices.Contains([]string{"", gateway.MCPUsageGroupByServer, gateway.MCPUsageGroupByTool, gateway.MCPUsageGroupByUser,
+		gateway.MCPUsageGroupByClient, gateway.MCPUsageGroupByAPIKey}, opts.GroupBy) {
+		return types.NewErrBadRequest("invalid group_by %q, expected server, tool, user, client or api_key", opts.GroupBy)
+	}
+
+	var (
+		err        error
+		start, end time.Time
+	)
+	if startTime := query.Get("start_time"); startTime != "" {
+		start, err = time.Parse(time.RFC3339, startTime)
+		if err != nil {
+			return types.NewErrBadRequest("invalid start_time format, expected RFC3339")
+		}
+	} else {
+		// Default to last 7 days
+		start = time.Now().AddDate(0, 0, -7)
+	}
+
+	if endTime := query.Get("end_time"); endTime != "" {
+		end, err = time.Parse(time.RFC3339, endTime)
+		if err != nil {
+			return types.NewErrBadRequest("invalid end_time format, expected RFC3339")
+		}
+	} else {
+		end = time.Now()
+	}
+
+	opts.StartTime = start
+	opts.EndTime = end
+
+	result := types.MCPUsageTimeSeries{
+		Interval:  opts.Interval,
+		GroupBy:   opts.GroupBy,
+		TimeStart: *types.NewTime(start),
+		TimeEnd:   *types.NewTime(end),
+		Series:    []types.MCPUsageSeries{},
+	}
+
+	// Apply scope filtering based on user role (same logic as usage stats)
+	if !req.UserIsAdmin() && !req.UserIsAuditor() {
+		ownServerMCPIDs, err := getOwnServerMCPIDs(req)
+		if err != nil {
+			return fmt.Errorf("failed to get own server MCPIDs: %w", err)
+		}
+		opts.OwnServerMCPIDs = ownServerMCPIDs
+
+		// PowerUsers also see workspace servers
+		if req.UserIsPowerUser() {
+			workspaceID := system.GetPowerUserWorkspaceID(req.User.GetUID())
+			opts.PowerUserWorkspaceID = []string{workspaceID}
+		}
+
+		// Return empty if no access scope
+		if len(opts.OwnServerMCPIDs) == 0 && len(opts.PowerUserWorkspaceID) == 0 {
+			return req.Write(result)
+		}
+	}
+
+	series, err := req.GatewayClient.GetMCPUsageTimeSeries(req.Context(), opts)
+	if err != nil {
+		return err
+	}
+
+	for _, s := range series {
+		usageSeries := types.MCPUsageSeries{
+			MCPID:                s.MCPID,
+			MCPServerDisplayName: s.MCPServerDisplayName,
+			ToolName:             s.ToolName,
+			UserID:               s.UserID,
+			ClientName:           s.ClientName,
+			APIKey:               s.APIKey,
+			Points:               make([]types.MCPUsagePoint, 0, len(s.Points)),
+		}
+		for _, p := range s.Points {
+			point := types.MCPUsagePoint{
+				Time:                *types.NewTime(p.Time),
+				CallCount:           p.CallCount,
+				ErrorCount:          p.ErrorCount,
+				AvgProcessingTimeMs: p.AvgProcessingTimeMs,
+				P50ProcessingTimeMs: p.P50ProcessingTimeMs,
+				P95ProcessingTimeMs: p.P95ProcessingTimeMs,
+				P99ProcessingTimeMs: p.P99ProcessingTimeMs,
+			}
+			if p.CallCount > 0 {
+				point.ErrorRate = float64(p.ErrorCount) / float64(p.CallCount)
+			}
+			for _, status := range p.ResponseStatuses {
+				point.ResponseStatuses = append(point.ResponseStatuses, types.MCPUsageStatusCount{
+					ResponseStatus: status.ResponseStatus,
+					Count:          status.Count,
+				})
+			}
+			usageSeries.Points = append(usageSeries.Points, point)
+		}
+		result.Series = append(result.Series, usageSeries)
+	}
+
+	return req.Write(result)
+}
@@ -0,0 +1,134 @@
+package mcpgateway
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+const redactedHeaderValue = "[REDACTED]"
+
+// auditLogPolicyTarget identifies the MCP server whose audit logs a policy is resolved for.
+type auditLogPolicyTarget struct {
+	mcpServerName        string
+	catalogEntryName     string
+	catalogName          string
+	powerUserWorkspaceID string
+}
+
+// auditLogPolicySpecificity returns how specifically the policy matches the target, or -1 if it doesn't match.
+// A policy naming the server wins over one naming its catalog entry, then its workspace, then its catalog,
+// then the "*" selector.
+func auditLogPolicySpecificity(manifest types.MCPAuditLogPolicyManifest, target auditLogPolicyTarget) int {
+	specificity := -1
+	for _, resource := range manifest.Resources {
+		switch {
+		case resource.Type == types.ResourceTypeMCPServer && resource.ID == target.mcpServerName:
+			specificity = max(specificity, 4)
+		case resource.Type == types.ResourceTypeMCPServerCatalogEntry && target.catalogEntryName != "" && resource.ID == target.catalogEntryName:
+			specificity = max(specificity, 3)
+		case resource.Type == types.ResourceTypeMcpCatalog && target.catalogName != "" && resource.ID == target.catalogName:
+			specificity = max(specificity, 1)
+		case resource.Type == types.ResourceTypeSelector && resource.ID == "*":
+			specificity = max(specificity, 0)
+		}
+	}
+	if target.powerUserWorkspaceID != "" && slices.Contains(manifest.PowerUserWorkspaceIDs, target.powerUserWorkspaceID) {
+		specificity = max(specificity, 2)
+	}
+	return specificity
+}
+
+// resolveAuditLogPolicy returns the most specific policy that applies to the target, or nil if none does.
+// Ties are broken by the policy's name so that the result doesn't depend on the list order.
+func resolveAuditLogPolicy(policies []v1.MCPAuditLogPolicy, target auditLogPolicyTarget) *types.MCPAuditLogPolicyManifest {
+	var (
+		result          *v1.MCPAuditLogPolicy
+		bestSpecificity = -1
+	)
+	for i := range policies {
+		specificity := auditLogPolicySpecificity(policies[i].Spec.Manifest, target)
+		if specificity < 0 {
+			continue
+		}
+		if specificity > bestSpecificity || specificity == bestSpecificity && policies[i].Name < result.Name {
+			result = &policies[i]
+			bestSpecificity = specificity
+		}
+	}
+
+	if result == nil {
+		return nil
+	}
+	return &result.Spec.Manifest
+}
+
+// applyAuditLogPolicy changes the audit log so that it only captures what the policy allows.
+func applyAuditLogPolicy(policy *types.MCPAuditLogPolicyManifest, auditLog *gatewaytypes.MCPAuditLog) {
+	if policy == nil {
+		return
+	}
+
+	auditLog.RetentionDays = policy.RetentionDays
+
+	if policy.DisableBodyCapture {
+		auditLog.RequestBody = nil
+		auditLog.ResponseBody = nil
+	} else if policy.MaxBodyBytes > 0 {
+		auditLog.RequestBody = truncateAuditLogBody(auditLog.RequestBody, policy.MaxBodyBytes)
+		auditLog.ResponseBody = truncateAuditLogBody(auditLog.ResponseBody, policy.MaxBodyBytes)
+	}
+
+	if len(policy.RedactHeaders) > 0 {
+		auditLog.RequestHeaders = redactAuditLogHeaders(auditLog.RequestHeaders, policy.RedactHeaders)
+		auditLog.ResponseHeaders = redactAuditLogHeaders(auditLog.ResponseHeaders, policy.RedactHeaders)
+	}
+}
+
+// truncateAuditLogBody replaces a body larger than maxBytes with a JSON object holding the start of the body,
+// so that the stored body is still valid JSON.
+func truncateAuditLogBody(body json.RawMessage, maxBytes int) json.RawMessage {
+	if len(body) <= maxBytes {
+		return body
+	}
+
+	truncated, err := json.Marshal(map[string]any{
+		"truncated": true,
+		"size":      len(body),
+		"prefix":    string(body[:maxBytes]),
+	})
+	if err != nil {
+		return nil
+	}
+	return truncated
+}
+
+// redactAuditLogHeaders replaces the values of the named headers. Header names are matched case-insensitively.
+func redactAuditLogHeaders(headers json.RawMessage, names []string) json.RawMessage {
+	if len(headers) == 0 {
+		return headers
+	}
+
+	var values map[string]json.RawMessage
+	if err := json.Unmarshal(headers, &values); err != nil {
+		// Headers that can't be parsed can't be redacted, so don't store them at all.
+		return nil
+	}
+
+	redacted, _ := json.Marshal(redactedHeaderValue)
+	for name := range values {
+		if slices.ContainsFunc(names, func(n string) bool { return strings.EqualFold(n, name) }) {
+			values[name] = redacted
+		}
+	}
+
+	result, err := json.Marshal(values)
+	if err != nil {
+		return nil
+	}
+	return result
+}
@@ -0,0 +1,80 @@
+package mcpgateway
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestResolveAuditLogPolicy(t *testing.T) {
+	policy := func(name string, resources []types.Resource, workspaces ...string) v1.MCPAuditLogPolicy {
+		return v1.MCPAuditLogPolicy{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: v1.MCPAuditLogPolicySpec{Manifest: types.MCPAuditLogPolicyManifest{
+				DisplayName:           name,
+				Resources:             resources,
+				PowerUserWorkspaceIDs: workspaces,
+			}},
+		}
+	}
+	policies := []v1.MCPAuditLogPolicy{
+		policy("malp1b-all", []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}}),
+		policy("malp1a-all", []types.Resource{{Type: types.ResourceTypeSelector, ID: "*"}}),
+		policy("malp1catalog", []types.Resource{{Type: types.ResourceTypeMcpCatalog, ID: "default"}}),
+		policy("malp1workspace", nil, "puw1team"),
+		policy("malp1entry", []types.Resource{{Type: types.ResourceTypeMCPServerCatalogEntry, ID: "github"}}),
+		policy("malp1server", []types.Resource{{Type: types.ResourceTypeMCPServer, ID: "ms1github"}}),
+	}
+
+	for name, tt := range map[string]struct {
+		target   auditLogPolicyTarget
+		expected string
+	}{
+		"server":        {auditLogPolicyTarget{mcpServerName: "ms1github", catalogEntryName: "github", catalogName: "default", powerUserWorkspaceID: "puw1team"}, "malp1server"},
+		"entry":         {auditLogPolicyTarget{mcpServerName: "ms1other", catalogEntryName: "github", catalogName: "default", powerUserWorkspaceID: "puw1team"}, "malp1entry"},
+		"workspace":     {auditLogPolicyTarget{mcpServerName: "ms1other", catalogName: "default", powerUserWorkspaceID: "puw1team"}, "malp1workspace"},
+		"catalog":       {auditLogPolicyTarget{mcpServerName: "ms1other", catalogName: "default"}, "malp1catalog"},
+		"selector":      {auditLogPolicyTarget{mcpServerName: "sms1obot"}, "malp1a-all"},
+		"other catalog": {auditLogPolicyTarget{mcpServerName: "ms1other", catalogName: "other"}, "malp1a-all"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			result := resolveAuditLogPolicy(policies, tt.target)
+			require.NotNil(t, result)
+			assert.Equal(t, tt.expected, result.DisplayName)
+		})
+	}
+
+	assert.Nil(t, resolveAuditLogPolicy(policies[2:], auditLogPolicyTarget{mcpServerName: "sms1obot"}))
+}
+
+func TestApplyAuditLogPolicy(t *testing.T) {
+	auditLog := gatewaytypes.MCPAuditLog{
+		RequestBody:     json.RawMessage(`{"name":"search","arguments":{"query":"weather"}}`),
+		ResponseBody:    json.RawMessage(`{"ok":true}`),
+		RequestHeaders:  json.RawMessage(`{"authorization":"Bearer secret","Accept":["application/json"]}`),
+		ResponseHeaders: json.RawMessage(`{"Set-Cookie":["a=b","c=d"]}`),
+	}
+
+	applyAuditLogPolicy(&types.MCPAuditLogPolicyManifest{
+		RetentionDays: 30,
+		MaxBodyBytes:  12,
+		RedactHeaders: []string{"Authorization", "set-cookie"},
+	}, &auditLog)
+
+	assert.Equal(t, 30, auditLog.RetentionDays)
+	assert.JSONEq(t, `{"truncated":true,"size":49,"prefix":"{\"name\":\"sea"}`, string(auditLog.RequestBody))
+	assert.JSONEq(t, `{"ok":true}`, string(auditLog.ResponseBody))
+	assert.JSONEq(t, `{"authorization":"[REDACTED]","Accept":["application/json"]}`, string(auditLog.RequestHeaders))
+	assert.JSONEq(t, `{"Set-Cookie":"[REDACTED]"}`, string(auditLog.ResponseHeaders))
+
+	applyAuditLogPolicy(&types.MCPAuditLogPolicyManifest{DisableBodyCapture: true}, &auditLog)
+	assert.Nil(t, auditLog.RequestBody)
+	assert.Nil(t, auditLog.ResponseBody)
+	assert.Zero(t, auditLog.RetentionDays)
+}
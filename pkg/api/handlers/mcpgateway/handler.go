@@ -1,6 +1,7 @@
 package mcpgateway
 
 import (
+	"errors"
 	"fmt"
 	"maps"
 	"net/http"
@@ -16,17 +17,24 @@ import (
 	"github.com/obot-platform/obot/pkg/mcp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
+	"github.com/obot-platform/obot/pkg/webhookvalidation"
 	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 )
 
+// coldStartRetryAfterSeconds is how long clients are asked to wait before retrying a request to a server that is still
+// starting.
+const coldStartRetryAfterSeconds = "10"
+
 type Handler struct {
 	mcpSessionManager         *mcp.SessionManager
 	webhookHelper             *mcp.WebhookHelper
+	validators                *webhookvalidation.Cache
 	nanobotIntegrationEnabled bool
 	scope                     string
 	transport                 http.RoundTripper
+	webhookClient             *http.Client
 }
 
 func NewHandler(mcpSessionManager *mcp.SessionManager, webhookHelper *mcp.WebhookHelper, scopesSupported []string, nanobotIntegrationEnabled bool) *Handler {
@@ -37,9 +45,11 @@ func NewHandler(mcpSessionManager *mcp.SessionManager, webhookHelper *mcp.Webhoo
 	return &Handler{
 		mcpSessionManager:         mcpSessionManager,
 		webhookHelper:             webhookHelper,
+		validators:                webhookvalidation.NewCache(),
 		nanobotIntegrationEnabled: nanobotIntegrationEnabled,
 		scope:                     scope,
 		transport:                 otelhttp.NewTransport(http.DefaultTransport),
+		webhookClient:             &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)},
 	}
 }
 
@@ -49,11 +59,25 @@ func (h *Handler) Proxy(req api.Context) error {
 		return apierrors.NewUnauthorized("user is not authenticated")
 	}
 
-	mcpURL, allowDifferentPaths, err := h.ensureServerIsDeployed(req)
-	if err != nil {
+	mcpURL, allowDifferentPaths, validations, err := h.ensureServerIsDeployed(req)
+	if errors.Is(err, mcp.ErrColdStartTimeout) {
+		// The server is still starting. Ask the client to retry, instead of failing the request.
+		req.ResponseWriter.Header().Set("Retry-After", coldStartRetryAfterSeconds)
+		return types.NewErrHTTP(http.StatusServiceUnavailable, err.Error())
+	} else if err != nil {
 		return fmt.Errorf("failed to ensure server is deployed: %v", err)
 	}
 
+	responseValidation, rejected, err := h.checkRequest(req, validations)
+	if err != nil || rejected {
+		return err
+	}
+
+	var modifyResponse func(*http.Response) error
+	if responseValidation != nil {
+		modifyResponse = responseValidation.modifyResponse
+	}
+
 	u, err := url.Parse(mcpURL)
 	if err != nil {
 		http.Error(req.ResponseWriter, err.Error(), http.StatusInternalServerError)
@@ -61,7 +85,8 @@ func (h *Handler) Proxy(req api.Context) error {
 	}
 
 	(&httputil.ReverseProxy{
-		Transport: h.transport,
+		Transport:      h.transport,
+		ModifyResponse: modifyResponse,
 		Director: func(r *http.Request) {
 			r.Header.Set("X-Forwarded-Host", r.Host)
 			scheme := "https"
@@ -98,38 +123,54 @@ func (h *Handler) Proxy(req api.Context) error {
 	return nil
 }
 
-func (h *Handler) ensureServerIsDeployed(req api.Context) (string, bool, error) {
+// ensureServerIsDeployed launches the MCP server and returns its URL, whether requests can be sent to other paths,
+// and the validations that the gateway runs for it.
+func (h *Handler) ensureServerIsDeployed(req api.Context) (string, bool, gatewayValidations, error) {
 	mcpID := req.PathValue("mcp_id")
 
 	if system.IsSystemMCPServerID(mcpID) {
-		return h.ensureSystemServerIsDeployed(req, mcpID)
+		url, allowDifferentPaths, err := h.ensureSystemServerIsDeployed(req, mcpID)
+		return url, allowDifferentPaths, gatewayValidations{}, err
 	}
 
 	mcpID, mcpServer, mcpServerConfig, err := handlers.ServerForActionWithConnectID(req, mcpID)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to get mcp server config: %w", err)
+		return "", false, gatewayValidations{}, fmt.Errorf("failed to get mcp server config: %w", err)
 	}
 	if mcpServer.Spec.Template {
-		return "", false, apierrors.NewNotFound(schema.GroupResource{Group: "obot.obot.ai", Resource: "mcpserver"}, mcpID)
+		return "", false, gatewayValidations{}, apierrors.NewNotFound(schema.GroupResource{Group: "obot.obot.ai", Resource: "mcpserver"}, mcpID)
 	}
 
 	// Add-hoc authorization for nanobot agents
 	if h.nanobotIntegrationEnabled && mcpServerConfig.NanobotAgentName != "" {
 		var agent v1.NanobotAgent
 		if err = req.Get(&agent, mcpServerConfig.NanobotAgentName); err != nil {
-			return "", false, fmt.Errorf("failed to get nanobot agent %q: %w", mcpServerConfig.NanobotAgentName, err)
+			return "", false, gatewayValidations{}, fmt.Errorf("failed to get nanobot agent %q: %w", mcpServerConfig.NanobotAgentName, err)
 		}
 		if agent.Spec.UserID != req.User.GetUID() && (!req.UserCanImpersonate() || !req.UserIsAdmin()) {
-			return "", false, types.NewErrForbidden("user is not authorized to access nanobot agent %q", mcpServerConfig.NanobotAgentName)
+			return "", false, gatewayValidations{}, types.NewErrForbidden("user is not authorized to access nanobot agent %q", mcpServerConfig.NanobotAgentName)
 		}
 	}
 
 	url, err := h.mcpSessionManager.LaunchServer(req.Context(), mcpServerConfig)
 	if err != nil {
-		return "", false, fmt.Errorf("failed to launch mcp server: %w", err)
+		return "", false, gatewayValidations{}, fmt.Errorf("failed to launch mcp server: %w", err)
+	}
+
+	validations, err := h.validationsForServer(mcpServerConfig)
+	if err != nil {
+		return "", false, gatewayValidations{}, err
+	}
+
+	overrides, err := componentOverrides(req, mcpID, mcpServer)
+	if err != nil {
+		return "", false, gatewayValidations{}, err
+	}
+	if overrides != nil {
+		validations.rewriters = append(validations.rewriters, overrides)
 	}
 
-	return url, h.nanobotIntegrationEnabled && mcpServerConfig.NanobotAgentName != "", nil
+	return url, h.nanobotIntegrationEnabled && mcpServerConfig.NanobotAgentName != "", validations, nil
 }
 
 func (h *Handler) ensureSystemServerIsDeployed(req api.Context, mcpID string) (string, bool, error) {
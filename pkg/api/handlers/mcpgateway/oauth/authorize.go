@@ -150,24 +150,7 @@ func (h *handler) authorize(req api.Context) error {
 		})
 	}
 
-	scope := req.FormValue("scope")
-	if scope != "" {
-		var (
-			supported []string
-			scopes    = make(map[string]struct{})
-		)
-		for s := range strings.SplitSeq(oauthClient.Spec.Manifest.Scope, " ") {
-			scopes[s] = struct{}{}
-		}
-
-		for s := range strings.SplitSeq(scope, " ") {
-			if _, ok := scopes[s]; s != "" && ok {
-				supported = append(supported, s)
-			}
-		}
-
-		scope = strings.Join(supported, " ")
-	}
+	scope := filterScope(oauthClient.Spec.Manifest.Scope, req.FormValue("scope"))
 
 	mcpID := req.PathValue("mcp_id")
 	resource := req.FormValue("resource")
@@ -386,6 +369,18 @@ func (h *handler) oauthCallback(req api.Context) error {
 		return nil
 	}
 
+	if oauthAppAuthRequest.Spec.GrantType == grantTypeDeviceCode {
+		// There is no client to redirect to. The device's next poll of the token endpoint completes 1st level OAuth.
+		oauthAppAuthRequest.Spec.Approved = true
+		if err := req.Update(&oauthAppAuthRequest); err != nil {
+			return fmt.Errorf("failed to approve device authorization request: %w", err)
+		}
+
+		log.Infof("Completed MCP OAuth callback and approved device authorization request: authRequest=%s mcpServer=%s", oauthAppAuthRequest.Name, mcpServerID)
+		http.Redirect(req.ResponseWriter, req.Request, "/login_complete", http.StatusFound)
+		return nil
+	}
+
 	// Not a component of a composite MCP server, redirect to complete 1st level OAuth
 	// Update the authorization code since we only saved the hash of it the first time.
 	code := strings.ToLower(rand.Text() + rand.Text())
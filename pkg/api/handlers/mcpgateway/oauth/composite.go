@@ -91,6 +91,19 @@ func (h *handler) checkCompositeAuth(req api.Context) error {
 		return req.Write(pending)
 	}
 
+	if oauthAuthRequestID != "" && authRequest.Spec.GrantType == grantTypeDeviceCode {
+		// There is no client to redirect to, so approve the device authorization request and finish in the UI.
+		authRequest.Spec.Approved = true
+		if err := req.Update(&authRequest); err != nil {
+			return fmt.Errorf("failed to approve device authorization request: %w", err)
+		}
+
+		log.Infof("Composite OAuth completed; approved device authorization request: compositeMCPID=%s authRequest=%s", compositeMCPID, authRequest.Name)
+		return req.Write(map[string]string{
+			"redirect_uri": "/login_complete",
+		})
+	}
+
 	if oauthAuthRequestID != "" {
 		// All pending second level OAuth requests are complete, so produce a new authorization code and return redirect URL as JSON for client-side redirect.
 		code := strings.ToLower(rand.Text() + rand.Text())
@@ -0,0 +1,427 @@
+package oauth
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/api/handlers"
+	"github.com/obot-platform/obot/pkg/auth"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/storage/selectors"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	grantTypeDeviceCode  = "urn:ietf:params:oauth:grant-type:device_code"
+	deviceCodeExpiration = 10 * time.Minute
+	// devicePollInterval is the number of seconds that clients must wait between polls of the token endpoint.
+	devicePollInterval = 5
+	// userCodeAlphabet has no vowels, so that user codes don't spell words, and no easily confused letters.
+	userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+	userCodeLength   = 8
+
+	ErrAuthorizationPending = ErrorCode("authorization_pending")
+	ErrSlowDown             = ErrorCode("slow_down")
+	ErrExpiredToken         = ErrorCode("expired_token")
+)
+
+// DeviceAuthorizationResponse represents an RFC 8628 device authorization response
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceVerification describes a pending device authorization request to the user that is asked to approve it.
+type DeviceVerification struct {
+	ClientName string     `json:"clientName"`
+	ClientURI  string     `json:"clientURI,omitempty"`
+	Resource   string     `json:"resource,omitempty"`
+	Scope      string     `json:"scope,omitempty"`
+	ExpiresAt  types.Time `json:"expiresAt"`
+	// RedirectURI is set when the user must complete the MCP server's own OAuth flow before the request is approved.
+	RedirectURI string `json:"redirect_uri,omitempty"`
+	Approved    bool   `json:"approved,omitempty"`
+	Denied      bool   `json:"denied,omitempty"`
+}
+
+type deviceVerificationRequest struct {
+	Approve bool `json:"approve"`
+}
+
+// deviceAuthorization issues a device code and a user code to clients that can't redirect the user, as per RFC 8628.
+func (h *handler) deviceAuthorization(req api.Context) error {
+	if err := req.ParseForm(); err != nil {
+		return types.NewErrBadRequest("failed to parse request body: %v", err)
+	}
+
+	client, err := authenticateClient(req)
+	if err != nil {
+		return err
+	}
+
+	if !slices.Contains(client.Spec.Manifest.GrantTypes, grantTypeDeviceCode) {
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrUnauthorizedClient,
+			Description: "client is not allowed to use the device_code grant type",
+		})
+	}
+
+	resource := req.FormValue("resource")
+	mcpID, err := mcpIDFromResource(req.PathValue("mcp_id"), resource)
+	if err != nil {
+		return err
+	}
+
+	userCode, err := newUserCode()
+	if err != nil {
+		return fmt.Errorf("failed to generate user code: %w", err)
+	}
+	deviceCode := strings.ToLower(rand.Text() + rand.Text())
+
+	oauthAuthRequest := v1.OAuthAuthRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.OAuthAppPrefix,
+			Namespace:    client.Namespace,
+		},
+		Spec: v1.OAuthAuthRequestSpec{
+			Scope:            filterScope(client.Spec.Manifest.Scope, req.FormValue("scope")),
+			Resource:         resource,
+			ClientID:         client.Name,
+			GrantType:        grantTypeDeviceCode,
+			MCPID:            mcpID,
+			HashedDeviceCode: fmt.Sprintf("%x", sha256.Sum256([]byte(deviceCode))),
+			HashedUserCode:   hashUserCode(userCode),
+			PollInterval:     devicePollInterval,
+		},
+	}
+
+	if err := req.Create(&oauthAuthRequest); err != nil {
+		return fmt.Errorf("failed to create device authorization request: %w", err)
+	}
+	log.Infof("Created OAuth device authorization request: authRequest=%s client=%s requestedMCPID=%s", oauthAuthRequest.Name, client.Name, mcpID)
+
+	verificationURI := h.baseURL + "/device"
+	return req.Write(DeviceAuthorizationResponse{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?" + url.Values{"user_code": {userCode}}.Encode(),
+		ExpiresIn:               int(deviceCodeExpiration.Seconds()),
+		Interval:                devicePollInterval,
+	})
+}
+
+// doDeviceCode handles the token requests that clients poll with while the user approves their device code.
+func (h *handler) doDeviceCode(req api.Context, oauthClient v1.OAuthClient, deviceCode string) error {
+	if deviceCode == "" {
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: "device_code is required",
+		})
+	}
+
+	var oauthAuthRequestList v1.OAuthAuthRequestList
+	if err := req.Storage.List(req.Context(), &oauthAuthRequestList, &kclient.ListOptions{
+		Namespace: oauthClient.Namespace,
+		FieldSelector: fields.SelectorFromSet(selectors.RemoveEmpty(map[string]string{
+			"spec.hashedDeviceCode": fmt.Sprintf("%x", sha256.Sum256([]byte(deviceCode))),
+		})),
+	}); err != nil {
+		return err
+	}
+	if len(oauthAuthRequestList.Items) != 1 || oauthAuthRequestList.Items[0].Spec.ClientID != oauthClient.Name {
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: "device_code is invalid",
+		})
+	}
+
+	oauthAuthRequest := oauthAuthRequestList.Items[0]
+	now := time.Now()
+
+	if deviceCodeExpired(oauthAuthRequest, now) {
+		h.deleteDeviceRequest(req, &oauthAuthRequest)
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrExpiredToken,
+			Description: "device_code has expired",
+		})
+	}
+
+	if oauthAuthRequest.Spec.Denied {
+		h.deleteDeviceRequest(req, &oauthAuthRequest)
+		log.Infof("Denied OAuth device code token request because the user denied it: authRequest=%s client=%s", oauthAuthRequest.Name, oauthClient.Name)
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrAccessDenied,
+			Description: "the user denied the request",
+		})
+	}
+
+	if oauthAuthRequest.Spec.Approved {
+		// Device codes are one-time use, so only the poll that deletes the request gets a token.
+		if err := req.Delete(&oauthAuthRequest); err != nil {
+			return types.NewErrBadRequest("%v", Error{
+				Code:        ErrInvalidRequest,
+				Description: "device_code is invalid",
+			})
+		}
+		return h.issueTokens(req, oauthClient, oauthAuthRequest, grantTypeDeviceCode)
+	}
+
+	code := ErrAuthorizationPending
+	interval := time.Duration(max(oauthAuthRequest.Spec.PollInterval, devicePollInterval)) * time.Second
+	if last := oauthAuthRequest.Spec.LastPolledAt; last != nil && now.Sub(last.Time) < interval {
+		// The client is polling too quickly, so it must wait 5 seconds longer from now on.
+		code = ErrSlowDown
+		oauthAuthRequest.Spec.PollInterval = max(oauthAuthRequest.Spec.PollInterval, devicePollInterval) + devicePollInterval
+	}
+
+	oauthAuthRequest.Spec.LastPolledAt = &metav1.Time{Time: now}
+	if err := req.Update(&oauthAuthRequest); err != nil && !apierrors.IsConflict(err) {
+		// A conflict means that the user just acted on the request, and the next poll will see it.
+		return fmt.Errorf("failed to update device authorization request: %w", err)
+	}
+
+	return types.NewErrBadRequest("%v", Error{
+		Code:        code,
+		Description: "the user has not approved the request yet",
+	})
+}
+
+// getDeviceVerification describes the device authorization request with the user code in the path.
+func (h *handler) getDeviceVerification(req api.Context) error {
+	oauthAuthRequest, err := h.deviceRequestForUser(req)
+	if err != nil {
+		return err
+	}
+
+	verification, err := h.deviceVerification(req, *oauthAuthRequest)
+	if err != nil {
+		return err
+	}
+
+	return req.Write(verification)
+}
+
+// verifyDevice approves or denies the device authorization request with the user code in the path.
+func (h *handler) verifyDevice(req api.Context) error {
+	var input deviceVerificationRequest
+	if err := req.Read(&input); err != nil {
+		return types.NewErrBadRequest("invalid request body: %v", err)
+	}
+
+	oauthAuthRequest, err := h.deviceRequestForUser(req)
+	if err != nil {
+		return err
+	}
+
+	if oauthAuthRequest.Spec.Approved || oauthAuthRequest.Spec.Denied {
+		return types.NewErrHTTP(http.StatusConflict, "the request has already been approved or denied")
+	}
+
+	if !input.Approve {
+		oauthAuthRequest.Spec.Denied = true
+		if err := req.Update(oauthAuthRequest); err != nil {
+			return fmt.Errorf("failed to deny device authorization request: %w", err)
+		}
+		log.Infof("User denied OAuth device authorization request: authRequest=%s client=%s", oauthAuthRequest.Name, oauthAuthRequest.Spec.ClientID)
+
+		verification, err := h.deviceVerification(req, *oauthAuthRequest)
+		if err != nil {
+			return err
+		}
+		return req.Write(verification)
+	}
+
+	mcpID := oauthAuthRequest.Spec.MCPID
+	if mcpID != "" {
+		serverOrInstanceID, audience, err := handlers.MCPIDAndAudienceFromConnectURL(req, mcpID)
+		if err != nil {
+			return err
+		}
+
+		mcpID = serverOrInstanceID
+		audience = "/" + audience
+		if !strings.HasSuffix(oauthAuthRequest.Spec.Resource, audience) || oauthAuthRequest.Spec.MCPID != mcpID {
+			// Ensure the audience is what the server expects.
+			oauthAuthRequest.Spec.Resource = fmt.Sprintf("%s/mcp-connect%s", h.baseURL, audience)
+			oauthAuthRequest.Spec.MCPID = mcpID
+		}
+	}
+
+	authProviderName, authProviderNamespace := req.AuthProviderNameAndNamespace()
+	oauthAuthRequest.Spec.UserID = req.UserID()
+	oauthAuthRequest.Spec.AuthProviderUserID = auth.FirstExtraValue(req.User.GetExtra(), "auth_provider_user_id")
+	oauthAuthRequest.Spec.AuthProviderNamespace = authProviderNamespace
+	oauthAuthRequest.Spec.AuthProviderName = authProviderName
+
+	var redirectURI string
+	if mcpID != "" {
+		// Check whether the MCP server needs authentication. If it does, the request is approved when that completes.
+		mcpID, mcpServer, mcpServerConfig, err := handlers.ServerForActionWithConnectID(req, mcpID)
+		if err != nil {
+			return err
+		}
+
+		redirectURI, err = h.oauthChecker.CheckForMCPAuth(req, mcpServer, mcpServerConfig, req.User.GetUID(), mcpID, oauthAuthRequest.Name)
+		if err != nil {
+			return fmt.Errorf("failed to check MCP server authentication: %w", err)
+		}
+	}
+
+	oauthAuthRequest.Spec.Approved = redirectURI == ""
+	if err := req.Update(oauthAuthRequest); err != nil {
+		return fmt.Errorf("failed to approve device authorization request: %w", err)
+	}
+
+	if redirectURI != "" {
+		log.Infof("OAuth device authorization requires second-level MCP authentication: authRequest=%s mcpID=%s", oauthAuthRequest.Name, mcpID)
+	} else {
+		log.Infof("User approved OAuth device authorization request: authRequest=%s client=%s", oauthAuthRequest.Name, oauthAuthRequest.Spec.ClientID)
+	}
+
+	verification, err := h.deviceVerification(req, *oauthAuthRequest)
+	if err != nil {
+		return err
+	}
+	verification.RedirectURI = redirectURI
+	return req.Write(verification)
+}
+
+// deviceRequestForUser returns the pending device authorization request with the user code in the path, if the
+// requesting user can act on it.
+func (h *handler) deviceRequestForUser(req api.Context) (*v1.OAuthAuthRequest, error) {
+	authProviderName, authProviderNamespace := req.AuthProviderNameAndNamespace()
+	if !req.UserIsAuthenticated() ||
+		req.User.GetName() == "bootstrap" ||
+		authProviderName == "bootstrap" ||
+		authProviderNamespace == "bootstrap" {
+		return nil, types.NewErrForbidden("device authorization requires a user that is authenticated with an auth provider")
+	}
+
+	hashedUserCode := hashUserCode(req.PathValue("user_code"))
+	if hashedUserCode == "" {
+		return nil, types.NewErrNotFound("invalid or expired code")
+	}
+
+	var oauthAuthRequestList v1.OAuthAuthRequestList
+	if err := req.Storage.List(req.Context(), &oauthAuthRequestList, &kclient.ListOptions{
+		FieldSelector: fields.SelectorFromSet(selectors.RemoveEmpty(map[string]string{
+			"spec.hashedUserCode": hashedUserCode,
+		})),
+	}); err != nil {
+		return nil, err
+	}
+
+	// Once a user has acted on a request, no other user can.
+	if len(oauthAuthRequestList.Items) != 1 ||
+		deviceCodeExpired(oauthAuthRequestList.Items[0], time.Now()) ||
+		oauthAuthRequestList.Items[0].Spec.UserID != 0 && oauthAuthRequestList.Items[0].Spec.UserID != req.UserID() {
+		return nil, types.NewErrNotFound("invalid or expired code")
+	}
+
+	return &oauthAuthRequestList.Items[0], nil
+}
+
+func (h *handler) deviceVerification(req api.Context, oauthAuthRequest v1.OAuthAuthRequest) (DeviceVerification, error) {
+	var client v1.OAuthClient
+	if err := req.Storage.Get(req.Context(), kclient.ObjectKey{Namespace: oauthAuthRequest.Namespace, Name: oauthAuthRequest.Spec.ClientID}, &client); err != nil {
+		return DeviceVerification{}, fmt.Errorf("failed to get OAuth client: %w", err)
+	}
+
+	clientName := client.Spec.Manifest.ClientName
+	if clientName == "" {
+		clientName = client.Name
+	}
+
+	return DeviceVerification{
+		ClientName: clientName,
+		ClientURI:  client.Spec.Manifest.ClientURI,
+		Resource:   oauthAuthRequest.Spec.Resource,
+		Scope:      oauthAuthRequest.Spec.Scope,
+		ExpiresAt:  *types.NewTime(oauthAuthRequest.CreationTimestamp.Add(deviceCodeExpiration)),
+		Approved:   oauthAuthRequest.Spec.Approved,
+		Denied:     oauthAuthRequest.Spec.Denied,
+	}, nil
+}
+
+func (h *handler) deleteDeviceRequest(req api.Context, oauthAuthRequest *v1.OAuthAuthRequest) {
+	if err := req.Delete(oauthAuthRequest); err != nil {
+		// Don't return an error if we can't delete the auth request
+		log.Warnf("failed to delete device authorization request: %v", err)
+	}
+}
+
+func deviceCodeExpired(oauthAuthRequest v1.OAuthAuthRequest, now time.Time) bool {
+	return now.After(oauthAuthRequest.CreationTimestamp.Add(deviceCodeExpiration))
+}
+
+// newUserCode returns a random user code in the form BCDF-GHJK.
+func newUserCode() (string, error) {
+	var code strings.Builder
+	for i := range userCodeLength {
+		if i == userCodeLength/2 {
+			code.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, big.NewInt(int64(len(userCodeAlphabet))))
+		if err != nil {
+			return "", err
+		}
+		code.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return code.String(), nil
+}
+
+// hashUserCode hashes the user code after normalizing it, so that users can enter it in any case and with or without
+// the dash. It returns an empty string if the code can't be valid.
+func hashUserCode(userCode string) string {
+	normalized := strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(userCode))
+	if len(normalized) != userCodeLength || strings.Trim(normalized, userCodeAlphabet) != "" {
+		return ""
+	}
+	return fmt.Sprintf("%x", sha256.Sum256([]byte(normalized)))
+}
+
+// filterScope returns the scopes in requested that the client supports.
+func filterScope(clientScope, requested string) string {
+	if requested == "" {
+		return ""
+	}
+
+	var (
+		supported []string
+		scopes    = make(map[string]struct{})
+	)
+	for s := range strings.SplitSeq(clientScope, " ") {
+		scopes[s] = struct{}{}
+	}
+
+	for s := range strings.SplitSeq(requested, " ") {
+		if _, ok := scopes[s]; s != "" && ok {
+			supported = append(supported, s)
+		}
+	}
+
+	return strings.Join(supported, " ")
+}
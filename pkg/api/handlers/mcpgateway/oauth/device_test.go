@@ -0,0 +1,49 @@
+package oauth
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestNewUserCode(t *testing.T) {
+	code, err := newUserCode()
+	require.NoError(t, err)
+
+	require.Len(t, code, userCodeLength+1)
+	assert.Equal(t, byte('-'), code[userCodeLength/2])
+	assert.Empty(t, strings.Trim(strings.Replace(code, "-", "", 1), userCodeAlphabet))
+}
+
+func TestHashUserCode(t *testing.T) {
+	hashed := hashUserCode("BCDF-GHJK")
+	require.NotEmpty(t, hashed)
+
+	// Users can enter the code in any case and with or without the dash.
+	assert.Equal(t, hashed, hashUserCode("bcdfghjk"))
+	assert.Equal(t, hashed, hashUserCode(" bcdf ghjk"))
+
+	assert.NotEqual(t, hashed, hashUserCode("BCDF-GHJL"))
+	assert.Empty(t, hashUserCode("BCDF-GHJ"), "too short")
+	assert.Empty(t, hashUserCode("ABCD-EFGH"), "vowels are not in the alphabet")
+	assert.Empty(t, hashUserCode(""))
+}
+
+func TestDeviceCodeExpired(t *testing.T) {
+	created := time.Now()
+	request := v1.OAuthAuthRequest{ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(created)}}
+
+	assert.False(t, deviceCodeExpired(request, created.Add(deviceCodeExpiration-time.Second)))
+	assert.True(t, deviceCodeExpired(request, created.Add(deviceCodeExpiration+time.Second)))
+}
+
+func TestFilterScope(t *testing.T) {
+	assert.Equal(t, "profile", filterScope("profile email", "profile admin"))
+	assert.Empty(t, filterScope("profile", "admin"))
+	assert.Empty(t, filterScope("profile", ""))
+}
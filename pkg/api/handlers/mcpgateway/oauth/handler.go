@@ -31,6 +31,7 @@ func SetupHandlers(oauthChecker *MCPOAuthHandlerFactory, tokenStore mcp.GlobalTo
 	mux.HandleFunc("GET /oauth/authorize/{mcp_id}", h.authorize)
 	mux.HandleFunc("GET /oauth/callback/{oauth_auth_request}/{mcp_id}", h.callback)
 	mux.HandleFunc("POST /oauth/token/{mcp_id}", h.token)
+	mux.HandleFunc("POST /oauth/device_authorization/{mcp_id}", h.deviceAuthorization)
 	mux.HandleFunc("GET /oauth/mcp/callback", h.oauthCallback)
 
 	// These endpoints allow clients that don't follow the spec to connect to Obot MCP servers.
@@ -41,11 +42,18 @@ func SetupHandlers(oauthChecker *MCPOAuthHandlerFactory, tokenStore mcp.GlobalTo
 	mux.HandleFunc("GET /oauth/authorize", h.authorize)
 	mux.HandleFunc("GET /oauth/callback/{oauth_auth_request}", h.callback)
 	mux.HandleFunc("POST /oauth/token", h.token)
+	mux.HandleFunc("POST /oauth/device_authorization", h.deviceAuthorization)
+	mux.HandleFunc("POST /oauth/revoke", h.revoke)
+	mux.HandleFunc("POST /oauth/introspect", h.introspect)
 
 	mux.HandleFunc("GET /oauth/jwks.json", h.tokenService.ServeJWKS)
 	mux.HandleFunc("POST /oauth/replace-jwks", h.tokenService.ReplaceJWK)
 
 	mux.HandleFunc("GET /api/oauth/composite/{mcp_id}", h.checkCompositeAuth)
 
+	// The device verification page uses these to approve or deny device authorization requests.
+	mux.HandleFunc("GET /api/oauth/device/{user_code}", h.getDeviceVerification)
+	mux.HandleFunc("POST /api/oauth/device/{user_code}", h.verifyDevice)
+
 	mux.HandleFunc("GET /oauth/userinfo", h.userInfo)
 }
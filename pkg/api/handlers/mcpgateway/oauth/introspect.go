@@ -0,0 +1,90 @@
+package oauth
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+)
+
+// IntrospectionResponse represents an RFC 7662 token introspection response
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Username  string `json:"username,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	IssuedAt  int64  `json:"iat,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  string `json:"aud,omitempty"`
+	Issuer    string `json:"iss,omitempty"`
+	MCPID     string `json:"mcp_id,omitempty"`
+}
+
+// introspect handles RFC 7662 token introspection requests from resource servers. Only clients that authenticate with a
+// secret can introspect tokens. They can introspect any access token that was issued to an OAuth client, but only their
+// own refresh tokens.
+func (h *handler) introspect(req api.Context) error {
+	if err := req.ParseForm(); err != nil {
+		return types.NewErrBadRequest("failed to parse request body: %v", err)
+	}
+
+	client, err := authenticateClient(req)
+	if err != nil {
+		return err
+	}
+
+	switch client.Spec.Manifest.TokenEndpointAuthMethod {
+	case "client_secret_basic", "client_secret_post":
+	default:
+		log.Infof("Denied OAuth token introspection for client without a secret: client=%s/%s", client.Namespace, client.Name)
+		return types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
+	}
+
+	token := req.FormValue("token")
+	if token == "" {
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: "token is required",
+		})
+	}
+
+	refreshToken, tokenCtx, err := h.lookupToken(req, client, token, req.FormValue("token_type_hint"))
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case refreshToken != nil && refreshToken.Spec.ClientID == client.Name:
+		return req.Write(IntrospectionResponse{
+			Active:    true,
+			Scope:     refreshToken.Spec.Scope,
+			ClientID:  oauthClientID(client),
+			TokenType: "refresh_token",
+			IssuedAt:  refreshToken.CreationTimestamp.Unix(),
+			Subject:   fmt.Sprintf("%d", refreshToken.Spec.UserID),
+			Audience:  refreshToken.Spec.Resource,
+			Issuer:    h.baseURL,
+			MCPID:     refreshToken.Spec.MCPID,
+		})
+	case tokenCtx != nil && tokenCtx.OAuthClientID != "":
+		// Tokens that weren't issued to an OAuth client, like the tokens of runs, are not for resource servers.
+		return req.Write(IntrospectionResponse{
+			Active:    true,
+			Scope:     tokenCtx.OAuthScope,
+			ClientID:  tokenCtx.OAuthClientID,
+			Username:  tokenCtx.UserName,
+			TokenType: "Bearer",
+			ExpiresAt: tokenCtx.ExpiresAt.Unix(),
+			IssuedAt:  tokenCtx.IssuedAt.Unix(),
+			Subject:   tokenCtx.UserID,
+			Audience:  tokenCtx.Audience,
+			Issuer:    h.baseURL,
+			MCPID:     tokenCtx.MCPID,
+		})
+	}
+
+	return req.Write(IntrospectionResponse{})
+}
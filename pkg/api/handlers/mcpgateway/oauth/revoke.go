@@ -0,0 +1,135 @@
+package oauth
+
+import (
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/jwt/persistent"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// revokedGrantRetention is how long a revoked grant is remembered. It must be longer than any access token issued for a
+// grant lives, including the tokens issued by token exchange.
+const revokedGrantRetention = 2 * time.Hour
+
+// revoke handles RFC 7009 token revocation requests. Revoking an access or refresh token revokes the whole grant that
+// it was issued for: the refresh token, and all access tokens that were issued for it or exchanged from them.
+func (h *handler) revoke(req api.Context) error {
+	if err := req.ParseForm(); err != nil {
+		return types.NewErrBadRequest("failed to parse request body: %v", err)
+	}
+
+	client, err := authenticateClient(req)
+	if err != nil {
+		return err
+	}
+
+	token := req.FormValue("token")
+	if token == "" {
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: "token is required",
+		})
+	}
+
+	refreshToken, tokenCtx, err := h.lookupToken(req, client, token, req.FormValue("token_type_hint"))
+	if err != nil {
+		return err
+	}
+
+	var grantID string
+	switch {
+	case refreshToken != nil:
+		if refreshToken.Spec.ClientID != client.Name {
+			return types.NewErrBadRequest("%v", Error{
+				Code:        ErrUnauthorizedClient,
+				Description: "token was not issued to this client",
+			})
+		}
+		if refreshToken.Spec.GrantID == "" {
+			// Tokens issued before grants were tracked only have the refresh token to revoke.
+			if err := req.Delete(refreshToken); err != nil && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to revoke refresh token: %w", err)
+			}
+			log.Infof("Revoked OAuth refresh token: client=%s userID=%d", client.Name, refreshToken.Spec.UserID)
+			return nil
+		}
+		grantID = refreshToken.Spec.GrantID
+	case tokenCtx != nil:
+		if tokenCtx.OAuthClientID != oauthClientID(client) {
+			return types.NewErrBadRequest("%v", Error{
+				Code:        ErrUnauthorizedClient,
+				Description: "token was not issued to this client",
+			})
+		}
+		grantID = tokenCtx.GrantID
+	}
+
+	if grantID == "" {
+		// Invalid and already revoked tokens don't need to be revoked, and clients can't do anything about them.
+		return nil
+	}
+
+	if err := h.revokeGrant(req, client.Namespace, grantID); err != nil {
+		return err
+	}
+	log.Infof("Revoked OAuth grant: client=%s grantID=%s", client.Name, grantID)
+
+	return nil
+}
+
+// lookupToken returns the refresh token or the decoded access token that the token is, or neither if it isn't a valid
+// token. The hint only changes which kind of token is looked for first.
+func (h *handler) lookupToken(req api.Context, client v1.OAuthClient, token, hint string) (*v1.OAuthToken, *persistent.TokenContext, error) {
+	lookupRefreshToken := func() (*v1.OAuthToken, error) {
+		var oauthToken v1.OAuthToken
+		if err := req.Storage.Get(req.Context(), kclient.ObjectKey{Namespace: client.Namespace, Name: fmt.Sprintf("%x", sha256.Sum256([]byte(token)))}, &oauthToken); apierrors.IsNotFound(err) {
+			return nil, nil
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get refresh token: %w", err)
+		}
+		return &oauthToken, nil
+	}
+
+	if hint != "access_token" {
+		if oauthToken, err := lookupRefreshToken(); err != nil || oauthToken != nil {
+			return oauthToken, nil, err
+		}
+	}
+
+	if tokenCtx, err := h.tokenService.DecodeToken(req.Context(), token); err == nil {
+		return nil, tokenCtx, nil
+	}
+
+	if hint == "access_token" {
+		oauthToken, err := lookupRefreshToken()
+		return oauthToken, nil, err
+	}
+
+	return nil, nil, nil
+}
+
+// revokeGrant rejects all access tokens issued for the grant, and deletes its refresh token.
+func (h *handler) revokeGrant(req api.Context, namespace, grantID string) error {
+	if err := req.GatewayClient.RevokeOAuthGrant(req.Context(), grantID, time.Now().Add(revokedGrantRetention)); err != nil {
+		return fmt.Errorf("failed to revoke grant: %w", err)
+	}
+
+	var oauthTokens v1.OAuthTokenList
+	if err := req.Storage.List(req.Context(), &oauthTokens, kclient.InNamespace(namespace), kclient.MatchingFields{"spec.grantID": grantID}); err != nil {
+		return fmt.Errorf("failed to list refresh tokens: %w", err)
+	}
+
+	for _, oauthToken := range oauthTokens.Items {
+		if err := req.Delete(&oauthToken); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+	}
+
+	return nil
+}
@@ -38,6 +38,9 @@ const (
 	ErrUnsupportedGrantType = ErrorCode("unsupported_grant_type")
 )
 
+// grantTypeClientCredentials is for OAuth clients that are bound to a service account.
+const grantTypeClientCredentials = "client_credentials"
+
 // TokenExchangeResponse represents an RFC 8693 token exchange response
 type TokenExchangeResponse struct {
 	AccessToken     string `json:"access_token"`
@@ -51,67 +54,11 @@ func (h *handler) token(req api.Context) error {
 		return types.NewErrBadRequest("failed to parse request body: %v", err)
 	}
 
-	var clientSecret string
-	clientID := req.FormValue("client_id")
-	if clientID == "" {
-		creds := strings.TrimPrefix(req.Request.Header.Get("Authorization"), "Basic ")
-		if creds == "" {
-			log.Infof("Denied OAuth token request due to missing client credentials")
-			return types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
-		}
-
-		c, err := base64.StdEncoding.DecodeString(creds)
-		if err != nil {
-			log.Infof("Denied OAuth token request due to invalid basic auth encoding")
-			return types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
-		}
-
-		idx := bytes.LastIndex(c, []byte{':'})
-		if idx == -1 {
-			log.Infof("Denied OAuth token request due to malformed basic auth credentials")
-			return types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
-		}
-
-		clientID, clientSecret = string(c[:idx]), string(c[idx+1:])
-		if clientID == "" {
-			return types.NewErrBadRequest("%v", Error{
-				Code:        ErrInvalidRequest,
-				Description: "client_id is required",
-			})
-		}
-
-		clientID, err = url.QueryUnescape(clientID)
-		if err != nil {
-			return types.NewErrBadRequest("%v", Error{
-				Code:        ErrInvalidRequest,
-				Description: "client_id is invalid",
-			})
-		}
-	} else {
-		clientSecret = req.FormValue("client_secret")
-	}
-
-	clientNamespace, clientName, ok := strings.Cut(clientID, ":")
-	if !ok {
-		return types.NewErrBadRequest("%v", Error{
-			Code:        ErrInvalidRequest,
-			Description: "client_id is invalid",
-		})
-	}
-
-	var client v1.OAuthClient
-	if err := req.Storage.Get(req.Context(), kclient.ObjectKey{Namespace: clientNamespace, Name: clientName}, &client); err != nil {
+	client, err := authenticateClient(req)
+	if err != nil {
 		return err
 	}
 
-	switch client.Spec.Manifest.TokenEndpointAuthMethod {
-	case "client_secret_basic", "client_secret_post":
-		if bcrypt.CompareHashAndPassword(client.Spec.ClientSecretHash, []byte(clientSecret)) != nil {
-			log.Infof("Denied OAuth token request due to invalid client secret: client=%s/%s", client.Namespace, client.Name)
-			return types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
-		}
-	}
-
 	grantType := req.FormValue("grant_type")
 	if !slices.Contains(h.oauthConfig.GrantTypesSupported, grantType) {
 		return types.NewErrBadRequest("%v", Error{
@@ -129,10 +76,14 @@ func (h *handler) token(req api.Context) error {
 	log.Debugf("Processing OAuth token request: client=%s/%s grantType=%s", client.Namespace, client.Name, grantType)
 
 	switch grantType {
+	case grantTypeDeviceCode:
+		return h.doDeviceCode(req, client, req.FormValue("device_code"))
 	case "authorization_code":
 		return h.doAuthorizationCode(req, client, req.FormValue("code"), req.FormValue("code_verifier"))
 	case "refresh_token":
 		return h.doRefreshToken(req, client, req.FormValue("refresh_token"))
+	case grantTypeClientCredentials:
+		return h.doClientCredentials(req, client, req.FormValue("scope"), req.FormValue("resource"))
 	case "urn:ietf:params:oauth:grant-type:token-exchange":
 		return h.doTokenExchange(req, client, req.FormValue("resource"), req.FormValue("subject_token"), req.FormValue("subject_token_type"), req.FormValue("requested_token_type"))
 	default:
@@ -199,6 +150,11 @@ func (h *handler) doAuthorizationCode(req api.Context, oauthClient v1.OAuthClien
 		}
 	}
 
+	return h.issueTokens(req, oauthClient, oauthAuthRequest, "authorization_code")
+}
+
+// issueTokens writes a new access and refresh token for the user that completed the authorization request.
+func (h *handler) issueTokens(req api.Context, oauthClient v1.OAuthClient, oauthAuthRequest v1.OAuthAuthRequest, grantType string) error {
 	userID := fmt.Sprintf("%d", oauthAuthRequest.Spec.UserID)
 	user, err := req.GatewayClient.UserByID(req.Context(), userID)
 	if err != nil {
@@ -223,6 +179,8 @@ func (h *handler) doAuthorizationCode(req api.Context, oauthClient v1.OAuthClien
 		AuthProviderNamespace: oauthAuthRequest.Spec.AuthProviderNamespace,
 		AuthProviderUserID:    oauthAuthRequest.Spec.AuthProviderUserID,
 		MCPID:                 oauthAuthRequest.Spec.MCPID,
+		GrantID:               strings.ToLower(rand.Text()),
+		OAuthClientID:         oauthClientID(oauthClient),
 	}
 	tkn, err := h.tokenService.NewToken(req.Context(), tknCtx)
 	if err != nil {
@@ -245,13 +203,14 @@ func (h *handler) doAuthorizationCode(req api.Context, oauthClient v1.OAuthClien
 			AuthProviderName:      oauthAuthRequest.Spec.AuthProviderName,
 			AuthProviderUserID:    oauthAuthRequest.Spec.AuthProviderUserID,
 			MCPID:                 oauthAuthRequest.Spec.MCPID,
+			GrantID:               tknCtx.GrantID,
 		},
 	}
 
 	if err = req.Create(&oauthToken); err != nil {
 		return fmt.Errorf("failed to create oauth token: %w", err)
 	}
-	log.Infof("Issued OAuth access and refresh token via authorization_code: client=%s userID=%d mcpID=%s", oauthClient.Name, oauthAuthRequest.Spec.UserID, oauthAuthRequest.Spec.MCPID)
+	log.Infof("Issued OAuth access and refresh token via %s: client=%s userID=%d mcpID=%s", grantType, oauthClient.Name, oauthAuthRequest.Spec.UserID, oauthAuthRequest.Spec.MCPID)
 
 	return req.Write(types.OAuthToken{
 		AccessToken:  tkn,
@@ -304,9 +263,15 @@ func (h *handler) doRefreshToken(req api.Context, oauthClient v1.OAuthClient, re
 		oauthToken.Spec.Resource = fmt.Sprintf("%s/mcp-connect/%s", h.baseURL, mcpServerInstance.Spec.MCPServerName)
 	}
 
+	grantID := oauthToken.Spec.GrantID
+	if grantID == "" {
+		// Tokens issued before grants were tracked start a new grant.
+		grantID = strings.ToLower(rand.Text())
+	}
+
 	now := time.Now()
 	tknCtx := persistent.TokenContext{
-		Scope:                 oauthToken.Spec.Scope,
+		OAuthScope:            oauthToken.Spec.Scope,
 		Audience:              oauthToken.Spec.Resource,
 		IssuedAt:              now,
 		ExpiresAt:             now.Add(tokenExpiration),
@@ -319,6 +284,8 @@ func (h *handler) doRefreshToken(req api.Context, oauthClient v1.OAuthClient, re
 		AuthProviderNamespace: oauthToken.Spec.AuthProviderNamespace,
 		AuthProviderUserID:    oauthToken.Spec.AuthProviderUserID,
 		MCPID:                 oauthToken.Spec.MCPID,
+		GrantID:               grantID,
+		OAuthClientID:         oauthClientID(oauthClient),
 	}
 	tkn, err := h.tokenService.NewToken(req.Context(), tknCtx)
 	if err != nil {
@@ -336,10 +303,12 @@ func (h *handler) doRefreshToken(req api.Context, oauthClient v1.OAuthClient, re
 			Resource:              oauthToken.Spec.Resource,
 			ClientID:              oauthClient.Name,
 			UserID:                oauthToken.Spec.UserID,
+			Scope:                 oauthToken.Spec.Scope,
 			AuthProviderNamespace: oauthToken.Spec.AuthProviderNamespace,
 			AuthProviderName:      oauthToken.Spec.AuthProviderName,
 			AuthProviderUserID:    oauthToken.Spec.AuthProviderUserID,
 			MCPID:                 oauthToken.Spec.MCPID,
+			GrantID:               grantID,
 		},
 	}
 
@@ -356,6 +325,65 @@ func (h *handler) doRefreshToken(req api.Context, oauthClient v1.OAuthClient, re
 	})
 }
 
+// doClientCredentials issues an access token for the service account that the client is bound to. No refresh token is
+// issued, because the client can request a new access token with its credentials whenever it needs one.
+func (h *handler) doClientCredentials(req api.Context, oauthClient v1.OAuthClient, scope, resource string) error {
+	switch oauthClient.Spec.Manifest.TokenEndpointAuthMethod {
+	case "client_secret_basic", "client_secret_post":
+	default:
+		log.Infof("Denied client_credentials grant for client without a secret: client=%s/%s", oauthClient.Namespace, oauthClient.Name)
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrUnauthorizedClient,
+			Description: "client must authenticate with a client secret to use the client_credentials grant type",
+		})
+	}
+
+	user, err := req.GatewayClient.UserByID(req.Context(), oauthClient.Spec.Manifest.ServiceAccountID)
+	if err != nil || !user.ServiceAccount {
+		log.Infof("Denied client_credentials grant for client without a service account: client=%s/%s", oauthClient.Namespace, oauthClient.Name)
+		return types.NewErrBadRequest("%v", Error{
+			Code:        ErrUnauthorizedClient,
+			Description: "client is not bound to a service account",
+		})
+	}
+
+	mcpID, err := mcpIDFromResource(req.PathValue("mcp_id"), resource)
+	if err != nil {
+		return err
+	}
+
+	if scope == "" {
+		scope = oauthClient.Spec.Manifest.Scope
+	}
+
+	now := time.Now()
+	tknCtx := persistent.TokenContext{
+		Audience:      resource,
+		OAuthScope:    filterScope(oauthClient.Spec.Manifest.Scope, scope),
+		IssuedAt:      now,
+		ExpiresAt:     now.Add(tokenExpiration),
+		UserID:        fmt.Sprintf("%d", user.ID),
+		UserName:      user.Username,
+		UserEmail:     user.Email,
+		Picture:       user.IconURL,
+		UserGroups:    user.Role.Groups(),
+		MCPID:         mcpID,
+		GrantID:       strings.ToLower(rand.Text()),
+		OAuthClientID: oauthClientID(oauthClient),
+	}
+	tkn, err := h.tokenService.NewToken(req.Context(), tknCtx)
+	if err != nil {
+		return fmt.Errorf("failed to create auth token: %w", err)
+	}
+	log.Infof("Issued OAuth access token via client_credentials: client=%s userID=%d mcpID=%s", oauthClient.Name, user.ID, mcpID)
+
+	return req.Write(types.OAuthToken{
+		AccessToken: tkn,
+		TokenType:   "bearer",
+		ExpiresIn:   int(time.Until(tknCtx.ExpiresAt).Milliseconds() / 1000),
+	})
+}
+
 func (h *handler) doTokenExchange(req api.Context, oauthClient v1.OAuthClient, resource, subjectToken, subjectTokenType, requestedTokenType string) error {
 	if subjectToken == "" {
 		return types.NewErrBadRequest("%v", Error{
@@ -583,14 +611,20 @@ func (h *handler) doTokenExchange(req api.Context, oauthClient v1.OAuthClient, r
 
 		now := time.Now()
 		expiresAt := now.Add(time.Hour)
-		token, err := h.tokenService.NewToken(req.Context(), persistent.TokenContext{
+		tknCtx := persistent.TokenContext{
 			Audience:   h.baseURL,
 			IssuedAt:   now,
 			ExpiresAt:  expiresAt,
 			UserID:     userID,
 			UserGroups: userGroups,
 			Namespace:  system.DefaultNamespace,
-		})
+		}
+		if tokenCtx != nil {
+			// Revoking the subject token's grant also revokes this token.
+			tknCtx.GrantID = tokenCtx.GrantID
+			tknCtx.OAuthClientID = tokenCtx.OAuthClientID
+		}
+		token, err := h.tokenService.NewToken(req.Context(), tknCtx)
 		if err != nil {
 			return fmt.Errorf("failed to generate token: %w", err)
 		}
@@ -692,3 +726,101 @@ func validateAPIKeyAccess(ctx api.Context, apiKey *gwtypes.APIKey, mcpID string)
 
 	return fmt.Errorf("API key does not have access to MCP server %s", mcpID)
 }
+
+// authenticateClient returns the OAuth client of the request, after checking its secret if it has one.
+func authenticateClient(req api.Context) (v1.OAuthClient, error) {
+	var clientSecret string
+	clientID := req.FormValue("client_id")
+	if clientID == "" {
+		creds := strings.TrimPrefix(req.Request.Header.Get("Authorization"), "Basic ")
+		if creds == "" {
+			log.Infof("Denied OAuth client authentication due to missing client credentials")
+			return v1.OAuthClient{}, types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
+		}
+
+		c, err := base64.StdEncoding.DecodeString(creds)
+		if err != nil {
+			log.Infof("Denied OAuth client authentication due to invalid basic auth encoding")
+			return v1.OAuthClient{}, types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
+		}
+
+		idx := bytes.LastIndex(c, []byte{':'})
+		if idx == -1 {
+			log.Infof("Denied OAuth client authentication due to malformed basic auth credentials")
+			return v1.OAuthClient{}, types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
+		}
+
+		clientID, clientSecret = string(c[:idx]), string(c[idx+1:])
+		if clientID == "" {
+			return v1.OAuthClient{}, types.NewErrBadRequest("%v", Error{
+				Code:        ErrInvalidRequest,
+				Description: "client_id is required",
+			})
+		}
+
+		clientID, err = url.QueryUnescape(clientID)
+		if err != nil {
+			return v1.OAuthClient{}, types.NewErrBadRequest("%v", Error{
+				Code:        ErrInvalidRequest,
+				Description: "client_id is invalid",
+			})
+		}
+	} else {
+		clientSecret = req.FormValue("client_secret")
+	}
+
+	clientNamespace, clientName, ok := strings.Cut(clientID, ":")
+	if !ok {
+		return v1.OAuthClient{}, types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: "client_id is invalid",
+		})
+	}
+
+	var client v1.OAuthClient
+	if err := req.Storage.Get(req.Context(), kclient.ObjectKey{Namespace: clientNamespace, Name: clientName}, &client); err != nil {
+		return v1.OAuthClient{}, err
+	}
+
+	switch client.Spec.Manifest.TokenEndpointAuthMethod {
+	case "client_secret_basic", "client_secret_post":
+		if bcrypt.CompareHashAndPassword(client.Spec.ClientSecretHash, []byte(clientSecret)) != nil {
+			log.Infof("Denied OAuth client authentication due to invalid client secret: client=%s/%s", client.Namespace, client.Name)
+			return v1.OAuthClient{}, types.NewErrHTTP(http.StatusUnauthorized, "Invalid client credentials")
+		}
+	}
+
+	return client, nil
+}
+
+// mcpIDFromResource returns the ID of the MCP server that the resource URL is for. If the request was made for a
+// specific MCP server, then the resource must be for that server.
+func mcpIDFromResource(mcpID, resource string) (string, error) {
+	if resource == "" {
+		return mcpID, nil
+	}
+
+	u, err := url.Parse(resource)
+	if err != nil {
+		return "", types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: fmt.Sprintf("invalid resource URL: %s", resource),
+		})
+	}
+
+	if mcpID == "" {
+		return strings.TrimPrefix(u.Path, "/mcp-connect/"), nil
+	} else if !strings.HasSuffix(u.Path, "/"+mcpID) {
+		return "", types.NewErrBadRequest("%v", Error{
+			Code:        ErrInvalidRequest,
+			Description: fmt.Sprintf("resource doesn't match mcp_id: %s", mcpID),
+		})
+	}
+
+	return mcpID, nil
+}
+
+// oauthClientID returns the client ID that the OAuth client uses, which includes its namespace.
+func oauthClientID(oauthClient v1.OAuthClient) string {
+	return fmt.Sprintf("%s:%s", oauthClient.Namespace, oauthClient.Name)
+}
@@ -0,0 +1,32 @@
+package oauth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMCPIDFromResource(t *testing.T) {
+	mcpID, err := mcpIDFromResource("", "")
+	require.NoError(t, err)
+	assert.Empty(t, mcpID)
+
+	mcpID, err = mcpIDFromResource("ms1abc", "")
+	require.NoError(t, err)
+	assert.Equal(t, "ms1abc", mcpID)
+
+	mcpID, err = mcpIDFromResource("", "https://obot.example.com/mcp-connect/ms1abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ms1abc", mcpID)
+
+	mcpID, err = mcpIDFromResource("ms1abc", "https://obot.example.com/mcp-connect/ms1abc")
+	require.NoError(t, err)
+	assert.Equal(t, "ms1abc", mcpID)
+
+	_, err = mcpIDFromResource("ms1abc", "https://obot.example.com/mcp-connect/ms1def")
+	assert.Error(t, err, "resource for another MCP server")
+
+	_, err = mcpIDFromResource("", "https://obot.example.com/%zz")
+	assert.Error(t, err, "invalid URL")
+}
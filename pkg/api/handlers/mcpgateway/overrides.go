@@ -0,0 +1,103 @@
+package mcpgateway
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/mcp"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/webhookvalidation"
+)
+
+// messageRewriter rewrites the params of the requests that the MCP gateway forwards to a MCP server, and the results of
+// their responses.
+type messageRewriter interface {
+	RewriteParams(method string, params json.RawMessage) (json.RawMessage, error)
+	RewriteResult(method string, result json.RawMessage) (json.RawMessage, bool)
+}
+
+// componentOverrides returns the prompt and resource overrides that the composite server has for the MCP server, if it
+// is a component of a composite server.
+func componentOverrides(req api.Context, mcpID string, mcpServer v1.MCPServer) (*mcp.ComponentOverrideSet, error) {
+	compositeName, componentID := mcpServer.Spec.CompositeName, mcpServer.Spec.MCPServerCatalogEntryName
+	if compositeName == "" && mcpID != mcpServer.Name {
+		// Multi-user servers are components of composite servers through their instances.
+		var instance v1.MCPServerInstance
+		if err := req.Get(&instance, mcpID); err != nil {
+			return nil, fmt.Errorf("failed to get MCP server instance %q: %w", mcpID, err)
+		}
+		compositeName, componentID = instance.Spec.CompositeName, instance.Spec.MCPServerName
+	}
+	if compositeName == "" {
+		return nil, nil
+	}
+
+	var composite v1.MCPServer
+	if err := req.Get(&composite, compositeName); err != nil {
+		return nil, fmt.Errorf("failed to get composite MCP server %q: %w", compositeName, err)
+	}
+	if composite.Spec.Manifest.CompositeConfig == nil {
+		return nil, nil
+	}
+
+	for _, component := range composite.Spec.Manifest.CompositeConfig.ComponentServers {
+		if component.ComponentID() == componentID {
+			return mcp.NewComponentOverrideSet(component), nil
+		}
+	}
+	return nil, nil
+}
+
+// applyOverrides rewrites the params of the JSON-RPC messages of a POST request with the rewriters, and replaces the
+// request body if any of them changed. If a message is invalid, a JSON-RPC error is written and true is returned.
+func applyOverrides(req api.Context, rewriters []messageRewriter, msgs []webhookvalidation.Request, batch bool) ([]webhookvalidation.Request, bool, error) {
+	var changed bool
+	for i, msg := range msgs {
+		params := msg.Params
+		for _, r := range rewriters {
+			var err error
+			if params, err = r.RewriteParams(msg.Method, params); err != nil {
+				return nil, true, writeInvalidParams(req, msgs, batch, err)
+			}
+		}
+		if bytes.Equal(params, msg.Params) {
+			continue
+		}
+
+		var err error
+		if msgs[i], err = msg.WithParams(params); err != nil {
+			return nil, false, err
+		}
+		changed = true
+	}
+
+	if !changed {
+		return msgs, false, nil
+	}
+
+	body, err := webhookvalidation.EncodeRequests(msgs, batch)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to encode request body: %w", err)
+	}
+	req.Request.Body = io.NopCloser(bytes.NewReader(body))
+	req.Request.ContentLength = int64(len(body))
+
+	return msgs, false, nil
+}
+
+func writeInvalidParams(req api.Context, msgs []webhookvalidation.Request, batch bool, invalid error) error {
+	resp := webhookvalidation.ErrorResponse(msgs, batch, webhookvalidation.ErrorCodeInvalidParams, invalid.Error())
+	if resp == nil {
+		http.Error(req.ResponseWriter, invalid.Error(), http.StatusBadRequest)
+		return nil
+	}
+
+	req.ResponseWriter.Header().Set("Content-Type", "application/json")
+	req.ResponseWriter.WriteHeader(http.StatusOK)
+	_, err := req.ResponseWriter.Write(resp)
+	return err
+}
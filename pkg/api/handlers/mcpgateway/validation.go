@@ -0,0 +1,292 @@
+package mcpgateway
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/api/handlers"
+	gateway "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/mcp"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/webhookvalidation"
+)
+
+var log = logger.Package()
+
+// shadowWebhookTimeout is the timeout for calling a webhook in shadow mode.
+const shadowWebhookTimeout = 30 * time.Second
+
+// gatewayValidations are the validations that the MCP gateway runs for a MCP server.
+type gatewayValidations struct {
+	validators []*webhookvalidation.Validator
+	// shadowWebhooks are the webhook validations in shadow mode. They are called without enforcing their decisions.
+	shadowWebhooks []*v1.MCPWebhookValidation
+	// rewriters apply the tool, prompt and resource overrides to the messages before they are validated, and to the
+	// results of their responses.
+	rewriters []messageRewriter
+}
+
+// validationsForServer returns the validations that are run by the MCP gateway for the MCP server.
+func (h *Handler) validationsForServer(serverConfig mcp.ServerConfig) (gatewayValidations, error) {
+	validations, err := h.webhookHelper.GetGatewayValidationsForMCPServer(serverConfig)
+	if err != nil {
+		return gatewayValidations{}, fmt.Errorf("failed to get gateway validations: %w", err)
+	}
+
+	var result gatewayValidations
+	if overrides := mcp.NewToolOverrideSet(serverConfig); overrides != nil {
+		result.rewriters = append(result.rewriters, overrides)
+	}
+	for _, validation := range validations {
+		if !validation.Spec.Manifest.ValidationType.IsBuiltin() {
+			result.shadowWebhooks = append(result.shadowWebhooks, validation)
+			continue
+		}
+
+		displayName := validation.Spec.Manifest.Name
+		if displayName == "" {
+			displayName = validation.Name
+		}
+
+		v, err := h.validators.Get(validation.Name, validation.Generation, displayName, validation.Spec.Manifest)
+		if err != nil {
+			// The manifest is validated when it is saved, so this should only happen if it was changed directly.
+			log.Errorf("failed to compile built-in validation %s: %v", validation.Name, err)
+			continue
+		}
+		result.validators = append(result.validators, v)
+	}
+
+	return result, nil
+}
+
+// responseValidation redacts the responses to the messages of a request, and applies the overrides to their results.
+type responseValidation struct {
+	redactors []*webhookvalidation.Validator
+	rewriters []messageRewriter
+	msgs      []webhookvalidation.Request
+	recorder  *shadowRecorder
+}
+
+// checkRequest applies the overrides and runs the gateway validations on the JSON-RPC messages in the body of a POST
+// request. If a validation rejects a message, or a message is invalid, a JSON-RPC error is written and true is
+// returned. Otherwise, the rewriting of the responses to the messages is returned, if any validations redact them or
+// there are overrides. The decisions of validations in shadow mode are recorded instead of enforced.
+func (h *Handler) checkRequest(req api.Context, validations gatewayValidations) (*responseValidation, bool, error) {
+	if len(validations.validators) == 0 && len(validations.shadowWebhooks) == 0 && len(validations.rewriters) == 0 ||
+		req.Request.Method != http.MethodPost || req.Request.Body == nil {
+		return nil, false, nil
+	}
+
+	body, err := io.ReadAll(req.Request.Body)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to read request body: %w", err)
+	}
+	req.Request.Body = io.NopCloser(bytes.NewReader(body))
+
+	msgs, batch, err := webhookvalidation.ParseRequests(body)
+	if err != nil {
+		// Let the MCP server respond to messages that can't be parsed.
+		return nil, false, nil
+	}
+
+	if len(validations.rewriters) > 0 {
+		var rejected bool
+		msgs, rejected, err = applyOverrides(req, validations.rewriters, msgs, batch)
+		if err != nil || rejected {
+			return nil, rejected, err
+		}
+	}
+
+	recorder := &shadowRecorder{
+		gatewayClient: req.GatewayClient,
+		mcpID:         req.PathValue("mcp_id"),
+		userID:        req.User.GetUID(),
+	}
+
+	for _, msg := range msgs {
+		identifier := msg.Identifier()
+		for _, validation := range validations.shadowWebhooks {
+			if validation.Spec.Manifest.Selectors.Matches(msg.Method, identifier) {
+				go h.callShadowWebhook(req.GPTClient, recorder, validation, msg)
+			}
+		}
+	}
+
+	var redactors []*webhookvalidation.Validator
+	for _, v := range validations.validators {
+		for _, msg := range msgs {
+			if err := v.CheckRequest(msg, req.User.GetUID()); err != nil {
+				var rejected *webhookvalidation.RejectedError
+				if !errors.As(err, &rejected) {
+					return nil, false, err
+				}
+				if v.Shadow() {
+					recorder.record(v.Name(), msg, types.MCPWebhookDecisionRejected, rejected.Reason, 0, 0)
+					continue
+				}
+				return nil, true, writeRejection(req, msgs, batch, rejected)
+			}
+			if v.Shadow() && !v.RedactsResponses() && msg.Method == "tools/call" && v.Matches(msg) {
+				recorder.record(v.Name(), msg, types.MCPWebhookDecisionAccepted, "", 0, 0)
+			}
+		}
+
+		if v.RedactsResponses() && slices.ContainsFunc(msgs, v.Matches) {
+			redactors = append(redactors, v)
+		}
+	}
+
+	if len(redactors) == 0 && len(validations.rewriters) == 0 {
+		return nil, false, nil
+	}
+
+	return &responseValidation{
+		redactors: redactors,
+		rewriters: validations.rewriters,
+		msgs:      msgs,
+		recorder:  recorder,
+	}, false, nil
+}
+
+func writeRejection(req api.Context, msgs []webhookvalidation.Request, batch bool, rejected *webhookvalidation.RejectedError) error {
+	log.Infof("Rejected MCP request: mcpID=%s userID=%s validation=%s reason=%s", req.PathValue("mcp_id"), req.User.GetUID(), rejected.Validation, rejected.Reason)
+
+	resp := webhookvalidation.RejectionResponse(msgs, batch, rejected)
+	if resp == nil {
+		http.Error(req.ResponseWriter, rejected.Error(), http.StatusBadRequest)
+		return nil
+	}
+
+	req.ResponseWriter.Header().Set("Content-Type", "application/json")
+	req.ResponseWriter.WriteHeader(http.StatusOK)
+	_, err := req.ResponseWriter.Write(resp)
+	return err
+}
+
+// callShadowWebhook sends the message to the webhook in shadow mode and records its decision.
+func (h *Handler) callShadowWebhook(gptClient *gptscript.GPTScript, recorder *shadowRecorder, validation *v1.MCPWebhookValidation, msg webhookvalidation.Request) {
+	ctx, cancel := context.WithTimeout(context.Background(), shadowWebhookTimeout)
+	defer cancel()
+
+	secret, err := handlers.MCPWebhookValidationSecret(ctx, gptClient, validation.Name)
+	if err != nil {
+		log.Errorf("Failed to get secret of webhook validation %s: %v", validation.Name, err)
+		return
+	}
+
+	result, err := webhookvalidation.CallWebhook(ctx, h.webhookClient, validation.Spec.Manifest.URL, secret, msg.Raw)
+	message := result.Response
+	if err != nil {
+		result.Decision = types.MCPWebhookDecisionRejected
+		message = err.Error()
+	}
+	recorder.record(validation.Name, msg, result.Decision, message, result.Latency.Milliseconds(), result.StatusCode)
+}
+
+// modifyResponse is used for httputil.ReverseProxy.ModifyResponse to rewrite the results in JSON and event stream
+// responses. Redactions of validators in shadow mode are recorded instead of applied.
+func (rv *responseValidation) modifyResponse(resp *http.Response) error {
+	mediaType, _, _ := mime.ParseMediaType(resp.Header.Get("Content-Type"))
+	switch mediaType {
+	case "application/json":
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			return fmt.Errorf("failed to read response body: %w", err)
+		}
+		_ = resp.Body.Close()
+
+		body = rv.rewrite(body)
+		resp.Body = io.NopCloser(bytes.NewReader(body))
+		resp.ContentLength = int64(len(body))
+		resp.Header.Set("Content-Length", strconv.Itoa(len(body)))
+	case "text/event-stream":
+		resp.Body = webhookvalidation.RewriteEventStream(resp.Body, rv.rewrite)
+		resp.ContentLength = -1
+		resp.Header.Del("Content-Length")
+	}
+	return nil
+}
+
+// rewrite applies the overrides to the results and redacts the results in a JSON-RPC response or batch of responses.
+func (rv *responseValidation) rewrite(data []byte) []byte {
+	if len(rv.rewriters) > 0 {
+		data = webhookvalidation.RewriteResults(data, rv.rewriteResult)
+	}
+	data, redactors := webhookvalidation.RedactMessage(data, rv.redactors)
+	rv.recordShadowRedactions(redactors)
+	return data
+}
+
+// rewriteResult applies the overrides to the result of the response to the request with the ID.
+func (rv *responseValidation) rewriteResult(id, result json.RawMessage) (json.RawMessage, bool) {
+	i := slices.IndexFunc(rv.msgs, func(msg webhookvalidation.Request) bool {
+		return len(msg.ID) > 0 && bytes.Equal(msg.ID, id)
+	})
+	if i < 0 {
+		return result, false
+	}
+
+	var changed bool
+	for _, r := range rv.rewriters {
+		if rewritten, ok := r.RewriteResult(rv.msgs[i].Method, result); ok {
+			result, changed = rewritten, true
+		}
+	}
+	return result, changed
+}
+
+func (rv *responseValidation) recordShadowRedactions(redactors []*webhookvalidation.Validator) {
+	for _, v := range redactors {
+		if !v.Shadow() {
+			continue
+		}
+		if i := slices.IndexFunc(rv.msgs, v.Matches); i >= 0 {
+			rv.recorder.record(v.Name(), rv.msgs[i], types.MCPWebhookDecisionRedacted, "", 0, 0)
+		}
+	}
+}
+
+// shadowRecorder records the decisions of validations in shadow mode for the requests to a MCP server.
+type shadowRecorder struct {
+	gatewayClient *gateway.Client
+	mcpID, userID string
+}
+
+func (r *shadowRecorder) record(validationName string, msg webhookvalidation.Request, decision types.MCPWebhookDecision, message string, latencyMs int64, statusCode int) {
+	d := &gatewaytypes.MCPWebhookShadowDecision{
+		ValidationName:    validationName,
+		MCPID:             r.mcpID,
+		UserID:            r.userID,
+		CallType:          msg.Method,
+		CallIdentifier:    msg.Identifier(),
+		Decision:          string(decision),
+		Message:           message,
+		LatencyMs:         latencyMs,
+		WebhookStatusCode: statusCode,
+	}
+
+	// Recording the decision shouldn't delay the request.
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := r.gatewayClient.LogMCPWebhookShadowDecision(ctx, d); err != nil {
+			log.Errorf("Failed to record shadow decision of webhook validation %s: %v", validationName, err)
+		}
+	}()
+}
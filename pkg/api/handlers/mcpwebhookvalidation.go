@@ -1,18 +1,28 @@
 package handlers
 
 import (
+	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/gptscript-ai/go-gptscript"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
+	"github.com/obot-platform/obot/pkg/webhookvalidation"
+	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// webhookTestTimeout is the timeout for calling a webhook when testing a validation.
+const webhookTestTimeout = 30 * time.Second
+
 type MCPWebhookValidationHandler struct{}
 
 func NewMCPWebhookValidationHandler() *MCPWebhookValidationHandler {
@@ -64,8 +74,8 @@ func (m *MCPWebhookValidationHandler) Create(req api.Context) error {
 		return types.NewErrBadRequest("failed to read manifest: %v", err)
 	}
 
-	if err := manifest.Validate(); err != nil {
-		return types.NewErrBadRequest("invalid manifest: %v", err)
+	if err := validateMCPWebhookValidationManifest(&manifest); err != nil {
+		return err
 	}
 
 	var secretCred map[string]string
@@ -116,8 +126,8 @@ func (m *MCPWebhookValidationHandler) Update(req api.Context) error {
 		return types.NewErrBadRequest("failed to read manifest: %v", err)
 	}
 
-	if err := manifest.Validate(); err != nil {
-		return types.NewErrBadRequest("invalid manifest: %v", err)
+	if err := validateMCPWebhookValidationManifest(&manifest); err != nil {
+		return err
 	}
 
 	var secretCred map[string]string
@@ -166,6 +176,10 @@ func (m *MCPWebhookValidationHandler) Delete(req api.Context) error {
 		return fmt.Errorf("failed to delete credential: %w", err)
 	}
 
+	if err := req.GatewayClient.DeleteMCPWebhookShadowDecisions(req.Context(), validation.Name); err != nil {
+		return fmt.Errorf("failed to delete shadow decisions: %w", err)
+	}
+
 	if err := req.Delete(&validation); err != nil {
 		return fmt.Errorf("failed to delete mcp webhook validation: %w", err)
 	}
@@ -187,6 +201,161 @@ func (m *MCPWebhookValidationHandler) RemoveSecret(req api.Context) error {
 	return nil
 }
 
+// Test sends a tools/call request through the validation and returns its decision without enforcing it.
+// The request is either given or replayed from a MCP audit log.
+func (m *MCPWebhookValidationHandler) Test(req api.Context) error {
+	var validation v1.MCPWebhookValidation
+	if err := req.Get(&validation, req.PathValue("mcp_webhook_validation_id")); err != nil {
+		return err
+	}
+
+	var input types.MCPWebhookValidationTestRequest
+	if err := req.Read(&input); err != nil {
+		return types.NewErrBadRequest("failed to read test request: %v", err)
+	}
+
+	body := input.Request
+	var response json.RawMessage
+	if input.AuditLogID != 0 {
+		if len(body) > 0 {
+			return types.NewErrBadRequest("only one of request and auditLogID can be set")
+		}
+		// Replaying sends the request of the audit log to the webhook, so it requires the same access as reading it.
+		if !req.UserIsAuditor() {
+			return types.NewErrForbidden("only auditors can replay the requests of audit logs")
+		}
+
+		auditLog, err := req.GatewayClient.GetMCPAuditLog(req.Context(), input.AuditLogID, true)
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return types.NewErrNotFound("audit log %d not found", input.AuditLogID)
+		} else if err != nil {
+			return fmt.Errorf("failed to get audit log: %w", err)
+		}
+		if auditLog.CallType != "tools/call" {
+			return types.NewErrBadRequest("audit log %d is not for a tools/call request", input.AuditLogID)
+		}
+
+		body, response = auditLog.RequestBody, auditLog.ResponseBody
+	}
+	if len(body) == 0 {
+		return types.NewErrBadRequest("request or auditLogID is required")
+	}
+
+	msgs, batch, err := webhookvalidation.ParseRequests(body)
+	if err != nil || batch || msgs[0].Method != "tools/call" {
+		return types.NewErrBadRequest("request must be a single JSON-RPC tools/call request")
+	}
+	msg := msgs[0]
+
+	manifest := validation.Spec.Manifest
+	result := types.MCPWebhookValidationTestResult{
+		Decision: types.MCPWebhookDecisionAccepted,
+		Request:  msg.Raw,
+	}
+
+	if !manifest.ValidationType.IsBuiltin() {
+		secret, err := MCPWebhookValidationSecret(req.Context(), req.GPTClient, validation.Name)
+		if err != nil {
+			return err
+		}
+
+		ctx, cancel := context.WithTimeout(req.Context(), webhookTestTimeout)
+		defer cancel()
+
+		webhookResult, err := webhookvalidation.CallWebhook(ctx, http.DefaultClient, manifest.URL, secret, msg.Raw)
+		result.Decision = webhookResult.Decision
+		result.StatusCode = webhookResult.StatusCode
+		result.Response = webhookResult.Response
+		result.LatencyMs = webhookResult.Latency.Milliseconds()
+		if err != nil {
+			result.Decision = types.MCPWebhookDecisionRejected
+			result.Message = err.Error()
+		}
+	} else {
+		displayName := manifest.Name
+		if displayName == "" {
+			displayName = validation.Name
+		}
+
+		// Use a new validator without shadow mode, so that the test sees what the validation would do and doesn't
+		// count towards the rate limits of real calls.
+		manifest.ShadowMode = false
+		v, err := webhookvalidation.New(validation.Name, displayName, manifest)
+		if err != nil {
+			return fmt.Errorf("failed to compile validation: %w", err)
+		}
+
+		start := time.Now()
+		if err := v.CheckRequest(msg, req.User.GetUID()); err != nil {
+			result.Decision = types.MCPWebhookDecisionRejected
+			result.Message = err.Error()
+		} else if redacted, redactors := webhookvalidation.RedactMessage(response, []*webhookvalidation.Validator{v}); len(redactors) > 0 {
+			result.Decision = types.MCPWebhookDecisionRedacted
+			result.Response = string(redacted)
+		}
+		result.LatencyMs = time.Since(start).Milliseconds()
+	}
+
+	if result.Message == "" && !manifest.Selectors.Matches(msg.Method, msg.Identifier()) {
+		result.Message = "the request doesn't match the selectors of the validation, so real calls like it are not validated"
+	}
+
+	return req.Write(result)
+}
+
+// ListShadowDecisions returns the most recent decisions that the validation recorded in shadow mode.
+func (m *MCPWebhookValidationHandler) ListShadowDecisions(req api.Context) error {
+	var validation v1.MCPWebhookValidation
+	if err := req.Get(&validation, req.PathValue("mcp_webhook_validation_id")); err != nil {
+		return err
+	}
+
+	limit := 100
+	if l := req.URL.Query().Get("limit"); l != "" {
+		if parsed, err := strconv.Atoi(l); err == nil && parsed > 0 {
+			limit = min(parsed, 1000)
+		}
+	}
+
+	decisions, err := req.GatewayClient.GetMCPWebhookShadowDecisions(req.Context(), validation.Name, limit)
+	if err != nil {
+		return err
+	}
+
+	items := make([]types.MCPWebhookShadowDecision, 0, len(decisions))
+	for _, d := range decisions {
+		items = append(items, gatewaytypes.ConvertMCPWebhookShadowDecision(d))
+	}
+
+	return req.Write(types.MCPWebhookShadowDecisionList{Items: items})
+}
+
+// MCPWebhookValidationSecret returns the secret that the payloads sent to the webhook of the validation are signed with.
+func MCPWebhookValidationSecret(ctx context.Context, gptClient *gptscript.GPTScript, name string) (string, error) {
+	cred, err := gptClient.RevealCredential(ctx, []string{system.MCPWebhookValidationCredentialContext}, name)
+	if errors.As(err, &gptscript.ErrNotFound{}) {
+		return "", nil
+	} else if err != nil {
+		return "", fmt.Errorf("failed to reveal credential: %w", err)
+	}
+	return cred.Env["secret"], nil
+}
+
+func validateMCPWebhookValidationManifest(manifest *types.MCPWebhookValidationManifest) error {
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	if manifest.ValidationType.IsBuiltin() {
+		// Compile the built-in validation so that problems like unresolvable schemas are reported now.
+		if _, err := webhookvalidation.New(manifest.Name, manifest.Name, *manifest); err != nil {
+			return types.NewErrBadRequest("invalid manifest: %v", err)
+		}
+	}
+
+	return nil
+}
+
 func convertMCPWebhookValidation(validation v1.MCPWebhookValidation, hasSecret bool) types.MCPWebhookValidation {
 	return types.MCPWebhookValidation{
 		Metadata:                     MetadataFrom(&validation),
@@ -232,6 +232,9 @@ func (h *NanobotAgentHandler) Launch(req api.Context) error {
 		if errors.Is(err, mcp.ErrInsufficientCapacity) {
 			return types.NewErrHTTP(http.StatusServiceUnavailable, "Insufficient capacity to deploy MCP server for agent. Please contact your administrator.")
 		}
+		if errors.Is(err, mcp.ErrServerQuotaExceeded) {
+			return types.NewErrHTTP(http.StatusTooManyRequests, "Maximum number of running MCP servers reached. Please contact your administrator.")
+		}
 		if nse := (*mcp.ErrNotSupportedByBackend)(nil); errors.As(err, &nse) {
 			return types.NewErrHTTP(http.StatusBadRequest, nse.Error())
 		}
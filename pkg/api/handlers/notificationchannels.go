@@ -0,0 +1,252 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/notification"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+type NotificationChannelHandler struct {
+	sender *notification.Sender
+}
+
+func NewNotificationChannelHandler() *NotificationChannelHandler {
+	return &NotificationChannelHandler{
+		sender: notification.NewSender(),
+	}
+}
+
+func (n *NotificationChannelHandler) List(req api.Context) error {
+	var list v1.NotificationChannelList
+	if err := req.List(&list); err != nil {
+		return fmt.Errorf("failed to list notification channels: %w", err)
+	}
+
+	// Non-admins can list channels so that they can subscribe their tasks to them,
+	// but they shouldn't see where the notifications are sent.
+	if !req.UserIsAdmin() {
+		items := make([]types.NotificationChannel, 0, len(list.Items))
+		for _, item := range list.Items {
+			if item.Spec.Manifest.Disabled {
+				continue
+			}
+			items = append(items, types.NotificationChannel{
+				Metadata: MetadataFrom(&item),
+				NotificationChannelManifest: types.NotificationChannelManifest{
+					DisplayName: item.Spec.Manifest.DisplayName,
+					ChannelType: item.Spec.Manifest.ChannelType,
+				},
+			})
+		}
+		return req.Write(types.NotificationChannelList{Items: items})
+	}
+
+	creds, err := req.GPTClient.ListCredentials(req.Context(), gptscript.ListCredentialsOptions{CredentialContexts: []string{system.NotificationChannelCredentialContext}})
+	if err != nil {
+		return fmt.Errorf("failed to list credentials: %w", err)
+	}
+
+	credMap := make(map[string]struct{}, len(creds))
+	for _, cred := range creds {
+		credMap[cred.ToolName] = struct{}{}
+	}
+
+	items := make([]types.NotificationChannel, 0, len(list.Items))
+	for _, item := range list.Items {
+		_, hasSecret := credMap[item.Name]
+		items = append(items, convertNotificationChannel(item, hasSecret))
+	}
+
+	return req.Write(types.NotificationChannelList{Items: items})
+}
+
+func (n *NotificationChannelHandler) Get(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("notification_channel_id")); err != nil {
+		return err
+	}
+
+	secretCred, err := req.GPTClient.RevealCredential(req.Context(), []string{system.NotificationChannelCredentialContext}, channel.Name)
+	if err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+		return fmt.Errorf("failed to reveal credential: %w", err)
+	}
+
+	return req.Write(convertNotificationChannel(channel, secretCred.Env != nil))
+}
+
+func (n *NotificationChannelHandler) Create(req api.Context) error {
+	var manifest types.NotificationChannelManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	var secretCred map[string]string
+	if manifest.Secret != "" {
+		secretCred = map[string]string{
+			"secret": manifest.Secret,
+		}
+
+		// Don't save the secrets in the database.
+		manifest.Secret = ""
+	}
+
+	channel := v1.NotificationChannel{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.NotificationChannelPrefix,
+			Namespace:    req.Namespace(),
+		},
+		Spec: v1.NotificationChannelSpec{
+			Manifest: manifest,
+		},
+	}
+
+	if err := req.Create(&channel); err != nil {
+		return fmt.Errorf("failed to create notification channel: %w", err)
+	}
+
+	if secretCred != nil {
+		if err := req.GPTClient.CreateCredential(req.Context(), gptscript.Credential{
+			Context:  system.NotificationChannelCredentialContext,
+			ToolName: channel.Name,
+			Type:     gptscript.CredentialTypeTool,
+			Env:      secretCred,
+		}); err != nil {
+			_ = req.Delete(&channel)
+			return fmt.Errorf("failed to create credential: %w", err)
+		}
+	}
+
+	return req.WriteCreated(convertNotificationChannel(channel, secretCred != nil))
+}
+
+func (n *NotificationChannelHandler) Update(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("notification_channel_id")); err != nil {
+		return err
+	}
+
+	var manifest types.NotificationChannelManifest
+	if err := req.Read(&manifest); err != nil {
+		return types.NewErrBadRequest("failed to read manifest: %v", err)
+	}
+
+	if err := manifest.Validate(); err != nil {
+		return types.NewErrBadRequest("invalid manifest: %v", err)
+	}
+
+	var secretCred map[string]string
+	if manifest.Secret != "" {
+		secretCred = map[string]string{
+			"secret": manifest.Secret,
+		}
+		// Don't save the secrets in the database.
+		manifest.Secret = ""
+	}
+
+	channel.Spec.Manifest = manifest
+
+	if secretCred != nil {
+		if err := req.GPTClient.CreateCredential(req.Context(), gptscript.Credential{
+			Context:  system.NotificationChannelCredentialContext,
+			ToolName: channel.Name,
+			Type:     gptscript.CredentialTypeTool,
+			Env:      secretCred,
+		}); err != nil {
+			return fmt.Errorf("failed to create credential: %w", err)
+		}
+	} else {
+		cred, err := req.GPTClient.RevealCredential(req.Context(), []string{system.NotificationChannelCredentialContext}, channel.Name)
+		if err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+			return fmt.Errorf("failed to reveal credential: %w", err)
+		}
+
+		secretCred = cred.Env
+	}
+
+	if err := req.Update(&channel); err != nil {
+		return fmt.Errorf("failed to update notification channel: %w", err)
+	}
+
+	return req.Write(convertNotificationChannel(channel, secretCred != nil))
+}
+
+func (n *NotificationChannelHandler) Delete(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("notification_channel_id")); err != nil {
+		return err
+	}
+
+	if err := req.GPTClient.DeleteCredential(req.Context(), system.NotificationChannelCredentialContext, channel.Name); err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	if err := req.Delete(&channel); err != nil {
+		return fmt.Errorf("failed to delete notification channel: %w", err)
+	}
+
+	return req.Write(convertNotificationChannel(channel, false))
+}
+
+func (n *NotificationChannelHandler) RemoveSecret(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("notification_channel_id")); err != nil {
+		return err
+	}
+
+	if err := req.GPTClient.DeleteCredential(req.Context(), system.NotificationChannelCredentialContext, channel.Name); err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+		return fmt.Errorf("failed to delete credential: %w", err)
+	}
+
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+// Test sends a sample notification to the channel so that admins can verify its configuration.
+func (n *NotificationChannelHandler) Test(req api.Context) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, req.PathValue("notification_channel_id")); err != nil {
+		return err
+	}
+
+	cred, err := req.GPTClient.RevealCredential(req.Context(), []string{system.NotificationChannelCredentialContext}, channel.Name)
+	if err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+		return fmt.Errorf("failed to reveal credential: %w", err)
+	}
+
+	now := time.Now()
+	if err := n.sender.Send(req.Context(), channel.Spec.Manifest, cred.Env["secret"], notification.Notification{
+		Event:     types.NotificationEventSuccess,
+		TaskName:  "Test notification",
+		RunID:     "test",
+		State:     types.WorkflowStateComplete,
+		Output:    "This is a test notification from Obot.",
+		StartTime: now,
+		EndTime:   now,
+	}); err != nil {
+		return types.NewErrHTTP(http.StatusBadGateway, err.Error())
+	}
+
+	req.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func convertNotificationChannel(channel v1.NotificationChannel, hasSecret bool) types.NotificationChannel {
+	return types.NotificationChannel{
+		Metadata:                    MetadataFrom(&channel),
+		NotificationChannelManifest: channel.Spec.Manifest,
+		HasSecret:                   hasSecret,
+	}
+}
@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"crypto/rand"
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -11,6 +12,7 @@ import (
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -85,6 +87,10 @@ func (h *OAuthClientsHandler) Create(req api.Context) error {
 		return types.NewErrBadRequest("%v", err)
 	}
 
+	if err = validateServiceAccount(req, client.Spec.Manifest.ServiceAccountID); err != nil {
+		return err
+	}
+
 	clientSecret := rand.Text() + rand.Text()
 	client.Spec.ClientSecretHash, err = bcrypt.GenerateFromPassword([]byte(clientSecret), bcrypt.DefaultCost)
 	if err != nil {
@@ -121,6 +127,10 @@ func (h *OAuthClientsHandler) Update(req api.Context) error {
 		return err
 	}
 
+	if err := validateServiceAccount(req, client.Spec.Manifest.ServiceAccountID); err != nil {
+		return err
+	}
+
 	if err := req.Update(&client); err != nil {
 		return err
 	}
@@ -168,14 +178,42 @@ func (h *OAuthClientsHandler) RollClientSecret(req api.Context) error {
 	return req.Write(ConvertClient(client, h.serverURL, clientSecret))
 }
 
+// validateServiceAccount checks that the user that a client is bound to is a service account.
+func validateServiceAccount(req api.Context, serviceAccountID string) error {
+	if serviceAccountID == "" {
+		return nil
+	}
+
+	user, err := req.GatewayClient.UserByID(req.Context(), serviceAccountID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && !user.ServiceAccount {
+		return types.NewErrBadRequest("service account %s not found", serviceAccountID)
+	} else if err != nil {
+		return fmt.Errorf("failed to get service account: %w", err)
+	}
+
+	return nil
+}
+
 func ValidateClientConfig(oauthClient *v1.OAuthClient, oauthConfig OAuthAuthorizationServerConfig) error {
 	//nolint: staticcheck
 	if oauthClient.Spec.Manifest.RedirectURI != "" {
 		oauthClient.Spec.Manifest.RedirectURIs = append(oauthClient.Spec.Manifest.RedirectURIs, oauthClient.Spec.Manifest.RedirectURI)
 	}
-	if len(oauthClient.Spec.Manifest.RedirectURIs) == 0 {
+	if len(oauthClient.Spec.Manifest.RedirectURIs) == 0 && (len(oauthClient.Spec.Manifest.GrantTypes) == 0 || slices.Contains(oauthClient.Spec.Manifest.GrantTypes, "authorization_code")) {
+		// Clients that only use grants without a redirect, like the device authorization grant, don't need redirect URIs.
 		return fmt.Errorf("redirect_uris is required")
 	}
+	if oauthClient.Spec.Manifest.ServiceAccountID != "" && !oauthClient.Spec.Static {
+		return fmt.Errorf("service_account_id can only be set by an administrator")
+	}
+	if slices.Contains(oauthClient.Spec.Manifest.GrantTypes, "client_credentials") {
+		if oauthClient.Spec.Manifest.ServiceAccountID == "" {
+			return fmt.Errorf("service_account_id is required for the client_credentials grant type")
+		}
+		if oauthClient.Spec.Manifest.TokenEndpointAuthMethod != "client_secret_basic" && oauthClient.Spec.Manifest.TokenEndpointAuthMethod != "client_secret_post" {
+			return fmt.Errorf("token_endpoint_auth_method must be client_secret_basic or client_secret_post for the client_credentials grant type")
+		}
+	}
 	if oauthClient.Spec.Manifest.TokenEndpointAuthMethod != "" && !slices.Contains(oauthConfig.TokenEndpointAuthMethodsSupported, oauthClient.Spec.Manifest.TokenEndpointAuthMethod) {
 		return fmt.Errorf("token_endpoint_auth_method must be %s, not %s", strings.Join(oauthConfig.TokenEndpointAuthMethodsSupported, ", "), oauthClient.Spec.Manifest.TokenEndpointAuthMethod)
 	}
@@ -282,6 +320,9 @@ type OAuthAuthorizationServerConfig struct {
 	// CodeChallengeMethodsSupported is a JSON array containing a list of PKCE code challenge methods supported by this authorization server.
 	// OPTIONAL. If omitted, the authorization server does not support PKCE.
 	CodeChallengeMethodsSupported []string `json:"code_challenge_methods_supported,omitempty"`
+	// DeviceAuthorizationEndpoint is the URL of the authorization server's device authorization endpoint, as defined in RFC 8628.
+	// OPTIONAL.
+	DeviceAuthorizationEndpoint string `json:"device_authorization_endpoint,omitempty"`
 
 	// Additional fields can be added here
 	UserInfoEndpoint string `json:"userinfo_endpoint,omitempty"`
@@ -0,0 +1,111 @@
+package handlers
+
+import (
+	"errors"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/packagepolicy"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PackagePolicyHandler struct{}
+
+func NewPackagePolicyHandler() *PackagePolicyHandler {
+	return &PackagePolicyHandler{}
+}
+
+func (h *PackagePolicyHandler) Get(req api.Context) error {
+	var policy v1.PackagePolicy
+	err := req.Storage.Get(req.Context(), client.ObjectKey{
+		Namespace: req.Namespace(),
+		Name:      system.PackagePolicyName,
+	}, &policy)
+
+	if apierrors.IsNotFound(err) {
+		// Return an empty policy if not yet configured
+		return req.Write(types.PackagePolicy{})
+	}
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertPackagePolicy(policy))
+}
+
+func (h *PackagePolicyHandler) Update(req api.Context) error {
+	var input types.PackagePolicyManifest
+	if err := req.Read(&input); err != nil {
+		return err
+	}
+
+	if err := packagepolicy.Validate(input); err != nil {
+		return types.NewErrBadRequest("invalid package policy: %v", err)
+	}
+
+	var policy v1.PackagePolicy
+	err := req.Get(&policy, system.PackagePolicyName)
+
+	if apierrors.IsNotFound(err) {
+		policy = v1.PackagePolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.PackagePolicyName,
+				Namespace: req.Namespace(),
+			},
+			Spec: v1.PackagePolicySpec{
+				PackagePolicyManifest: input,
+			},
+		}
+
+		if err := req.Create(&policy); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		policy.Spec.PackagePolicyManifest = input
+		if err := req.Update(&policy); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(convertPackagePolicy(policy))
+}
+
+func convertPackagePolicy(policy v1.PackagePolicy) types.PackagePolicy {
+	return types.PackagePolicy{
+		PackagePolicyManifest: policy.Spec.PackagePolicyManifest,
+		Metadata:              MetadataFrom(&policy),
+	}
+}
+
+// checkPackagePolicy returns a bad request error if any of the server's packages are not allowed by the package policy.
+func checkPackagePolicy(req api.Context, manifest types.MCPServerManifest) error {
+	policy, err := packagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		return err
+	}
+
+	return packagePolicyError(packagepolicy.CheckServerManifest(policy, manifest))
+}
+
+// checkCatalogEntryPackagePolicy returns a bad request error if any of the entry's packages are not allowed by the package policy.
+func checkCatalogEntryPackagePolicy(req api.Context, manifest types.MCPServerCatalogEntryManifest) error {
+	policy, err := packagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		return err
+	}
+
+	return packagePolicyError(packagepolicy.CheckCatalogEntryManifest(policy, manifest))
+}
+
+func packagePolicyError(err error) error {
+	if violation := (*packagepolicy.ViolationError)(nil); errors.As(err, &violation) {
+		return types.NewErrBadRequest("%v", violation)
+	}
+	return err
+}
@@ -0,0 +1,761 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"maps"
+	"path"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/nah/pkg/randomtoken"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/accesscontrolrule"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/gateway/server/dispatcher"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/gz"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/obot-platform/obot/pkg/wait"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	projectArchiveManifestFile = "project.json"
+	projectArchiveKnowledgeDir = "knowledge/"
+	projectArchiveThreadsDir   = "threads/"
+
+	// maxProjectArchiveSize is the maximum size of a project archive, and of all the files in it once extracted.
+	maxProjectArchiveSize = 500 * 1024 * 1024
+)
+
+type ProjectArchiveHandler struct {
+	acrHelper  *accesscontrolrule.Helper
+	dispatcher *dispatcher.Dispatcher
+}
+
+func NewProjectArchiveHandler(acrHelper *accesscontrolrule.Helper, dispatcher *dispatcher.Dispatcher) *ProjectArchiveHandler {
+	return &ProjectArchiveHandler{
+		acrHelper:  acrHelper,
+		dispatcher: dispatcher,
+	}
+}
+
+// projectArchiveContents is everything in a project archive.
+type projectArchiveContents struct {
+	manifest  types.ProjectArchive
+	knowledge map[string][]byte
+	threads   []types.ProjectArchiveThread
+}
+
+// Export writes the project as a project archive. Credentials, environment variable values and the connections of MCP
+// servers are left out, and the chat threads of the requesting user are included.
+func (h *ProjectArchiveHandler) Export(req api.Context) error {
+	thread, err := getProjectThread(req)
+	if err != nil {
+		return err
+	}
+
+	if !thread.Spec.Project || thread.Spec.Template {
+		return types.NewErrBadRequest("invalid project %s", req.PathValue("project_id"))
+	}
+
+	contents := projectArchiveContents{
+		manifest: types.ProjectArchive{
+			Version:    types.ProjectArchiveVersion,
+			ExportedAt: *types.NewTime(time.Now()),
+			// Integrations like Slack and webhooks aren't exported because they hold secrets and are specific to the instance.
+			Project: types.ProjectManifest{
+				ThreadManifest:       thread.Spec.Manifest,
+				DefaultModelProvider: thread.Spec.DefaultModelProvider,
+				DefaultModel:         thread.Spec.DefaultModel,
+				Models:               thread.Spec.Models,
+			},
+		},
+		knowledge: map[string][]byte{},
+	}
+
+	for _, env := range thread.Spec.Env {
+		env.Value = ""
+		env.Existing = false
+		contents.manifest.Env = append(contents.manifest.Env, env)
+	}
+
+	var workflows v1.WorkflowList
+	if err := req.List(&workflows, kclient.MatchingFields{
+		"spec.threadName": thread.Name,
+	}); err != nil {
+		return err
+	}
+	for _, workflow := range workflows.Items {
+		manifest := workflow.Spec.Manifest
+		manifest.Alias = ""
+		// Notification channels are specific to the instance.
+		manifest.Notifications = nil
+		contents.manifest.Tasks = append(contents.manifest.Tasks, types.ProjectArchiveTask{
+			ID:       workflow.Name,
+			Manifest: manifest,
+		})
+	}
+
+	var memorySet v1.MemorySet
+	if err := req.Get(&memorySet, thread.Name); err == nil {
+		contents.manifest.Memories = memorySet.Spec.Memories
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	var projectServers v1.ProjectMCPServerList
+	if err := req.List(&projectServers, kclient.MatchingFields{
+		"spec.threadName": thread.Name,
+	}); err != nil {
+		return err
+	}
+	for _, projectServer := range projectServers.Items {
+		if !projectServer.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		server := types.ProjectArchiveMCPServer{
+			Placeholder: projectServer.Name,
+			Alias:       projectServer.Spec.Manifest.Alias,
+			MCPID:       projectServer.Spec.Manifest.MCPID,
+		}
+		if mcpServer, err := getMCPServerForProjectServer(req.Context(), req.Storage, projectServer); err == nil {
+			server.Name = mcpServer.Spec.Manifest.Name
+			server.CatalogEntryID = mcpServer.Spec.MCPServerCatalogEntryName
+		} else if !apierrors.IsNotFound(err) {
+			return err
+		}
+		contents.manifest.MCPServers = append(contents.manifest.MCPServers, server)
+	}
+
+	if len(thread.Status.KnowledgeSetNames) > 0 {
+		if err := h.exportKnowledge(req, thread.Status.KnowledgeSetNames[0], contents.knowledge); err != nil {
+			return err
+		}
+	}
+
+	var threads v1.ThreadList
+	if err := req.List(&threads, kclient.MatchingFields{
+		"spec.parentThreadName": thread.Name,
+	}); err != nil {
+		return err
+	}
+	for _, chatThread := range threads.Items {
+		// Only export the chats of the requesting user, and not the threads of task runs.
+		if chatThread.Spec.UserID != req.User.GetUID() || chatThread.Spec.SystemTask || chatThread.Spec.Ephemeral ||
+			chatThread.Spec.WorkflowName != "" || chatThread.Spec.WorkflowExecutionName != "" || !chatThread.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		archived, err := h.exportThread(req, chatThread)
+		if err != nil {
+			return err
+		}
+		contents.threads = append(contents.threads, archived)
+	}
+
+	var buf bytes.Buffer
+	if err := writeProjectArchive(&buf, contents); err != nil {
+		return err
+	}
+
+	req.ResponseWriter.Header().Set("Content-Type", "application/zip")
+	req.ResponseWriter.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", strings.Replace(thread.Name, system.ThreadPrefix, system.ProjectPrefix, 1)+".zip"))
+	_, err = req.ResponseWriter.Write(buf.Bytes())
+	return err
+}
+
+func (h *ProjectArchiveHandler) exportKnowledge(req api.Context, knowledgeSetName string, knowledge map[string][]byte) error {
+	ws, err := getWorkspaceFromKnowledgeSet(req, knowledgeSetName)
+	if err != nil {
+		return err
+	}
+
+	var files v1.KnowledgeFileList
+	if err := req.List(&files, kclient.MatchingFields{
+		"spec.knowledgeSetName": knowledgeSetName,
+	}); err != nil {
+		return err
+	}
+
+	for _, file := range files.Items {
+		// Files from knowledge sources are fetched again by the source, so only uploaded files are exported.
+		if file.Spec.KnowledgeSourceName != "" {
+			continue
+		}
+
+		data, err := req.GPTClient.ReadFileInWorkspace(req.Context(), file.Spec.FileName, gptscript.ReadFileInWorkspaceOptions{WorkspaceID: ws.Status.WorkspaceID})
+		if err != nil {
+			if nfe := (*gptscript.NotFoundInWorkspaceError)(nil); errors.As(err, &nfe) {
+				continue
+			}
+			return fmt.Errorf("failed to read knowledge file %q: %w", file.Spec.FileName, err)
+		}
+		knowledge[file.Spec.FileName] = data
+	}
+
+	return nil
+}
+
+func (h *ProjectArchiveHandler) exportThread(req api.Context, thread v1.Thread) (types.ProjectArchiveThread, error) {
+	result := types.ProjectArchiveThread{
+		ID:          thread.Name,
+		Name:        thread.Spec.Manifest.Name,
+		Description: thread.Spec.Manifest.Description,
+	}
+
+	for runName := thread.Status.LastRunName; runName != ""; {
+		var run v1.Run
+		if err := req.Get(&run, runName); apierrors.IsNotFound(err) {
+			break
+		} else if err != nil {
+			return result, err
+		}
+
+		archived := types.ProjectArchiveRun{
+			Input:     run.Spec.Input,
+			Output:    run.Status.Output,
+			Error:     run.Status.Error,
+			CreatedAt: *types.NewTime(run.CreationTimestamp.Time),
+		}
+
+		runState, err := req.GatewayClient.RunState(req.Context(), run.Namespace, run.Name)
+		if err == nil {
+			archived.Program = runState.Program
+			archived.ChatState = runState.ChatState
+			archived.CallFrame = runState.CallFrame
+		} else if !apierrors.IsNotFound(err) {
+			return result, err
+		}
+
+		result.Runs = append(result.Runs, archived)
+		runName = run.Spec.PreviousRunName
+	}
+
+	slices.Reverse(result.Runs)
+	return result, nil
+}
+
+// Import creates a project from a project archive. MCP server placeholders are mapped to MCP servers with mcpServer
+// query parameters of the form <placeholder>=<MCP server ID>; placeholders that aren't mapped are left out.
+func (h *ProjectArchiveHandler) Import(req api.Context) (retErr error) {
+	agent, err := getAssistant(req, req.PathValue("assistant_id"))
+	if err != nil {
+		return err
+	}
+
+	mcpServerIDs, err := parseMCPServerMappings(req.URL.Query()["mcpServer"])
+	if err != nil {
+		return err
+	}
+
+	data, err := req.Body(api.BodyOptions{MaxBytes: maxProjectArchiveSize})
+	if err != nil {
+		return err
+	}
+
+	contents, err := readProjectArchive(data)
+	if err != nil {
+		return err
+	}
+
+	project := contents.manifest.Project
+	if project.DefaultModelProvider != "" && !slices.Contains(agent.Spec.Manifest.AllowedModelProviders, project.DefaultModelProvider) {
+		return types.NewErrBadRequest("model provider %s is not allowed for agent %s", project.DefaultModelProvider, agent.Name)
+	}
+	for provider := range project.Models {
+		if !slices.Contains(agent.Spec.Manifest.AllowedModelProviders, provider) {
+			return types.NewErrBadRequest("model provider %s is not allowed for agent %s", provider, agent.Name)
+		}
+	}
+
+	// Validate the project and its tasks like they are when they're created, before creating anything.
+	threadManifest := project.ThreadManifest
+	threadManifest.SharedTasks = nil
+	threadManifest.AllowedMCPTools = nil
+	if len(threadManifest.Tools) == 0 {
+		threadManifest.Tools = agent.Spec.Manifest.DefaultThreadTools
+	} else if err := validateThreadTools(agent, threadManifest.Tools); err != nil {
+		return err
+	}
+	if threadManifest.ModelProvider != "" && !slices.Contains(agent.Spec.Manifest.AllowedModelProviders, threadManifest.ModelProvider) {
+		return types.NewErrBadRequest("model provider %s is not allowed for agent %s", threadManifest.ModelProvider, agent.Name)
+	}
+	if threadManifest.Model != "" || threadManifest.ModelProvider != "" {
+		if err := validateThreadModel(agent, project.Models, threadManifest.ModelProvider, threadManifest.Model); err != nil {
+			return err
+		}
+	}
+	for _, task := range contents.manifest.Tasks {
+		if err := validateNotifications(req, task.Manifest.Notifications); err != nil {
+			return err
+		}
+	}
+
+	// Resolve the MCP servers before creating anything, so that a mapping that can't be used fails the whole import.
+	var (
+		projectServers []v1.ProjectMCPServer
+		placeholders   []string
+		unmapped       []types.ProjectArchiveMCPServer
+	)
+	for _, server := range contents.manifest.MCPServers {
+		mcpID, ok := mcpServerIDs[server.Placeholder]
+		if !ok {
+			unmapped = append(unmapped, server)
+			continue
+		}
+		delete(mcpServerIDs, server.Placeholder)
+
+		projectServer := v1.ProjectMCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: system.ProjectMCPServerPrefix,
+				Namespace:    req.Namespace(),
+				Finalizers:   []string{v1.ProjectMCPServerFinalizer},
+			},
+			Spec: v1.ProjectMCPServerSpec{
+				Manifest: types.ProjectMCPServerManifest{
+					MCPID: mcpID,
+					Alias: server.Alias,
+				},
+				UserID: req.User.GetUID(),
+			},
+		}
+
+		mcpServer, err := getMCPServerForProjectServer(req.Context(), req.Storage, projectServer)
+		if apierrors.IsNotFound(err) {
+			return types.NewErrBadRequest("MCP server %s for placeholder %s not found", mcpID, server.Placeholder)
+		} else if err != nil {
+			return err
+		}
+		if err := checkProjectMCPServerAccess(req, h.acrHelper, mcpServer); err != nil {
+			return err
+		}
+
+		projectServer.Spec.MCPServerName = mcpServer.Name
+		projectServers = append(projectServers, projectServer)
+		placeholders = append(placeholders, server.Placeholder)
+	}
+	if len(mcpServerIDs) > 0 {
+		return types.NewErrBadRequest("archive has no MCP server placeholders %s", strings.Join(slices.Sorted(maps.Keys(mcpServerIDs)), ", "))
+	}
+
+	thread := &v1.Thread{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.ThreadPrefix,
+			Namespace:    agent.Namespace,
+			Finalizers:   []string{v1.ThreadFinalizer},
+		},
+		Spec: v1.ThreadSpec{
+			Manifest:             threadManifest,
+			AgentName:            agent.Name,
+			Project:              true,
+			UserID:               req.User.GetUID(),
+			Env:                  contents.manifest.Env,
+			DefaultModelProvider: project.DefaultModelProvider,
+			DefaultModel:         project.DefaultModel,
+			Models:               project.Models,
+		},
+	}
+	if err := req.Create(thread); err != nil {
+		return err
+	}
+	defer func() {
+		if retErr != nil {
+			if err := req.Delete(thread); err != nil {
+				log.Errorf("Failed to clean up project %s after failed import: %v", thread.Name, err)
+			}
+		}
+	}()
+
+	taskIDs := make(map[string]string, len(contents.manifest.Tasks))
+	for _, task := range contents.manifest.Tasks {
+		manifest := task.Manifest
+		manifest.Alias, err = randomtoken.Generate()
+		if err != nil {
+			return fmt.Errorf("failed to generate alias: %w", err)
+		}
+		if len(manifest.Alias) > 12 {
+			manifest.Alias = manifest.Alias[:12]
+		}
+
+		workflow := v1.Workflow{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: system.WorkflowPrefix,
+				Namespace:    thread.Namespace,
+			},
+			Spec: v1.WorkflowSpec{
+				ThreadName: thread.Name,
+				Manifest:   manifest,
+			},
+		}
+		if err := req.Create(&workflow); err != nil {
+			return err
+		}
+		taskIDs[task.ID] = workflow.Name
+	}
+
+	if len(contents.manifest.Memories) > 0 {
+		if err := req.Create(&v1.MemorySet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      thread.Name,
+				Namespace: thread.Namespace,
+			},
+			Spec: v1.MemorySetSpec{
+				ThreadName: thread.Name,
+				Memories:   contents.manifest.Memories,
+			},
+		}); err != nil {
+			return err
+		}
+	}
+
+	projectServerNames := make(map[string]string, len(projectServers))
+	for i, projectServer := range projectServers {
+		projectServer.Spec.ThreadName = thread.Name
+		if err := req.Create(&projectServer); err != nil {
+			return err
+		}
+		projectServerNames[placeholders[i]] = projectServer.Name
+	}
+
+	// Point shared tasks and allowed MCP tools at the imported tasks and MCP servers.
+	for _, taskID := range project.SharedTasks {
+		if newID, ok := taskIDs[taskID]; ok {
+			thread.Spec.Manifest.SharedTasks = append(thread.Spec.Manifest.SharedTasks, newID)
+		}
+	}
+	for placeholder, tools := range project.AllowedMCPTools {
+		if name, ok := projectServerNames[placeholder]; ok {
+			if thread.Spec.Manifest.AllowedMCPTools == nil {
+				thread.Spec.Manifest.AllowedMCPTools = map[string][]string{}
+			}
+			thread.Spec.Manifest.AllowedMCPTools[name] = tools
+		}
+	}
+	if len(thread.Spec.Manifest.SharedTasks) > 0 || len(thread.Spec.Manifest.AllowedMCPTools) > 0 {
+		if err := req.Update(thread); err != nil {
+			return err
+		}
+	}
+
+	if len(contents.knowledge) > 0 {
+		if err := h.importKnowledge(req, thread, contents.knowledge); err != nil {
+			return err
+		}
+	}
+
+	for _, archived := range contents.threads {
+		if err := importThread(req, thread, archived); err != nil {
+			return err
+		}
+	}
+
+	return req.WriteCreated(types.ProjectImportResult{
+		Project:            convertProject(thread, nil),
+		UnmappedMCPServers: unmapped,
+	})
+}
+
+func (h *ProjectArchiveHandler) importKnowledge(req api.Context, thread *v1.Thread, knowledge map[string][]byte) error {
+	// The knowledge set and its workspace are created by the controller once the project is created.
+	project, err := wait.For(req.Context(), req.Storage, thread, func(thread *v1.Thread) (bool, error) {
+		return len(thread.Status.KnowledgeSetNames) > 0, nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to wait for the knowledge set of project %s: %w", thread.Name, err)
+	}
+
+	knowledgeSetName := project.Status.KnowledgeSetNames[0]
+	knowledgeSet, err := wait.For(req.Context(), req.Storage, &v1.KnowledgeSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      knowledgeSetName,
+			Namespace: project.Namespace,
+		},
+	}, func(knowledgeSet *v1.KnowledgeSet) (bool, error) {
+		return knowledgeSet.Status.WorkspaceName != "", nil
+	}, wait.Option{WaitForExists: true})
+	if err != nil {
+		return fmt.Errorf("failed to wait for knowledge set %s: %w", knowledgeSetName, err)
+	}
+
+	ws, err := wait.For(req.Context(), req.Storage, &v1.Workspace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      knowledgeSet.Status.WorkspaceName,
+			Namespace: knowledgeSet.Namespace,
+		},
+	}, func(ws *v1.Workspace) (bool, error) {
+		return ws.Status.WorkspaceID != "", nil
+	}, wait.Option{WaitForExists: true})
+	if err != nil {
+		return fmt.Errorf("failed to wait for workspace %s: %w", knowledgeSet.Status.WorkspaceName, err)
+	}
+
+	for _, filename := range slices.Sorted(maps.Keys(knowledge)) {
+		contents := knowledge[filename]
+		if fromProvider, err := h.dispatcher.ScanFile(req.Context(), req.GPTClient, contents); err != nil {
+			if fromProvider {
+				return types.NewErrBadRequest("knowledge file %s is infected with virus: %v", filename, err)
+			}
+			return fmt.Errorf("failed to scan knowledge file %q: %w", filename, err)
+		}
+
+		if err := req.GPTClient.WriteFileInWorkspace(req.Context(), filename, contents, gptscript.WriteFileInWorkspaceOptions{WorkspaceID: ws.Status.WorkspaceID}); err != nil {
+			return fmt.Errorf("failed to upload knowledge file %q to workspace %q: %w", filename, ws.Status.WorkspaceID, err)
+		}
+
+		if err := req.Storage.Create(req.Context(), &v1.KnowledgeFile{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      v1.ObjectNameFromAbsolutePath(path.Join(ws.Status.WorkspaceID, filename)),
+				Namespace: ws.Namespace,
+			},
+			Spec: v1.KnowledgeFileSpec{
+				FileName:         filename,
+				KnowledgeSetName: knowledgeSetName,
+				Approved:         &[]bool{true}[0],
+				SizeInBytes:      int64(len(contents)),
+			},
+		}); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// importThread creates a chat thread in the project with the runs of the archived thread, so that the chat history is
+// shown and the conversation can be continued.
+func importThread(req api.Context, project *v1.Thread, archived types.ProjectArchiveThread) error {
+	thread := v1.Thread{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.ThreadPrefix,
+			Namespace:    project.Namespace,
+			Finalizers:   []string{v1.ThreadFinalizer},
+		},
+		Spec: v1.ThreadSpec{
+			Manifest: types.ThreadManifest{
+				ThreadManifestManagedFields: types.ThreadManifestManagedFields{
+					Name:        archived.Name,
+					Description: archived.Description,
+				},
+			},
+			AgentName:        project.Spec.AgentName,
+			ParentThreadName: project.Name,
+			UserID:           req.User.GetUID(),
+		},
+	}
+	if err := req.Create(&thread); err != nil {
+		return err
+	}
+
+	var previousRun *v1.Run
+	for _, archivedRun := range archived.Runs {
+		run := v1.Run{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: system.ChatRunPrefix,
+				Namespace:    thread.Namespace,
+				Finalizers:   []string{v1.RunFinalizer},
+			},
+			Spec: v1.RunSpec{
+				// Imported runs have already run, and synchronous runs are never resumed by the controller.
+				Synchronous: true,
+				ThreadName:  thread.Name,
+				AgentName:   thread.Spec.AgentName,
+				Input:       archivedRun.Input,
+			},
+		}
+		if previousRun != nil {
+			run.Spec.PreviousRunName = previousRun.Name
+		}
+		if err := req.Create(&run); err != nil {
+			return err
+		}
+
+		run.Status.State = v1.Continue
+		run.Status.Output = archivedRun.Output
+		run.Status.Error = archivedRun.Error
+		run.Status.EndTime = metav1.NewTime(archivedRun.CreatedAt.Time)
+		if err := req.Storage.Status().Update(req.Context(), &run); err != nil {
+			return err
+		}
+
+		output, err := gz.Compress(archivedRun.Output)
+		if err != nil {
+			return err
+		}
+		if err := req.GatewayClient.CreateRunState(req.Context(), &gatewaytypes.RunState{
+			UserID:     thread.Spec.UserID,
+			Name:       run.Name,
+			Namespace:  run.Namespace,
+			ThreadName: thread.Name,
+			Program:    archivedRun.Program,
+			ChatState:  archivedRun.ChatState,
+			CallFrame:  archivedRun.CallFrame,
+			Output:     output,
+			Done:       true,
+			Error:      archivedRun.Error,
+		}); err != nil {
+			return err
+		}
+
+		previousRun = &run
+	}
+
+	if previousRun == nil {
+		return nil
+	}
+
+	thread.Status.LastRunName = previousRun.Name
+	thread.Status.LastRunState = previousRun.Status.State
+	return req.Storage.Status().Update(req.Context(), &thread)
+}
+
+// parseMCPServerMappings parses mappings of the form <placeholder>=<MCP server ID>.
+func parseMCPServerMappings(mappings []string) (map[string]string, error) {
+	result := make(map[string]string, len(mappings))
+	for _, mapping := range mappings {
+		placeholder, mcpID, ok := strings.Cut(mapping, "=")
+		if !ok || placeholder == "" || mcpID == "" {
+			return nil, types.NewErrBadRequest("invalid MCP server mapping %q, expected <placeholder>=<MCP server ID>", mapping)
+		}
+		if _, exists := result[placeholder]; exists {
+			return nil, types.NewErrBadRequest("MCP server placeholder %s is mapped more than once", placeholder)
+		}
+		result[placeholder] = mcpID
+	}
+	return result, nil
+}
+
+func writeProjectArchive(w io.Writer, contents projectArchiveContents) error {
+	manifest := contents.manifest
+	manifest.KnowledgeFiles = slices.Sorted(maps.Keys(contents.knowledge))
+	manifest.Threads = nil
+	for _, thread := range contents.threads {
+		manifest.Threads = append(manifest.Threads, thread.ID)
+	}
+
+	zw := zip.NewWriter(w)
+	writeJSON := func(name string, obj any) error {
+		f, err := zw.Create(name)
+		if err != nil {
+			return err
+		}
+		enc := json.NewEncoder(f)
+		enc.SetIndent("", "  ")
+		return enc.Encode(obj)
+	}
+
+	if err := writeJSON(projectArchiveManifestFile, manifest); err != nil {
+		return err
+	}
+	for _, filename := range manifest.KnowledgeFiles {
+		f, err := zw.Create(projectArchiveKnowledgeDir + filename)
+		if err != nil {
+			return err
+		}
+		if _, err := f.Write(contents.knowledge[filename]); err != nil {
+			return err
+		}
+	}
+	for _, thread := range contents.threads {
+		if err := writeJSON(projectArchiveThreadsDir+thread.ID+".json", thread); err != nil {
+			return err
+		}
+	}
+
+	return zw.Close()
+}
+
+func readProjectArchive(data []byte) (*projectArchiveContents, error) {
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, types.NewErrBadRequest("invalid project archive: %v", err)
+	}
+
+	var (
+		files     = make(map[string]*zip.File, len(zr.File))
+		remaining = int64(maxProjectArchiveSize)
+	)
+	for _, f := range zr.File {
+		files[f.Name] = f
+	}
+
+	// read reads a file of the archive, limiting the total size of what is extracted.
+	read := func(name string) ([]byte, error) {
+		f, ok := files[name]
+		if !ok {
+			return nil, types.NewErrBadRequest("invalid project archive: missing %s", name)
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, types.NewErrBadRequest("invalid project archive: failed to open %s: %v", name, err)
+		}
+		defer rc.Close()
+
+		content, err := io.ReadAll(io.LimitReader(rc, remaining+1))
+		if err != nil {
+			return nil, types.NewErrBadRequest("invalid project archive: failed to read %s: %v", name, err)
+		}
+		if int64(len(content)) > remaining {
+			return nil, types.NewErrBadRequest("project archive is too large")
+		}
+		remaining -= int64(len(content))
+		return content, nil
+	}
+
+	manifestData, err := read(projectArchiveManifestFile)
+	if err != nil {
+		return nil, err
+	}
+
+	contents := &projectArchiveContents{
+		knowledge: map[string][]byte{},
+	}
+	if err := json.Unmarshal(manifestData, &contents.manifest); err != nil {
+		return nil, types.NewErrBadRequest("invalid project archive manifest: %v", err)
+	}
+	if contents.manifest.Version < 1 || contents.manifest.Version > types.ProjectArchiveVersion {
+		return nil, types.NewErrBadRequest("unsupported project archive version %d", contents.manifest.Version)
+	}
+
+	for _, filename := range contents.manifest.KnowledgeFiles {
+		if !isPlainFileName(filename) {
+			return nil, types.NewErrBadRequest("invalid knowledge file name %q", filename)
+		}
+		if contents.knowledge[filename], err = read(projectArchiveKnowledgeDir + filename); err != nil {
+			return nil, err
+		}
+	}
+
+	for _, threadID := range contents.manifest.Threads {
+		if !isPlainFileName(threadID) {
+			return nil, types.NewErrBadRequest("invalid thread ID %q", threadID)
+		}
+		threadData, err := read(projectArchiveThreadsDir + threadID + ".json")
+		if err != nil {
+			return nil, err
+		}
+		var thread types.ProjectArchiveThread
+		if err := json.Unmarshal(threadData, &thread); err != nil {
+			return nil, types.NewErrBadRequest("invalid project archive thread %s: %v", threadID, err)
+		}
+		contents.threads = append(contents.threads, thread)
+	}
+
+	return contents, nil
+}
+
+// isPlainFileName returns true if the name is a single path element that doesn't refer to a parent directory.
+func isPlainFileName(name string) bool {
+	return name != "" && name != "." && name != ".." && !strings.ContainsAny(name, `/\`)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"archive/zip"
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kuser "k8s.io/apiserver/pkg/authentication/user"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestProjectArchiveRoundTrip(t *testing.T) {
+	contents := projectArchiveContents{
+		manifest: types.ProjectArchive{
+			Version: types.ProjectArchiveVersion,
+			Project: types.ProjectManifest{
+				ThreadManifest: types.ThreadManifest{
+					ThreadManifestManagedFields: types.ThreadManifestManagedFields{Name: "Release notes"},
+					Prompt:                      "Write release notes.",
+					SharedTasks:                 []string{"w1task"},
+					AllowedMCPTools:             map[string][]string{"pms1github": {"*"}},
+				},
+			},
+			Tasks:      []types.ProjectArchiveTask{{ID: "w1task", Manifest: types.WorkflowManifest{Name: "Weekly notes"}}},
+			Memories:   []types.Memory{{ID: "m1", Content: "Notes are in markdown."}},
+			MCPServers: []types.ProjectArchiveMCPServer{{Placeholder: "pms1github", Name: "GitHub", MCPID: "ms1github"}},
+		},
+		knowledge: map[string][]byte{
+			"style.md":   []byte("# Style"),
+			"labels.csv": []byte("label,section"),
+		},
+		threads: []types.ProjectArchiveThread{{
+			ID:   "t1chat",
+			Name: "Draft",
+			Runs: []types.ProjectArchiveRun{{Input: "Draft the notes", Output: "Here they are", ChatState: []byte{1, 2, 3}}},
+		}},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeProjectArchive(&buf, contents))
+
+	read, err := readProjectArchive(buf.Bytes())
+	require.NoError(t, err)
+	assert.Equal(t, contents.manifest.Project, read.manifest.Project)
+	assert.Equal(t, contents.manifest.Tasks, read.manifest.Tasks)
+	assert.Equal(t, contents.manifest.Memories, read.manifest.Memories)
+	assert.Equal(t, contents.manifest.MCPServers, read.manifest.MCPServers)
+	assert.Equal(t, []string{"labels.csv", "style.md"}, read.manifest.KnowledgeFiles)
+	assert.Equal(t, contents.knowledge, read.knowledge)
+	assert.Equal(t, contents.threads, read.threads)
+}
+
+func TestReadProjectArchiveRejectsInvalidArchives(t *testing.T) {
+	archive := func(files map[string]string) []byte {
+		var buf bytes.Buffer
+		zw := zip.NewWriter(&buf)
+		for name, content := range files {
+			f, err := zw.Create(name)
+			require.NoError(t, err)
+			_, err = f.Write([]byte(content))
+			require.NoError(t, err)
+		}
+		require.NoError(t, zw.Close())
+		return buf.Bytes()
+	}
+
+	for name, data := range map[string][]byte{
+		"not a zip":           []byte("project"),
+		"missing manifest":    archive(map[string]string{"knowledge/a.md": "a"}),
+		"unsupported version": archive(map[string]string{"project.json": `{"version": 2}`}),
+		"path traversal":      archive(map[string]string{"project.json": `{"version": 1, "knowledgeFiles": ["../a.md"]}`, "knowledge/../a.md": "a"}),
+		"missing file":        archive(map[string]string{"project.json": `{"version": 1, "threads": ["t1chat"]}`}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := readProjectArchive(data)
+			var httpErr *types.ErrHTTP
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, 400, httpErr.Code)
+		})
+	}
+}
+
+func TestParseMCPServerMappings(t *testing.T) {
+	mappings, err := parseMCPServerMappings([]string{"pms1github=ms1abc", "pms1slack=msi1def"})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"pms1github": "ms1abc", "pms1slack": "msi1def"}, mappings)
+
+	_, err = parseMCPServerMappings([]string{"pms1github"})
+	assert.Error(t, err)
+
+	_, err = parseMCPServerMappings([]string{"pms1github=ms1abc", "pms1github=ms1def"})
+	assert.Error(t, err)
+}
+
+func TestImportRejectsDisallowedProjects(t *testing.T) {
+	agent := &v1.Agent{
+		ObjectMeta: metav1.ObjectMeta{Name: "a1agent", Namespace: system.DefaultNamespace},
+		Spec: v1.AgentSpec{
+			Manifest: types.AgentManifest{
+				DefaultThreadTools:    []string{"default-tool"},
+				AvailableThreadTools:  []string{"available-tool"},
+				AllowedModelProviders: []string{"allowed-provider"},
+				AllowedModels:         []string{"allowed-model"},
+			},
+		},
+	}
+
+	for name, test := range map[string]struct {
+		project types.ThreadManifest
+		wantErr string
+	}{
+		"disallowed tool": {
+			project: types.ThreadManifest{Tools: []string{"default-tool", "other-tool"}},
+			wantErr: "tool other-tool is not available for this agent",
+		},
+		"disallowed model": {
+			project: types.ThreadManifest{ModelProvider: "allowed-provider", Model: "other-model"},
+			wantErr: `model "other-model" is not allowed for assistant and project`,
+		},
+		"disallowed model provider": {
+			project: types.ThreadManifest{ModelProvider: "other-provider", Model: "allowed-model"},
+			wantErr: "model provider other-provider is not allowed for agent a1agent",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var archive bytes.Buffer
+			require.NoError(t, writeProjectArchive(&archive, projectArchiveContents{
+				manifest: types.ProjectArchive{
+					Version: types.ProjectArchiveVersion,
+					Project: types.ProjectManifest{ThreadManifest: test.project},
+				},
+			}))
+
+			req := httptest.NewRequest(http.MethodPost, "/api/assistants/a1agent/projects/import", &archive)
+			req.SetPathValue("assistant_id", agent.Name)
+			storage := fake.NewClientBuilder().WithScheme(storagescheme.Scheme).WithObjects(agent.DeepCopy()).Build()
+
+			err := (&ProjectArchiveHandler{}).Import(api.Context{
+				ResponseWriter: httptest.NewRecorder(),
+				Request:        req,
+				Storage:        storage,
+				User:           &kuser.DefaultInfo{UID: "user1"},
+			})
+			var httpErr *types.ErrHTTP
+			require.ErrorAs(t, err, &httpErr)
+			assert.Equal(t, http.StatusBadRequest, httpErr.Code)
+			assert.Contains(t, httpErr.Message, test.wantErr)
+
+			var threads v1.ThreadList
+			require.NoError(t, storage.List(t.Context(), &threads))
+			assert.Empty(t, threads.Items, "nothing should be created")
+		})
+	}
+}
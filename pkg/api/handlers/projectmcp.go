@@ -94,6 +94,31 @@ func getMCPServerForProjectServer(ctx context.Context, client kclient.Client, pr
 	return &mcpServer, nil
 }
 
+// checkProjectMCPServerAccess returns a not found error if the user can't add the MCP server to a project.
+func checkProjectMCPServerAccess(req api.Context, acrHelper *accesscontrolrule.Helper, mcpServer *v1.MCPServer) error {
+	if req.UserIsAdmin() || mcpServer.Spec.UserID == req.User.GetUID() {
+		return nil
+	}
+
+	var (
+		hasAccess bool
+		err       error
+	)
+	if mcpServer.Spec.MCPCatalogID != "" {
+		hasAccess, err = acrHelper.UserHasAccessToMCPServerInCatalog(req.User, mcpServer.Name, mcpServer.Spec.MCPCatalogID)
+	} else if mcpServer.Spec.PowerUserWorkspaceID != "" {
+		hasAccess, err = acrHelper.UserHasAccessToMCPServerInWorkspace(req.User, mcpServer.Name, mcpServer.Spec.PowerUserWorkspaceID, mcpServer.Spec.UserID)
+	}
+
+	if err != nil {
+		return err
+	}
+	if !hasAccess {
+		return types.NewErrNotFound("MCP server %s is not found", mcpServer.Name)
+	}
+	return nil
+}
+
 func (p *ProjectMCPHandler) ListServer(req api.Context) error {
 	project, err := getThreadForScope(req)
 	if err != nil {
@@ -200,23 +225,8 @@ func (p *ProjectMCPHandler) CreateServer(req api.Context) error {
 
 	projectServer.Spec.MCPServerName = mcpServer.Name
 
-	if !req.UserIsAdmin() && mcpServer.Spec.UserID != req.User.GetUID() {
-		var (
-			hasAccess bool
-			err       error
-		)
-		if mcpServer.Spec.MCPCatalogID != "" {
-			hasAccess, err = p.acrHelper.UserHasAccessToMCPServerInCatalog(req.User, mcpServer.Name, mcpServer.Spec.MCPCatalogID)
-		} else if mcpServer.Spec.PowerUserWorkspaceID != "" {
-			hasAccess, err = p.acrHelper.UserHasAccessToMCPServerInWorkspace(req.User, mcpServer.Name, mcpServer.Spec.PowerUserWorkspaceID, mcpServer.Spec.UserID)
-		}
-
-		if err != nil {
-			return err
-		}
-		if !hasAccess {
-			return types.NewErrNotFound("MCP server %s is not found", mcpServer.Name)
-		}
+	if err := checkProjectMCPServerAccess(req, p.acrHelper, mcpServer); err != nil {
+		return err
 	}
 
 	var cred map[string]string
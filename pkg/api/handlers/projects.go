@@ -375,6 +375,10 @@ func (h *ProjectsHandler) DeleteProject(req api.Context) error {
 		return types.NewErrBadRequest("only the project creator can delete this project")
 	}
 
+	if err := checkLegalHolds(req, project); err != nil {
+		return err
+	}
+
 	return req.Delete(project)
 }
 
@@ -575,6 +579,9 @@ func (h *ProjectsHandler) DeleteProjectThread(req api.Context) error {
 	if err := req.Get(&thread, req.PathValue("thread_id")); err != nil {
 		return err
 	}
+	if err := checkLegalHolds(req, &thread); err != nil {
+		return err
+	}
 	return req.Delete(&thread)
 }
 
@@ -620,20 +627,8 @@ func (h *ProjectsHandler) CreateProjectThread(req api.Context) error {
 				return err
 			}
 
-			// Check if model is allowed by assistant OR project
-			allowedByAssistant := len(agent.Spec.Manifest.AllowedModels) == 0 || slices.Contains(agent.Spec.Manifest.AllowedModels, bodyContents.Model)
-			allowedByProject := false
-			if projectModels, ok := projectThread.Spec.Models[bodyContents.ModelProvider]; ok {
-				allowedByProject = slices.Contains(projectModels, bodyContents.Model)
-			}
-
-			// if bodyContents.ModelProvider is empty it means that it is set at global level so allowedByProject should be true
-			if bodyContents.ModelProvider == "" {
-				allowedByProject = true
-			}
-
-			if !allowedByAssistant && !allowedByProject {
-				return types.NewErrBadRequest("model %q is not allowed for assistant and project", bodyContents.Model)
+			if err := validateThreadModel(agent, projectThread.Spec.Models, bodyContents.ModelProvider, bodyContents.Model); err != nil {
+				return err
 			}
 		}
 
@@ -648,6 +643,26 @@ func (h *ProjectsHandler) CreateProjectThread(req api.Context) error {
 	return req.WriteCreated(convertThread(thread))
 }
 
+// validateThreadModel returns an error if the model of a thread is allowed by neither the agent nor the project.
+func validateThreadModel(agent *v1.Agent, projectModels map[string][]string, modelProvider, model string) error {
+	// Check if model is allowed by assistant OR project
+	allowedByAssistant := len(agent.Spec.Manifest.AllowedModels) == 0 || slices.Contains(agent.Spec.Manifest.AllowedModels, model)
+	allowedByProject := false
+	if models, ok := projectModels[modelProvider]; ok {
+		allowedByProject = slices.Contains(models, model)
+	}
+
+	// if modelProvider is empty it means that it is set at global level so allowedByProject should be true
+	if modelProvider == "" {
+		allowedByProject = true
+	}
+
+	if !allowedByAssistant && !allowedByProject {
+		return types.NewErrBadRequest("model %q is not allowed for assistant and project", model)
+	}
+	return nil
+}
+
 func (h *ProjectsHandler) GetProjectThread(req api.Context) error {
 	var (
 		id = req.PathValue("id")
@@ -3,27 +3,33 @@ package handlers
 import (
 	"archive/zip"
 	"bytes"
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"errors"
 	"fmt"
 	"io"
+	"maps"
 	"net/http"
 	"os"
 	"path"
 	"path/filepath"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/auth"
 	"github.com/obot-platform/obot/pkg/hash"
+	"github.com/obot-platform/obot/pkg/search"
 	"github.com/obot-platform/obot/pkg/skillformat"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/storage/blob"
 	"github.com/obot-platform/obot/pkg/system"
+	"github.com/pmezard/go-difflib/difflib"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apiserver/pkg/authentication/user"
@@ -37,6 +43,7 @@ const (
 	maxSkillMDBytes           = 1024 * 1024
 	maxArtifactDescriptionLen = 1024
 	maxPublishRetries         = 3
+	maxReviewCommentLen       = 4096
 )
 
 // errConcurrentCreate is returned by createNewArtifact when another request
@@ -44,20 +51,26 @@ const (
 var errConcurrentCreate = fmt.Errorf("concurrent create detected")
 
 type publishedArtifactUpdateRequest struct {
-	Description *string         `json:"description,omitempty"`
-	Version     *int            `json:"version,omitempty"`
-	Subjects    []types.Subject `json:"subjects,omitempty"`
+	Description        *string         `json:"description,omitempty"`
+	Version            *int            `json:"version,omitempty"`
+	Subjects           []types.Subject `json:"subjects,omitempty"`
+	Deprecated         *bool           `json:"deprecated,omitempty"`
+	DeprecationMessage *string         `json:"deprecationMessage,omitempty"`
+	Yanked             *bool           `json:"yanked,omitempty"`
+	YankReason         *string         `json:"yankReason,omitempty"`
 }
 
 type PublishedArtifactHandler struct {
-	blobStore blob.BlobStore
-	bucket    string
+	blobStore   blob.BlobStore
+	bucket      string
+	searchIndex *search.Index
 }
 
-func NewPublishedArtifactHandler(blobStore blob.BlobStore, bucket string) *PublishedArtifactHandler {
+func NewPublishedArtifactHandler(blobStore blob.BlobStore, bucket string, searchIndex *search.Index) *PublishedArtifactHandler {
 	return &PublishedArtifactHandler{
-		blobStore: blobStore,
-		bucket:    bucket,
+		blobStore:   blobStore,
+		bucket:      bucket,
+		searchIndex: searchIndex,
 	}
 }
 
@@ -92,6 +105,17 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 
 	log.Debugf("Artifact author: id=%q email=%q", authorID, authorEmail)
 
+	reviewPolicy, err := getPublishedArtifactReviewPolicy(req.Context(), req.Storage)
+	if err != nil {
+		return err
+	}
+	// New versions aren't visible to their subjects until they are approved.
+	var reviewState types.PublishedArtifactReviewState
+	if reviewPolicy.RequireReview {
+		reviewState = types.PublishedArtifactReviewStatePending
+	}
+	isReviewer := subjectsContainUser(reviewPolicy.Reviewers, req.User)
+
 	// Build the manifest for DB storage from SKILL.md frontmatter.
 	manifest := types.PublishedArtifactManifest{
 		Name:         fm.Name,
@@ -113,7 +137,7 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 
 		if apierrors.IsNotFound(err) {
 			// No existing artifact — try to create a new one.
-			if err := h.createNewArtifact(req, data, fm, body, manifest, authorID, authorEmail, artifactName); errors.Is(err, errConcurrentCreate) {
+			if err := h.createNewArtifact(req, data, fm, body, manifest, authorID, authorEmail, artifactName, reviewState, isReviewer); errors.Is(err, errConcurrentCreate) {
 				log.Debugf("Concurrent create for artifact %s (attempt %d/%d), retrying", artifactName, attempt+1, maxPublishRetries)
 				continue
 			} else if err != nil {
@@ -123,8 +147,9 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 		}
 
 		// Update existing artifact with a new version.
-		previousVersionSubjects := versionSubjects(&existing, existing.Spec.LatestVersion)
-		version := existing.Spec.LatestVersion + 1
+		previousVersion := highestVersion(&existing)
+		previousVersionSubjects := versionSubjects(&existing, previousVersion)
+		version := previousVersion + 1
 
 		// Stamp publish metadata into SKILL.md before uploading the next version.
 		fmCopy := withArtifactMetadata(fm, existing.Name, authorEmail, version)
@@ -139,23 +164,29 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 			return fmt.Errorf("failed to generate upload nonce: %w", err)
 		}
 		blobKey := fmt.Sprintf("published-artifacts/%s/v%d-%s.zip", existing.Name, version, nonce)
-		log.Debugf("Updating existing artifact %s: v%d -> v%d, blobKey=%s", existing.Name, existing.Spec.LatestVersion, version, blobKey)
+		log.Debugf("Updating existing artifact %s: v%d -> v%d, blobKey=%s", existing.Name, previousVersion, version, blobKey)
 
 		if err := h.blobStore.Upload(req.Context(), h.bucket, blobKey, bytes.NewReader(rewrittenData)); err != nil {
 			return fmt.Errorf("failed to upload artifact: %w", err)
 		}
 
-		existing.Spec.LatestVersion = version
-		existing.Spec.BlobKey = blobKey
-		existing.Spec.Description = manifest.Description
-		existing.Spec.AuthorEmail = manifest.AuthorEmail
-		existing.Status.Versions = append(existing.Status.Versions, types.PublishedArtifactVersionEntry{
+		entry := types.PublishedArtifactVersionEntry{
 			Version:     version,
 			BlobKey:     blobKey,
 			Description: manifest.Description,
+			AuthorEmail: manifest.AuthorEmail,
+			Body:        search.TruncateBody(body),
 			CreatedAt:   *types.NewTime(time.Now()),
 			Subjects:    previousVersionSubjects,
-		})
+			PublishedArtifactVersionState: types.PublishedArtifactVersionState{
+				ReviewState: reviewState,
+			},
+		}
+		existing.Status.Versions = append(existing.Status.Versions, entry)
+		// Subjects keep seeing the last published version until this one is approved.
+		if entry.IsPublished() {
+			publishVersion(&existing, entry)
+		}
 
 		if err := req.Update(&existing); apierrors.IsConflict(err) {
 			log.Debugf("Conflict updating artifact %s (attempt %d/%d), retrying", existing.Name, attempt+1, maxPublishRetries)
@@ -171,14 +202,14 @@ func (h *PublishedArtifactHandler) Create(req api.Context) error {
 			return err
 		}
 
-		log.Infof("Published artifact %s v%d (updated)", existing.Name, version)
-		return req.Write(convertPublishedArtifactForRequester(&existing, req.User, req.UserIsAdmin()))
+		log.Infof("Published artifact %s v%d (updated, reviewState=%q)", existing.Name, version, reviewState)
+		return req.Write(convertPublishedArtifactForRequester(&existing, req.User, req.UserIsAdmin(), isReviewer))
 	}
 
 	return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("failed to publish artifact after %d attempts due to concurrent updates, please retry", maxPublishRetries))
 }
 
-func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byte, fm skillformat.Frontmatter, body string, manifest types.PublishedArtifactManifest, authorID, authorEmail, artifactName string) error {
+func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byte, fm skillformat.Frontmatter, body string, manifest types.PublishedArtifactManifest, authorID, authorEmail, artifactName string, reviewState types.PublishedArtifactReviewState, isReviewer bool) error {
 	// Stamp publish metadata into SKILL.md before uploading the first version.
 	fm = withArtifactMetadata(fm, artifactName, authorEmail, 1)
 	data, err := rewriteSkillFrontmatterInZIP(data, fm, body)
@@ -198,28 +229,38 @@ func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byt
 		return fmt.Errorf("failed to upload artifact: %w", err)
 	}
 
+	entry := types.PublishedArtifactVersionEntry{
+		Version:     1,
+		BlobKey:     blobKey,
+		Description: manifest.Description,
+		AuthorEmail: manifest.AuthorEmail,
+		Body:        search.TruncateBody(body),
+		CreatedAt:   *types.NewTime(time.Now()),
+		PublishedArtifactVersionState: types.PublishedArtifactVersionState{
+			ReviewState: reviewState,
+		},
+	}
 	artifact := v1.PublishedArtifact{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      artifactName,
 			Namespace: req.Namespace(),
 		},
 		Spec: v1.PublishedArtifactSpec{
-			PublishedArtifactManifest: manifest,
-			AuthorID:                  authorID,
-			LatestVersion:             1,
-			BlobKey:                   blobKey,
+			PublishedArtifactManifest: types.PublishedArtifactManifest{
+				Name:         manifest.Name,
+				ArtifactType: manifest.ArtifactType,
+				AuthorEmail:  manifest.AuthorEmail,
+			},
+			AuthorID: authorID,
 		},
 		Status: v1.PublishedArtifactStatus{
-			Versions: []types.PublishedArtifactVersionEntry{
-				{
-					Version:     1,
-					BlobKey:     blobKey,
-					Description: manifest.Description,
-					CreatedAt:   *types.NewTime(time.Now()),
-				},
-			},
+			Versions: []types.PublishedArtifactVersionEntry{entry},
 		},
 	}
+	// The description and body are only set once the first version is published.
+	if entry.IsPublished() {
+		publishVersion(&artifact, entry)
+	}
 
 	if err := req.Create(&artifact); apierrors.IsAlreadyExists(err) {
 		// Another concurrent request created this artifact first — clean up our blob
@@ -235,8 +276,8 @@ func (h *PublishedArtifactHandler) createNewArtifact(req api.Context, data []byt
 		return err
 	}
 
-	log.Infof("Published artifact %s v1 (new, id=%s)", manifest.Name, artifact.Name)
-	return req.WriteCreated(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin()))
+	log.Infof("Published artifact %s v1 (new, id=%s, reviewState=%q)", manifest.Name, artifact.Name, reviewState)
+	return req.WriteCreated(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin(), isReviewer))
 }
 
 func (h *PublishedArtifactHandler) List(req api.Context) error {
@@ -259,34 +300,83 @@ func (h *PublishedArtifactHandler) List(req api.Context) error {
 	}
 
 	query := strings.ToLower(req.URL.Query().Get("q"))
+	// reviewState=pending lists the artifacts with versions waiting for review, for building a review queue.
+	reviewState := types.PublishedArtifactReviewState(req.URL.Query().Get("reviewState"))
 
-	log.Debugf("Listing artifacts: type=%q query=%q userID=%q isAdmin=%v totalInDB=%d", artifactType, query, req.User.GetUID(), req.UserIsAdmin(), len(artifacts.Items))
+	isReviewer, err := h.isReviewer(req)
+	if err != nil {
+		return err
+	}
+
+	log.Debugf("Listing artifacts: type=%q query=%q reviewState=%q userID=%q isAdmin=%v isReviewer=%v totalInDB=%d", artifactType, query, reviewState, req.User.GetUID(), req.UserIsAdmin(), isReviewer, len(artifacts.Items))
 
 	items := make([]types.PublishedArtifact, 0, len(artifacts.Items))
+	var documents []search.Document
 	for i := range artifacts.Items {
 		a := &artifacts.Items[i]
 
-		if !h.canAccessArtifact(a, req.User, req.UserIsAdmin()) {
+		if !h.canAccessArtifact(a, req.User, req.UserIsAdmin(), isReviewer) {
+			continue
+		}
+
+		if reviewState != "" && !slices.ContainsFunc(a.Status.Versions, func(version types.PublishedArtifactVersionEntry) bool {
+			return version.ReviewState == reviewState
+		}) {
 			continue
 		}
 
-		// Text search filter
 		if query != "" {
-			nameMatch := strings.Contains(strings.ToLower(a.Spec.Name), query)
-			displayNameMatch := strings.Contains(strings.ToLower(skillformat.DisplayName(a.Spec.Name)), query)
-			descMatch := strings.Contains(strings.ToLower(a.Spec.Description), query)
-			if !nameMatch && !displayNameMatch && !descMatch {
+			// Only the latest published version that isn't yanked is searchable.
+			doc, ok := search.PublishedArtifactDocument(a)
+			if !ok {
 				continue
 			}
+
+			if h.searchIndex != nil {
+				documents = append(documents, doc)
+			} else {
+				// Text search filter, when the search index isn't available to rank the results.
+				nameMatch := strings.Contains(strings.ToLower(doc.Name), query)
+				displayNameMatch := strings.Contains(strings.ToLower(doc.DisplayName), query)
+				descMatch := strings.Contains(strings.ToLower(doc.Description), query)
+				if !nameMatch && !displayNameMatch && !descMatch {
+					continue
+				}
+			}
 		}
 
-		items = append(items, convertPublishedArtifactForRequester(a, req.User, req.UserIsAdmin()))
+		items = append(items, convertPublishedArtifactForRequester(a, req.User, req.UserIsAdmin(), isReviewer))
+	}
+
+	if query != "" && h.searchIndex != nil {
+		if items, err = h.searchArtifacts(req.Context(), query, documents, items); err != nil {
+			return err
+		}
 	}
 
 	log.Debugf("Returning %d artifacts (filtered from %d)", len(items), len(artifacts.Items))
 	return req.Write(types.PublishedArtifactList{Items: items})
 }
 
+// searchArtifacts returns the artifacts whose documents match the query, most relevant first.
+func (h *PublishedArtifactHandler) searchArtifacts(ctx context.Context, query string, documents []search.Document, artifacts []types.PublishedArtifact) ([]types.PublishedArtifact, error) {
+	byID := make(map[string]types.PublishedArtifact, len(artifacts))
+	for _, artifact := range artifacts {
+		byID[artifact.ID] = artifact
+	}
+
+	results, err := h.searchIndex.Search(ctx, search.KindPublishedArtifact, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]types.PublishedArtifact, 0, len(results))
+	for _, result := range results {
+		matched = append(matched, byID[result.ID])
+	}
+	return matched, nil
+}
+
 func (h *PublishedArtifactHandler) Get(req api.Context) error {
 	if err := h.checkConfigured(); err != nil {
 		return err
@@ -297,11 +387,12 @@ func (h *PublishedArtifactHandler) Get(req api.Context) error {
 		return err
 	}
 
-	if err := h.checkVisibility(&artifact, req); err != nil {
+	isReviewer, err := h.checkVisibility(&artifact, req)
+	if err != nil {
 		return err
 	}
 
-	return req.Write(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin()))
+	return req.Write(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin(), isReviewer))
 }
 
 func (h *PublishedArtifactHandler) Download(req api.Context) error {
@@ -315,7 +406,8 @@ func (h *PublishedArtifactHandler) Download(req api.Context) error {
 		return err
 	}
 
-	if err := h.checkVisibility(&artifact, req); err != nil {
+	isReviewer, err := h.checkVisibility(&artifact, req)
+	if err != nil {
 		return err
 	}
 
@@ -331,7 +423,7 @@ func (h *PublishedArtifactHandler) Download(req api.Context) error {
 		version = parsed
 	}
 
-	if !h.canAccessArtifactVersion(&artifact, version, req.User, req.UserIsAdmin()) {
+	if !h.canAccessArtifactVersion(&artifact, version, req.User, req.UserIsAdmin(), isReviewer) {
 		return types.NewErrNotFound("version %d not found", version)
 	}
 
@@ -382,7 +474,8 @@ func (h *PublishedArtifactHandler) GetSkillMD(req api.Context) error {
 		return err
 	}
 
-	if err := h.checkVisibility(&artifact, req); err != nil {
+	isReviewer, err := h.checkVisibility(&artifact, req)
+	if err != nil {
 		return err
 	}
 
@@ -390,7 +483,7 @@ func (h *PublishedArtifactHandler) GetSkillMD(req api.Context) error {
 	if err != nil || version < 1 {
 		return types.NewErrBadRequest("invalid version: %s", req.PathValue("version"))
 	}
-	if !h.canAccessArtifactVersion(&artifact, version, req.User, req.UserIsAdmin()) {
+	if !h.canAccessArtifactVersion(&artifact, version, req.User, req.UserIsAdmin(), isReviewer) {
 		return types.NewErrNotFound("version %d not found", version)
 	}
 
@@ -481,11 +574,13 @@ func (h *PublishedArtifactHandler) Update(req api.Context) error {
 		log.Debugf("Updating artifact %s description: %q -> %q", id, artifact.Spec.Description, *update.Description)
 		artifact.Spec.Description = *update.Description
 	}
-	if update.Subjects != nil {
-		if err := validatePublishedArtifactSubjects(update.Subjects); err != nil {
-			return types.NewErrBadRequest("invalid subjects: %v", err)
+	if update.Subjects != nil || update.Deprecated != nil || update.DeprecationMessage != nil || update.Yanked != nil || update.YankReason != nil {
+		if update.Subjects != nil {
+			if err := validatePublishedArtifactSubjects(update.Subjects); err != nil {
+				return types.NewErrBadRequest("invalid subjects: %v", err)
+			}
 		}
-		version := artifact.Spec.LatestVersion
+		version := highestVersion(&artifact)
 		if update.Version != nil {
 			if *update.Version < 1 {
 				return types.NewErrBadRequest("version must be >= 1")
@@ -496,16 +591,26 @@ func (h *PublishedArtifactHandler) Update(req api.Context) error {
 		if entry == nil {
 			return types.NewErrNotFound("version %d not found", version)
 		}
-		log.Debugf("Updating artifact %s version %d subjects (count=%d)", id, version, len(update.Subjects))
-		entry.Subjects = update.Subjects
+		if update.Subjects != nil {
+			log.Debugf("Updating artifact %s version %d subjects (count=%d)", id, version, len(update.Subjects))
+			entry.Subjects = update.Subjects
+		}
+		if err := updateVersionState(entry, update); err != nil {
+			return err
+		}
 	}
 
 	if err := req.Update(&artifact); err != nil {
 		return err
 	}
 
+	isReviewer, err := h.isReviewer(req)
+	if err != nil {
+		return err
+	}
+
 	log.Infof("Updated artifact %s (%s)", id, artifact.Spec.Name)
-	return req.Write(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin()))
+	return req.Write(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin(), isReviewer))
 }
 
 func (h *PublishedArtifactHandler) Delete(req api.Context) error {
@@ -540,14 +645,250 @@ func (h *PublishedArtifactHandler) Delete(req api.Context) error {
 	return nil
 }
 
-func (h *PublishedArtifactHandler) checkVisibility(artifact *v1.PublishedArtifact, req api.Context) error {
-	if h.canAccessArtifact(artifact, req.User, req.UserIsAdmin()) {
-		return nil
+// Review records a reviewer's decision on a pending version. Approving a version makes it visible to its subjects.
+func (h *PublishedArtifactHandler) Review(req api.Context) error {
+	if err := h.checkConfigured(); err != nil {
+		return err
 	}
-	return types.NewErrNotFound("artifact %s not found", req.PathValue("id"))
+
+	id := req.PathValue("id")
+	var artifact v1.PublishedArtifact
+	if err := req.Get(&artifact, id); err != nil {
+		return err
+	}
+
+	isReviewer, err := h.checkVisibility(&artifact, req)
+	if err != nil {
+		return err
+	}
+	if !isReviewer && !req.UserIsAdmin() {
+		return types.NewErrForbidden("only reviewers can review artifacts")
+	}
+	if artifact.Spec.AuthorID == req.User.GetUID() {
+		return types.NewErrForbidden("authors can't review their own artifacts")
+	}
+
+	version, err := strconv.Atoi(req.PathValue("version"))
+	if err != nil || version < 1 {
+		return types.NewErrBadRequest("invalid version: %s", req.PathValue("version"))
+	}
+	entry := findVersionEntry(&artifact, version)
+	if entry == nil {
+		return types.NewErrNotFound("version %d not found", version)
+	}
+
+	var review types.PublishedArtifactReviewRequest
+	if err := req.Read(&review); err != nil {
+		return err
+	}
+	if review.Decision != types.PublishedArtifactReviewStateApproved && review.Decision != types.PublishedArtifactReviewStateRejected {
+		return types.NewErrBadRequest("decision must be %q or %q", types.PublishedArtifactReviewStateApproved, types.PublishedArtifactReviewStateRejected)
+	}
+	if len(review.Comment) > maxReviewCommentLen {
+		return types.NewErrBadRequest("comment must be %d characters or fewer", maxReviewCommentLen)
+	}
+	if entry.ReviewState != types.PublishedArtifactReviewStatePending {
+		return types.NewErrHTTP(http.StatusConflict, fmt.Sprintf("version %d is not pending review", version))
+	}
+
+	entry.ReviewState = review.Decision
+	entry.Reviews = append(entry.Reviews, types.PublishedArtifactReview{
+		ReviewerID: req.User.GetUID(),
+		Decision:   review.Decision,
+		Comment:    review.Comment,
+		CreatedAt:  *types.NewTime(time.Now()),
+	})
+	if entry.IsPublished() {
+		publishVersion(&artifact, *entry)
+	}
+
+	if err := req.Update(&artifact); err != nil {
+		return err
+	}
+
+	log.Infof("Artifact %s v%d %s by %s", id, version, review.Decision, req.User.GetUID())
+	return req.Write(convertPublishedArtifactForRequester(&artifact, req.User, req.UserIsAdmin(), isReviewer))
 }
 
-func (h *PublishedArtifactHandler) canAccessArtifact(artifact *v1.PublishedArtifact, requester user.Info, isAdmin bool) bool {
+// Diff returns the difference between a version and the closest earlier version that was published, so reviewers
+// can see what changed.
+func (h *PublishedArtifactHandler) Diff(req api.Context) error {
+	if err := h.checkConfigured(); err != nil {
+		return err
+	}
+
+	id := req.PathValue("id")
+	var artifact v1.PublishedArtifact
+	if err := req.Get(&artifact, id); err != nil {
+		return err
+	}
+
+	isReviewer, err := h.checkVisibility(&artifact, req)
+	if err != nil {
+		return err
+	}
+	if !isReviewer && !req.UserIsAdmin() && artifact.Spec.AuthorID != req.User.GetUID() {
+		return types.NewErrForbidden("only the author and reviewers can view version diffs")
+	}
+
+	version, err := strconv.Atoi(req.PathValue("version"))
+	if err != nil || version < 1 {
+		return types.NewErrBadRequest("invalid version: %s", req.PathValue("version"))
+	}
+	entry := findVersionEntry(&artifact, version)
+	if entry == nil {
+		return types.NewErrNotFound("version %d not found", version)
+	}
+
+	var base *types.PublishedArtifactVersionEntry
+	for i, candidate := range artifact.Status.Versions {
+		if candidate.Version < version && candidate.IsPublished() && (base == nil || candidate.Version > base.Version) {
+			base = &artifact.Status.Versions[i]
+		}
+	}
+
+	target, err := h.readArtifactFiles(req.Context(), entry.BlobKey)
+	if err != nil {
+		return err
+	}
+
+	result := types.PublishedArtifactVersionDiff{Version: version}
+	var baseFiles map[string][]byte
+	if base != nil {
+		result.BaseVersion = base.Version
+		if baseFiles, err = h.readArtifactFiles(req.Context(), base.BlobKey); err != nil {
+			return err
+		}
+	}
+	result.Files = diffArtifactFiles(baseFiles, target)
+
+	return req.Write(result)
+}
+
+// readArtifactFiles returns the contents of the files in the artifact ZIP stored at the blob key.
+func (h *PublishedArtifactHandler) readArtifactFiles(ctx context.Context, blobKey string) (map[string][]byte, error) {
+	reader, err := h.blobStore.Download(ctx, h.bucket, blobKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to download artifact: %w", err)
+	}
+	defer reader.Close()
+
+	data, err := io.ReadAll(io.LimitReader(reader, maxArtifactUploadBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact blob: %w", err)
+	}
+	if len(data) > maxArtifactUploadBytes {
+		return nil, fmt.Errorf("artifact blob exceeds maximum size")
+	}
+
+	r, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read artifact ZIP: %w", err)
+	}
+	if err := validateZIP(r); err != nil {
+		return nil, err
+	}
+
+	var totalRead int
+	files := make(map[string][]byte, len(r.File))
+	for _, f := range r.File {
+		if f.Mode().IsDir() {
+			continue
+		}
+		rc, err := f.Open()
+		if err != nil {
+			return nil, fmt.Errorf("failed to open entry %s: %w", f.Name, err)
+		}
+		content, err := io.ReadAll(io.LimitReader(rc, int64(maxZIPUncompressedBytes-totalRead)+1))
+		rc.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read entry %s: %w", f.Name, err)
+		}
+		totalRead += len(content)
+		if totalRead > maxZIPUncompressedBytes {
+			return nil, fmt.Errorf("ZIP total uncompressed size exceeds limit (%d bytes)", maxZIPUncompressedBytes)
+		}
+		files[f.Name] = content
+	}
+	return files, nil
+}
+
+// diffArtifactFiles returns the added, removed, and modified files between two versions, sorted by path.
+func diffArtifactFiles(base, target map[string][]byte) []types.PublishedArtifactFileDiff {
+	paths := make(map[string]struct{}, len(base)+len(target))
+	for p := range base {
+		paths[p] = struct{}{}
+	}
+	for p := range target {
+		paths[p] = struct{}{}
+	}
+
+	result := make([]types.PublishedArtifactFileDiff, 0, len(paths))
+	for _, p := range slices.Sorted(maps.Keys(paths)) {
+		before, inBase := base[p]
+		after, inTarget := target[p]
+
+		fileDiff := types.PublishedArtifactFileDiff{Path: p}
+		switch {
+		case !inBase:
+			fileDiff.Status = "added"
+		case !inTarget:
+			fileDiff.Status = "removed"
+		case bytes.Equal(before, after):
+			continue
+		default:
+			fileDiff.Status = "modified"
+		}
+
+		if isBinaryContent(before) || isBinaryContent(after) {
+			fileDiff.Binary = true
+		} else {
+			fromFile, toFile := "a/"+p, "b/"+p
+			if !inBase {
+				fromFile = "/dev/null"
+			}
+			if !inTarget {
+				toFile = "/dev/null"
+			}
+			fileDiff.Diff, _ = difflib.GetUnifiedDiffString(difflib.UnifiedDiff{
+				A:        difflib.SplitLines(string(before)),
+				B:        difflib.SplitLines(string(after)),
+				FromFile: fromFile,
+				ToFile:   toFile,
+				Context:  3,
+			})
+		}
+		result = append(result, fileDiff)
+	}
+	return result
+}
+
+func isBinaryContent(content []byte) bool {
+	return !utf8.Valid(content) || bytes.IndexByte(content, 0) >= 0
+}
+
+// checkVisibility returns a not found error if the requester can't see the artifact, and whether the requester is a reviewer.
+func (h *PublishedArtifactHandler) checkVisibility(artifact *v1.PublishedArtifact, req api.Context) (bool, error) {
+	isReviewer, err := h.isReviewer(req)
+	if err != nil {
+		return false, err
+	}
+	if h.canAccessArtifact(artifact, req.User, req.UserIsAdmin(), isReviewer) {
+		return isReviewer, nil
+	}
+	return false, types.NewErrNotFound("artifact %s not found", req.PathValue("id"))
+}
+
+// isReviewer returns whether the requester is one of the review policy's reviewers.
+func (h *PublishedArtifactHandler) isReviewer(req api.Context) (bool, error) {
+	policy, err := getPublishedArtifactReviewPolicy(req.Context(), req.Storage)
+	if err != nil {
+		return false, err
+	}
+	return subjectsContainUser(policy.Reviewers, req.User), nil
+}
+
+func (h *PublishedArtifactHandler) canAccessArtifact(artifact *v1.PublishedArtifact, requester user.Info, isAdmin, isReviewer bool) bool {
 	if requester == nil {
 		return false
 	}
@@ -555,7 +896,7 @@ func (h *PublishedArtifactHandler) canAccessArtifact(artifact *v1.PublishedArtif
 		return true
 	}
 	for _, version := range artifact.Status.Versions {
-		if subjectsContainUser(version.Subjects, requester) {
+		if versionVisibleTo(version, requester, isReviewer) {
 			return true
 		}
 	}
@@ -569,33 +910,86 @@ func (h *PublishedArtifactHandler) checkOwnership(artifact *v1.PublishedArtifact
 	return types.NewErrNotFound("artifact %s not found", req.PathValue("id"))
 }
 
-func (h *PublishedArtifactHandler) canAccessArtifactVersion(artifact *v1.PublishedArtifact, version int, requester user.Info, isAdmin bool) bool {
+func (h *PublishedArtifactHandler) canAccessArtifactVersion(artifact *v1.PublishedArtifact, version int, requester user.Info, isAdmin, isReviewer bool) bool {
 	if requester == nil {
 		return false
 	}
+	entry := findVersionEntry(artifact, version)
+	if entry == nil {
+		return false
+	}
 	if isAdmin || artifact.Spec.AuthorID == requester.GetUID() {
-		return findVersionEntry(artifact, version) != nil
+		return true
 	}
-	return subjectsContainUser(versionSubjects(artifact, version), requester)
+	return versionVisibleTo(*entry, requester, isReviewer)
 }
 
+// defaultDownloadVersion returns the latest version the requester can download without asking for a specific one.
+// Yanked versions are never the default, and neither are unpublished versions, except for the author and admins.
 func (h *PublishedArtifactHandler) defaultDownloadVersion(artifact *v1.PublishedArtifact, requester user.Info, isAdmin bool) int {
 	if requester == nil {
 		return 0
 	}
-	if isAdmin || artifact.Spec.AuthorID == requester.GetUID() {
-		return artifact.Spec.LatestVersion
-	}
+	isOwner := isAdmin || artifact.Spec.AuthorID == requester.GetUID()
 
 	latestVisible := 0
 	for _, version := range artifact.Status.Versions {
-		if subjectsContainUser(version.Subjects, requester) && version.Version > latestVisible {
+		if version.Yanked || version.Version <= latestVisible {
+			continue
+		}
+		if isOwner || (version.IsPublished() && subjectsContainUser(version.Subjects, requester)) {
 			latestVisible = version.Version
 		}
 	}
 	return latestVisible
 }
 
+// versionVisibleTo returns whether a requester who is neither the author nor an admin can see the version.
+// Reviewers can see every version that is pending review.
+func versionVisibleTo(version types.PublishedArtifactVersionEntry, requester user.Info, isReviewer bool) bool {
+	if isReviewer && version.ReviewState == types.PublishedArtifactReviewStatePending {
+		return true
+	}
+	return version.IsPublished() && subjectsContainUser(version.Subjects, requester)
+}
+
+// updateVersionState applies the deprecation and yank changes of the update to the version.
+func updateVersionState(entry *types.PublishedArtifactVersionEntry, update publishedArtifactUpdateRequest) error {
+	if update.Deprecated != nil {
+		entry.Deprecated = *update.Deprecated
+		if !entry.Deprecated {
+			entry.DeprecationMessage = ""
+		}
+	}
+	if update.DeprecationMessage != nil {
+		if !entry.Deprecated {
+			return types.NewErrBadRequest("only deprecated versions can have a deprecation message")
+		}
+		if len(*update.DeprecationMessage) > maxArtifactDescriptionLen {
+			return types.NewErrBadRequest("deprecation message must be %d characters or fewer", maxArtifactDescriptionLen)
+		}
+		entry.DeprecationMessage = *update.DeprecationMessage
+	}
+
+	if update.Yanked != nil {
+		entry.Yanked = *update.Yanked
+		if !entry.Yanked {
+			entry.YankReason = ""
+		}
+	}
+	if update.YankReason != nil {
+		if !entry.Yanked {
+			return types.NewErrBadRequest("only yanked versions can have a yank reason")
+		}
+		if len(*update.YankReason) > maxArtifactDescriptionLen {
+			return types.NewErrBadRequest("yank reason must be %d characters or fewer", maxArtifactDescriptionLen)
+		}
+		entry.YankReason = *update.YankReason
+	}
+
+	return nil
+}
+
 func validatePublishedArtifactSubjects(subjects []types.Subject) error {
 	seen := make(map[types.Subject]struct{}, len(subjects))
 	for _, subject := range subjects {
@@ -626,6 +1020,29 @@ func findVersionEntry(artifact *v1.PublishedArtifact, version int) *types.Publis
 	return nil
 }
 
+// highestVersion returns the highest version of the artifact, whether or not it is published.
+func highestVersion(artifact *v1.PublishedArtifact) int {
+	highest := 0
+	for _, entry := range artifact.Status.Versions {
+		highest = max(highest, entry.Version)
+	}
+	return highest
+}
+
+// publishVersion makes the version the one that subjects see and search, unless a later version is already published.
+func publishVersion(artifact *v1.PublishedArtifact, version types.PublishedArtifactVersionEntry) {
+	if version.Version < artifact.Spec.LatestVersion {
+		return
+	}
+	artifact.Spec.LatestVersion = version.Version
+	artifact.Spec.BlobKey = version.BlobKey
+	artifact.Spec.Description = version.Description
+	artifact.Spec.Body = version.Body
+	if version.AuthorEmail != "" {
+		artifact.Spec.AuthorEmail = version.AuthorEmail
+	}
+}
+
 func versionSubjects(artifact *v1.PublishedArtifact, version int) []types.Subject {
 	entry := findVersionEntry(artifact, version)
 	if entry == nil {
@@ -871,13 +1288,13 @@ func randomHex(n int) (string, error) {
 	return hex.EncodeToString(b), nil
 }
 
-func convertPublishedArtifactForRequester(a *v1.PublishedArtifact, requester user.Info, isAdmin bool) types.PublishedArtifact {
-	versions := visibleVersionSummaries(a, requester, isAdmin)
-	latestVersion := a.Spec.LatestVersion
+func convertPublishedArtifactForRequester(a *v1.PublishedArtifact, requester user.Info, isAdmin, isReviewer bool) types.PublishedArtifact {
+	versions := visibleVersionSummaries(a, requester, isAdmin, isReviewer)
+	latestVersion := highestVersion(a)
 	if requester == nil || (a.Spec.AuthorID != requester.GetUID() && !isAdmin) {
 		latestVersion = 0
 		for _, version := range versions {
-			if version.Version > latestVersion {
+			if version.Version > latestVersion && version.IsPublished() && !version.Yanked {
 				latestVersion = version.Version
 			}
 		}
@@ -893,21 +1310,29 @@ func convertPublishedArtifactForRequester(a *v1.PublishedArtifact, requester use
 	}
 }
 
-func visibleVersionSummaries(a *v1.PublishedArtifact, requester user.Info, isAdmin bool) []types.PublishedArtifactVersionSummary {
+func visibleVersionSummaries(a *v1.PublishedArtifact, requester user.Info, isAdmin, isReviewer bool) []types.PublishedArtifactVersionSummary {
 	if requester == nil || len(a.Status.Versions) == 0 {
 		return nil
 	}
 
+	isOwner := a.Spec.AuthorID == requester.GetUID() || isAdmin
 	result := make([]types.PublishedArtifactVersionSummary, 0, len(a.Status.Versions))
 	for _, version := range a.Status.Versions {
-		if a.Spec.AuthorID != requester.GetUID() && !isAdmin && !subjectsContainUser(version.Subjects, requester) {
+		if !isOwner && !versionVisibleTo(version, requester, isReviewer) {
 			continue
 		}
+
+		state := version.PublishedArtifactVersionState
+		if !isOwner && !isReviewer {
+			// Review comments are only for the author, admins, and reviewers.
+			state.Reviews = nil
+		}
 		result = append(result, types.PublishedArtifactVersionSummary{
-			Version:     version.Version,
-			Description: version.Description,
-			CreatedAt:   version.CreatedAt,
-			Subjects:    version.Subjects,
+			Version:                       version.Version,
+			Description:                   version.Description,
+			CreatedAt:                     version.CreatedAt,
+			Subjects:                      version.Subjects,
+			PublishedArtifactVersionState: state,
 		})
 	}
 	return result
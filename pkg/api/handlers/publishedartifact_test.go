@@ -372,7 +372,7 @@ func TestConvertPublishedArtifact(t *testing.T) {
 		},
 	}
 
-	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "user-123"}, false)
+	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "user-123"}, false, false)
 
 	if result.ID != "pa1abc123" {
 		t.Errorf("ID = %q, want %q", result.ID, "pa1abc123")
@@ -418,7 +418,7 @@ func TestConvertPublishedArtifact(t *testing.T) {
 
 func TestConvertPublishedArtifact_ZeroValue(t *testing.T) {
 	input := &v1.PublishedArtifact{}
-	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "user-123"}, false)
+	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "user-123"}, false, false)
 
 	if result.Name != "" {
 		t.Errorf("Name = %q, want empty", result.Name)
@@ -508,10 +508,10 @@ func TestCanAccessArtifact(t *testing.T) {
 		},
 	}
 
-	if !handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "owner"}, false) {
+	if !handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "owner"}, false, false) {
 		t.Fatal("owner should have access")
 	}
-	if !handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "other"}, true) {
+	if !handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "other"}, true, false) {
 		t.Fatal("admin should have access")
 	}
 	if !handler.canAccessArtifact(artifact, &kuser.DefaultInfo{
@@ -519,10 +519,10 @@ func TestCanAccessArtifact(t *testing.T) {
 		Extra: map[string][]string{
 			"auth_provider_groups": {"group1"},
 		},
-	}, false) {
+	}, false, false) {
 		t.Fatal("group member should have access")
 	}
-	if handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "other"}, false) {
+	if handler.canAccessArtifact(artifact, &kuser.DefaultInfo{UID: "other"}, false, false) {
 		t.Fatal("unmatched user should not have access")
 	}
 }
@@ -550,7 +550,7 @@ func TestConvertPublishedArtifactForRequester_FiltersVersions(t *testing.T) {
 		},
 	}
 
-	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "other"}, false)
+	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "other"}, false, false)
 
 	if result.LatestVersion != 1 {
 		t.Fatalf("LatestVersion = %d, want 1", result.LatestVersion)
@@ -560,6 +560,98 @@ func TestConvertPublishedArtifactForRequester_FiltersVersions(t *testing.T) {
 	}
 }
 
+func TestConvertPublishedArtifactForRequester_ReviewAndYank(t *testing.T) {
+	subjects := []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}}
+	input := &v1.PublishedArtifact{
+		Spec: v1.PublishedArtifactSpec{
+			AuthorID:      "owner",
+			LatestVersion: 4,
+		},
+		Status: v1.PublishedArtifactStatus{
+			Versions: []types.PublishedArtifactVersionEntry{
+				{
+					Version:  1,
+					Subjects: subjects,
+				},
+				{
+					Version:  2,
+					Subjects: subjects,
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{
+						ReviewState: types.PublishedArtifactReviewStateApproved,
+						Reviews:     []types.PublishedArtifactReview{{ReviewerID: "reviewer", Decision: types.PublishedArtifactReviewStateApproved}},
+						Yanked:      true,
+					},
+				},
+				{
+					Version:                       3,
+					Subjects:                      subjects,
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{ReviewState: types.PublishedArtifactReviewStateRejected},
+				},
+				{
+					Version:                       4,
+					Subjects:                      subjects,
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{ReviewState: types.PublishedArtifactReviewStatePending},
+				},
+			},
+		},
+	}
+
+	result := convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "other"}, false, false)
+	if result.LatestVersion != 1 {
+		t.Fatalf("LatestVersion = %d, want 1", result.LatestVersion)
+	}
+	if len(result.Versions) != 2 || result.Versions[1].Version != 2 {
+		t.Fatalf("Versions = %+v, want v1 and v2", result.Versions)
+	}
+	if result.Versions[1].Reviews != nil {
+		t.Fatalf("Reviews = %+v, want none for a subject", result.Versions[1].Reviews)
+	}
+
+	result = convertPublishedArtifactForRequester(input, &kuser.DefaultInfo{UID: "reviewer"}, false, true)
+	if len(result.Versions) != 3 || result.Versions[2].Version != 4 {
+		t.Fatalf("Versions = %+v, want v1, v2, and the pending v4", result.Versions)
+	}
+	if len(result.Versions[1].Reviews) != 1 {
+		t.Fatalf("Reviews = %+v, want the approval", result.Versions[1].Reviews)
+	}
+
+	handler := &PublishedArtifactHandler{}
+	if v := handler.defaultDownloadVersion(input, &kuser.DefaultInfo{UID: "other"}, false); v != 1 {
+		t.Fatalf("defaultDownloadVersion() = %d, want 1", v)
+	}
+	if !handler.canAccessArtifactVersion(input, 2, &kuser.DefaultInfo{UID: "other"}, false, false) {
+		t.Fatal("yanked version should still be downloadable by version")
+	}
+	if handler.canAccessArtifactVersion(input, 4, &kuser.DefaultInfo{UID: "other"}, false, false) {
+		t.Fatal("pending version should not be visible to subjects")
+	}
+}
+
+func TestDiffArtifactFiles(t *testing.T) {
+	diffs := diffArtifactFiles(map[string][]byte{
+		"SKILL.md":    []byte("line 1\nline 2\n"),
+		"removed.txt": []byte("gone\n"),
+		"same.txt":    []byte("same\n"),
+	}, map[string][]byte{
+		"SKILL.md":  []byte("line 1\nline 2 changed\n"),
+		"same.txt":  []byte("same\n"),
+		"image.png": {0x89, 'P', 'N', 'G', 0x00},
+	})
+
+	if len(diffs) != 3 {
+		t.Fatalf("diffs = %+v, want 3", diffs)
+	}
+	if diffs[0].Path != "SKILL.md" || diffs[0].Status != "modified" || !strings.Contains(diffs[0].Diff, "+line 2 changed") {
+		t.Errorf("diffs[0] = %+v, want modified SKILL.md", diffs[0])
+	}
+	if diffs[1].Path != "image.png" || diffs[1].Status != "added" || !diffs[1].Binary || diffs[1].Diff != "" {
+		t.Errorf("diffs[1] = %+v, want added binary image.png", diffs[1])
+	}
+	if diffs[2].Path != "removed.txt" || diffs[2].Status != "removed" || !strings.Contains(diffs[2].Diff, "-gone") {
+		t.Errorf("diffs[2] = %+v, want removed removed.txt", diffs[2])
+	}
+}
+
 func newPublishedArtifactTestStorage(t *testing.T, objects ...kclient.Object) kclient.WithWatch {
 	t.Helper()
 	return fake.NewClientBuilder().
@@ -607,7 +699,7 @@ func TestPublishedArtifactCreate_InheritsSubjectsFromPreviousVersion(t *testing.
 	if err != nil {
 		t.Fatalf("failed to create directory blob store: %v", err)
 	}
-	handler := NewPublishedArtifactHandler(blobStore, "test-bucket")
+	handler := NewPublishedArtifactHandler(blobStore, "test-bucket", nil)
 	reqBody := createArtifactTestZIP(t, map[string][]byte{
 		skillformat.SkillMainFile: createSkillMDContent(t, "workflow-a", "v2", nil),
 	})
@@ -675,6 +767,127 @@ func TestPublishedArtifactCreate_InheritsSubjectsFromPreviousVersion(t *testing.
 	}
 }
 
+func TestPublishedArtifactList_PendingVersionHiddenFromSubjects(t *testing.T) {
+	artifactName := system.PublishedArtifactPrefix + hash.String("owner" + string(types.PublishedArtifactTypeWorkflow) + "workflow-a")[:12]
+
+	storage := newPublishedArtifactTestStorage(t, &v1.PublishedArtifactReviewPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      system.PublishedArtifactReviewPolicyName,
+			Namespace: system.DefaultNamespace,
+		},
+		Spec: v1.PublishedArtifactReviewPolicySpec{
+			PublishedArtifactReviewPolicyManifest: types.PublishedArtifactReviewPolicyManifest{
+				RequireReview: true,
+				Reviewers:     []types.Subject{{Type: types.SubjectTypeUser, ID: "reviewer"}},
+			},
+		},
+	}, &v1.PublishedArtifact{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      artifactName,
+			Namespace: system.DefaultNamespace,
+		},
+		Spec: v1.PublishedArtifactSpec{
+			PublishedArtifactManifest: types.PublishedArtifactManifest{
+				Name:         "workflow-a",
+				Description:  "v1",
+				ArtifactType: types.PublishedArtifactTypeWorkflow,
+				AuthorEmail:  "owner@example.com",
+			},
+			AuthorID:      "owner",
+			LatestVersion: 1,
+			Body:          "v1 body",
+			BlobKey:       "published-artifacts/" + artifactName + "/v1.zip",
+		},
+		Status: v1.PublishedArtifactStatus{
+			Versions: []types.PublishedArtifactVersionEntry{
+				{
+					Version:     1,
+					BlobKey:     "published-artifacts/" + artifactName + "/v1.zip",
+					Description: "v1",
+					CreatedAt:   *types.NewTime(metav1.Now().Time),
+					Subjects:    []types.Subject{{Type: types.SubjectTypeSelector, ID: "*"}},
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{
+						ReviewState: types.PublishedArtifactReviewStateApproved,
+					},
+				},
+			},
+		},
+	})
+
+	blobStore, err := blobpkg.NewDirectoryStore(t.TempDir())
+	if err != nil {
+		t.Fatalf("failed to create directory blob store: %v", err)
+	}
+	handler := NewPublishedArtifactHandler(blobStore, "test-bucket", nil)
+	reqBody := createArtifactTestZIP(t, map[string][]byte{
+		skillformat.SkillMainFile: createSkillMDContent(t, "workflow-a", "v2", nil),
+	})
+
+	err = handler.Create(api.Context{
+		ResponseWriter: httptest.NewRecorder(),
+		Request:        httptest.NewRequest(http.MethodPost, "/api/published-artifacts", bytes.NewReader(reqBody)),
+		Storage:        storage,
+		User: &kuser.DefaultInfo{
+			Name:   "owner",
+			UID:    "owner",
+			Groups: []string{types.GroupAuthenticated},
+			Extra: map[string][]string{
+				"email": {"owner@example.com"},
+			},
+		},
+	})
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	var artifact v1.PublishedArtifact
+	if err := storage.Get(context.Background(), kclient.ObjectKey{
+		Namespace: system.DefaultNamespace,
+		Name:      artifactName,
+	}, &artifact); err != nil {
+		t.Fatalf("failed to fetch updated artifact: %v", err)
+	}
+	if artifact.Spec.LatestVersion != 1 || artifact.Spec.Description != "v1" || artifact.Spec.Body != "v1 body" {
+		t.Fatalf("Spec = %+v, want the approved v1", artifact.Spec)
+	}
+	if len(artifact.Status.Versions) != 2 || artifact.Status.Versions[1].Description != "v2" {
+		t.Fatalf("Versions = %+v, want the pending v2 recorded", artifact.Status.Versions)
+	}
+
+	rec := httptest.NewRecorder()
+	err = handler.List(api.Context{
+		ResponseWriter: rec,
+		Request:        httptest.NewRequest(http.MethodGet, "/api/published-artifacts", nil),
+		Storage:        storage,
+		User: &kuser.DefaultInfo{
+			Name:   "subject",
+			UID:    "subject",
+			Groups: []string{types.GroupAuthenticated},
+		},
+	})
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	var list types.PublishedArtifactList
+	if err := json.NewDecoder(rec.Body).Decode(&list); err != nil {
+		t.Fatalf("failed to decode response: %v", err)
+	}
+	if len(list.Items) != 1 {
+		t.Fatalf("items len = %d, want 1", len(list.Items))
+	}
+	got := list.Items[0]
+	if got.Description != "v1" {
+		t.Errorf("Description = %q, want %q", got.Description, "v1")
+	}
+	if got.LatestVersion != 1 {
+		t.Errorf("LatestVersion = %d, want 1", got.LatestVersion)
+	}
+	if len(got.Versions) != 1 || got.Versions[0].Version != 1 {
+		t.Errorf("Versions = %+v, want only v1", got.Versions)
+	}
+}
+
 func TestValidateZIP_PathTraversal(t *testing.T) {
 	zipData := createArtifactTestZIP(t, map[string][]byte{
 		"../etc/passwd": []byte("bad"),
@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type PublishedArtifactReviewPolicyHandler struct{}
+
+func NewPublishedArtifactReviewPolicyHandler() *PublishedArtifactReviewPolicyHandler {
+	return &PublishedArtifactReviewPolicyHandler{}
+}
+
+func (h *PublishedArtifactReviewPolicyHandler) Get(req api.Context) error {
+	var policy v1.PublishedArtifactReviewPolicy
+	err := req.Storage.Get(req.Context(), kclient.ObjectKey{
+		Namespace: req.Namespace(),
+		Name:      system.PublishedArtifactReviewPolicyName,
+	}, &policy)
+
+	if apierrors.IsNotFound(err) {
+		// Return an empty policy if not yet configured
+		return req.Write(types.PublishedArtifactReviewPolicy{})
+	}
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertPublishedArtifactReviewPolicy(policy))
+}
+
+func (h *PublishedArtifactReviewPolicyHandler) Update(req api.Context) error {
+	var input types.PublishedArtifactReviewPolicyManifest
+	if err := req.Read(&input); err != nil {
+		return err
+	}
+
+	if err := validatePublishedArtifactReviewPolicy(input); err != nil {
+		return types.NewErrBadRequest("invalid review policy: %v", err)
+	}
+
+	var policy v1.PublishedArtifactReviewPolicy
+	err := req.Get(&policy, system.PublishedArtifactReviewPolicyName)
+
+	if apierrors.IsNotFound(err) {
+		policy = v1.PublishedArtifactReviewPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.PublishedArtifactReviewPolicyName,
+				Namespace: req.Namespace(),
+			},
+			Spec: v1.PublishedArtifactReviewPolicySpec{
+				PublishedArtifactReviewPolicyManifest: input,
+			},
+		}
+
+		if err := req.Create(&policy); err != nil {
+			return err
+		}
+	} else if err != nil {
+		return err
+	} else {
+		policy.Spec.PublishedArtifactReviewPolicyManifest = input
+		if err := req.Update(&policy); err != nil {
+			return err
+		}
+	}
+
+	return req.Write(convertPublishedArtifactReviewPolicy(policy))
+}
+
+func convertPublishedArtifactReviewPolicy(policy v1.PublishedArtifactReviewPolicy) types.PublishedArtifactReviewPolicy {
+	return types.PublishedArtifactReviewPolicy{
+		PublishedArtifactReviewPolicyManifest: policy.Spec.PublishedArtifactReviewPolicyManifest,
+		Metadata:                              MetadataFrom(&policy),
+	}
+}
+
+func validatePublishedArtifactReviewPolicy(policy types.PublishedArtifactReviewPolicyManifest) error {
+	if policy.RequireReview && len(policy.Reviewers) == 0 {
+		return fmt.Errorf("at least one reviewer is required when review is required")
+	}
+
+	seen := make(map[types.Subject]struct{}, len(policy.Reviewers))
+	for _, reviewer := range policy.Reviewers {
+		if reviewer.Type != types.SubjectTypeUser && reviewer.Type != types.SubjectTypeGroup {
+			return fmt.Errorf("reviewers must be users or groups")
+		}
+		if err := reviewer.Validate(); err != nil {
+			return err
+		}
+		if _, ok := seen[reviewer]; ok {
+			return fmt.Errorf("duplicate reviewer: %s/%s", reviewer.Type, reviewer.ID)
+		}
+		seen[reviewer] = struct{}{}
+	}
+
+	return nil
+}
+
+// getPublishedArtifactReviewPolicy returns the review policy. If one hasn't been configured, then an empty policy
+// that doesn't require review is returned.
+func getPublishedArtifactReviewPolicy(ctx context.Context, client kclient.Client) (types.PublishedArtifactReviewPolicyManifest, error) {
+	var policy v1.PublishedArtifactReviewPolicy
+	if err := client.Get(ctx, kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: system.PublishedArtifactReviewPolicyName}, &policy); apierrors.IsNotFound(err) {
+		return types.PublishedArtifactReviewPolicyManifest{}, nil
+	} else if err != nil {
+		return types.PublishedArtifactReviewPolicyManifest{}, fmt.Errorf("failed to get published artifact review policy: %w", err)
+	}
+
+	return policy.Spec.PublishedArtifactReviewPolicyManifest, nil
+}
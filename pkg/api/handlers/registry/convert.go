@@ -127,6 +127,7 @@ func ConvertMCPServerCatalogEntryToRegistry(
 	entry v1.MCPServerCatalogEntry,
 	serverURL string,
 	reverseDNS string,
+	exposure *packageExposure,
 	mimeFetcher *mimeFetcher,
 ) (obottypes.RegistryServerResponse, error) {
 	manifest := entry.Spec.Manifest
@@ -183,6 +184,9 @@ func ConvertMCPServerCatalogEntryToRegistry(
 		}
 	}
 
+	// Add packages if the entry's catalog exposes them, so clients can install the server locally
+	serverDetail.Packages = exposure.packages(manifest)
+
 	// Check if the catalog entry requires configuration.
 	// Composite servers always require configuration in the UI before they can be used.
 	requiresConfiguration := manifest.Runtime == obottypes.RuntimeComposite
@@ -223,7 +227,9 @@ func ConvertMCPServerCatalogEntryToRegistry(
 			ConfigurationMessage:  "This server needs to be configured before use. Please visit the Obot UI to set it up.",
 		}
 
-		serverDetail.Meta.PublisherProvided.GitHub.Readme = fmt.Sprintf("> Note: This server requires configuration and cannot be installed directly from your client. Please visit [Obot](%s) to to configure this server and obtain a connection URL.\n\n%s", serverURL, serverDetail.Meta.PublisherProvided.GitHub.Readme)
+		if len(serverDetail.Packages) == 0 {
+			serverDetail.Meta.PublisherProvided.GitHub.Readme = fmt.Sprintf("> Note: This server requires configuration and cannot be installed directly from your client. Please visit [Obot](%s) to to configure this server and obtain a connection URL.\n\n%s", serverURL, serverDetail.Meta.PublisherProvided.GitHub.Readme)
+		}
 	} else {
 		// No configuration required - provide connection URL
 		serverDetail.Remotes = []obottypes.RegistryServerRemote{
@@ -107,8 +107,10 @@ func (h *Handler) collectAccessibleServers(req api.Context, reverseDNS string) (
 		return nil, err
 	}
 
+	exposure := h.packageExposureForCatalog(req, system.DefaultCatalog)
+
 	for _, entry := range catalogEntries {
-		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, h.mimeFetcher)
+		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, exposure, h.mimeFetcher)
 		if err != nil {
 			// If conversion fails, just skip the entry
 			continue
@@ -145,7 +147,7 @@ func (h *Handler) collectAccessibleServers(req api.Context, reverseDNS string) (
 	}
 
 	for _, entry := range workspaceEntries {
-		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, h.mimeFetcher)
+		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, nil, h.mimeFetcher)
 		if err != nil {
 			// If conversion fails, just skip the entry
 			continue
@@ -193,6 +195,8 @@ func (h *Handler) collectAccessibleServersNoAuth(req api.Context, reverseDNS str
 		return nil, fmt.Errorf("failed to list catalog entries: %w", err)
 	}
 
+	exposure := h.packageExposureForCatalog(req, system.DefaultCatalog)
+
 	// Filter for wildcard ACR access
 	for _, entry := range entryList.Items {
 		hasWildcardAccess, err := h.acrHelper.HasWildcardAccessToMCPServerCatalogEntryInCatalog(
@@ -203,7 +207,7 @@ func (h *Handler) collectAccessibleServersNoAuth(req api.Context, reverseDNS str
 			continue
 		}
 
-		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, h.mimeFetcher)
+		converted, err := ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, exposure, h.mimeFetcher)
 		if err != nil {
 			// If conversion fails, just skip the entry
 			continue
@@ -823,7 +827,12 @@ func (h *Handler) findMCPServerCatalogEntry(req api.Context, entryName string, r
 		return types.RegistryServerResponse{}, fmt.Errorf("catalog entry not found")
 	}
 
-	return ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, h.mimeFetcher)
+	var exposure *packageExposure
+	if entry.Spec.MCPCatalogName != "" {
+		exposure = h.packageExposureForCatalog(req, entry.Spec.MCPCatalogName)
+	}
+
+	return ConvertMCPServerCatalogEntryToRegistry(req.Context(), entry, h.serverURL, reverseDNS, exposure, h.mimeFetcher)
 }
 
 // notFoundError returns a standard 404 error response in the format:
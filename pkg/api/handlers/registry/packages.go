@@ -0,0 +1,207 @@
+package registry
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	obottypes "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/imagepolicy"
+	"github.com/obot-platform/obot/pkg/packagepolicy"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+var log = logger.Package()
+
+var envVarRegex = regexp.MustCompile(`\${([^}]+)}`)
+
+const (
+	defaultNPMRegistryURL  = "https://registry.npmjs.org"
+	defaultPyPIRegistryURL = "https://pypi.org"
+)
+
+// packageExposure decides which catalog entries are exposed as packages in the registry API, and how.
+// A nil packageExposure exposes no packages.
+type packageExposure struct {
+	imagePolicy   obottypes.ContainerImagePolicyManifest
+	packagePolicy obottypes.PackagePolicyManifest
+}
+
+// packageExposureForCatalog returns the package exposure for the entries of the catalog, or nil if the catalog
+// doesn't expose packages. If the catalog or the policies can't be retrieved, then the failure is logged and no
+// packages are exposed, so that the entries are still listed.
+func (h *Handler) packageExposureForCatalog(req api.Context, catalogName string) *packageExposure {
+	var catalog v1.MCPCatalog
+	if err := req.Get(&catalog, catalogName); err != nil {
+		log.Warnf("failed to get catalog to expose packages: catalog=%s error=%v", catalogName, err)
+		return nil
+	}
+	if !catalog.Spec.ExposePackages {
+		return nil
+	}
+
+	imagePolicy, err := imagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		log.Warnf("failed to get container image policy to expose packages: catalog=%s error=%v", catalogName, err)
+		return nil
+	}
+
+	packagePolicy, err := packagepolicy.Get(req.Context(), req.Storage)
+	if err != nil {
+		log.Warnf("failed to get package policy to expose packages: catalog=%s error=%v", catalogName, err)
+		return nil
+	}
+
+	return &packageExposure{
+		imagePolicy:   imagePolicy,
+		packagePolicy: packagePolicy,
+	}
+}
+
+// packages returns the package descriptors for the catalog entry. Entries that aren't allowed by the policies,
+// or whose configuration can't be described by a package, have none.
+func (p *packageExposure) packages(manifest obottypes.MCPServerCatalogEntryManifest) []obottypes.RegistryServerPackage {
+	if p == nil ||
+		imagepolicy.CheckCatalogEntryManifest(p.imagePolicy, manifest) != nil ||
+		packagepolicy.CheckCatalogEntryManifest(p.packagePolicy, manifest) != nil {
+		return nil
+	}
+
+	env := make(map[string]obottypes.MCPEnv, len(manifest.Env))
+	for _, e := range manifest.Env {
+		if e.File {
+			// The server expects a path to a file with the value, which a client can't be asked for.
+			return nil
+		}
+		env[e.Key] = e
+	}
+
+	var (
+		pkg  obottypes.RegistryServerPackage
+		args []string
+	)
+	switch {
+	case manifest.Runtime == obottypes.RuntimeNPX && manifest.NPXConfig != nil:
+		name, version := splitPackageVersion(manifest.NPXConfig.Package, "@")
+		pkg = obottypes.RegistryServerPackage{
+			RegistryType:    "npm",
+			RegistryBaseURL: defaultNPMRegistryURL,
+			Identifier:      name,
+			Version:         version,
+			RuntimeHint:     "npx",
+			Transport:       obottypes.RegistryServerTransport{Type: "stdio"},
+		}
+		if p.packagePolicy.NPM.MirrorURL != "" {
+			pkg.RegistryBaseURL = p.packagePolicy.NPM.MirrorURL
+		}
+		args = manifest.NPXConfig.Args
+	case manifest.Runtime == obottypes.RuntimeUVX && manifest.UVXConfig != nil:
+		name, version := splitPackageVersion(manifest.UVXConfig.Package, "==", "@")
+		if manifest.UVXConfig.Command != "" && manifest.UVXConfig.Command != name {
+			// Running a command other than the package's own can't be described by a package.
+			return nil
+		}
+		pkg = obottypes.RegistryServerPackage{
+			RegistryType:    "pypi",
+			RegistryBaseURL: defaultPyPIRegistryURL,
+			Identifier:      name,
+			Version:         version,
+			RuntimeHint:     "uvx",
+			Transport:       obottypes.RegistryServerTransport{Type: "stdio"},
+		}
+		if p.packagePolicy.PyPI.MirrorURL != "" {
+			pkg.RegistryBaseURL = p.packagePolicy.PyPI.MirrorURL
+		}
+		args = manifest.UVXConfig.Args
+	case manifest.Runtime == obottypes.RuntimeContainerized && manifest.ContainerizedConfig != nil:
+		config := manifest.ContainerizedConfig
+		if config.Command != "" || strings.Contains(config.Image, "${") {
+			return nil
+		}
+		pkg = obottypes.RegistryServerPackage{
+			RegistryType: "oci",
+			Identifier:   config.Image,
+			Version:      imageTag(config.Image),
+			Transport: obottypes.RegistryServerTransport{
+				Type: "streamable-http",
+				URL:  fmt.Sprintf("http://localhost:%d%s", config.Port, config.Path),
+			},
+		}
+		args = config.Args
+	default:
+		return nil
+	}
+
+	for _, arg := range args {
+		pkg.PackageArguments = append(pkg.PackageArguments, convertArgument(arg, env))
+	}
+	for _, e := range manifest.Env {
+		pkg.EnvironmentVariables = append(pkg.EnvironmentVariables, convertEnv(e))
+	}
+
+	return []obottypes.RegistryServerPackage{pkg}
+}
+
+// splitPackageVersion splits a package spec like "name@1.2.3" into its name and version at the last of the
+// separators found. The version is "latest" if the spec doesn't have one.
+func splitPackageVersion(spec string, separators ...string) (string, string) {
+	for _, separator := range separators {
+		if i := strings.LastIndex(spec, separator); i > 0 && i+len(separator) < len(spec) {
+			return strings.TrimSpace(spec[:i]), strings.TrimSpace(spec[i+len(separator):])
+		}
+	}
+	return spec, "latest"
+}
+
+// imageTag returns the tag of an image reference, or "latest" if it doesn't have one.
+func imageTag(image string) string {
+	image, _, _ = strings.Cut(image, "@")
+	if lastSegment := image[strings.LastIndex(image, "/")+1:]; strings.Contains(lastSegment, ":") {
+		return lastSegment[strings.LastIndex(lastSegment, ":")+1:]
+	}
+	return "latest"
+}
+
+// convertArgument converts an argument to a positional package argument. References to environment variables,
+// like "${API_KEY}", become {API_KEY} placeholders that the client asks the user for.
+func convertArgument(arg string, env map[string]obottypes.MCPEnv) obottypes.RegistryArgument {
+	result := obottypes.RegistryArgument{Type: "positional"}
+	result.Value = envVarRegex.ReplaceAllStringFunc(arg, func(match string) string {
+		key := match[2 : len(match)-1]
+		if result.Variables == nil {
+			result.Variables = make(map[string]obottypes.RegistryKeyValueInput)
+		}
+
+		variable := convertEnv(env[key])
+		variable.Name = ""
+		variable.IsRequired = true
+		result.Variables[key] = variable
+		return "{" + key + "}"
+	})
+	return result
+}
+
+func convertEnv(env obottypes.MCPEnv) obottypes.RegistryKeyValueInput {
+	description := env.Description
+	if description == "" {
+		description = env.Name
+	}
+
+	input := obottypes.RegistryKeyValueInput{
+		Name:        env.Key,
+		Description: description,
+		IsRequired:  env.Required,
+		IsSecret:    env.Sensitive,
+	}
+	if env.Value != "" {
+		if env.Sensitive {
+			// Don't hand out secrets configured by the admin; the user has to supply their own.
+			input.IsRequired = true
+		} else {
+			input.Value = env.Value
+		}
+	}
+	return input
+}
@@ -0,0 +1,133 @@
+package registry
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackages(t *testing.T) {
+	apiKey := types.MCPEnv{MCPHeader: types.MCPHeader{Name: "API Key", Key: "API_KEY", Required: true, Sensitive: true}}
+
+	tests := []struct {
+		name     string
+		exposure *packageExposure
+		manifest types.MCPServerCatalogEntryManifest
+		expected []types.RegistryServerPackage
+	}{
+		{
+			name: "not exposed",
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeNPX,
+				NPXConfig: &types.NPXRuntimeConfig{Package: "@example/server@1.0.0"},
+			},
+		},
+		{
+			name:     "npx with env var argument",
+			exposure: &packageExposure{},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeNPX,
+				NPXConfig: &types.NPXRuntimeConfig{Package: "@example/server@1.0.0", Args: []string{"--key=${API_KEY}"}},
+				Env: []types.MCPEnv{
+					apiKey,
+					{MCPHeader: types.MCPHeader{Key: "REGION", Description: "The region", Value: "us-east-1"}},
+				},
+			},
+			expected: []types.RegistryServerPackage{{
+				RegistryType:    "npm",
+				RegistryBaseURL: defaultNPMRegistryURL,
+				Identifier:      "@example/server",
+				Version:         "1.0.0",
+				RuntimeHint:     "npx",
+				Transport:       types.RegistryServerTransport{Type: "stdio"},
+				PackageArguments: []types.RegistryArgument{{
+					Type:      "positional",
+					Value:     "--key={API_KEY}",
+					Variables: map[string]types.RegistryKeyValueInput{"API_KEY": {Description: "API Key", IsRequired: true, IsSecret: true}},
+				}},
+				EnvironmentVariables: []types.RegistryKeyValueInput{
+					{Name: "API_KEY", Description: "API Key", IsRequired: true, IsSecret: true},
+					{Name: "REGION", Description: "The region", Value: "us-east-1"},
+				},
+			}},
+		},
+		{
+			name: "uvx from mirror",
+			exposure: &packageExposure{packagePolicy: types.PackagePolicyManifest{
+				PyPI: types.PackageRegistryPolicy{MirrorURL: "https://pypi.example.com/simple"},
+			}},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeUVX,
+				UVXConfig: &types.UVXRuntimeConfig{Package: "mcp-server-fetch"},
+			},
+			expected: []types.RegistryServerPackage{{
+				RegistryType:    "pypi",
+				RegistryBaseURL: "https://pypi.example.com/simple",
+				Identifier:      "mcp-server-fetch",
+				Version:         "latest",
+				RuntimeHint:     "uvx",
+				Transport:       types.RegistryServerTransport{Type: "stdio"},
+			}},
+		},
+		{
+			name:     "uvx with another command",
+			exposure: &packageExposure{},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeUVX,
+				UVXConfig: &types.UVXRuntimeConfig{Package: "obot-mcp==1.0.0", Command: "obot-mcp-server"},
+			},
+		},
+		{
+			name:     "containerized",
+			exposure: &packageExposure{},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime: types.RuntimeContainerized,
+				ContainerizedConfig: &types.ContainerizedRuntimeConfig{
+					Image: "ghcr.io/example/server:1.2.3",
+					Port:  8080,
+					Path:  "/mcp",
+				},
+			},
+			expected: []types.RegistryServerPackage{{
+				RegistryType: "oci",
+				Identifier:   "ghcr.io/example/server:1.2.3",
+				Version:      "1.2.3",
+				Transport:    types.RegistryServerTransport{Type: "streamable-http", URL: "http://localhost:8080/mcp"},
+			}},
+		},
+		{
+			name: "not allowed by the package policy",
+			exposure: &packageExposure{packagePolicy: types.PackagePolicyManifest{
+				NPM: types.PackageRegistryPolicy{AllowedPackages: []string{"@other/*"}},
+			}},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeNPX,
+				NPXConfig: &types.NPXRuntimeConfig{Package: "@example/server@1.0.0"},
+			},
+		},
+		{
+			name:     "file env var",
+			exposure: &packageExposure{},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:   types.RuntimeNPX,
+				NPXConfig: &types.NPXRuntimeConfig{Package: "@example/server@1.0.0"},
+				Env:       []types.MCPEnv{{MCPHeader: types.MCPHeader{Key: "CREDENTIALS"}, File: true}},
+			},
+		},
+		{
+			name:     "remote",
+			exposure: &packageExposure{},
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime:      types.RuntimeRemote,
+				RemoteConfig: &types.RemoteCatalogConfig{FixedURL: "https://example.com/mcp"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, tt.exposure.packages(tt.manifest))
+		})
+	}
+}
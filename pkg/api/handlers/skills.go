@@ -17,6 +17,7 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
 	"github.com/obot-platform/obot/pkg/controller/handlers/skillrepository"
+	"github.com/obot-platform/obot/pkg/search"
 	"github.com/obot-platform/obot/pkg/skillaccessrule"
 	"github.com/obot-platform/obot/pkg/skillformat"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
@@ -31,12 +32,14 @@ const (
 
 type SkillHandler struct {
 	skillAccessRuleHelper  *skillaccessrule.Helper
+	searchIndex            *search.Index
 	materializeSkillSource func(ctx context.Context, skill *v1.Skill) (func(), string, error)
 }
 
-func NewSkillHandler(skillAccessRuleHelper *skillaccessrule.Helper) *SkillHandler {
+func NewSkillHandler(skillAccessRuleHelper *skillaccessrule.Helper, searchIndex *search.Index) *SkillHandler {
 	return &SkillHandler{
 		skillAccessRuleHelper:  skillAccessRuleHelper,
+		searchIndex:            searchIndex,
 		materializeSkillSource: skillrepository.MaterializeSkillSource,
 	}
 }
@@ -54,25 +57,47 @@ func (h *SkillHandler) List(req api.Context) error {
 
 	includeInvalid := (req.UserIsAdmin() || req.UserIsOwner() || req.UserIsAuditor()) && req.URL.Query().Get("all") == "true"
 	query := strings.ToLower(strings.TrimSpace(req.URL.Query().Get("q")))
-	filtered := make([]types.Skill, 0, len(items))
+	license := strings.TrimSpace(req.URL.Query().Get("license"))
+	compatibility := strings.ToLower(strings.TrimSpace(req.URL.Query().Get("compatibility")))
+	candidates := make([]v1.Skill, 0, len(items))
 	for _, item := range items {
 		if !item.Status.Valid && !includeInvalid {
 			continue
 		}
-		if query != "" && !matchesSkillQuery(item, query) {
+		if license != "" && !strings.EqualFold(item.Spec.License, license) {
 			continue
 		}
-		filtered = append(filtered, convertSkill(item))
+		if compatibility != "" && !strings.Contains(strings.ToLower(item.Spec.Compatibility), compatibility) {
+			continue
+		}
+		candidates = append(candidates, item)
 	}
 
-	slices.SortStableFunc(filtered, func(a, b types.Skill) int {
-		aName := strings.ToLower(skillSortName(a.DisplayName, a.Name))
-		bName := strings.ToLower(skillSortName(b.DisplayName, b.Name))
-		if cmp := strings.Compare(aName, bName); cmp != 0 {
-			return cmp
+	var filtered []types.Skill
+	if query != "" && h.searchIndex != nil {
+		// Rank the skills by relevance to the query.
+		filtered, err = h.searchSkills(req.Context(), query, candidates)
+		if err != nil {
+			return err
+		}
+	} else {
+		filtered = make([]types.Skill, 0, len(candidates))
+		for _, item := range candidates {
+			if query != "" && !matchesSkillQuery(item, query) {
+				continue
+			}
+			filtered = append(filtered, convertSkill(item))
 		}
-		return strings.Compare(a.ID, b.ID)
-	})
+
+		slices.SortStableFunc(filtered, func(a, b types.Skill) int {
+			aName := strings.ToLower(skillSortName(a.DisplayName, a.Name))
+			bName := strings.ToLower(skillSortName(b.DisplayName, b.Name))
+			if cmp := strings.Compare(aName, bName); cmp != 0 {
+				return cmp
+			}
+			return strings.Compare(a.ID, b.ID)
+		})
+	}
 
 	if len(filtered) > limit {
 		filtered = filtered[:limit]
@@ -254,6 +279,33 @@ func parseSkillListLimit(raw string) (int, error) {
 	return limit, nil
 }
 
+// searchSkills returns the skills that match the query, most relevant first.
+func (h *SkillHandler) searchSkills(ctx context.Context, query string, skills []v1.Skill) ([]types.Skill, error) {
+	documents := make([]search.Document, 0, len(skills))
+	byName := make(map[string]v1.Skill, len(skills))
+	for _, skill := range skills {
+		documents = append(documents, search.Document{
+			Kind:        search.KindSkill,
+			ID:          skill.Name,
+			Name:        skill.Spec.Name,
+			DisplayName: skill.Spec.DisplayName,
+			Description: skill.Spec.Description,
+		})
+		byName[skill.Name] = skill
+	}
+
+	results, err := h.searchIndex.Search(ctx, search.KindSkill, query, documents)
+	if err != nil {
+		return nil, err
+	}
+
+	matched := make([]types.Skill, 0, len(results))
+	for _, result := range results {
+		matched = append(matched, convertSkill(byName[result.ID]))
+	}
+	return matched, nil
+}
+
 func matchesSkillQuery(skill v1.Skill, query string) bool {
 	return strings.Contains(strings.ToLower(skill.Spec.Name), query) ||
 		strings.Contains(strings.ToLower(skill.Spec.DisplayName), query) ||
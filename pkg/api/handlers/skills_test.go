@@ -182,7 +182,7 @@ func TestSkillHandlerListFiltersByAccessAndValidity(t *testing.T) {
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule-repo", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
 		newSkillRule("rule-skill", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkill, ID: "sk-direct"}}),
-	))
+	), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/skills?q=helper&limit=10", nil)
 	rec := httptest.NewRecorder()
@@ -239,7 +239,7 @@ func TestSkillHandlerListAllTrueScopeBypass(t *testing.T) {
 	// user1 has access only to repo-1 via skill access rules
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule-repo", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
-	))
+	), nil)
 
 	listSkills := func(t *testing.T, user kuser.Info, query string) []string {
 		t.Helper()
@@ -309,7 +309,7 @@ func TestSkillHandlerGetReturnsNotFoundWhenUnauthorized(t *testing.T) {
 		Status: v1.SkillStatus{Valid: true},
 	})
 
-	handler := NewSkillHandler(newSkillAccessRuleHelper(t))
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t), nil)
 	req := httptest.NewRequest(http.MethodGet, "/api/skills/sk1", nil)
 	req.SetPathValue("id", "sk1")
 	rec := httptest.NewRecorder()
@@ -337,7 +337,7 @@ func TestSkillHandlerGetACRBypass(t *testing.T) {
 		Status: v1.SkillStatus{Valid: true},
 	})
 
-	handler := NewSkillHandler(newSkillAccessRuleHelper(t))
+	handler := NewSkillHandler(newSkillAccessRuleHelper(t), nil)
 
 	doGet := func(t *testing.T, user kuser.Info) (int, *types.Skill) {
 		t.Helper()
@@ -399,7 +399,7 @@ func TestSkillHandlerGetWithACRAccess(t *testing.T) {
 
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkill, ID: "sk1"}}),
-	))
+	), nil)
 
 	req := httptest.NewRequest(http.MethodGet, "/api/skills/sk1", nil)
 	req.SetPathValue("id", "sk1")
@@ -438,7 +438,7 @@ func TestSkillHandlerDownloadPackagesMaterializedSkill(t *testing.T) {
 
 	handler := NewSkillHandler(newSkillAccessRuleHelper(t,
 		newSkillRule("rule1", []types.Subject{{Type: types.SubjectTypeUser, ID: "user1"}}, []types.SkillResource{{Type: types.SkillResourceTypeSkillRepository, ID: "repo-1"}}),
-	))
+	), nil)
 	handler.materializeSkillSource = func(_ context.Context, got *v1.Skill) (func(), string, error) {
 		assert.Equal(t, "abc123", got.Spec.CommitSHA)
 		assert.Equal(t, "skills/postgres-helper", got.Spec.RelativePath)
@@ -12,11 +12,13 @@ import (
 	"github.com/obot-platform/obot/apiclient"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/controller/handlers/cronjob"
 	"github.com/obot-platform/obot/pkg/events"
 	"github.com/obot-platform/obot/pkg/invoke"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/wait"
+	"k8s.io/apimachinery/pkg/api/equality"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -380,6 +382,8 @@ func convertTaskRun(workflow *v1.Workflow, wfe *v1.WorkflowExecution) types.Task
 		EndTime:   endTime,
 		Error:     wfe.Status.Error,
 		Warning:   wfe.Status.Warning,
+
+		Notifications: wfe.Status.Notifications,
 	}
 }
 
@@ -483,6 +487,11 @@ func validate(task types.TaskManifest) error {
 	if task.Schedule != nil && task.OnDemand != nil {
 		return types.NewErrBadRequest("only one trigger is allowed, schedule or onDemand")
 	}
+	if task.Schedule != nil {
+		if err := cronjob.ValidateSchedule(*task.Schedule); err != nil {
+			return types.NewErrBadRequest("invalid schedule: %v", err)
+		}
+	}
 	return nil
 }
 
@@ -537,7 +546,7 @@ func (t *TaskHandler) updateCron(req api.Context, workflow *v1.Workflow, task ty
 	}
 
 	trigger.CronJob = &cron
-	if cron.Spec.TaskSchedule == nil || *cron.Spec.TaskSchedule != *task.Schedule {
+	if cron.Spec.TaskSchedule == nil || !equality.Semantic.DeepEqual(*cron.Spec.TaskSchedule, *task.Schedule) {
 		cron.Spec.TaskSchedule = task.Schedule
 		return req.Update(&cron)
 	}
@@ -561,10 +570,31 @@ func (t *TaskHandler) getManifestFromRequest(req api.Context) (types.WorkflowMan
 		return types.WorkflowManifest{}, types.TaskManifest{}, err
 	}
 
+	if err := validateNotifications(req, manifest.Notifications); err != nil {
+		return types.WorkflowManifest{}, types.TaskManifest{}, err
+	}
+
 	wfManifest := ToWorkflowManifest(manifest)
 	return wfManifest, manifest, nil
 }
 
+// validateNotifications returns an error if a notification subscription is invalid or its channel doesn't exist.
+func validateNotifications(req api.Context, notifications []types.NotificationSubscription) error {
+	for _, sub := range notifications {
+		if err := sub.Validate(); err != nil {
+			return types.NewErrBadRequest("invalid notification: %v", err)
+		}
+
+		var channel v1.NotificationChannel
+		if err := req.Get(&channel, sub.ChannelID); apierrors.IsNotFound(err) {
+			return types.NewErrBadRequest("notification channel %s not found", sub.ChannelID)
+		} else if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 func (t *TaskHandler) getThreadAndManifestFromWorkflow(req api.Context, workflow *v1.Workflow) (*v1.Thread, types.WorkflowManifest, types.TaskManifest, error) {
 	var thread v1.Thread
 	if err := req.Get(&thread, workflow.Spec.ThreadName); err != nil {
@@ -625,6 +655,8 @@ func ToWorkflowManifest(manifest types.TaskManifest) types.WorkflowManifest {
 		Description: manifest.Description,
 		Steps:       toWorkflowSteps(manifest.Steps),
 		Params:      toParams(manifest),
+
+		Notifications: manifest.Notifications,
 	}
 }
 
@@ -824,6 +856,8 @@ func ConvertTaskManifest(manifest *types.WorkflowManifest) types.TaskManifest {
 		Name:        manifest.Name,
 		Description: manifest.Description,
 		Steps:       toTaskSteps(manifest.Steps),
+
+		Notifications: manifest.Notifications,
 	}
 }
 
@@ -16,7 +16,6 @@ import (
 	"github.com/obot-platform/obot/pkg/system"
 	threadmodel "github.com/obot-platform/obot/pkg/thread"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/util/retry"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
 )
@@ -173,14 +172,19 @@ func (a *ThreadHandler) ByID(req api.Context) error {
 
 func (a *ThreadHandler) Delete(req api.Context) error {
 	var (
-		id = req.PathValue("id")
+		id     = req.PathValue("id")
+		thread v1.Thread
 	)
-	return req.Delete(&v1.Thread{
-		ObjectMeta: metav1.ObjectMeta{
-			Name:      id,
-			Namespace: req.Namespace(),
-		},
-	})
+
+	if err := req.Get(&thread, id); err != nil {
+		return err
+	}
+
+	if err := checkLegalHolds(req, &thread); err != nil {
+		return err
+	}
+
+	return req.Delete(&thread)
 }
 
 func (a *ThreadHandler) Update(req api.Context) error {
@@ -299,6 +303,10 @@ func (a *ThreadHandler) DeleteKnowledge(req api.Context) error {
 		return types.NewErrHTTP(http.StatusTooEarly, fmt.Sprintf("thread %q knowledge set is not created yet", thread.Name))
 	}
 
+	if err := checkLegalHolds(req, &thread); err != nil {
+		return err
+	}
+
 	return deleteKnowledge(req, req.PathValue("file"), thread.Status.SharedKnowledgeSetName)
 }
 
@@ -0,0 +1,168 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/controller/handlers/userdataexport"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+	"github.com/obot-platform/obot/pkg/system"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+type UserDataExportHandler struct {
+	blobStore blob.BlobStore
+	bucket    string
+}
+
+func NewUserDataExportHandler(blobStore blob.BlobStore, bucket string) *UserDataExportHandler {
+	return &UserDataExportHandler{
+		blobStore: blobStore,
+		bucket:    bucket,
+	}
+}
+
+// Create exports the requesting user's data.
+func (h *UserDataExportHandler) Create(req api.Context) error {
+	return h.create(req, req.User.GetUID())
+}
+
+// CreateForUser exports the data of the user in the path. It is only available to admins.
+func (h *UserDataExportHandler) CreateForUser(req api.Context) error {
+	userID := req.PathValue("user_id")
+	if _, err := req.GatewayClient.UserByIDIncludeDeleted(req.Context(), userID); errors.Is(err, gorm.ErrRecordNotFound) {
+		return types.NewErrNotFound("user %s not found", userID)
+	} else if err != nil {
+		return err
+	}
+
+	return h.create(req, userID)
+}
+
+func (h *UserDataExportHandler) create(req api.Context, userID string) error {
+	export := v1.UserDataExport{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.UserDataExportPrefix,
+			Namespace:    req.Namespace(),
+		},
+		Spec: v1.UserDataExportSpec{
+			UserID:      userID,
+			RequestedBy: req.User.GetUID(),
+		},
+		Status: v1.UserDataExportStatus{
+			State: types.UserDataExportStatePending,
+		},
+	}
+
+	if err := req.Create(&export); err != nil {
+		return fmt.Errorf("failed to create user data export: %w", err)
+	}
+
+	return req.WriteCreated(convertUserDataExport(export))
+}
+
+// List returns all exports to admins, and the requesting user's own exports to everyone else.
+func (h *UserDataExportHandler) List(req api.Context) error {
+	var opts []kclient.ListOption
+	if !req.UserIsAdmin() {
+		opts = append(opts, kclient.MatchingFields{"spec.userID": req.User.GetUID()})
+	}
+
+	var list v1.UserDataExportList
+	if err := req.List(&list, opts...); err != nil {
+		return fmt.Errorf("failed to list user data exports: %w", err)
+	}
+
+	items := make([]types.UserDataExport, 0, len(list.Items))
+	for _, item := range list.Items {
+		items = append(items, convertUserDataExport(item))
+	}
+
+	return req.Write(types.UserDataExportList{Items: items})
+}
+
+func (h *UserDataExportHandler) Get(req api.Context) error {
+	export, err := h.get(req)
+	if err != nil {
+		return err
+	}
+
+	return req.Write(convertUserDataExport(*export))
+}
+
+func (h *UserDataExportHandler) Download(req api.Context) error {
+	export, err := h.get(req)
+	if err != nil {
+		return err
+	}
+
+	if export.Status.State != types.UserDataExportStateCompleted {
+		return types.NewErrBadRequest("user data export %s is not complete", export.Name)
+	}
+
+	reader, err := h.blobStore.Download(req.Context(), h.bucket, export.Status.Key)
+	if err != nil {
+		return fmt.Errorf("failed to download user data export: %w", err)
+	}
+	defer reader.Close()
+
+	req.ResponseWriter.Header().Set("Content-Type", "application/zip")
+	req.ResponseWriter.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.zip"`, export.Name))
+	_, err = io.Copy(req.ResponseWriter, reader)
+	return err
+}
+
+func (h *UserDataExportHandler) Delete(req api.Context) error {
+	export, err := h.get(req)
+	if err != nil {
+		return err
+	}
+
+	if export.Status.Key != "" {
+		if err := h.blobStore.Delete(req.Context(), h.bucket, export.Status.Key); err != nil {
+			return fmt.Errorf("failed to delete user data export archive: %w", err)
+		}
+	}
+
+	if err := req.Delete(export); err != nil {
+		return fmt.Errorf("failed to delete user data export: %w", err)
+	}
+
+	return req.Write(convertUserDataExport(*export))
+}
+
+// get returns the export in the path if the requesting user is an admin or the export's user.
+func (h *UserDataExportHandler) get(req api.Context) (*v1.UserDataExport, error) {
+	var export v1.UserDataExport
+	if err := req.Get(&export, req.PathValue("export_id")); err != nil {
+		return nil, err
+	}
+
+	if !req.UserIsAdmin() && export.Spec.UserID != req.User.GetUID() {
+		return nil, types.NewErrNotFound("user data export %s not found", export.Name)
+	}
+
+	return &export, nil
+}
+
+func convertUserDataExport(export v1.UserDataExport) types.UserDataExport {
+	result := types.UserDataExport{
+		Metadata:    MetadataFrom(&export),
+		UserID:      export.Spec.UserID,
+		RequestedBy: export.Spec.RequestedBy,
+		State:       export.Status.State,
+		Error:       export.Status.Error,
+		Size:        export.Status.Size,
+	}
+	if export.Status.CompletedAt != nil {
+		result.CompletedAt = types.NewTime(export.Status.CompletedAt.Time)
+		result.ExpiresAt = types.NewTime(userdataexport.ExpiresAt(&export))
+	}
+	return result
+}
@@ -51,9 +51,13 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	accessControlRules := handlers.NewAccessControlRuleHandler()
 	skillRepositories := handlers.NewSkillRepositoryHandler()
 	skillAccessRules := handlers.NewSkillAccessRuleHandler()
-	skills := handlers.NewSkillHandler(services.SkillAccessRuleHelper)
+	skills := handlers.NewSkillHandler(services.SkillAccessRuleHelper, services.SearchIndex)
 	powerUserWorkspaces := handlers.NewPowerUserWorkspaceHandler(services.ServerURL, services.AccessControlRuleHelper)
 	mcpWebhookValidations := handlers.NewMCPWebhookValidationHandler()
+	notificationChannels := handlers.NewNotificationChannelHandler()
+	mcpAuditLogPolicies := handlers.NewMCPAuditLogPolicyHandler()
+	legalHolds := handlers.NewLegalHoldHandler()
+	userDataExports := handlers.NewUserDataExportHandler(services.ArtifactBlobStore, services.ArtifactBlobBucket)
 	availableModels := handlers.NewAvailableModelsHandler(services.ProviderDispatcher)
 	modelProviders := handlers.NewModelProviderHandler(services.ProviderDispatcher, services.Invoker)
 	modelAccessPolicies := handlers.NewModelAccessPolicyHandler()
@@ -72,6 +76,8 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	workflows := handlers.NewWorkflowHandler()
 	images := handlers.NewImageHandler(services.GeminiClient)
 	mcp := handlers.NewMCPHandler(services.MCPLoader, services.AccessControlRuleHelper, oauthChecker, services.MCPRuntimeBackend, services.ServerURL)
+	projectArchives := handlers.NewProjectArchiveHandler(services.AccessControlRuleHelper, services.ProviderDispatcher)
+	chatHistory := handlers.NewChatHistoryHandler()
 	projectMCP := handlers.NewProjectMCPHandler(services.MCPLoader, services.AccessControlRuleHelper, oauthChecker, services.ServerURL, services.InternalServerURL)
 	projectInvitations := handlers.NewProjectInvitationHandler()
 	mcpGateway := mcpgateway.NewHandler(services.MCPLoader, services.WebhookHelper, services.OAuthServerConfig.ScopesSupported, services.NanobotIntegration)
@@ -83,7 +89,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	setupHandler := setup.NewHandler(services.ServerURL)
 	registryHandler := registry.NewHandler(services.AccessControlRuleHelper, services.ServerURL, services.RegistryNoAuth)
 	oauthClients := handlers.NewOAuthClientsHandler(services.OAuthServerConfig, services.ServerURL)
-	publishedArtifacts := handlers.NewPublishedArtifactHandler(services.ArtifactBlobStore, services.ArtifactBlobBucket)
+	publishedArtifacts := handlers.NewPublishedArtifactHandler(services.ArtifactBlobStore, services.ArtifactBlobBucket, services.SearchIndex)
 
 	// Version
 	mux.HandleFunc("GET /api/version", version.GetVersion)
@@ -226,8 +232,13 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/assistants/{assistant_id}/projects/{project_id}", projects.GetProject)
 	mux.HandleFunc("PUT /api/assistants/{assistant_id}/projects/{project_id}", projects.UpdateProject)
 	mux.HandleFunc("POST /api/assistants/{assistant_id}/projects/{project_id}/copy", projects.CopyProject)
+	mux.HandleFunc("GET /api/assistants/{assistant_id}/projects/{project_id}/export", projectArchives.Export)
+	mux.HandleFunc("POST /api/assistants/{assistant_id}/projects/import", projectArchives.Import)
 	mux.HandleFunc("GET /api/assistants/{assistant_id}/projects/{project_id}/default-model", projects.GetDefaultModelForProject)
 
+	// Chat history
+	mux.HandleFunc("GET /api/chat-history/search", chatHistory.Search)
+
 	// Project Threads
 	mux.HandleFunc("POST /api/assistants/{assistant_id}/projects/{project_id}/threads", projects.CreateProjectThread)
 	mux.HandleFunc("GET /api/assistants/{assistant_id}/projects/{project_id}/threads", projects.ListProjectThreads)
@@ -560,6 +571,39 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("PUT /api/mcp-webhook-validations/{mcp_webhook_validation_id}", mcpWebhookValidations.Update)
 	mux.HandleFunc("DELETE /api/mcp-webhook-validations/{mcp_webhook_validation_id}", mcpWebhookValidations.Delete)
 	mux.HandleFunc("DELETE /api/mcp-webhook-validations/{mcp_webhook_validation_id}/secret", mcpWebhookValidations.RemoveSecret)
+	mux.HandleFunc("POST /api/mcp-webhook-validations/{mcp_webhook_validation_id}/test", mcpWebhookValidations.Test)
+	mux.HandleFunc("GET /api/mcp-webhook-validations/{mcp_webhook_validation_id}/shadow-decisions", mcpWebhookValidations.ListShadowDecisions)
+
+	// Notification Channels (admin only, except listing)
+	mux.HandleFunc("GET /api/notification-channels", notificationChannels.List)
+	mux.HandleFunc("GET /api/notification-channels/{notification_channel_id}", notificationChannels.Get)
+	mux.HandleFunc("POST /api/notification-channels", notificationChannels.Create)
+	mux.HandleFunc("PUT /api/notification-channels/{notification_channel_id}", notificationChannels.Update)
+	mux.HandleFunc("DELETE /api/notification-channels/{notification_channel_id}", notificationChannels.Delete)
+	mux.HandleFunc("DELETE /api/notification-channels/{notification_channel_id}/secret", notificationChannels.RemoveSecret)
+	mux.HandleFunc("POST /api/notification-channels/{notification_channel_id}/test", notificationChannels.Test)
+
+	// MCP Audit Log Policies (admin only)
+	mux.HandleFunc("GET /api/mcp-audit-log-policies", mcpAuditLogPolicies.List)
+	mux.HandleFunc("GET /api/mcp-audit-log-policies/{policy_id}", mcpAuditLogPolicies.Get)
+	mux.HandleFunc("POST /api/mcp-audit-log-policies", mcpAuditLogPolicies.Create)
+	mux.HandleFunc("PUT /api/mcp-audit-log-policies/{policy_id}", mcpAuditLogPolicies.Update)
+	mux.HandleFunc("DELETE /api/mcp-audit-log-policies/{policy_id}", mcpAuditLogPolicies.Delete)
+
+	// Legal Holds (admin only, holds are released rather than deleted)
+	mux.HandleFunc("GET /api/legal-holds", legalHolds.List)
+	mux.HandleFunc("GET /api/legal-holds/{legal_hold_id}", legalHolds.Get)
+	mux.HandleFunc("POST /api/legal-holds", legalHolds.Create)
+	mux.HandleFunc("PUT /api/legal-holds/{legal_hold_id}", legalHolds.Update)
+	mux.HandleFunc("POST /api/legal-holds/{legal_hold_id}/release", legalHolds.Release)
+
+	// User Data Exports (users can export their own data, admins can export any user's data)
+	mux.HandleFunc("POST /api/user-data-exports", userDataExports.Create)
+	mux.HandleFunc("POST /api/users/{user_id}/data-exports", userDataExports.CreateForUser)
+	mux.HandleFunc("GET /api/user-data-exports", userDataExports.List)
+	mux.HandleFunc("GET /api/user-data-exports/{export_id}", userDataExports.Get)
+	mux.HandleFunc("GET /api/user-data-exports/{export_id}/download", userDataExports.Download)
+	mux.HandleFunc("DELETE /api/user-data-exports/{export_id}", userDataExports.Delete)
 
 	// System MCP Servers (admin only)
 	mux.HandleFunc("GET /api/system-mcp-servers", systemMCPServers.List)
@@ -594,6 +638,7 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/mcp-audit-logs/{mcp_id}", mcpAuditLogs.ListAuditLogs)
 	mux.HandleFunc("GET /api/mcp-stats", mcpAuditLogs.GetUsageStats)
 	mux.HandleFunc("GET /api/mcp-stats/{mcp_id}", mcpAuditLogs.GetUsageStats)
+	mux.HandleFunc("GET /api/mcp-usage-timeseries", mcpAuditLogs.GetUsageTimeSeries)
 
 	// Audit Log Exports
 	mux.HandleFunc("POST /api/audit-log-exports", auditLogExports.CreateAuditLogExport)
@@ -620,6 +665,8 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/published-artifacts/{id}", publishedArtifacts.Get)
 	mux.HandleFunc("GET /api/published-artifacts/{id}/download", publishedArtifacts.Download)
 	mux.HandleFunc("GET /api/published-artifacts/{id}/{version}/skill", publishedArtifacts.GetSkillMD)
+	mux.HandleFunc("GET /api/published-artifacts/{id}/{version}/diff", publishedArtifacts.Diff)
+	mux.HandleFunc("POST /api/published-artifacts/{id}/{version}/reviews", publishedArtifacts.Review)
 	mux.HandleFunc("PUT /api/published-artifacts/{id}", publishedArtifacts.Update)
 	mux.HandleFunc("DELETE /api/published-artifacts/{id}", publishedArtifacts.Delete)
 
@@ -677,6 +724,21 @@ func Router(ctx context.Context, services *services.Services) (http.Handler, err
 	mux.HandleFunc("GET /api/k8s-settings", k8sSettingsHandler.Get)
 	mux.HandleFunc("PUT /api/k8s-settings", k8sSettingsHandler.Update)
 
+	// Container Image Policy
+	containerImagePolicyHandler := handlers.NewContainerImagePolicyHandler()
+	mux.HandleFunc("GET /api/container-image-policy", containerImagePolicyHandler.Get)
+	mux.HandleFunc("PUT /api/container-image-policy", containerImagePolicyHandler.Update)
+
+	// Package Policy
+	packagePolicyHandler := handlers.NewPackagePolicyHandler()
+	mux.HandleFunc("GET /api/package-policy", packagePolicyHandler.Get)
+	mux.HandleFunc("PUT /api/package-policy", packagePolicyHandler.Update)
+
+	// Published Artifact Review Policy
+	publishedArtifactReviewPolicyHandler := handlers.NewPublishedArtifactReviewPolicyHandler()
+	mux.HandleFunc("GET /api/published-artifact-review-policy", publishedArtifactReviewPolicyHandler.Get)
+	mux.HandleFunc("PUT /api/published-artifact-review-policy", publishedArtifactReviewPolicyHandler.Update)
+
 	// MCP Capacity (admin only)
 	mcpCapacityHandler := handlers.NewMCPCapacityHandler(services.MCPLoader)
 	mux.HandleFunc("GET /api/mcp-capacity", mcpCapacityHandler.GetCapacity)
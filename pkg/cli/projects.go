@@ -0,0 +1,93 @@
+package cli
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+type Projects struct{}
+
+func (p *Projects) Customize(cmd *cobra.Command) {
+	cmd.Use = "projects"
+	cmd.Aliases = []string{"project"}
+}
+
+func (p *Projects) Run(cmd *cobra.Command, _ []string) error {
+	return cmd.Help()
+}
+
+type ProjectExport struct {
+	Assistant string `usage:"ID of the assistant the project belongs to" default:"obot"`
+	Output    string `usage:"File to write the archive to, defaults to <project-id>.zip" short:"o"`
+	root      *Obot
+}
+
+func (p *ProjectExport) Customize(cmd *cobra.Command) {
+	cmd.Use = "export [flags] PROJECT_ID"
+	cmd.Short = "Export a project to a portable archive"
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (p *ProjectExport) Run(cmd *cobra.Command, args []string) error {
+	archive, err := p.root.Client.ExportProject(cmd.Context(), p.Assistant, args[0])
+	if err != nil {
+		return err
+	}
+
+	output := p.Output
+	if output == "" {
+		output = args[0] + ".zip"
+	}
+	if err := os.WriteFile(output, archive, 0600); err != nil {
+		return err
+	}
+
+	fmt.Printf("Exported project %s to %s\n", args[0], output)
+	return nil
+}
+
+type ProjectImport struct {
+	Assistant string   `usage:"ID of the assistant to create the project for" default:"obot"`
+	MCPServer []string `name:"mcp-server" usage:"Map an MCP server placeholder of the archive to an MCP server, as PLACEHOLDER=MCP_SERVER_ID"`
+	root      *Obot
+}
+
+func (p *ProjectImport) Customize(cmd *cobra.Command) {
+	cmd.Use = "import [flags] ARCHIVE"
+	cmd.Short = "Create a project from a portable archive"
+	cmd.Args = cobra.ExactArgs(1)
+}
+
+func (p *ProjectImport) Run(cmd *cobra.Command, args []string) error {
+	archive, err := os.ReadFile(args[0])
+	if err != nil {
+		return err
+	}
+
+	mcpServers := make(map[string]string, len(p.MCPServer))
+	for _, mapping := range p.MCPServer {
+		placeholder, mcpID, ok := strings.Cut(mapping, "=")
+		if !ok {
+			return fmt.Errorf("invalid MCP server mapping %q, expected PLACEHOLDER=MCP_SERVER_ID", mapping)
+		}
+		mcpServers[placeholder] = mcpID
+	}
+
+	result, err := p.root.Client.ImportProject(cmd.Context(), p.Assistant, archive, mcpServers)
+	if err != nil {
+		return err
+	}
+
+	fmt.Printf("Imported project %s\n", result.Project.ID)
+	for _, server := range result.UnmappedMCPServers {
+		name := server.Name
+		if server.Alias != "" {
+			name = server.Alias
+		}
+		fmt.Printf("MCP server %s (%s) was not mapped, connect it with --mcp-server %s=MCP_SERVER_ID\n", server.Placeholder, name, server.Placeholder)
+	}
+	return nil
+}
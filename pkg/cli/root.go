@@ -42,6 +42,10 @@ func New() *cobra.Command {
 		},
 	}
 	return cmd.Command(root,
+		cmd.Command(&Projects{},
+			&ProjectExport{root: root},
+			&ProjectImport{root: root},
+		),
 		&Server{},
 		&Token{root: root},
 		&Version{},
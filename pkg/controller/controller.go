@@ -250,6 +250,10 @@ func (c *Controller) PostStart(ctx context.Context, client kclient.Client) {
 	// This fixes a race condition where catalog entry changes might not trigger MCPServer
 	// reconciliation if the server hadn't registered its watch yet.
 	go c.retriggerCatalogEntries(ctx, client)
+
+	// Keep the images of the most used catalog entries warm, so that their servers start faster after being shut down
+	// for being idle.
+	go c.services.MCPLoader.RunWarmPool(ctx)
 }
 
 // retriggerCatalogEntries touches all MCPServerCatalogEntries to trigger their handlers,
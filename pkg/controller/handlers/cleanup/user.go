@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"slices"
 	"strconv"
+	"time"
 
 	"github.com/obot-platform/nah/pkg/router"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/pkg/accesscontrolrule"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/legalhold"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"k8s.io/apimachinery/pkg/fields"
@@ -29,7 +31,7 @@ func NewUserCleanup(gatewayClient *gclient.Client, acrHelper *accesscontrolrule.
 	}
 }
 
-func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
+func (u *UserCleanup) Cleanup(req router.Request, resp router.Response) error {
 	userDelete := req.Object.(*v1.UserDelete)
 	userID := strconv.FormatUint(uint64(userDelete.Spec.UserID), 10)
 	log.Infof("Starting user cleanup: userID=%s", userID)
@@ -53,6 +55,26 @@ func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
 	}
 	log.Infof("Removed user identities during cleanup: userID=%s identities=%d", userID, len(identities))
 
+	holds, err := legalhold.Active(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	var servers v1.MCPServerList
+	if err := req.List(&servers, &kclient.ListOptions{
+		Namespace: req.Namespace,
+		FieldSelector: fields.SelectorFromSet(map[string]string{
+			"spec.userID": userID,
+		}),
+	}); err != nil {
+		return err
+	}
+
+	// The user's nanobot agents and MCP servers are kept while the user, or the MCP server, is under a legal hold.
+	heldServer := func(server v1.MCPServer) bool {
+		return holds.HoldsUser(userID) || holds.HoldsMCPServer(server.Name)
+	}
+
 	var agents v1.NanobotAgentList
 	if err := req.List(&agents, &kclient.ListOptions{
 		Namespace: req.Namespace,
@@ -63,12 +85,20 @@ func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
 		return err
 	}
 
+	var heldAgents int
 	for _, agent := range agents.Items {
+		// Deleting an agent deletes its MCP servers.
+		if holds.HoldsUser(userID) || slices.ContainsFunc(servers.Items, func(server v1.MCPServer) bool {
+			return server.Spec.NanobotAgentID == agent.Name && heldServer(server)
+		}) {
+			heldAgents++
+			continue
+		}
 		if err := req.Delete(&agent); err != nil {
 			return err
 		}
 	}
-	log.Infof("Deleted nanobot agents during user cleanup: userID=%s agents=%d", userID, len(agents.Items))
+	log.Infof("Deleted nanobot agents during user cleanup: userID=%s agents=%d (held=%d)", userID, len(agents.Items)-heldAgents, heldAgents)
 
 	// Delete any API keys the user created. Nanobot-agent keys are handled by the
 	// NanobotAgent delete flow above; this sweeps user-created keys plus anything
@@ -94,28 +124,24 @@ func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
 		return err
 	}
 
-	var deletedProjectThreads int
+	var deletedProjectThreads, heldProjectThreads int
 	for _, thread := range threads.Items {
 		if thread.Spec.Project {
+			if held, err := holds.PreventsDeletion(req.Ctx, req.Client, &thread); err != nil {
+				return err
+			} else if held {
+				heldProjectThreads++
+				continue
+			}
 			if err := req.Delete(&thread); err != nil {
 				return err
 			}
 			deletedProjectThreads++
 		}
 	}
-	log.Infof("Deleted project threads during user cleanup: userID=%s threads=%d", userID, deletedProjectThreads)
-
-	var servers v1.MCPServerList
-	if err := req.List(&servers, &kclient.ListOptions{
-		Namespace: req.Namespace,
-		FieldSelector: fields.SelectorFromSet(map[string]string{
-			"spec.userID": userID,
-		}),
-	}); err != nil {
-		return err
-	}
+	log.Infof("Deleted project threads during user cleanup: userID=%s threads=%d (held=%d)", userID, deletedProjectThreads, heldProjectThreads)
 
-	var deletedServers int
+	var deletedServers, heldServers int
 	for _, server := range servers.Items {
 		// Skip multi-user servers in the default MCPCatalog — they should persist after user deletion.
 		// Also skip servers that are associated with an agent because we need the credential to stick
@@ -123,12 +149,16 @@ func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
 		if server.Spec.MCPCatalogID == system.DefaultCatalog || server.Spec.NanobotAgentID != "" {
 			continue
 		}
+		if heldServer(server) {
+			heldServers++
+			continue
+		}
 		if err := kclient.IgnoreNotFound(req.Delete(&server)); err != nil {
 			return err
 		}
 		deletedServers++
 	}
-	log.Infof("Deleted MCP servers during user cleanup: userID=%s servers=%d (skipped=%d)", userID, deletedServers, len(servers.Items)-deletedServers)
+	log.Infof("Deleted MCP servers during user cleanup: userID=%s servers=%d (held=%d, skipped=%d)", userID, deletedServers, heldServers, len(servers.Items)-deletedServers-heldServers)
 
 	// DeleteRefs should handle cleaning up most of the user's MCPServerInstances.
 	// But there still might be MCPServerInstances pointing to multi-user servers that we need to delete.
@@ -198,6 +228,26 @@ func (u *UserCleanup) Cleanup(req router.Request, _ router.Response) error {
 	}
 	log.Infof("Deleted power user workspaces during user cleanup: userID=%s workspaces=%d", userID, len(workspaces.Items))
 
+	// The MCP usage rollups are per user, so they are deleted with the user unless the user is under a legal hold.
+	// The user's audit logs are kept until their retention expires.
+	if holds.HoldsUser(userID) {
+		log.Infof("Kept MCP usage rollups during user cleanup because of a legal hold: userID=%s", userID)
+	} else {
+		deletedRollups, err := u.gatewayClient.DeleteMCPCallRollupsForUser(req.Ctx, userID)
+		if err != nil {
+			return fmt.Errorf("failed to delete MCP usage rollups for user %d: %w", userDelete.Spec.UserID, err)
+		}
+		log.Infof("Deleted MCP usage rollups during user cleanup: userID=%s rollups=%d", userID, deletedRollups)
+	}
+
+	// Keep this object until the legal hold is released, so that the held agents, MCP servers, project threads and
+	// usage rollups are deleted then.
+	if heldAgents > 0 || heldServers > 0 || heldProjectThreads > 0 || holds.HoldsUser(userID) {
+		log.Infof("Suspended user cleanup because of a legal hold: userID=%s agents=%d servers=%d threads=%d", userID, heldAgents, heldServers, heldProjectThreads)
+		resp.RetryAfter(time.Hour)
+		return nil
+	}
+
 	// If everything is cleaned up successfully, then delete this object because we don't need it.
 	log.Infof("Completed user cleanup: userID=%s", userID)
 	return req.Delete(userDelete)
@@ -4,7 +4,6 @@ import (
 	"fmt"
 	"time"
 
-	"github.com/adhocore/gronx"
 	"github.com/obot-platform/nah/pkg/router"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/logger"
@@ -26,6 +25,10 @@ func New() *Handler {
 
 func GetScheduleAndTimezone(cronJob v1.CronJob) (string, string) {
 	if cronJob.Spec.TaskSchedule != nil {
+		if cronJob.Spec.TaskSchedule.Cron != "" {
+			return cronJob.Spec.TaskSchedule.Cron, cronJob.Spec.TaskSchedule.TimeZone
+		}
+
 		schedule := ""
 		switch cronJob.Spec.TaskSchedule.Interval {
 		case "hourly":
@@ -51,68 +54,109 @@ func GetScheduleAndTimezone(cronJob v1.CronJob) (string, string) {
 
 func (h *Handler) Run(req router.Request, resp router.Response) error {
 	cj := req.Object.(*v1.CronJob)
+	s := newScheduler(*cj)
+	now := time.Now()
+
+	if s.paused {
+		if cj.Status.PausedAt == nil {
+			cj.Status.PausedAt = &metav1.Time{Time: now}
+		}
+		// Check again at the next scheduled time, in case the pause is lifted without the cron job changing.
+		return retryAfterNext(resp, s, now)
+	}
+
+	if cj.Status.PausedAt != nil {
+		// Runs scheduled while paused are never caught up.
+		log.Infof("Resuming paused cron job: cronJob=%s pausedAt=%s", cj.Name, cj.Status.PausedAt.Format(time.RFC3339))
+		cj.Status.PausedAt = nil
+		cj.Status.LastScheduleTime = &metav1.Time{Time: now}
+		return retryAfterNext(resp, s, now)
+	}
+
 	next, err := calculateNextRunTime(*cj)
 	if err != nil {
 		return fmt.Errorf("failed to calculate next run time: %w", err)
 	}
 
-	if until := time.Until(next); until > 0 {
+	if until := next.Sub(now); until > 0 {
 		resp.RetryAfter(until)
 		return nil
 	}
 
-	var workflow v1.Workflow
-	if err := req.Get(&workflow, cj.Namespace, cj.Spec.WorkflowName); apierror.IsNotFound(err) {
-		return nil
-	} else if err != nil {
+	due, err := s.due(lastScheduleTime(*cj), now)
+	if err != nil {
 		return err
 	}
 
-	execution := &v1.WorkflowExecution{
-		ObjectMeta: metav1.ObjectMeta{
-			GenerateName: system.WorkflowExecutionPrefix,
-			Namespace:    req.Namespace,
-		},
-		Spec: v1.WorkflowExecutionSpec{
-			WorkflowName: workflow.Name,
-			Input:        cj.Spec.Input,
-			CronJobName:  cj.Name,
-			ThreadName:   cj.Spec.ThreadName,
-		},
-	}
-	if err = req.Client.Create(req.Ctx, execution); err != nil {
-		return err
+	if len(due) == 0 {
+		return retryAfterNext(resp, s, now)
 	}
-	log.Infof("Triggered workflow execution from cron job: cronJob=%s workflow=%s execution=%s schedule=%s", cj.Name, workflow.Name, execution.Name, cj.Spec.Schedule)
-
-	cj.Status.LastRunStartedAt = &[]metav1.Time{metav1.Now()}[0]
-	return nil
-}
 
-func calculateNextRunTime(cronJob v1.CronJob) (time.Time, error) {
-	lastRun := cronJob.Status.LastRunStartedAt
-	if lastRun.IsZero() {
-		lastRun = &metav1.Time{Time: cronJob.CreationTimestamp.Time}
+	runs := s.runsFor(due, now)
+	if missed := len(due) - runs; missed > 0 {
+		log.Infof("Skipping missed cron job runs: cronJob=%s missed=%d catchUp=%s", cj.Name, missed, s.catchUp)
 	}
 
-	schedule, timezone := GetScheduleAndTimezone(cronJob)
-	var location *time.Location
-	if timezone != "" {
-		loc, err := time.LoadLocation(timezone)
-		if err == nil {
-			location = loc
+	if runs > 0 {
+		var workflow v1.Workflow
+		if err := req.Get(&workflow, cj.Namespace, cj.Spec.WorkflowName); apierror.IsNotFound(err) {
+			return nil
+		} else if err != nil {
+			return err
 		}
+
+		for range runs {
+			execution := &v1.WorkflowExecution{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: system.WorkflowExecutionPrefix,
+					Namespace:    req.Namespace,
+				},
+				Spec: v1.WorkflowExecutionSpec{
+					WorkflowName: workflow.Name,
+					Input:        cj.Spec.Input,
+					CronJobName:  cj.Name,
+					ThreadName:   cj.Spec.ThreadName,
+				},
+			}
+			if err = req.Client.Create(req.Ctx, execution); err != nil {
+				return err
+			}
+			log.Infof("Triggered workflow execution from cron job: cronJob=%s workflow=%s execution=%s schedule=%s", cj.Name, workflow.Name, execution.Name, s.schedule)
+		}
+
+		cj.Status.LastRunStartedAt = &metav1.Time{Time: now}
 	}
-	if location != nil {
-		lastRun = &metav1.Time{Time: lastRun.In(location)}
-	}
 
-	next, err := gronx.NextTickAfter(schedule, lastRun.Time, false)
+	cj.Status.LastScheduleTime = &metav1.Time{Time: now}
+	return retryAfterNext(resp, s, now)
+}
+
+// retryAfterNext requeues the cron job at its next scheduled time after now.
+func retryAfterNext(resp router.Response, s scheduler, now time.Time) error {
+	next, err := s.next(now)
 	if err != nil {
-		return time.Time{}, fmt.Errorf("failed to parse schedule: %w", err)
+		return fmt.Errorf("failed to calculate next run time: %w", err)
 	}
 
-	return next, nil
+	resp.RetryAfter(next.Sub(now))
+	return nil
+}
+
+// lastScheduleTime returns the time after which scheduled runs have not yet been handled.
+func lastScheduleTime(cronJob v1.CronJob) time.Time {
+	last := cronJob.CreationTimestamp.Time
+	if t := cronJob.Status.LastRunStartedAt; !t.IsZero() && t.After(last) {
+		last = t.Time
+	}
+	if t := cronJob.Status.LastScheduleTime; !t.IsZero() && t.After(last) {
+		last = t.Time
+	}
+	return last
+}
+
+// calculateNextRunTime returns the first scheduled time after the runs that have already been handled.
+func calculateNextRunTime(cronJob v1.CronJob) (time.Time, error) {
+	return newScheduler(cronJob).next(lastScheduleTime(cronJob))
 }
 
 func (h *Handler) SetSuccessRunTime(req router.Request, _ router.Response) error {
@@ -70,3 +70,109 @@ func TestCalculateNextRunTime(t *testing.T) {
 		require.Equal(t, expectedNextRun, nextRun)
 	})
 }
+
+func TestCronExpressionSchedule(t *testing.T) {
+	creationTime := time.Date(2025, 12, 24, 9, 0, 0, 0, time.UTC)
+	cronJob := v1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Time{Time: creationTime},
+		},
+		Spec: v1.CronJobSpec{
+			CronJobManifest: types.CronJobManifest{
+				TaskSchedule: &types.Schedule{
+					// Every weekday at 8:30, which Cron takes precedence over.
+					Interval:      "hourly",
+					Cron:          "30 8 * * 1-5",
+					TimeZone:      "UTC",
+					BlackoutDates: []string{"2025-12-25", "2025-12-26"},
+				},
+			},
+		},
+	}
+
+	nextRun, err := calculateNextRunTime(cronJob)
+	require.NoError(t, err)
+	// The 25th and 26th are blacked out and the 27th and 28th are a weekend.
+	require.Equal(t, time.Date(2025, 12, 29, 8, 30, 0, 0, time.UTC), nextRun.UTC())
+}
+
+func TestCalculateNextRunTimeAfterResume(t *testing.T) {
+	lastRun := time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+	resumed := lastRun.Add(5*time.Hour + 30*time.Minute)
+	cronJob := v1.CronJob{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.Time{Time: lastRun.Add(-time.Hour)},
+		},
+		Status: v1.CronJobStatus{
+			LastRunStartedAt: &metav1.Time{Time: lastRun},
+			LastScheduleTime: &metav1.Time{Time: resumed},
+		},
+		Spec: v1.CronJobSpec{
+			CronJobManifest: types.CronJobManifest{
+				TaskSchedule: &types.Schedule{
+					Interval: "hourly",
+					TimeZone: "UTC",
+				},
+			},
+		},
+	}
+
+	// The runs scheduled while paused are not caught up.
+	nextRun, err := calculateNextRunTime(cronJob)
+	require.NoError(t, err)
+	require.Equal(t, lastRun.Add(6*time.Hour), nextRun.UTC())
+}
+
+func TestCatchUp(t *testing.T) {
+	scheduleFor := func(policy types.CatchUpPolicy) scheduler {
+		return newScheduler(v1.CronJob{
+			Spec: v1.CronJobSpec{
+				CronJobManifest: types.CronJobManifest{
+					TaskSchedule: &types.Schedule{
+						Interval: "hourly",
+						TimeZone: "UTC",
+						CatchUp:  policy,
+					},
+				},
+			},
+		})
+	}
+
+	lastRun := time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	t.Run("missed runs", func(t *testing.T) {
+		now := lastRun.Add(3*time.Hour + 30*time.Minute)
+		due, err := scheduleFor("").due(lastRun, now)
+		require.NoError(t, err)
+		require.Len(t, due, 3)
+
+		require.Equal(t, 1, scheduleFor("").runsFor(due, now))
+		require.Equal(t, 1, scheduleFor(types.CatchUpPolicyRunOnce).runsFor(due, now))
+		require.Equal(t, 3, scheduleFor(types.CatchUpPolicyRunAll).runsFor(due, now))
+		require.Equal(t, 0, scheduleFor(types.CatchUpPolicySkip).runsFor(due, now))
+	})
+
+	t.Run("on time run", func(t *testing.T) {
+		now := lastRun.Add(time.Hour + time.Second)
+		due, err := scheduleFor(types.CatchUpPolicySkip).due(lastRun, now)
+		require.NoError(t, err)
+		require.Len(t, due, 1)
+		require.Equal(t, 1, scheduleFor(types.CatchUpPolicySkip).runsFor(due, now))
+	})
+
+	t.Run("run all is limited", func(t *testing.T) {
+		now := lastRun.Add(48 * time.Hour)
+		due, err := scheduleFor(types.CatchUpPolicyRunAll).due(lastRun, now)
+		require.NoError(t, err)
+		require.Len(t, due, 48)
+		require.Equal(t, maxCatchUpRuns, scheduleFor(types.CatchUpPolicyRunAll).runsFor(due, now))
+	})
+}
+
+func TestValidateSchedule(t *testing.T) {
+	require.NoError(t, ValidateSchedule(types.Schedule{Cron: "30 8 * * 1-5", BlackoutDates: []string{"2025-12-25"}, CatchUp: types.CatchUpPolicyRunAll}))
+	require.Error(t, ValidateSchedule(types.Schedule{Cron: "not a cron"}))
+	require.Error(t, ValidateSchedule(types.Schedule{BlackoutDates: []string{"12/25/2025"}}))
+	require.Error(t, ValidateSchedule(types.Schedule{CatchUp: "sometimes"}))
+	require.Error(t, ValidateSchedule(types.Schedule{TimeZone: "Mars/Olympus_Mons"}))
+}
@@ -0,0 +1,160 @@
+package cronjob
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/adhocore/gronx"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+const (
+	// catchUpGracePeriod is how late a scheduled time can be handled before it is considered missed.
+	catchUpGracePeriod = 5 * time.Minute
+	// maxCatchUpRuns limits the number of runs triggered at once by the run-all catch-up policy.
+	maxCatchUpRuns = 10
+	// maxScheduleTicks limits the number of scheduled times considered when looking for the next or missed runs.
+	maxScheduleTicks = 1000
+)
+
+type scheduler struct {
+	schedule string
+	location *time.Location
+	blackout map[string]struct{}
+	paused   bool
+	catchUp  types.CatchUpPolicy
+}
+
+func newScheduler(cronJob v1.CronJob) scheduler {
+	schedule, timezone := GetScheduleAndTimezone(cronJob)
+	s := scheduler{
+		schedule: schedule,
+		location: time.Local,
+		catchUp:  types.CatchUpPolicyRunOnce,
+	}
+
+	if timezone != "" {
+		if loc, err := time.LoadLocation(timezone); err == nil {
+			s.location = loc
+		}
+	}
+
+	if ts := cronJob.Spec.TaskSchedule; ts != nil {
+		s.paused = ts.Paused
+		if ts.CatchUp != "" {
+			s.catchUp = ts.CatchUp
+		}
+		s.blackout = make(map[string]struct{}, len(ts.BlackoutDates))
+		for _, date := range ts.BlackoutDates {
+			s.blackout[date] = struct{}{}
+		}
+	}
+
+	return s
+}
+
+func (s scheduler) blackedOut(t time.Time) bool {
+	_, ok := s.blackout[t.In(s.location).Format(time.DateOnly)]
+	return ok
+}
+
+// next returns the first scheduled time after the given time that is not on a blackout date.
+func (s scheduler) next(after time.Time) (time.Time, error) {
+	after = after.In(s.location)
+	for range maxScheduleTicks {
+		next, err := gronx.NextTickAfter(s.schedule, after, false)
+		if err != nil {
+			return time.Time{}, fmt.Errorf("failed to parse schedule: %w", err)
+		}
+		if !s.blackedOut(next) {
+			return next, nil
+		}
+		after = next
+	}
+
+	return time.Time{}, fmt.Errorf("no scheduled time found outside of the blackout dates")
+}
+
+// due returns the scheduled times after the given time and up to now that are not on a blackout date.
+func (s scheduler) due(after, now time.Time) ([]time.Time, error) {
+	var due []time.Time
+	after = after.In(s.location)
+	for range maxScheduleTicks {
+		next, err := gronx.NextTickAfter(s.schedule, after, false)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse schedule: %w", err)
+		}
+		if next.After(now) {
+			break
+		}
+		if !s.blackedOut(next) {
+			due = append(due, next)
+		}
+		after = next
+	}
+
+	return due, nil
+}
+
+// runsFor returns the number of runs to trigger for the given due times according to the catch-up policy.
+// A due time is only considered missed if it is more than catchUpGracePeriod in the past.
+func (s scheduler) runsFor(due []time.Time, now time.Time) int {
+	if len(due) == 0 {
+		return 0
+	}
+
+	onTime := now.Sub(due[len(due)-1]) <= catchUpGracePeriod
+	switch s.catchUp {
+	case types.CatchUpPolicySkip:
+		if onTime {
+			return 1
+		}
+		return 0
+	case types.CatchUpPolicyRunAll:
+		return min(len(due), maxCatchUpRuns)
+	default:
+		return 1
+	}
+}
+
+// NextRunTime returns the next time the cron job will trigger a run, or nil if it is paused or has no valid schedule.
+func NextRunTime(cronJob v1.CronJob) *time.Time {
+	s := newScheduler(cronJob)
+	if s.paused {
+		return nil
+	}
+
+	next, err := s.next(time.Now())
+	if err != nil {
+		return nil
+	}
+	return &next
+}
+
+// ValidateSchedule returns an error if the task schedule is not valid.
+func ValidateSchedule(schedule types.Schedule) error {
+	if schedule.Cron != "" && !gronx.IsValid(schedule.Cron) {
+		return fmt.Errorf("invalid cron expression %q", schedule.Cron)
+	}
+
+	if schedule.TimeZone != "" {
+		if _, err := time.LoadLocation(schedule.TimeZone); err != nil {
+			return fmt.Errorf("invalid time zone %q: %w", schedule.TimeZone, err)
+		}
+	}
+
+	for _, date := range schedule.BlackoutDates {
+		if _, err := time.Parse(time.DateOnly, date); err != nil {
+			return fmt.Errorf("invalid blackout date %q, expected YYYY-MM-DD", date)
+		}
+	}
+
+	switch schedule.CatchUp {
+	case "", types.CatchUpPolicySkip, types.CatchUpPolicyRunOnce, types.CatchUpPolicyRunAll:
+	default:
+		return fmt.Errorf("invalid catch-up policy %q", schedule.CatchUp)
+	}
+
+	return nil
+}
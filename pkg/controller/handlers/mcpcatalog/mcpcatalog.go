@@ -21,6 +21,8 @@ import (
 	"github.com/obot-platform/obot/logger"
 	"github.com/obot-platform/obot/pkg/accesscontrolrule"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/imagepolicy"
+	"github.com/obot-platform/obot/pkg/packagepolicy"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
 	"github.com/obot-platform/obot/pkg/validation"
@@ -86,11 +88,21 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 		}
 	}()
 
+	imagePolicy, err := imagepolicy.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
+	packagePolicy, err := packagepolicy.Get(req.Ctx, req.Client)
+	if err != nil {
+		return err
+	}
+
 	toAdd := make([]client.Object, 0)
 	mcpCatalog.Status.SyncErrors = make(map[string]string)
 
 	for _, sourceURL := range mcpCatalog.Spec.SourceURLs {
-		objs, err := h.readMCPCatalog(mcpCatalog.Name, sourceURL)
+		objs, err := h.readMCPCatalog(mcpCatalog.Name, sourceURL, imagePolicy, packagePolicy)
 		if err != nil {
 			log.Errorf("failed to read catalog %s: %v", sourceURL, err)
 			mcpCatalog.Status.SyncErrors[sourceURL] = err.Error()
@@ -137,10 +149,16 @@ func (h *Handler) Sync(req router.Request, resp router.Response) error {
 	return app.Apply(req.Ctx, mcpCatalog, toAdd...)
 }
 
-func (h *Handler) readMCPCatalog(catalogName, sourceURL string) ([]client.Object, error) {
+func (h *Handler) readMCPCatalog(catalogName, sourceURL string, imagePolicy types.ContainerImagePolicyManifest, packagePolicy types.PackagePolicyManifest) ([]client.Object, error) {
 	var entries []types.MCPServerCatalogEntryManifest
 
-	if strings.HasPrefix(sourceURL, "http://") || strings.HasPrefix(sourceURL, "https://") {
+	if isRegistrySource(sourceURL) {
+		var err error
+		entries, err = readRegistryCatalog(sourceURL)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read registry catalog %s: %w", sourceURL, err)
+		}
+	} else if strings.HasPrefix(sourceURL, "http://") || strings.HasPrefix(sourceURL, "https://") {
 		if isGitHubURL(sourceURL) {
 			var err error
 			entries, err = readGitHubCatalog(sourceURL)
@@ -250,6 +268,14 @@ func (h *Handler) readMCPCatalog(catalogName, sourceURL string) ([]client.Object
 			errs = append(errs, fmt.Errorf("failed to validate catalog entry %s: %w", entry.Name, err))
 			continue
 		}
+		if err := imagepolicy.CheckCatalogEntryManifest(imagePolicy, entry); err != nil {
+			errs = append(errs, fmt.Errorf("failed to validate catalog entry %s: %w", entry.Name, err))
+			continue
+		}
+		if err := packagepolicy.CheckCatalogEntryManifest(packagePolicy, entry); err != nil {
+			errs = append(errs, fmt.Errorf("failed to validate catalog entry %s: %w", entry.Name, err))
+			continue
+		}
 		catalogEntry.Spec.Manifest = entry
 
 		objs = append(objs, &catalogEntry)
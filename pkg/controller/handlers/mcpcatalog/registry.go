@@ -0,0 +1,396 @@
+package mcpcatalog
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+const (
+	// registrySourcePrefix marks a source URL as an MCP Registry API server, like
+	// "registry+https://registry.modelcontextprotocol.io?include=io.github.*". The include and exclude query parameters
+	// can be repeated. A pattern containing a "/" is matched against the full server name, and one without is matched
+	// against the namespace.
+	registrySourcePrefix = "registry+"
+	registryPageLimit    = 100
+	maxRegistryPages     = 200
+)
+
+var registryHTTPClient = &http.Client{Timeout: 30 * time.Second}
+
+func isRegistrySource(sourceURL string) bool {
+	return strings.HasPrefix(sourceURL, registrySourcePrefix)
+}
+
+type registrySource struct {
+	baseURL          string
+	include, exclude []string
+}
+
+func parseRegistrySource(sourceURL string) (registrySource, error) {
+	u, err := url.Parse(strings.TrimPrefix(sourceURL, registrySourcePrefix))
+	if err != nil {
+		return registrySource{}, fmt.Errorf("invalid registry URL: %w", err)
+	}
+	if (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return registrySource{}, fmt.Errorf("registry URL must be an absolute http or https URL")
+	}
+
+	query := u.Query()
+	source := registrySource{
+		include: query["include"],
+		exclude: query["exclude"],
+	}
+	for _, pattern := range slices.Concat(source.include, source.exclude) {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return registrySource{}, fmt.Errorf("invalid server name pattern %q: %w", pattern, err)
+		}
+	}
+
+	u.RawQuery, u.Fragment = "", ""
+	source.baseURL = strings.TrimSuffix(u.String(), "/")
+	return source, nil
+}
+
+// matches returns whether the registry server name, like "io.github.user/server", is selected by the source's patterns.
+func (s registrySource) matches(serverName string) bool {
+	matchAny := func(patterns []string) bool {
+		namespace, _, _ := strings.Cut(serverName, "/")
+		return slices.ContainsFunc(patterns, func(pattern string) bool {
+			if strings.Contains(pattern, "/") {
+				matched, _ := path.Match(pattern, serverName)
+				return matched
+			}
+			matched, _ := path.Match(pattern, namespace)
+			return matched
+		})
+	}
+
+	return (len(s.include) == 0 || matchAny(s.include)) && !matchAny(s.exclude)
+}
+
+// readRegistryCatalog lists the servers from an MCP Registry API server and converts the latest version of each
+// selected server to a catalog entry. Servers that can't be run by Obot are skipped.
+func readRegistryCatalog(sourceURL string) ([]types.MCPServerCatalogEntryManifest, error) {
+	source, err := parseRegistrySource(sourceURL)
+	if err != nil {
+		return nil, err
+	}
+
+	servers, err := listRegistryServers(source)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		entries   = make([]types.MCPServerCatalogEntryManifest, 0, len(servers))
+		usedNames = make(map[string]struct{}, len(servers))
+	)
+	for _, server := range servers {
+		entry, ok := convertRegistryServer(server)
+		if !ok {
+			log.Debugf("Skipping registry server without a supported package or remote: source=%s server=%s", source.baseURL, server.Server.Name)
+			continue
+		}
+
+		// Entries are named after their display names, so fall back to the unique registry name for duplicates.
+		cleanName := strings.ToLower(strings.ReplaceAll(entry.Name, " ", "-"))
+		if _, ok := usedNames[cleanName]; ok {
+			entry.Name = server.Server.Name
+			cleanName = strings.ToLower(strings.ReplaceAll(entry.Name, " ", "-"))
+		}
+		usedNames[cleanName] = struct{}{}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, nil
+}
+
+// listRegistryServers pages through the registry's servers and returns the latest version of each selected server,
+// in the order they were first listed.
+func listRegistryServers(source registrySource) ([]types.RegistryServerResponse, error) {
+	var (
+		latest = map[string]int{}
+		result []types.RegistryServerResponse
+		cursor string
+		seen   = map[string]struct{}{}
+	)
+	for range maxRegistryPages {
+		page, err := getRegistryServers(source.baseURL, cursor)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, server := range page.Servers {
+			if !source.matches(server.Server.Name) || server.Meta.Official.Status == "deleted" {
+				continue
+			}
+
+			if i, ok := latest[server.Server.Name]; !ok {
+				latest[server.Server.Name] = len(result)
+				result = append(result, server)
+			} else if isNewerRegistryVersion(server, result[i]) {
+				result[i] = server
+			}
+		}
+
+		if page.Metadata == nil || page.Metadata.NextCursor == "" {
+			return result, nil
+		}
+		if _, ok := seen[page.Metadata.NextCursor]; ok {
+			return nil, fmt.Errorf("registry %s returned cursor %q more than once", source.baseURL, page.Metadata.NextCursor)
+		}
+		cursor = page.Metadata.NextCursor
+		seen[cursor] = struct{}{}
+	}
+
+	return nil, fmt.Errorf("registry %s has more than %d pages of servers", source.baseURL, maxRegistryPages)
+}
+
+// isNewerRegistryVersion returns whether server should replace current, preferring the version the registry marks
+// as the latest and then the most recently created one.
+func isNewerRegistryVersion(server, current types.RegistryServerResponse) bool {
+	if server.Meta.Official.IsLatest != current.Meta.Official.IsLatest {
+		return server.Meta.Official.IsLatest
+	}
+	return server.Meta.Official.CreatedAt > current.Meta.Official.CreatedAt
+}
+
+func getRegistryServers(baseURL, cursor string) (types.RegistryServerList, error) {
+	query := url.Values{
+		"limit":   []string{strconv.Itoa(registryPageLimit)},
+		"version": []string{"latest"},
+	}
+	if cursor != "" {
+		query.Set("cursor", cursor)
+	}
+
+	resp, err := registryHTTPClient.Get(baseURL + "/v0.1/servers?" + query.Encode())
+	if err != nil {
+		return types.RegistryServerList{}, fmt.Errorf("failed to list servers from registry %s: %w", baseURL, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return types.RegistryServerList{}, fmt.Errorf("unexpected status %d when listing servers from registry %s: %s", resp.StatusCode, baseURL, string(body))
+	}
+
+	var list types.RegistryServerList
+	if err := json.NewDecoder(resp.Body).Decode(&list); err != nil {
+		return types.RegistryServerList{}, fmt.Errorf("failed to decode servers from registry %s: %w", baseURL, err)
+	}
+
+	return list, nil
+}
+
+// convertRegistryServer converts a registry server to a catalog entry. Remotes are preferred over packages, because
+// they don't require running anything. It returns false if the server has no remote or package that Obot can run.
+func convertRegistryServer(response types.RegistryServerResponse) (types.MCPServerCatalogEntryManifest, bool) {
+	server := response.Server
+
+	entry := types.MCPServerCatalogEntryManifest{
+		Metadata: map[string]string{
+			"registryName":    server.Name,
+			"registryVersion": server.Version,
+		},
+		Name:             server.Title,
+		ShortDescription: server.Description,
+		Description:      server.Description,
+	}
+	if entry.Name == "" {
+		_, entry.Name, _ = strings.Cut(server.Name, "/")
+		if entry.Name == "" {
+			entry.Name = server.Name
+		}
+	}
+	if server.Meta.PublisherProvided != nil && server.Meta.PublisherProvided.GitHub != nil && server.Meta.PublisherProvided.GitHub.Readme != "" {
+		entry.Description = server.Meta.PublisherProvided.GitHub.Readme
+	}
+	if len(server.Icons) > 0 {
+		entry.Icon = server.Icons[0].Src
+	}
+	if server.Repository != nil {
+		entry.RepoURL = server.Repository.URL
+	}
+
+	for _, remote := range server.Remotes {
+		if remote.Type != "streamable-http" || strings.Contains(remote.URL, "{") {
+			continue
+		}
+
+		entry.Runtime = types.RuntimeRemote
+		entry.RemoteConfig = &types.RemoteCatalogConfig{
+			FixedURL: remote.URL,
+			Headers:  convertRegistryHeaders(remote.Headers),
+		}
+		return entry, true
+	}
+
+	for _, pkg := range server.Packages {
+		if pkg.Identifier == "" {
+			continue
+		}
+
+		args, argEnv := convertRegistryArguments(pkg.PackageArguments)
+		switch {
+		case pkg.RegistryType == "npm" && pkg.Transport.Type == "stdio":
+			spec := pkg.Identifier
+			if pkg.Version != "" {
+				spec += "@" + pkg.Version
+			}
+			entry.Runtime = types.RuntimeNPX
+			entry.NPXConfig = &types.NPXRuntimeConfig{
+				Package: spec,
+				Args:    args,
+			}
+		case pkg.RegistryType == "pypi" && pkg.Transport.Type == "stdio":
+			spec := pkg.Identifier
+			if pkg.Version != "" {
+				spec += "==" + pkg.Version
+			}
+			entry.Runtime = types.RuntimeUVX
+			entry.UVXConfig = &types.UVXRuntimeConfig{
+				Package: spec,
+				Args:    args,
+			}
+		case pkg.RegistryType == "oci" && pkg.Transport.Type == "streamable-http":
+			port, mcpPath, ok := parseRegistryTransportURL(pkg.Transport.URL)
+			if !ok {
+				continue
+			}
+
+			image := pkg.Identifier
+			if lastSegment := image[strings.LastIndex(image, "/")+1:]; pkg.Version != "" && !strings.ContainsAny(lastSegment, ":@") {
+				image += ":" + pkg.Version
+			}
+			entry.Runtime = types.RuntimeContainerized
+			entry.ContainerizedConfig = &types.ContainerizedRuntimeConfig{
+				Image: image,
+				Args:  args,
+				Port:  port,
+				Path:  mcpPath,
+			}
+		default:
+			continue
+		}
+
+		entry.Env = append(convertRegistryEnv(pkg.EnvironmentVariables), argEnv...)
+		return entry, true
+	}
+
+	return types.MCPServerCatalogEntryManifest{}, false
+}
+
+// parseRegistryTransportURL returns the port and path of the MCP endpoint that a containerized server listens on,
+// like "http://localhost:8080/mcp".
+func parseRegistryTransportURL(transportURL string) (int, string, bool) {
+	u, err := url.Parse(transportURL)
+	if err != nil {
+		return 0, "", false
+	}
+
+	port, err := strconv.Atoi(u.Port())
+	if err != nil || port <= 0 {
+		return 0, "", false
+	}
+
+	mcpPath := u.Path
+	if mcpPath == "" {
+		mcpPath = "/"
+	}
+	return port, mcpPath, true
+}
+
+// convertRegistryArguments converts package arguments to command line arguments. Required arguments without a value
+// are supplied by the user through an environment variable that is referenced in the argument.
+func convertRegistryArguments(registryArgs []types.RegistryArgument) ([]string, []types.MCPEnv) {
+	var (
+		args []string
+		env  []types.MCPEnv
+	)
+	for _, arg := range registryArgs {
+		value := arg.Value
+		if value == "" {
+			value = arg.Default
+		}
+		if value == "" {
+			if !arg.IsRequired {
+				continue
+			}
+
+			key := strings.TrimLeft(arg.Name, "-")
+			if key == "" {
+				key = arg.ValueHint
+			}
+			if key == "" {
+				key = fmt.Sprintf("arg_%d", len(env)+1)
+			}
+			key = strings.ToUpper(strings.NewReplacer("-", "_", ".", "_", " ", "_").Replace(key))
+
+			env = append(env, types.MCPEnv{
+				MCPHeader: types.MCPHeader{
+					Name:        key,
+					Key:         key,
+					Description: arg.Description,
+					Sensitive:   arg.IsSecret,
+					Required:    true,
+				},
+			})
+			value = "${" + key + "}"
+		}
+
+		if arg.Type == "named" {
+			args = append(args, arg.Name)
+		}
+		args = append(args, value)
+	}
+
+	return args, env
+}
+
+func convertRegistryEnv(variables []types.RegistryKeyValueInput) []types.MCPEnv {
+	var env []types.MCPEnv
+	for _, variable := range variables {
+		env = append(env, types.MCPEnv{
+			MCPHeader: types.MCPHeader{
+				Name:        variable.Name,
+				Key:         variable.Name,
+				Description: variable.Description,
+				Value:       variable.Value,
+				Sensitive:   variable.IsSecret,
+				Required:    variable.IsRequired,
+			},
+		})
+	}
+	return env
+}
+
+func convertRegistryHeaders(headers []types.RegistryKeyValueInput) []types.MCPHeader {
+	if len(headers) == 0 {
+		return nil
+	}
+
+	result := make([]types.MCPHeader, 0, len(headers))
+	for _, header := range headers {
+		result = append(result, types.MCPHeader{
+			Name:        header.Name,
+			Key:         header.Name,
+			Description: header.Description,
+			Value:       header.Value,
+			Sensitive:   header.IsSecret,
+			Required:    header.IsRequired,
+		})
+	}
+	return result
+}
@@ -0,0 +1,166 @@
+package mcpcatalog
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReadRegistryCatalog(t *testing.T) {
+	pages := map[string]types.RegistryServerList{
+		"": {
+			Servers: []types.RegistryServerResponse{
+				{
+					Server: types.RegistryServerDetail{
+						Name:    "io.github.example/filesystem",
+						Title:   "Filesystem",
+						Version: "1.0.0",
+						Packages: []types.RegistryServerPackage{{
+							RegistryType: "npm",
+							Identifier:   "@example/server-filesystem",
+							Version:      "1.0.0",
+							Transport:    types.RegistryServerTransport{Type: "stdio"},
+							PackageArguments: []types.RegistryArgument{
+								{Type: "positional", ValueHint: "directory", IsRequired: true},
+							},
+						}},
+					},
+					Meta: types.RegistryMeta{Official: types.RegistryOfficialMeta{CreatedAt: "2025-01-01T00:00:00Z"}},
+				},
+				{
+					Server: types.RegistryServerDetail{
+						Name:    "io.github.example/fetch",
+						Version: "2.0.0",
+						Packages: []types.RegistryServerPackage{{
+							RegistryType: "pypi",
+							Identifier:   "mcp-server-fetch",
+							Version:      "2.0.0",
+							Transport:    types.RegistryServerTransport{Type: "stdio"},
+							EnvironmentVariables: []types.RegistryKeyValueInput{
+								{Name: "USER_AGENT", Description: "The user agent"},
+							},
+						}},
+					},
+					Meta: types.RegistryMeta{Official: types.RegistryOfficialMeta{IsLatest: true}},
+				},
+				{
+					Server: types.RegistryServerDetail{
+						Name:    "com.other/server",
+						Version: "1.0.0",
+						Remotes: []types.RegistryServerRemote{{Type: "streamable-http", URL: "https://other.com/mcp"}},
+					},
+					Meta: types.RegistryMeta{Official: types.RegistryOfficialMeta{IsLatest: true}},
+				},
+			},
+			Metadata: &types.RegistryServerListMetadata{NextCursor: "next"},
+		},
+		"next": {
+			Servers: []types.RegistryServerResponse{
+				{
+					Server: types.RegistryServerDetail{
+						Name:    "io.github.example/filesystem",
+						Title:   "Filesystem",
+						Version: "1.1.0",
+						Packages: []types.RegistryServerPackage{{
+							RegistryType: "oci",
+							Identifier:   "docker.io/example/filesystem",
+							Version:      "1.1.0",
+							Transport:    types.RegistryServerTransport{Type: "streamable-http", URL: "http://localhost:8080/mcp"},
+						}},
+						Remotes: []types.RegistryServerRemote{{
+							Type: "streamable-http",
+							URL:  "https://example.com/mcp",
+							Headers: []types.RegistryKeyValueInput{
+								{Name: "Authorization", IsRequired: true, IsSecret: true},
+							},
+						}},
+					},
+					Meta: types.RegistryMeta{Official: types.RegistryOfficialMeta{IsLatest: true, CreatedAt: "2025-02-01T00:00:00Z"}},
+				},
+				{
+					Server: types.RegistryServerDetail{
+						Name:     "io.github.example/stdio-image",
+						Version:  "1.0.0",
+						Packages: []types.RegistryServerPackage{{RegistryType: "oci", Identifier: "example/stdio", Transport: types.RegistryServerTransport{Type: "stdio"}}},
+					},
+					Meta: types.RegistryMeta{Official: types.RegistryOfficialMeta{IsLatest: true}},
+				},
+			},
+		},
+	}
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v0.1/servers" {
+			http.NotFound(w, r)
+			return
+		}
+		_ = json.NewEncoder(w).Encode(pages[r.URL.Query().Get("cursor")])
+	}))
+	defer srv.Close()
+
+	entries, err := readRegistryCatalog("registry+" + srv.URL + "?include=io.github.example&exclude=*/stdio-*")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+
+	// The later version marked as the latest replaces the earlier one, and its remote is preferred over its package.
+	assert.Equal(t, "Filesystem", entries[0].Name)
+	assert.Equal(t, "1.1.0", entries[0].Metadata["registryVersion"])
+	assert.Equal(t, types.RuntimeRemote, entries[0].Runtime)
+	assert.Equal(t, &types.RemoteCatalogConfig{
+		FixedURL: "https://example.com/mcp",
+		Headers:  []types.MCPHeader{{Name: "Authorization", Key: "Authorization", Required: true, Sensitive: true}},
+	}, entries[0].RemoteConfig)
+
+	assert.Equal(t, "fetch", entries[1].Name)
+	assert.Equal(t, "io.github.example/fetch", entries[1].Metadata["registryName"])
+	assert.Equal(t, types.RuntimeUVX, entries[1].Runtime)
+	assert.Equal(t, "mcp-server-fetch==2.0.0", entries[1].UVXConfig.Package)
+	assert.Equal(t, []types.MCPEnv{{MCPHeader: types.MCPHeader{Name: "USER_AGENT", Key: "USER_AGENT", Description: "The user agent"}}}, entries[1].Env)
+}
+
+func TestConvertRegistryServerPackages(t *testing.T) {
+	entry, ok := convertRegistryServer(types.RegistryServerResponse{Server: types.RegistryServerDetail{
+		Name: "io.github.example/filesystem",
+		Packages: []types.RegistryServerPackage{{
+			RegistryType: "npm",
+			Identifier:   "@example/server-filesystem",
+			Version:      "1.0.0",
+			Transport:    types.RegistryServerTransport{Type: "stdio"},
+			PackageArguments: []types.RegistryArgument{
+				{Type: "named", Name: "--mode", Value: "read-only"},
+				{Type: "named", Name: "--verbose"},
+				{Type: "positional", ValueHint: "allowed-directory", IsRequired: true},
+			},
+		}},
+	}})
+	require.True(t, ok)
+	assert.Equal(t, types.RuntimeNPX, entry.Runtime)
+	assert.Equal(t, &types.NPXRuntimeConfig{
+		Package: "@example/server-filesystem@1.0.0",
+		Args:    []string{"--mode", "read-only", "${ALLOWED_DIRECTORY}"},
+	}, entry.NPXConfig)
+	assert.Equal(t, []types.MCPEnv{{MCPHeader: types.MCPHeader{Name: "ALLOWED_DIRECTORY", Key: "ALLOWED_DIRECTORY", Required: true}}}, entry.Env)
+
+	entry, ok = convertRegistryServer(types.RegistryServerResponse{Server: types.RegistryServerDetail{
+		Name: "io.github.example/image",
+		Packages: []types.RegistryServerPackage{{
+			RegistryType: "oci",
+			Identifier:   "ghcr.io/example/image",
+			Version:      "1.2.3",
+			Transport:    types.RegistryServerTransport{Type: "streamable-http", URL: "http://localhost:3000/mcp"},
+		}},
+	}})
+	require.True(t, ok)
+	assert.Equal(t, &types.ContainerizedRuntimeConfig{Image: "ghcr.io/example/image:1.2.3", Port: 3000, Path: "/mcp"}, entry.ContainerizedConfig)
+
+	_, ok = convertRegistryServer(types.RegistryServerResponse{Server: types.RegistryServerDetail{
+		Name:    "io.github.example/templated",
+		Remotes: []types.RegistryServerRemote{{Type: "streamable-http", URL: "https://{tenant}.example.com/mcp"}},
+	}})
+	assert.False(t, ok)
+}
@@ -0,0 +1,218 @@
+package mcpserver
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/mcp"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+)
+
+const (
+	// healthProbeInterval is how often each deployed MCP server is probed.
+	healthProbeInterval = 2 * time.Minute
+	// healthFailureThreshold is the number of consecutive failed probes before a server is automatically restarted.
+	healthFailureThreshold = 3
+	// healthRestartBackoff is the minimum time between automatic restarts. It doubles with each restart that
+	// doesn't bring the server back to a healthy state, up to maxHealthRestartBackoff.
+	healthRestartBackoff    = 5 * time.Minute
+	maxHealthRestartBackoff = time.Hour
+	// maxHealthHistory is the number of health events kept on the server's status.
+	maxHealthHistory = 20
+)
+
+// healthProbes tracks when each server was last probed. This is kept in memory, instead of on the server's status,
+// so that healthy servers aren't updated every time they are probed.
+type healthProbes struct {
+	lock       sync.Mutex
+	lastProbes map[string]time.Time
+}
+
+// untilNext returns how long to wait before the server should be probed again.
+// If the server is due, then it is marked as probed and zero is returned.
+func (p *healthProbes) untilNext(name string, now time.Time) time.Duration {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	if last, ok := p.lastProbes[name]; ok {
+		if wait := healthProbeInterval - now.Sub(last); wait > 0 {
+			return wait
+		}
+	}
+
+	p.lastProbes[name] = now
+	return 0
+}
+
+func (p *healthProbes) forget(name string) {
+	p.lock.Lock()
+	defer p.lock.Unlock()
+
+	delete(p.lastProbes, name)
+}
+
+// ProbeHealth periodically probes the server's deployment, records the results on the server's status,
+// and restarts the server after repeated failures.
+func (h *Handler) ProbeHealth(req router.Request, resp router.Response) error {
+	server := req.Object.(*v1.MCPServer)
+	if !server.DeletionTimestamp.IsZero() {
+		h.healthProbes.forget(server.Name)
+		return nil
+	}
+	if server.Spec.Manifest.Runtime == "" || server.Spec.NeedsURL {
+		return nil
+	}
+
+	now := time.Now()
+	if wait := h.healthProbes.untilNext(server.Name, now); wait > 0 {
+		resp.RetryAfter(wait)
+		return nil
+	}
+	resp.RetryAfter(healthProbeInterval)
+
+	// The probe connects to the server like a user would, so it needs the audiences and user of the server's tokens.
+	serverConfig := mcp.ServerConfig{
+		MCPServerNamespace:   server.Namespace,
+		MCPServerName:        server.Name,
+		MCPServerDisplayName: server.Spec.Manifest.Name,
+		Runtime:              server.Spec.Manifest.Runtime,
+		NanobotAgentName:     server.Spec.NanobotAgentID,
+		Audiences:            server.ValidConnectURLs(h.baseURL),
+		Issuer:               h.baseURL,
+		UserID:               server.Spec.UserID,
+		OwnerUserID:          server.Spec.UserID,
+	}
+	if server.Spec.Manifest.ContainerizedConfig != nil {
+		serverConfig.ContainerPort = server.Spec.Manifest.ContainerizedConfig.Port
+		serverConfig.ContainerPath = server.Spec.Manifest.ContainerizedConfig.Path
+	}
+
+	var health types.MCPServerHealth
+	if server.Status.Health != nil {
+		health = *server.Status.Health.DeepCopy()
+	}
+
+	probeErr := h.mcpSessionManager.ProbeServer(req.Ctx, serverConfig)
+	if probeErr != nil && !errors.Is(probeErr, mcp.ErrServerNotRunning) {
+		log.Warnf("MCP server health probe failed: server=%s consecutiveFailures=%d error=%v", server.Name, health.ConsecutiveFailures+1, probeErr)
+		h.recordHealthEvent(req, server, types.MCPServerHealthEventProbeFailed, probeErr.Error())
+	} else if probeErr == nil && health.State == types.MCPServerHealthStateUnhealthy {
+		log.Infof("MCP server recovered: server=%s autoRestarts=%d", server.Name, health.AutoRestarts)
+		h.recordHealthEvent(req, server, types.MCPServerHealthEventRecovered, "health probe succeeded")
+	}
+
+	if recordProbeResult(&health, probeErr, now) {
+		// Only the server name is needed to restart. Other fields would be applied to the restarted deployment.
+		restartErr := h.mcpSessionManager.RestartServerDeployment(req.Ctx, mcp.ServerConfig{MCPServerName: server.Name})
+		if restartErr != nil {
+			log.Warnf("Failed to automatically restart unhealthy MCP server: server=%s error=%v", server.Name, restartErr)
+			h.recordHealthEvent(req, server, types.MCPServerHealthEventRestartFailed, restartErr.Error())
+		} else {
+			log.Infof("Automatically restarted unhealthy MCP server: server=%s consecutiveFailures=%d autoRestarts=%d", server.Name, health.ConsecutiveFailures, health.AutoRestarts+1)
+			h.recordHealthEvent(req, server, types.MCPServerHealthEventRestarted, fmt.Sprintf("restarted after %d failed health probes", healthFailureThreshold))
+		}
+		recordRestart(&health, restartErr, now)
+	}
+
+	if server.Status.Health != nil && equality.Semantic.DeepEqual(*server.Status.Health, health) {
+		return nil
+	}
+
+	server.Status.Health = &health
+	return req.Client.Status().Update(req.Ctx, server)
+}
+
+// recordHealthEvent publishes a health event as a Kubernetes event on the server, alongside the server's health history.
+func (h *Handler) recordHealthEvent(req router.Request, server *v1.MCPServer, eventType types.MCPServerHealthEventType, note string) {
+	if h.recorder == nil {
+		return
+	}
+
+	kind, action := corev1.EventTypeNormal, "Probe"
+	switch eventType {
+	case types.MCPServerHealthEventProbeFailed:
+		kind = corev1.EventTypeWarning
+	case types.MCPServerHealthEventRestarted:
+		action = "Restart"
+	case types.MCPServerHealthEventRestartFailed:
+		kind, action = corev1.EventTypeWarning, "Restart"
+	}
+
+	h.recorder.Eventf(req.Ctx, server, kind, string(eventType), action, "%s", note)
+}
+
+// recordProbeResult updates the health with the result of a probe, and returns true if the server should be restarted.
+func recordProbeResult(health *types.MCPServerHealth, probeErr error, now time.Time) bool {
+	switch {
+	case errors.Is(probeErr, mcp.ErrServerNotRunning):
+		// The server hasn't been deployed yet, or was shutdown because it was idle. Either way, there's nothing to probe.
+		setHealthState(health, types.MCPServerHealthStateNotRunning, now)
+		health.ConsecutiveFailures = 0
+	case probeErr == nil:
+		if health.State == types.MCPServerHealthStateUnhealthy {
+			addHealthEvent(health, types.MCPServerHealthEventRecovered, "", now)
+		}
+		setHealthState(health, types.MCPServerHealthStateHealthy, now)
+		health.ConsecutiveFailures = 0
+		health.AutoRestarts = 0
+	default:
+		setHealthState(health, types.MCPServerHealthStateUnhealthy, now)
+		health.ConsecutiveFailures++
+		addHealthEvent(health, types.MCPServerHealthEventProbeFailed, probeErr.Error(), now)
+
+		return health.ConsecutiveFailures >= healthFailureThreshold && restartAllowed(*health, now)
+	}
+
+	return false
+}
+
+// recordRestart updates the health with the result of an automatic restart.
+func recordRestart(health *types.MCPServerHealth, restartErr error, now time.Time) {
+	if restartErr != nil {
+		addHealthEvent(health, types.MCPServerHealthEventRestartFailed, restartErr.Error(), now)
+		return
+	}
+
+	health.AutoRestarts++
+	health.ConsecutiveFailures = 0
+	health.LastAutoRestartTime = types.NewTime(now)
+	addHealthEvent(health, types.MCPServerHealthEventRestarted, fmt.Sprintf("restarted after %d failed health probes", healthFailureThreshold), now)
+}
+
+func setHealthState(health *types.MCPServerHealth, state types.MCPServerHealthState, now time.Time) {
+	if health.State != state {
+		health.State = state
+		health.LastTransitionTime = types.NewTime(now)
+	}
+}
+
+func addHealthEvent(health *types.MCPServerHealth, eventType types.MCPServerHealthEventType, message string, now time.Time) {
+	health.History = append(health.History, types.MCPServerHealthEvent{
+		Time:    *types.NewTime(now),
+		Type:    eventType,
+		Message: message,
+	})
+	if len(health.History) > maxHealthHistory {
+		health.History = health.History[len(health.History)-maxHealthHistory:]
+	}
+}
+
+// restartAllowed returns true if enough time has passed since the last automatic restart.
+func restartAllowed(health types.MCPServerHealth, now time.Time) bool {
+	if health.AutoRestarts == 0 || health.LastAutoRestartTime == nil {
+		return true
+	}
+
+	backoff := healthRestartBackoff
+	for i := 1; i < health.AutoRestarts && backoff < maxHealthRestartBackoff; i++ {
+		backoff *= 2
+	}
+
+	return now.Sub(health.LastAutoRestartTime.Time) >= min(backoff, maxHealthRestartBackoff)
+}
@@ -0,0 +1,145 @@
+package mcpserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/controller/recorder"
+	"github.com/obot-platform/obot/pkg/mcp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+)
+
+func TestRestartAllowed(t *testing.T) {
+	now := time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name             string
+		autoRestarts     int
+		sinceLastRestart time.Duration
+		expected         bool
+	}{
+		{name: "never restarted", expected: true},
+		{name: "first backoff not elapsed", autoRestarts: 1, sinceLastRestart: 4 * time.Minute},
+		{name: "first backoff elapsed", autoRestarts: 1, sinceLastRestart: 5 * time.Minute, expected: true},
+		{name: "backoff doubles", autoRestarts: 2, sinceLastRestart: 9 * time.Minute},
+		{name: "doubled backoff elapsed", autoRestarts: 2, sinceLastRestart: 10 * time.Minute, expected: true},
+		{name: "backoff is capped", autoRestarts: 10, sinceLastRestart: 59 * time.Minute},
+		{name: "capped backoff elapsed", autoRestarts: 10, sinceLastRestart: time.Hour, expected: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			health := types.MCPServerHealth{AutoRestarts: tt.autoRestarts}
+			if tt.autoRestarts > 0 {
+				health.LastAutoRestartTime = types.NewTime(now.Add(-tt.sinceLastRestart))
+			}
+			assert.Equal(t, tt.expected, restartAllowed(health, now))
+		})
+	}
+}
+
+func TestHealthProbesUntilNext(t *testing.T) {
+	probes := &healthProbes{lastProbes: map[string]time.Time{}}
+	now := time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	assert.Zero(t, probes.untilNext("server", now), "a server that was never probed is due")
+	assert.Equal(t, healthProbeInterval-time.Minute, probes.untilNext("server", now.Add(time.Minute)))
+	assert.Zero(t, probes.untilNext("other", now.Add(time.Minute)), "servers are tracked separately")
+	assert.Zero(t, probes.untilNext("server", now.Add(healthProbeInterval)))
+
+	probes.forget("server")
+	assert.Zero(t, probes.untilNext("server", now.Add(healthProbeInterval+time.Second)), "a forgotten server is due")
+}
+
+func TestRecordProbeResult(t *testing.T) {
+	var (
+		health   types.MCPServerHealth
+		now      = time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+		probeErr = errors.New("connection refused")
+	)
+
+	// The server isn't restarted until the failure threshold is reached.
+	for i := 1; i < healthFailureThreshold; i++ {
+		require.False(t, recordProbeResult(&health, probeErr, now))
+		require.Equal(t, i, health.ConsecutiveFailures)
+	}
+	require.True(t, recordProbeResult(&health, probeErr, now))
+	require.Equal(t, types.MCPServerHealthStateUnhealthy, health.State)
+
+	// A failed restart is recorded, and the server is restarted on the next failed probe.
+	recordRestart(&health, errors.New("no such deployment"), now)
+	require.Equal(t, types.MCPServerHealthEventRestartFailed, health.History[len(health.History)-1].Type)
+	require.Zero(t, health.AutoRestarts)
+	require.True(t, recordProbeResult(&health, probeErr, now))
+
+	recordRestart(&health, nil, now)
+	require.Equal(t, 1, health.AutoRestarts)
+	require.Zero(t, health.ConsecutiveFailures)
+	require.Equal(t, types.MCPServerHealthEventRestarted, health.History[len(health.History)-1].Type)
+
+	// Restarts are backed off when the server doesn't recover.
+	now = now.Add(healthRestartBackoff - time.Second)
+	for range healthFailureThreshold {
+		require.False(t, recordProbeResult(&health, probeErr, now))
+	}
+	now = now.Add(time.Second)
+	require.True(t, recordProbeResult(&health, probeErr, now))
+	recordRestart(&health, nil, now)
+	require.Equal(t, 2, health.AutoRestarts)
+
+	// Recovering resets the restarts.
+	require.False(t, recordProbeResult(&health, nil, now))
+	require.Equal(t, types.MCPServerHealthStateHealthy, health.State)
+	require.Equal(t, types.MCPServerHealthEventRecovered, health.History[len(health.History)-1].Type)
+	require.Zero(t, health.AutoRestarts)
+
+	// A server that isn't running isn't a failure.
+	require.False(t, recordProbeResult(&health, probeErr, now))
+	require.False(t, recordProbeResult(&health, fmt.Errorf("probe: %w", mcp.ErrServerNotRunning), now))
+	require.Equal(t, types.MCPServerHealthStateNotRunning, health.State)
+	require.Zero(t, health.ConsecutiveFailures)
+}
+
+func TestHealthHistoryIsLimited(t *testing.T) {
+	var health types.MCPServerHealth
+	now := time.Date(2025, 4, 26, 9, 0, 0, 0, time.UTC)
+
+	for i := range maxHealthHistory + 5 {
+		addHealthEvent(&health, types.MCPServerHealthEventProbeFailed, fmt.Sprintf("failure %d", i), now)
+	}
+
+	require.Len(t, health.History, maxHealthHistory)
+	assert.Equal(t, fmt.Sprintf("failure %d", maxHealthHistory+4), health.History[maxHealthHistory-1].Message)
+}
+
+func TestRecordHealthEvent(t *testing.T) {
+	server := newMCPServer("unhealthy")
+	c := newFakeClient(t, server)
+	h := &Handler{recorder: recorder.New(c)}
+	req := router.Request{Client: c, Ctx: context.Background(), Object: server}
+
+	h.recordHealthEvent(req, server, types.MCPServerHealthEventProbeFailed, "connection refused")
+	h.recordHealthEvent(req, server, types.MCPServerHealthEventRestarted, "restarted after 3 failed health probes")
+
+	var events eventsv1.EventList
+	require.NoError(t, c.List(context.Background(), &events))
+	require.Len(t, events.Items, 2)
+
+	byReason := map[string]eventsv1.Event{}
+	for _, event := range events.Items {
+		assert.Equal(t, server.Name, event.Regarding.Name)
+		byReason[event.Reason] = event
+	}
+	assert.Equal(t, corev1.EventTypeWarning, byReason["ProbeFailed"].Type)
+	assert.Equal(t, "connection refused", byReason["ProbeFailed"].Note)
+	assert.Equal(t, corev1.EventTypeNormal, byReason["Restarted"].Type)
+	assert.Equal(t, "Restart", byReason["Restarted"].Action)
+}
@@ -13,6 +13,7 @@ import (
 	"github.com/obot-platform/nah/pkg/untriggered"
 	"github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/controller/recorder"
 	"github.com/obot-platform/obot/pkg/mcp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -34,9 +35,11 @@ type Handler struct {
 	multiUserIdleShutdownDelay  time.Duration
 	agentIdleShutdownDelay      time.Duration
 	baseURL                     string
+	healthProbes                *healthProbes
+	recorder                    *recorder.Recorder
 }
 
-func New(gptClient *gptscript.GPTScript, mcpSessionManager *mcp.SessionManager, singleUserIdleShutdownDelay, multiUserIdleShutdownDelay, agentIdleShutdownDelay time.Duration, baseURL string) *Handler {
+func New(gptClient *gptscript.GPTScript, mcpSessionManager *mcp.SessionManager, recorder *recorder.Recorder, singleUserIdleShutdownDelay, multiUserIdleShutdownDelay, agentIdleShutdownDelay time.Duration, baseURL string) *Handler {
 	return &Handler{
 		gptClient:                   gptClient,
 		mcpSessionManager:           mcpSessionManager,
@@ -44,6 +47,10 @@ func New(gptClient *gptscript.GPTScript, mcpSessionManager *mcp.SessionManager,
 		multiUserIdleShutdownDelay:  multiUserIdleShutdownDelay,
 		agentIdleShutdownDelay:      agentIdleShutdownDelay,
 		baseURL:                     baseURL,
+		healthProbes: &healthProbes{
+			lastProbes: map[string]time.Time{},
+		},
+		recorder: recorder,
 	}
 }
 
@@ -138,6 +145,11 @@ func configurationHasDrifted(serverManifest types.MCPServerManifest, entryManife
 		return true, nil
 	}
 
+	// Check tool overrides
+	if hash.Digest(serverManifest.ToolOverrides) != hash.Digest(entryManifest.ToolOverrides) {
+		return true, nil
+	}
+
 	// Check environment
 	return !utils.SlicesEqualIgnoreOrder(serverManifest.Env, entryManifest.Env), nil
 }
@@ -243,6 +255,13 @@ func compositeConfigHasDrifted(serverConfig *types.CompositeRuntimeConfig, entry
 			return true, nil
 		}
 
+		// Compare prompt and resource overrides
+		if serverComponent.Namespace != entryComponent.Namespace ||
+			hash.Digest(serverComponent.PromptOverrides) != hash.Digest(entryComponent.PromptOverrides) ||
+			hash.Digest(serverComponent.ResourceOverrides) != hash.Digest(entryComponent.ResourceOverrides) {
+			return true, nil
+		}
+
 		// Compare manifests
 		drifted, err := configurationHasDrifted(serverComponent.Manifest, entryComponent.Manifest)
 		if err != nil || drifted {
@@ -695,6 +714,9 @@ func (h *Handler) ShutdownIdleServers(req router.Request, resp router.Response)
 	}
 
 	idleInterval := time.Duration(mcpServer.Spec.Manifest.IdleShutdownIntervalHours) * time.Hour
+	if minutes := mcpServer.Spec.Manifest.IdleShutdownIntervalMinutes; minutes != 0 {
+		idleInterval = time.Duration(minutes) * time.Minute
+	}
 	if idleInterval == 0 {
 		idleInterval = h.singleUserIdleShutdownDelay
 		if mcpServer.Spec.NanobotAgentID != "" {
@@ -704,6 +726,14 @@ func (h *Handler) ShutdownIdleServers(req router.Request, resp router.Response)
 		}
 	}
 
+	if mcpServer.Status.IdleShutdownInterval.Duration != idleInterval {
+		// The API server uses the interval to decide how often to update the last request time.
+		mcpServer.Status.IdleShutdownInterval = metav1.Duration{Duration: idleInterval}
+		if err := req.Client.Status().Update(req.Ctx, mcpServer); err != nil {
+			return err
+		}
+	}
+
 	if idleInterval < 0 {
 		// If the idleInterval is negative, then shutdown is disabled for this server.
 		return nil
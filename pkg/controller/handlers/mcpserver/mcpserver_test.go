@@ -437,6 +437,31 @@ func TestConfigurationHasDrifted(t *testing.T) {
 			expectedDrift: false,
 			expectedError: true,
 		},
+		{
+			name: "drift - different tool overrides",
+			serverManifest: types.MCPServerManifest{
+				Name:    "test-server",
+				Runtime: types.RuntimeRemote,
+				RemoteConfig: &types.RemoteRuntimeConfig{
+					URL: "https://example.com/mcp",
+				},
+				ToolOverrides: []types.ToolOverride{{Name: "search_issues", Enabled: true}},
+			},
+			entryManifest: types.MCPServerCatalogEntryManifest{
+				Name:    "test-server",
+				Runtime: types.RuntimeRemote,
+				RemoteConfig: &types.RemoteCatalogConfig{
+					FixedURL: "https://example.com/mcp",
+				},
+				ToolOverrides: []types.ToolOverride{{
+					Name:      "search_issues",
+					Enabled:   true,
+					Arguments: []types.ToolArgumentOverride{{Name: "owner", Value: []byte(`"obot-platform"`)}},
+				}},
+			},
+			expectedDrift: true,
+			expectedError: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -662,6 +687,35 @@ func TestShutdownIdleServersSchedulesRetryUsingServerSpecificInterval(t *testing
 	assert.InDelta(t, (3 * time.Hour).Seconds(), resp.Delay.Seconds(), 1)
 }
 
+func TestShutdownIdleServersPrefersServerSpecificIntervalInMinutes(t *testing.T) {
+	server := newMCPServer("custom-interval-minutes")
+	server.Spec.Manifest.IdleShutdownIntervalHours = 5
+	server.Spec.Manifest.IdleShutdownIntervalMinutes = 15
+	server.Status.LastRequestTime = metav1.NewTime(time.Now().Add(-10 * time.Minute))
+
+	req := router.Request{
+		Client:    newFakeClient(t, server),
+		Ctx:       context.Background(),
+		Object:    server,
+		Namespace: server.Namespace,
+		Name:      server.Name,
+	}
+	resp := &router.ResponseWrapper{}
+
+	err := (&Handler{
+		singleUserIdleShutdownDelay: 15 * time.Hour,
+		multiUserIdleShutdownDelay:  20 * time.Hour,
+		agentIdleShutdownDelay:      25 * time.Hour,
+	}).ShutdownIdleServers(req, resp)
+	require.NoError(t, err)
+
+	assert.InDelta(t, (5 * time.Minute).Seconds(), resp.Delay.Seconds(), 1)
+
+	var updated v1.MCPServer
+	require.NoError(t, req.Client.Get(context.Background(), kclient.ObjectKeyFromObject(server), &updated))
+	assert.Equal(t, 15*time.Minute, updated.Status.IdleShutdownInterval.Duration)
+}
+
 func TestShutdownIdleServersUsesAgentDefaultIdleInterval(t *testing.T) {
 	server := newMCPServer("agent-server")
 	server.Spec.NanobotAgentID = "agent-1"
@@ -0,0 +1,158 @@
+package notification
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/notification"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierror "k8s.io/apimachinery/pkg/api/errors"
+)
+
+var log = logger.Package()
+
+const (
+	maxDeliveryAttempts = 3
+	retryInterval       = time.Minute
+)
+
+type Handler struct {
+	gptClient *gptscript.GPTScript
+	sender    *notification.Sender
+	serverURL string
+}
+
+func New(gptClient *gptscript.GPTScript, serverURL string) *Handler {
+	return &Handler{
+		gptClient: gptClient,
+		sender:    notification.NewSender(),
+		serverURL: strings.TrimSuffix(serverURL, "/"),
+	}
+}
+
+// NotifyWorkflowExecution sends the outcome of a finished task run to the notification channels the task subscribes to.
+func (h *Handler) NotifyWorkflowExecution(req router.Request, resp router.Response) error {
+	we := req.Object.(*v1.WorkflowExecution)
+	if we.Status.WorkflowManifest == nil || len(we.Status.WorkflowManifest.Notifications) == 0 {
+		return nil
+	}
+
+	event, matches := eventForExecution(we)
+	if event == "" {
+		return nil
+	}
+
+	n := h.notificationForExecution(we, event)
+
+	var retry bool
+	for _, sub := range we.Status.WorkflowManifest.Notifications {
+		if !sub.Matches(matches...) {
+			continue
+		}
+
+		idx := deliveryIndex(we.Status.Notifications, sub.ChannelID)
+		if idx == -1 {
+			we.Status.Notifications = append(we.Status.Notifications, types.NotificationDelivery{
+				ChannelID: sub.ChannelID,
+				Event:     event,
+			})
+			idx = len(we.Status.Notifications) - 1
+		}
+
+		delivery := &we.Status.Notifications[idx]
+		if delivery.SentAt != nil || delivery.Attempts >= maxDeliveryAttempts {
+			continue
+		}
+
+		delivery.Attempts++
+		if err := h.deliver(req, we.Namespace, sub.ChannelID, n); err != nil {
+			log.Warnf("Failed to send notification: workflowExecution=%s channel=%s attempt=%d error=%v", we.Name, sub.ChannelID, delivery.Attempts, err)
+			delivery.Error = err.Error()
+			retry = retry || delivery.Attempts < maxDeliveryAttempts
+			continue
+		}
+
+		delivery.Error = ""
+		delivery.SentAt = types.NewTime(time.Now())
+	}
+
+	if retry {
+		resp.RetryAfter(retryInterval)
+	}
+
+	return nil
+}
+
+func (h *Handler) deliver(req router.Request, namespace, channelID string, n notification.Notification) error {
+	var channel v1.NotificationChannel
+	if err := req.Get(&channel, namespace, channelID); apierror.IsNotFound(err) {
+		return fmt.Errorf("notification channel %s not found", channelID)
+	} else if err != nil {
+		return err
+	}
+
+	if channel.Spec.Manifest.Disabled {
+		return fmt.Errorf("notification channel %s is disabled", channelID)
+	}
+
+	cred, err := h.gptClient.RevealCredential(req.Ctx, []string{system.NotificationChannelCredentialContext}, channel.Name)
+	if err != nil && !errors.As(err, &gptscript.ErrNotFound{}) {
+		return fmt.Errorf("failed to reveal notification channel secret: %w", err)
+	}
+
+	return h.sender.Send(req.Ctx, channel.Spec.Manifest, cred.Env["secret"], n)
+}
+
+func (h *Handler) notificationForExecution(we *v1.WorkflowExecution, event types.NotificationEvent) notification.Notification {
+	n := notification.Notification{
+		Event:     event,
+		TaskID:    we.Spec.WorkflowName,
+		TaskName:  we.Status.WorkflowManifest.Name,
+		RunID:     we.Name,
+		State:     we.Status.State,
+		Output:    notification.Excerpt(we.Status.Output),
+		Error:     we.Status.Error,
+		Warning:   we.Status.Warning,
+		StartTime: we.CreationTimestamp.Time,
+	}
+	if we.Status.EndTime != nil {
+		n.EndTime = we.Status.EndTime.Time
+	}
+	if h.serverURL != "" && we.Status.ThreadName != "" {
+		projectID := strings.Replace(we.Spec.ThreadName, system.ThreadPrefix, system.ProjectPrefix, 1)
+		n.Link = fmt.Sprintf("%s/o/%s?thread=%s", h.serverURL, projectID, we.Status.ThreadName)
+	}
+	return n
+}
+
+// eventForExecution returns the event for a finished execution and the subscribed events that should be notified of it.
+// Runs that complete with a warning are sent to channels subscribed to either successes or warnings.
+func eventForExecution(we *v1.WorkflowExecution) (types.NotificationEvent, []types.NotificationEvent) {
+	switch we.Status.State {
+	case types.WorkflowStateError:
+		return types.NotificationEventFailure, []types.NotificationEvent{types.NotificationEventFailure}
+	case types.WorkflowStateComplete:
+		if we.Status.Warning != "" {
+			return types.NotificationEventWarning, []types.NotificationEvent{types.NotificationEventWarning, types.NotificationEventSuccess}
+		}
+		return types.NotificationEventSuccess, []types.NotificationEvent{types.NotificationEventSuccess}
+	default:
+		return "", nil
+	}
+}
+
+func deliveryIndex(deliveries []types.NotificationDelivery, channelID string) int {
+	for i, d := range deliveries {
+		if d.ChannelID == channelID {
+			return i
+		}
+	}
+	return -1
+}
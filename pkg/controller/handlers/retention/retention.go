@@ -5,6 +5,7 @@ import (
 
 	"github.com/obot-platform/nah/pkg/router"
 	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/legalhold"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
@@ -51,6 +52,19 @@ func RunRetention(policy time.Duration) func(req router.Request, resp router.Res
 		}
 
 		if !thread.Status.LastUsedTime.IsZero() && time.Since(thread.Status.LastUsedTime.Time) > policy {
+			holds, err := legalhold.Active(req.Ctx, req.Client)
+			if err != nil {
+				return err
+			}
+			if held, err := holds.PreventsDeletion(req.Ctx, req.Client, thread); err != nil {
+				return err
+			} else if held {
+				// Check again later so that the thread is deleted once the hold is released.
+				log.Infof("retention: skipping thread %s because it is under legal hold", thread.Name)
+				resp.RetryAfter(time.Hour)
+				return nil
+			}
+
 			log.Infof("retention: deleting thread %s/%s", thread.Namespace, thread.Name)
 			return req.Client.Delete(req.Ctx, thread)
 		}
@@ -0,0 +1,129 @@
+package runs
+
+import (
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/gz"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+)
+
+// maxChatMessageContentLength caps how much of a single message is indexed, tool outputs in particular can be large.
+const maxChatMessageContentLength = 64 * 1024
+
+// IndexChatMessages stores the messages and tool calls of a completed chat run so that users can search their chat history.
+func (h *Handler) IndexChatMessages(req router.Request, _ router.Response) error {
+	run := req.Object.(*v1.Run)
+	if run.Status.State != v1.Continue && !gptscript.RunState(run.Status.State).IsTerminal() {
+		return nil
+	}
+
+	var thread v1.Thread
+	if err := req.Get(&thread, run.Namespace, run.Spec.ThreadName); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+
+	if thread.Spec.Project || thread.Spec.ParentThreadName == "" || thread.Spec.SystemTask || thread.Spec.Ephemeral ||
+		thread.Spec.WorkflowName != "" || thread.Spec.UserID == "" {
+		return nil
+	}
+
+	if indexed, err := h.gatewayClient.HasChatMessages(req.Ctx, run.Name); err != nil || indexed {
+		return err
+	}
+
+	var (
+		prg    gptscript.Program
+		frames = gptscript.CallFrames{}
+		output = run.Status.Output
+	)
+	runState, err := h.gatewayClient.RunState(req.Ctx, run.Namespace, run.Name)
+	if err != nil && !apierrors.IsNotFound(err) {
+		return err
+	}
+	if runState != nil {
+		if len(runState.Program) != 0 {
+			if err := gz.Decompress(&prg, runState.Program); err != nil {
+				return err
+			}
+		}
+		if len(runState.CallFrame) != 0 {
+			if err := gz.Decompress(&frames, runState.CallFrame); err != nil {
+				return err
+			}
+		}
+		// The run status only holds the beginning of long outputs.
+		if len(runState.Output) != 0 {
+			if err := gz.Decompress(&output, runState.Output); err != nil {
+				return err
+			}
+		}
+	}
+
+	newMessage := func(role, toolName, content string) types.ChatMessage {
+		if len(content) > maxChatMessageContentLength {
+			content = strings.ToValidUTF8(content[:maxChatMessageContentLength], "")
+		}
+		return types.ChatMessage{
+			RunName:     run.Name,
+			ThreadName:  thread.Name,
+			ProjectName: thread.Spec.ParentThreadName,
+			UserID:      thread.Spec.UserID,
+			Role:        role,
+			ToolName:    toolName,
+			Content:     content,
+			CreatedAt:   run.CreationTimestamp.Time,
+		}
+	}
+
+	var messages []types.ChatMessage
+	if input := strings.TrimSpace(run.Spec.Input); input != "" {
+		messages = append(messages, newMessage(types.ChatMessageRoleUser, "", input))
+	}
+
+	parent := frames.ParentCallFrame()
+	for _, out := range parent.Output {
+		for _, callID := range slices.Sorted(maps.Keys(out.SubCalls)) {
+			subCall := out.SubCalls[callID]
+			tool, ok := prg.ToolSet[subCall.ToolID]
+			if !ok {
+				continue
+			}
+
+			content := subCall.Input
+			if frame, ok := frames[callID]; ok && len(frame.Output) > 0 {
+				content += "\n" + frame.Output[len(frame.Output)-1].Content
+			}
+			if content = strings.TrimSpace(content); content == "" {
+				continue
+			}
+
+			message := newMessage(types.ChatMessageRoleTool, tool.Name, content)
+			if frame, ok := frames[callID]; ok && !frame.Start.IsZero() {
+				message.CreatedAt = frame.Start
+			}
+			messages = append(messages, message)
+		}
+	}
+
+	if output = strings.TrimSpace(output); output != "" {
+		message := newMessage(types.ChatMessageRoleAssistant, "", output)
+		if !run.Status.EndTime.IsZero() {
+			message.CreatedAt = run.Status.EndTime.Time
+		}
+		messages = append(messages, message)
+	}
+
+	if len(messages) == 0 {
+		return nil
+	}
+
+	return h.gatewayClient.ReplaceChatMessages(req.Ctx, run.Name, messages)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/obot-platform/nah/pkg/router"
 	gclient "github.com/obot-platform/obot/pkg/gateway/client"
 	"github.com/obot-platform/obot/pkg/invoke"
+	"github.com/obot-platform/obot/pkg/legalhold"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +33,9 @@ func New(invoker *invoke.Invoker, backend backend.Backend, gatewayClient *gclien
 
 func (h *Handler) DeleteRunState(req router.Request, _ router.Response) error {
 	run := req.Object.(*v1.Run)
+	if err := h.gatewayClient.DeleteChatMessages(req.Ctx, run.Name); err != nil {
+		return err
+	}
 	if run.Status.ExternalCall != nil {
 		if err := client.IgnoreNotFound(h.gatewayClient.DeleteRunState(req.Ctx, run.Namespace,
 			v1.RunStateNameWithExternalID(run.Name, run.Status.ExternalCall.ID))); err != nil {
@@ -86,11 +90,35 @@ func (h *Handler) Resume(req router.Request, _ router.Response) error {
 	return h.invoker.Resume(req.Ctx, h.gptClient, req.Client, &thread, run)
 }
 
-func (h *Handler) DeleteFinished(req router.Request, _ router.Response) error {
+func (h *Handler) DeleteFinished(req router.Request, resp router.Response) error {
 	run := req.Object.(*v1.Run)
 	if run.Status.State == v1.Finished && time.Since(run.Status.EndTime.Time) > 12*time.Hour || (run.Spec.Synchronous && run.Status.State == "" && time.Since(run.CreationTimestamp.Time) > 12*time.Hour) {
 		// These will be system tasks. Everything is a chat and finished with Continue status
+		if held, err := heldRun(req, run); err != nil {
+			return err
+		} else if held {
+			// Check again later so that the run is deleted once the hold is released.
+			resp.RetryAfter(time.Hour)
+			return nil
+		}
 		return req.Delete(run)
 	}
 	return nil
 }
+
+// heldRun returns whether the run's thread, its project or its user is under legal hold.
+func heldRun(req router.Request, run *v1.Run) (bool, error) {
+	holds, err := legalhold.Active(req.Ctx, req.Client)
+	if err != nil || holds.Empty() || run.Spec.ThreadName == "" {
+		return false, err
+	}
+
+	var thread v1.Thread
+	if err := req.Get(&thread, run.Namespace, run.Spec.ThreadName); apierrors.IsNotFound(err) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return holds.HoldsThread(&thread), nil
+}
@@ -0,0 +1,77 @@
+package runs
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func finishedRun(name, threadName string) *v1.Run {
+	return &v1.Run{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.DefaultNamespace},
+		Spec:       v1.RunSpec{ThreadName: threadName},
+		Status: v1.RunStatus{
+			State:   v1.Finished,
+			EndTime: metav1.NewTime(time.Now().Add(-13 * time.Hour)),
+		},
+	}
+}
+
+func TestDeleteFinishedSkipsHeldRuns(t *testing.T) {
+	var (
+		ctx      = context.Background()
+		heldRun  = finishedRun("r1held", "t1held")
+		otherRun = finishedRun("r1other", "t1other")
+	)
+	c := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithObjects(
+			&v1.Thread{
+				ObjectMeta: metav1.ObjectMeta{Name: "t1held", Namespace: system.DefaultNamespace},
+				Spec:       v1.ThreadSpec{UserID: "2"},
+			},
+			&v1.Thread{
+				ObjectMeta: metav1.ObjectMeta{Name: "t1other", Namespace: system.DefaultNamespace},
+				Spec:       v1.ThreadSpec{UserID: "1"},
+			},
+			&v1.LegalHold{
+				ObjectMeta: metav1.ObjectMeta{Name: "lh1", Namespace: system.DefaultNamespace},
+				Spec:       v1.LegalHoldSpec{Manifest: types.LegalHoldManifest{UserIDs: []string{"2"}}},
+			},
+			heldRun,
+			otherRun,
+		).
+		Build()
+
+	h := &Handler{}
+	for _, run := range []*v1.Run{heldRun, otherRun} {
+		resp := &router.ResponseWrapper{}
+		require.NoError(t, h.DeleteFinished(router.Request{
+			Client:    c,
+			Object:    run,
+			Ctx:       ctx,
+			Namespace: run.Namespace,
+			Name:      run.Name,
+		}, resp))
+
+		err := c.Get(ctx, kclient.ObjectKeyFromObject(run), &v1.Run{})
+		if run == heldRun {
+			require.NoError(t, err, "the held run should be kept")
+			assert.Equal(t, time.Hour, resp.Delay)
+		} else {
+			assert.True(t, apierrors.IsNotFound(err), "the run should be deleted")
+		}
+	}
+}
@@ -0,0 +1,76 @@
+package searchindex
+
+import (
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/pkg/search"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+// embedRetryInterval is how long to wait before indexing a document again when it couldn't be embedded, for example
+// because the text-embedding model isn't configured yet.
+const embedRetryInterval = 15 * time.Minute
+
+type Handler struct {
+	index *search.Index
+}
+
+func New(index *search.Index) *Handler {
+	return &Handler{
+		index: index,
+	}
+}
+
+// IndexSkill adds valid skills to the search index, and removes invalid ones.
+func (h *Handler) IndexSkill(req router.Request, resp router.Response) error {
+	skill := req.Object.(*v1.Skill)
+	if !skill.Status.Valid {
+		return h.index.Remove(req.Ctx, search.KindSkill, skill.Name)
+	}
+
+	embedded, err := h.index.Add(req.Ctx, search.Document{
+		Kind:        search.KindSkill,
+		ID:          skill.Name,
+		Name:        skill.Spec.Name,
+		DisplayName: skill.Spec.DisplayName,
+		Description: skill.Spec.Description,
+		Body:        skill.Spec.Body,
+	})
+	if err != nil {
+		return err
+	}
+	if !embedded {
+		resp.RetryAfter(embedRetryInterval)
+	}
+	return nil
+}
+
+func (h *Handler) RemoveSkill(req router.Request, _ router.Response) error {
+	return h.index.Remove(req.Ctx, search.KindSkill, req.Object.GetName())
+}
+
+// IndexPublishedArtifact adds the latest published version of artifacts that isn't yanked to the search index, and
+// removes artifacts without one. Artifacts are indexed again whenever a version is published, approved, rejected, or
+// yanked.
+func (h *Handler) IndexPublishedArtifact(req router.Request, resp router.Response) error {
+	artifact := req.Object.(*v1.PublishedArtifact)
+
+	doc, ok := search.PublishedArtifactDocument(artifact)
+	if !ok {
+		return h.index.Remove(req.Ctx, search.KindPublishedArtifact, artifact.Name)
+	}
+
+	embedded, err := h.index.Add(req.Ctx, doc)
+	if err != nil {
+		return err
+	}
+	if !embedded {
+		resp.RetryAfter(embedRetryInterval)
+	}
+	return nil
+}
+
+func (h *Handler) RemovePublishedArtifact(req router.Request, _ router.Response) error {
+	return h.index.Remove(req.Ctx, search.KindPublishedArtifact, req.Object.GetName())
+}
@@ -14,6 +14,7 @@ import (
 
 	"github.com/obot-platform/nah/pkg/name"
 	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/search"
 	"github.com/obot-platform/obot/pkg/skillformat"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	"github.com/obot-platform/obot/pkg/system"
@@ -104,7 +105,7 @@ func buildSkill(dirPath, relPath string, repo *v1.SkillRepository, commitSHA str
 
 	// Parse what we can even if the file is oversized, so we can populate the
 	// skill record with whatever metadata is available.
-	fm, _, parseErr := skillformat.ParseFrontmatter(string(content))
+	fm, body, parseErr := skillformat.ParseFrontmatter(string(content))
 	skillName := fm.Name
 	if skillName == "" {
 		skillName = filepath.Base(dirPath)
@@ -134,6 +135,7 @@ func buildSkill(dirPath, relPath string, repo *v1.SkillRepository, commitSHA str
 			RepoRef:      repo.Spec.Ref,
 			CommitSHA:    commitSHA,
 			RelativePath: relPath,
+			Body:         search.TruncateBody(body),
 		},
 		Status: v1.SkillStatus{
 			LastIndexedAt: indexedAt,
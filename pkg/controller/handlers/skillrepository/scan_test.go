@@ -161,6 +161,7 @@ func TestBuildSkill(t *testing.T) {
 		assert.Equal(t, "main", skill.Spec.RepoRef)
 		assert.Equal(t, commitSHA, skill.Spec.CommitSHA)
 		assert.Equal(t, "my-skill", skill.Spec.RelativePath)
+		assert.Contains(t, skill.Spec.Body, "Body.")
 		assert.NotEmpty(t, skill.Spec.InstallHash)
 		assert.Equal(t, "default", skill.Namespace)
 	})
@@ -0,0 +1,504 @@
+package userdataexport
+
+import (
+	"archive/zip"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/logger"
+	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/storage/blob"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var log = logger.Package()
+
+// TTL is how long an export is kept after it completes or fails.
+const TTL = 7 * 24 * time.Hour
+
+const auditLogBatchSize = 1000
+
+type Handler struct {
+	gptClient     *gptscript.GPTScript
+	gatewayClient *gclient.Client
+	blobStore     blob.BlobStore
+	bucket        string
+}
+
+func NewHandler(gptClient *gptscript.GPTScript, gatewayClient *gclient.Client, blobStore blob.BlobStore, bucket string) *Handler {
+	return &Handler{
+		gptClient:     gptClient,
+		gatewayClient: gatewayClient,
+		blobStore:     blobStore,
+		bucket:        bucket,
+	}
+}
+
+// ExpiresAt returns when the export and its archive are deleted.
+func ExpiresAt(export *v1.UserDataExport) time.Time {
+	if export.Status.CompletedAt != nil {
+		return export.Status.CompletedAt.Add(TTL)
+	}
+	return export.CreationTimestamp.Add(TTL)
+}
+
+func (h *Handler) Export(req router.Request, resp router.Response) error {
+	export := req.Object.(*v1.UserDataExport)
+
+	if export.Status.State == types.UserDataExportStateCompleted || export.Status.State == types.UserDataExportStateFailed {
+		if expiresAt := ExpiresAt(export); time.Now().Before(expiresAt) {
+			resp.RetryAfter(time.Until(expiresAt))
+			return nil
+		}
+
+		log.Infof("Deleting expired user data export: export=%s userID=%s", export.Name, export.Spec.UserID)
+		if export.Status.Key != "" {
+			if err := h.blobStore.Delete(req.Ctx, h.bucket, export.Status.Key); err != nil {
+				return fmt.Errorf("failed to delete user data export archive: %w", err)
+			}
+		}
+		return kclient.IgnoreNotFound(req.Delete(export))
+	}
+
+	// An export that was interrupted, for example by a restart, starts over.
+	export.Status.State = types.UserDataExportStateRunning
+	export.Status.StartedAt = &metav1.Time{Time: time.Now()}
+	if err := req.Client.Status().Update(req.Ctx, export); err != nil {
+		return fmt.Errorf("failed to update export status: %w", err)
+	}
+
+	log.Infof("Exporting user data: export=%s userID=%s requestedBy=%s", export.Name, export.Spec.UserID, export.Spec.RequestedBy)
+	key := "user-data-exports/" + export.Name + ".zip"
+	size, err := h.upload(req.Ctx, key, func(zw *zip.Writer) error {
+		return h.writeArchive(req.Ctx, req.Client, zw, export.Spec.UserID)
+	})
+
+	export.Status.CompletedAt = &metav1.Time{Time: time.Now()}
+	if err != nil {
+		export.Status.State = types.UserDataExportStateFailed
+		export.Status.Error = err.Error()
+		if statusErr := req.Client.Status().Update(req.Ctx, export); statusErr != nil {
+			return fmt.Errorf("failed to update failed export status: %w", statusErr)
+		}
+		return fmt.Errorf("user data export failed: %w", err)
+	}
+
+	export.Status.State = types.UserDataExportStateCompleted
+	export.Status.Key = key
+	export.Status.Size = size
+	resp.RetryAfter(TTL)
+	return req.Client.Status().Update(req.Ctx, export)
+}
+
+// upload streams the archive written by write to the blob store and returns its size.
+func (h *Handler) upload(ctx context.Context, key string, write func(*zip.Writer) error) (int64, error) {
+	pr, pw := io.Pipe()
+
+	uploadErrCh := make(chan error, 1)
+	go func() {
+		err := h.blobStore.Upload(ctx, h.bucket, key, pr)
+		// Unblock the writer if the upload stopped reading early.
+		if err != nil {
+			_ = pr.CloseWithError(err)
+		} else {
+			_ = pr.Close()
+		}
+		uploadErrCh <- err
+	}()
+
+	counter := &countingWriter{w: pw}
+	zw := zip.NewWriter(counter)
+	err := write(zw)
+	if err == nil {
+		err = zw.Close()
+	}
+	_ = pw.CloseWithError(err)
+
+	if uploadErr := <-uploadErrCh; err == nil && uploadErr != nil {
+		err = fmt.Errorf("failed to upload archive: %w", uploadErr)
+	}
+	return counter.n, err
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	n, err := c.w.Write(p)
+	c.n += int64(n)
+	return n, err
+}
+
+type profile struct {
+	User       *types.User           `json:"user"`
+	Identities []identity            `json:"identities"`
+	GroupIDs   []string              `json:"groupIDs"`
+	APIKeys    []gatewaytypes.APIKey `json:"apiKeys"`
+}
+
+type identity struct {
+	AuthProviderName      string `json:"authProviderName"`
+	AuthProviderNamespace string `json:"authProviderNamespace"`
+	ProviderUsername      string `json:"providerUsername"`
+	ProviderUserID        string `json:"providerUserID"`
+	Email                 string `json:"email,omitempty"`
+	IconURL               string `json:"iconURL,omitempty"`
+}
+
+type thread struct {
+	ID          string     `json:"id"`
+	Name        string     `json:"name,omitempty"`
+	Description string     `json:"description,omitempty"`
+	Project     bool       `json:"project,omitempty"`
+	ProjectID   string     `json:"projectID,omitempty"`
+	Created     types.Time `json:"created"`
+	Runs        []run      `json:"runs,omitempty"`
+}
+
+type run struct {
+	ID      string     `json:"id"`
+	Input   string     `json:"input,omitempty"`
+	Output  string     `json:"output,omitempty"`
+	Error   string     `json:"error,omitempty"`
+	Created types.Time `json:"created"`
+}
+
+func (h *Handler) writeArchive(ctx context.Context, c kclient.Client, zw *zip.Writer, userID string) error {
+	id, err := strconv.ParseUint(userID, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid user ID %q: %w", userID, err)
+	}
+
+	user, err := h.gatewayClient.UserByIDIncludeDeleted(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	p := profile{User: gatewaytypes.ConvertUser(user, false, "")}
+
+	identities, err := h.gatewayClient.FindIdentitiesForUser(ctx, uint(id))
+	if err != nil {
+		return fmt.Errorf("failed to get identities: %w", err)
+	}
+	for _, i := range identities {
+		p.Identities = append(p.Identities, identity{
+			AuthProviderName:      i.AuthProviderName,
+			AuthProviderNamespace: i.AuthProviderNamespace,
+			ProviderUsername:      i.ProviderUsername,
+			ProviderUserID:        i.ProviderUserID,
+			Email:                 i.Email,
+			IconURL:               i.IconURL,
+		})
+	}
+
+	if p.GroupIDs, err = h.gatewayClient.ListGroupIDsForUser(ctx, uint(id)); err != nil {
+		return fmt.Errorf("failed to get group memberships: %w", err)
+	}
+	if p.APIKeys, err = h.gatewayClient.ListAPIKeys(ctx, uint(id)); err != nil {
+		return fmt.Errorf("failed to get API keys: %w", err)
+	}
+
+	if err := writeJSON(zw, "profile.json", p); err != nil {
+		return err
+	}
+
+	activities, err := h.gatewayClient.TokenUsageForUser(ctx, userID, time.Time{}, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to get token usage: %w", err)
+	}
+	usage := make([]types.TokenUsage, 0, len(activities))
+	for _, activity := range activities {
+		usage = append(usage, gatewaytypes.ConvertTokenActivity(activity))
+	}
+	if err := writeJSON(zw, "token-usage.json", usage); err != nil {
+		return err
+	}
+
+	if err := h.writeThreads(ctx, c, zw, userID); err != nil {
+		return err
+	}
+
+	if err := h.writeAuditLogs(ctx, zw, userID); err != nil {
+		return err
+	}
+
+	if err := h.writeMCPUsage(ctx, zw, userID); err != nil {
+		return err
+	}
+
+	return h.writeMessagePolicyViolations(ctx, zw, userID)
+}
+
+func (h *Handler) writeThreads(ctx context.Context, c kclient.Client, zw *zip.Writer, userID string) error {
+	var threads v1.ThreadList
+	if err := c.List(ctx, &threads, kclient.InNamespace(system.DefaultNamespace), kclient.MatchingFields{
+		"spec.userUID": userID,
+	}); err != nil {
+		return fmt.Errorf("failed to list threads: %w", err)
+	}
+
+	for _, t := range threads.Items {
+		if t.Spec.SystemTask || !t.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		exported := thread{
+			ID:          t.Name,
+			Name:        t.Spec.Manifest.Name,
+			Description: t.Spec.Manifest.Description,
+			Project:     t.Spec.Project,
+			Created:     *types.NewTime(t.CreationTimestamp.Time),
+		}
+		if t.Spec.Project {
+			exported.ID = strings.Replace(t.Name, system.ThreadPrefix, system.ProjectPrefix, 1)
+		} else if t.Spec.ParentThreadName != "" {
+			exported.ProjectID = strings.Replace(t.Spec.ParentThreadName, system.ThreadPrefix, system.ProjectPrefix, 1)
+		}
+
+		runs, err := listRuns(ctx, c, &t)
+		if err != nil {
+			return err
+		}
+		exported.Runs = runs
+
+		if err := writeJSON(zw, path.Join("threads", exported.ID+".json"), exported); err != nil {
+			return err
+		}
+
+		if t.Status.WorkspaceID != "" {
+			if err := h.writeWorkspaceFiles(ctx, zw, t.Status.WorkspaceID, "files/", path.Join("files", exported.ID)); err != nil {
+				return err
+			}
+		}
+
+		if !t.Spec.Project {
+			continue
+		}
+
+		var memorySet v1.MemorySet
+		if err := c.Get(ctx, kclient.ObjectKey{Namespace: t.Namespace, Name: t.Name}, &memorySet); err == nil {
+			if err := writeJSON(zw, path.Join("memories", exported.ID+".json"), memorySet.Spec.Memories); err != nil {
+				return err
+			}
+		} else if !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to get memories of project %s: %w", exported.ID, err)
+		}
+
+		if len(t.Status.KnowledgeSetNames) > 0 {
+			if err := h.writeKnowledgeFiles(ctx, c, zw, t.Namespace, t.Status.KnowledgeSetNames[0], path.Join("knowledge", exported.ID)); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func listRuns(ctx context.Context, c kclient.Client, t *v1.Thread) ([]run, error) {
+	var runs []run
+	for runName := t.Status.LastRunName; runName != ""; {
+		var r v1.Run
+		if err := c.Get(ctx, kclient.ObjectKey{Namespace: t.Namespace, Name: runName}, &r); apierrors.IsNotFound(err) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("failed to get run %s: %w", runName, err)
+		}
+
+		runs = append(runs, run{
+			ID:      r.Name,
+			Input:   r.Spec.Input,
+			Output:  r.Status.Output,
+			Error:   r.Status.Error,
+			Created: *types.NewTime(r.CreationTimestamp.Time),
+		})
+		runName = r.Spec.PreviousRunName
+	}
+
+	slices.Reverse(runs)
+	return runs, nil
+}
+
+func (h *Handler) writeWorkspaceFiles(ctx context.Context, zw *zip.Writer, workspaceID, prefix, dir string) error {
+	files, err := h.gptClient.ListFilesInWorkspace(ctx, gptscript.ListFilesInWorkspaceOptions{
+		WorkspaceID: workspaceID,
+		Prefix:      prefix,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to list files in workspace %s: %w", workspaceID, err)
+	}
+
+	for _, file := range files {
+		if err := h.writeWorkspaceFile(ctx, zw, workspaceID, file, path.Join(dir, strings.TrimPrefix(file, prefix))); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) writeKnowledgeFiles(ctx context.Context, c kclient.Client, zw *zip.Writer, namespace, knowledgeSetName, dir string) error {
+	var knowledgeSet v1.KnowledgeSet
+	if err := c.Get(ctx, kclient.ObjectKey{Namespace: namespace, Name: knowledgeSetName}, &knowledgeSet); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get knowledge set %s: %w", knowledgeSetName, err)
+	}
+
+	var ws v1.Workspace
+	if err := c.Get(ctx, kclient.ObjectKey{Namespace: namespace, Name: knowledgeSet.Status.WorkspaceName}, &ws); apierrors.IsNotFound(err) {
+		return nil
+	} else if err != nil {
+		return fmt.Errorf("failed to get workspace of knowledge set %s: %w", knowledgeSetName, err)
+	}
+
+	var files v1.KnowledgeFileList
+	if err := c.List(ctx, &files, kclient.InNamespace(namespace), kclient.MatchingFields{
+		"spec.knowledgeSetName": knowledgeSetName,
+	}); err != nil {
+		return fmt.Errorf("failed to list knowledge files: %w", err)
+	}
+
+	for _, file := range files.Items {
+		// Files from knowledge sources are fetched from elsewhere, so only uploaded files are the user's data.
+		if file.Spec.KnowledgeSourceName != "" {
+			continue
+		}
+		if err := h.writeWorkspaceFile(ctx, zw, ws.Status.WorkspaceID, file.Spec.FileName, path.Join(dir, file.Spec.FileName)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) writeWorkspaceFile(ctx context.Context, zw *zip.Writer, workspaceID, file, name string) error {
+	// Don't let file names escape their directory when the archive is extracted.
+	name = path.Clean("/" + name)[1:]
+
+	data, err := h.gptClient.ReadFileInWorkspace(ctx, file, gptscript.ReadFileInWorkspaceOptions{WorkspaceID: workspaceID})
+	if err != nil {
+		if nfe := (*gptscript.NotFoundInWorkspaceError)(nil); errors.As(err, &nfe) {
+			return nil
+		}
+		return fmt.Errorf("failed to read file %q: %w", file, err)
+	}
+
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+func (h *Handler) writeAuditLogs(ctx context.Context, zw *zip.Writer, userID string) error {
+	w, err := zw.Create("mcp-audit-logs.jsonl")
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for offset := 0; ; {
+		logs, _, err := h.gatewayClient.GetMCPAuditLogs(ctx, gclient.MCPAuditLogOptions{
+			UserID:                 []string{userID},
+			WithRequestAndResponse: true,
+			SortBy:                 "created_at",
+			SortOrder:              "asc",
+			Limit:                  auditLogBatchSize,
+			Offset:                 offset,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to get audit logs: %w", err)
+		}
+
+		for _, l := range logs {
+			if err := enc.Encode(gatewaytypes.ConvertMCPAuditLog(l)); err != nil {
+				return err
+			}
+		}
+
+		if len(logs) < auditLogBatchSize {
+			return nil
+		}
+		offset += len(logs)
+	}
+}
+
+func (h *Handler) writeMCPUsage(ctx context.Context, zw *zip.Writer, userID string) error {
+	rollups, err := h.gatewayClient.MCPCallRollupsForUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get MCP usage: %w", err)
+	}
+
+	w, err := zw.Create("mcp-usage.jsonl")
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for _, r := range rollups {
+		if err := enc.Encode(r); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (h *Handler) writeMessagePolicyViolations(ctx context.Context, zw *zip.Writer, userID string) error {
+	violations, err := h.gatewayClient.MessagePolicyViolationsForUser(ctx, userID)
+	if err != nil {
+		return fmt.Errorf("failed to get message policy violations: %w", err)
+	}
+
+	w, err := zw.Create("message-policy-violations.jsonl")
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	for _, v := range violations {
+		if err := enc.Encode(types.MessagePolicyViolation{
+			ID:                   v.ID,
+			CreatedAt:            *types.NewTime(v.CreatedAt),
+			UserID:               v.UserID,
+			PolicyID:             v.PolicyID,
+			PolicyName:           v.PolicyName,
+			PolicyDefinition:     v.PolicyDefinition,
+			Direction:            v.Direction,
+			ViolationExplanation: v.ViolationExplanation,
+			BlockedContent:       v.BlockedContent,
+			ProjectID:            v.ProjectID,
+			ThreadID:             v.ThreadID,
+		}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func writeJSON(zw *zip.Writer, name string, v any) error {
+	w, err := zw.Create(name)
+	if err != nil {
+		return err
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(v)
+}
@@ -0,0 +1,258 @@
+package userdataexport
+
+import (
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/apiclient/types"
+	gclient "github.com/obot-platform/obot/pkg/gateway/client"
+	gatewaydb "github.com/obot-platform/obot/pkg/gateway/db"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	sservices "github.com/obot-platform/obot/pkg/storage/services"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// memoryBlobStore keeps uploaded archives in memory, or fails uploads with uploadErr.
+type memoryBlobStore struct {
+	lock      sync.Mutex
+	objects   map[string][]byte
+	deleted   []string
+	uploadErr error
+}
+
+func (m *memoryBlobStore) Upload(_ context.Context, _, key string, data io.Reader) error {
+	if m.uploadErr != nil {
+		return m.uploadErr
+	}
+	b, err := io.ReadAll(data)
+	if err != nil {
+		return err
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	m.objects[key] = b
+	return nil
+}
+
+func (m *memoryBlobStore) Download(_ context.Context, _, key string) (io.ReadCloser, error) {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	return io.NopCloser(bytes.NewReader(m.objects[key])), nil
+}
+
+func (m *memoryBlobStore) Delete(_ context.Context, _, key string) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+	delete(m.objects, key)
+	m.deleted = append(m.deleted, key)
+	return nil
+}
+
+func (m *memoryBlobStore) Test(context.Context) error {
+	return nil
+}
+
+func newTestHandler(t *testing.T, blobStore *memoryBlobStore, objects ...kclient.Object) (*Handler, kclient.Client, *gorm.DB) {
+	t.Helper()
+
+	services, err := sservices.New(sservices.Config{
+		DSN: "sqlite://:memory:",
+	})
+	require.NoError(t, err)
+
+	db, err := gatewaydb.New(services.DB.DB, services.DB.SQLDB, true)
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate())
+
+	c := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithStatusSubresource(&v1.UserDataExport{}).
+		WithIndex(&v1.Thread{}, "spec.userUID", func(obj kclient.Object) []string {
+			return []string{obj.(*v1.Thread).Spec.UserID}
+		}).
+		WithObjects(objects...).
+		Build()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+
+	gatewayClient := gclient.New(ctx, db, c, nil, nil, nil, time.Hour, 100, 0)
+	return NewHandler(nil, gatewayClient, blobStore, "bucket"), c, services.DB.DB
+}
+
+func newExport(userID string) *v1.UserDataExport {
+	return &v1.UserDataExport{
+		ObjectMeta: metav1.ObjectMeta{Name: "ude1" + userID, Namespace: system.DefaultNamespace},
+		Spec:       v1.UserDataExportSpec{UserID: userID, RequestedBy: "1"},
+	}
+}
+
+func newThread(name, userID string) *v1.Thread {
+	return &v1.Thread{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.DefaultNamespace},
+		Spec:       v1.ThreadSpec{UserID: userID},
+	}
+}
+
+func request(c kclient.Client, export *v1.UserDataExport) router.Request {
+	return router.Request{
+		Client:    c,
+		Object:    export,
+		Ctx:       context.Background(),
+		Namespace: export.Namespace,
+		Name:      export.Name,
+	}
+}
+
+// readArchive returns the contents of the files in the archive by name.
+func readArchive(t *testing.T, data []byte) map[string][]byte {
+	t.Helper()
+
+	zr, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	require.NoError(t, err)
+
+	files := make(map[string][]byte, len(zr.File))
+	for _, f := range zr.File {
+		r, err := f.Open()
+		require.NoError(t, err)
+		files[f.Name], err = io.ReadAll(r)
+		require.NoError(t, err)
+		require.NoError(t, r.Close())
+	}
+	return files
+}
+
+// userIDs returns the userID of each line of a JSON lines file.
+func userIDs(t *testing.T, data []byte) []string {
+	t.Helper()
+
+	var ids []string
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		var line struct {
+			UserID string `json:"userID"`
+		}
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &line))
+		ids = append(ids, line.UserID)
+	}
+	require.NoError(t, scanner.Err())
+	return ids
+}
+
+func TestExportArchive(t *testing.T) {
+	blobStore := &memoryBlobStore{objects: map[string][]byte{}}
+	export := newExport("1")
+	h, c, db := newTestHandler(t, blobStore, export, newThread("t1mine", "1"), newThread("t1theirs", "2"))
+
+	created := time.Now().Add(-time.Hour)
+	for _, row := range []any{
+		&gatewaytypes.User{ID: 1, Username: "alice", HashedUsername: "alice", Email: "alice@example.com"},
+		&gatewaytypes.User{ID: 2, Username: "bob", HashedUsername: "bob", Email: "bob@example.com"},
+		&gatewaytypes.RunTokenActivity{CreatedAt: created, UserID: "1", Model: "model", TotalTokens: 10},
+		&gatewaytypes.RunTokenActivity{CreatedAt: created, UserID: "2", Model: "model", TotalTokens: 20},
+		&gatewaytypes.MCPAuditLog{CreatedAt: created, UserID: "1", MCPID: "ms1mine"},
+		&gatewaytypes.MCPAuditLog{CreatedAt: created, UserID: "2", MCPID: "ms1theirs"},
+		&gatewaytypes.MCPCallRollup{BucketStart: created.Truncate(time.Hour), UserID: "1", MCPID: "ms1mine", CallCount: 1},
+		&gatewaytypes.MCPCallRollup{BucketStart: created.Truncate(time.Hour), UserID: "2", MCPID: "ms1theirs", CallCount: 1},
+		&gatewaytypes.MessagePolicyViolation{CreatedAt: created, UserID: "1", PolicyID: "mp1"},
+		&gatewaytypes.MessagePolicyViolation{CreatedAt: created, UserID: "2", PolicyID: "mp1"},
+	} {
+		require.NoError(t, db.Create(row).Error)
+	}
+
+	resp := &router.ResponseWrapper{}
+	require.NoError(t, h.Export(request(c, export), resp))
+	assert.Equal(t, TTL, resp.Delay)
+
+	var updated v1.UserDataExport
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKeyFromObject(export), &updated))
+	require.Equal(t, types.UserDataExportStateCompleted, updated.Status.State)
+	require.Contains(t, blobStore.objects, updated.Status.Key)
+	assert.EqualValues(t, len(blobStore.objects[updated.Status.Key]), updated.Status.Size)
+
+	files := readArchive(t, blobStore.objects[updated.Status.Key])
+
+	var p profile
+	require.NoError(t, json.Unmarshal(files["profile.json"], &p))
+	assert.Equal(t, "alice", p.User.Username)
+
+	var usage []types.TokenUsage
+	require.NoError(t, json.Unmarshal(files["token-usage.json"], &usage))
+	require.Len(t, usage, 1)
+	assert.EqualValues(t, 10, usage[0].TotalTokens)
+
+	assert.Contains(t, files, "threads/t1mine.json")
+	assert.NotContains(t, files, "threads/t1theirs.json")
+
+	assert.Equal(t, []string{"1"}, userIDs(t, files["mcp-audit-logs.jsonl"]))
+	assert.Equal(t, []string{"1"}, userIDs(t, files["mcp-usage.jsonl"]))
+	assert.Equal(t, []string{"1"}, userIDs(t, files["message-policy-violations.jsonl"]))
+}
+
+func TestExportUploadFailure(t *testing.T) {
+	blobStore := &memoryBlobStore{objects: map[string][]byte{}, uploadErr: errors.New("bucket not found")}
+	export := newExport("1")
+	h, c, db := newTestHandler(t, blobStore, export)
+	require.NoError(t, db.Create(&gatewaytypes.User{ID: 1, Username: "alice", HashedUsername: "alice"}).Error)
+
+	err := h.Export(request(c, export), &router.ResponseWrapper{})
+	require.ErrorContains(t, err, "bucket not found")
+
+	var updated v1.UserDataExport
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKeyFromObject(export), &updated))
+	assert.Equal(t, types.UserDataExportStateFailed, updated.Status.State)
+	assert.Contains(t, updated.Status.Error, "bucket not found")
+	assert.Empty(t, updated.Status.Key)
+	assert.NotNil(t, updated.Status.CompletedAt)
+}
+
+func TestExportExpiry(t *testing.T) {
+	expired := newExport("1")
+	expired.Status = v1.UserDataExportStatus{
+		State:       types.UserDataExportStateCompleted,
+		Key:         "user-data-exports/ude11.zip",
+		CompletedAt: &metav1.Time{Time: time.Now().Add(-TTL - time.Minute)},
+	}
+	current := newExport("2")
+	current.Status = v1.UserDataExportStatus{
+		State:       types.UserDataExportStateCompleted,
+		Key:         "user-data-exports/ude12.zip",
+		CompletedAt: &metav1.Time{Time: time.Now().Add(-time.Hour)},
+	}
+
+	blobStore := &memoryBlobStore{objects: map[string][]byte{
+		expired.Status.Key: []byte("expired"),
+		current.Status.Key: []byte("current"),
+	}}
+	h, c, _ := newTestHandler(t, blobStore, expired, current)
+
+	require.NoError(t, h.Export(request(c, expired), &router.ResponseWrapper{}))
+	err := c.Get(context.Background(), kclient.ObjectKeyFromObject(expired), &v1.UserDataExport{})
+	assert.True(t, apierrors.IsNotFound(err), "the expired export should be deleted")
+	assert.Equal(t, []string{expired.Status.Key}, blobStore.deleted)
+
+	resp := &router.ResponseWrapper{}
+	require.NoError(t, h.Export(request(c, current), resp))
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKeyFromObject(current), &v1.UserDataExport{}))
+	assert.Contains(t, blobStore.objects, current.Status.Key)
+	assert.InDelta(t, (TTL - time.Hour).Seconds(), resp.Delay.Seconds(), 1)
+}
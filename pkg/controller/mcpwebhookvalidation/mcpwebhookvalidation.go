@@ -72,6 +72,19 @@ func (h *Handler) CleanupResources(req router.Request, _ router.Response) error
 func (h *Handler) EnsureSystemServer(req router.Request, _ router.Response) error {
 	webhookValidation := req.Object.(*v1.MCPWebhookValidation)
 
+	if webhookValidation.Spec.Manifest.RunsInGateway() {
+		// Built-in validations and webhooks in shadow mode are run by the MCP gateway, so there is no webhook server to run.
+		if err := req.Delete(&v1.SystemMCPServer{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      system.SystemMCPServerPrefix + webhookValidation.Name,
+				Namespace: webhookValidation.Namespace,
+			},
+		}); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete webhook validation server %s: %w", webhookValidation.Name, err)
+		}
+		return nil
+	}
+
 	secret, err := h.getWebhookSecret(req, webhookValidation.Name)
 	if err != nil {
 		return err
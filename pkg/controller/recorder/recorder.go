@@ -0,0 +1,93 @@
+package recorder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	"github.com/obot-platform/obot/logger"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+var log = logger.Package()
+
+const (
+	reportingController = "obot.ai/controller"
+	// eventTTL is how long events are kept, like the default of the Kubernetes API server.
+	eventTTL = time.Hour
+)
+
+// Recorder records Kubernetes events about the objects in Obot's storage.
+type Recorder struct {
+	client   kclient.Client
+	instance string
+}
+
+func New(client kclient.Client) *Recorder {
+	instance, _ := os.Hostname()
+	return &Recorder{
+		client:   client,
+		instance: instance,
+	}
+}
+
+// Eventf records an event about the object. Recording is best effort, so failures are logged instead of returned.
+func (r *Recorder) Eventf(ctx context.Context, regarding kclient.Object, eventType, reason, action, note string, args ...any) {
+	gvk, err := apiutil.GVKForObject(regarding, r.client.Scheme())
+	if err != nil {
+		log.Warnf("failed to record event: object=%s reason=%s error=%v", regarding.GetName(), reason, err)
+		return
+	}
+
+	if len(args) > 0 {
+		note = fmt.Sprintf(note, args...)
+	}
+
+	event := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: regarding.GetName() + ".",
+			Namespace:    regarding.GetNamespace(),
+		},
+		EventTime:           metav1.NowMicro(),
+		ReportingController: reportingController,
+		ReportingInstance:   r.instance,
+		Action:              action,
+		Reason:              reason,
+		Regarding: corev1.ObjectReference{
+			APIVersion:      gvk.GroupVersion().String(),
+			Kind:            gvk.Kind,
+			Namespace:       regarding.GetNamespace(),
+			Name:            regarding.GetName(),
+			UID:             regarding.GetUID(),
+			ResourceVersion: regarding.GetResourceVersion(),
+		},
+		Note: note,
+		Type: eventType,
+	}
+	if err := r.client.Create(ctx, event); err != nil {
+		log.Warnf("failed to record event: object=%s reason=%s error=%v", regarding.GetName(), reason, err)
+	}
+}
+
+// DeleteExpired deletes events once they are older than the event TTL.
+func DeleteExpired(req router.Request, resp router.Response) error {
+	event := req.Object.(*eventsv1.Event)
+
+	created := event.EventTime.Time
+	if created.IsZero() {
+		created = event.CreationTimestamp.Time
+	}
+
+	if until := eventTTL - time.Since(created); until > 0 {
+		resp.RetryAfter(until)
+		return nil
+	}
+
+	return kclient.IgnoreNotFound(req.Client.Delete(req.Ctx, event))
+}
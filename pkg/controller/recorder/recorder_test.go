@@ -0,0 +1,83 @@
+package recorder
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/nah/pkg/router"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func newFakeClient(t *testing.T, objects ...kclient.Object) kclient.WithWatch {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	require.NoError(t, v1.AddToScheme(scheme))
+	require.NoError(t, eventsv1.AddToScheme(scheme))
+
+	return fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+}
+
+func TestEventf(t *testing.T) {
+	server := &v1.MCPServer{
+		ObjectMeta: metav1.ObjectMeta{Name: "ms1abc", Namespace: system.DefaultNamespace, UID: "uid"},
+	}
+	c := newFakeClient(t, server)
+
+	New(c).Eventf(context.Background(), server, corev1.EventTypeWarning, "ProbeFailed", "Probe", "probe failed %d times", 3)
+
+	var events eventsv1.EventList
+	require.NoError(t, c.List(context.Background(), &events))
+	require.Len(t, events.Items, 1)
+
+	event := events.Items[0]
+	assert.Equal(t, system.DefaultNamespace, event.Namespace)
+	assert.Equal(t, corev1.EventTypeWarning, event.Type)
+	assert.Equal(t, "ProbeFailed", event.Reason)
+	assert.Equal(t, "Probe", event.Action)
+	assert.Equal(t, "probe failed 3 times", event.Note)
+	assert.Equal(t, reportingController, event.ReportingController)
+	assert.Equal(t, corev1.ObjectReference{
+		APIVersion:      v1.SchemeGroupVersion.String(),
+		Kind:            "MCPServer",
+		Namespace:       server.Namespace,
+		Name:            server.Name,
+		UID:             server.UID,
+		ResourceVersion: server.ResourceVersion,
+	}, event.Regarding)
+}
+
+func TestDeleteExpired(t *testing.T) {
+	expired := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "expired", Namespace: system.DefaultNamespace},
+		EventTime:  metav1.NewMicroTime(time.Now().Add(-eventTTL - time.Minute)),
+	}
+	current := &eventsv1.Event{
+		ObjectMeta: metav1.ObjectMeta{Name: "current", Namespace: system.DefaultNamespace},
+		EventTime:  metav1.NewMicroTime(time.Now().Add(-time.Minute)),
+	}
+	c := newFakeClient(t, expired, current)
+
+	for _, event := range []*eventsv1.Event{expired, current} {
+		resp := &router.ResponseWrapper{}
+		require.NoError(t, DeleteExpired(router.Request{Client: c, Ctx: context.Background(), Object: event}, resp))
+		if event == current {
+			assert.InDelta(t, (eventTTL - time.Minute).Seconds(), resp.Delay.Seconds(), 1)
+		}
+	}
+
+	err := c.Get(context.Background(), kclient.ObjectKeyFromObject(expired), &eventsv1.Event{})
+	assert.True(t, apierrors.IsNotFound(err), "the expired event should be deleted")
+	require.NoError(t, c.Get(context.Background(), kclient.ObjectKeyFromObject(current), &eventsv1.Event{}))
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/mcpsession"
 	"github.com/obot-platform/obot/pkg/controller/handlers/modelaccesspolicy"
 	"github.com/obot-platform/obot/pkg/controller/handlers/nanobotagent"
+	"github.com/obot-platform/obot/pkg/controller/handlers/notification"
 	"github.com/obot-platform/obot/pkg/controller/handlers/oauthapp"
 	"github.com/obot-platform/obot/pkg/controller/handlers/oauthclients"
 	"github.com/obot-platform/obot/pkg/controller/handlers/oktagroupmigration"
@@ -33,23 +34,29 @@ import (
 	"github.com/obot-platform/obot/pkg/controller/handlers/runs"
 	"github.com/obot-platform/obot/pkg/controller/handlers/runstates"
 	"github.com/obot-platform/obot/pkg/controller/handlers/scheduledauditlogexport"
+	"github.com/obot-platform/obot/pkg/controller/handlers/searchindex"
 	"github.com/obot-platform/obot/pkg/controller/handlers/skillrepository"
 	"github.com/obot-platform/obot/pkg/controller/handlers/systemmcpserver"
 	"github.com/obot-platform/obot/pkg/controller/handlers/threads"
 	"github.com/obot-platform/obot/pkg/controller/handlers/threadshare"
 	"github.com/obot-platform/obot/pkg/controller/handlers/toolinfo"
 	"github.com/obot-platform/obot/pkg/controller/handlers/toolreference"
+	"github.com/obot-platform/obot/pkg/controller/handlers/userdataexport"
 	"github.com/obot-platform/obot/pkg/controller/handlers/workflow"
 	"github.com/obot-platform/obot/pkg/controller/handlers/workflowexecution"
 	"github.com/obot-platform/obot/pkg/controller/handlers/workflowstep"
 	"github.com/obot-platform/obot/pkg/controller/handlers/workspace"
 	"github.com/obot-platform/obot/pkg/controller/mcpwebhookvalidation"
+	"github.com/obot-platform/obot/pkg/controller/recorder"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	eventsv1 "k8s.io/api/events/v1"
 )
 
 func (c *Controller) setupRoutes() {
 	root := c.router
 
+	eventRecorder := recorder.New(c.services.StorageClient)
+
 	workflowExecution := workflowexecution.New(c.services.Invoker)
 	workflowStep := workflowstep.New(c.services.Invoker, c.services.GPTClient, c.services.MCPLoader)
 	toolRef := toolreference.New(
@@ -74,8 +81,9 @@ func (c *Controller) setupRoutes() {
 	userCleanup := cleanup.NewUserCleanup(c.services.GatewayClient, c.services.AccessControlRuleHelper)
 	mcpCatalog := mcpcatalog.New(c.services.DefaultMCPCatalogPath, c.services.GatewayClient, c.services.AccessControlRuleHelper)
 	skillRepository := skillrepository.New()
+	searchIndex := searchindex.New(c.services.SearchIndex)
 	mcpSession := mcpsession.New(c.services.GPTClient)
-	mcpserver := mcpserver.New(c.services.GPTClient, c.services.MCPLoader, c.services.SingleUserIdleServerShutdownInterval, c.services.MultiUserIdleServerShutdownInterval, c.services.AgentIdleServerShutdownInterval, c.services.ServerURL)
+	mcpserver := mcpserver.New(c.services.GPTClient, c.services.MCPLoader, eventRecorder, c.services.SingleUserIdleServerShutdownInterval, c.services.MultiUserIdleServerShutdownInterval, c.services.AgentIdleServerShutdownInterval, c.services.ServerURL)
 	mcpserverinstance := mcpserverinstance.New(c.services.GatewayClient)
 	accesscontrolrule := accesscontrolrule.New(c.services.AccessControlRuleHelper)
 	mcpWebhookValidations := mcpwebhookvalidation.New(c.services.GPTClient, c.services.MCPHTTPWebhookBaseImage)
@@ -83,18 +91,21 @@ func (c *Controller) setupRoutes() {
 	adminWorkspaceHandler := adminworkspace.New(c.services.GatewayClient)
 	mcpServerCatalogEntryHandler := mcpservercatalogentry.NewHandler(c.services.GPTClient)
 	auditLogExportHandler := auditlogexport.NewHandler(c.services.GPTClient, c.services.GatewayClient, c.services.EncryptionConfig)
+	userDataExportHandler := userdataexport.NewHandler(c.services.GPTClient, c.services.GatewayClient, c.services.ArtifactBlobStore, c.services.ArtifactBlobBucket)
 	scheduledAuditLogExportHandler := scheduledauditlogexport.NewHandler()
 	oauthclients := oauthclients.NewHandler(c.services.GPTClient)
 	projectMCPServerHandler := projectmcpserver.NewHandler()
 	systemMCPServerHandler := systemmcpserver.New(c.services.GPTClient, c.services.MCPLoader, c.services.ServerURL)
 	nanobotAgentHandler := nanobotagent.New(c.services.GPTClient, c.services.PersistentTokenServer, c.services.GatewayClient, c.localK8sRouter, c.services.NanobotAgentImage, c.services.ServerURL, c.services.MCPServerNamespace, c.services.MCPLoader)
 	oktaGroupMigrationHandler := oktagroupmigration.New()
+	notifications := notification.New(c.services.GPTClient, c.services.ServerURL)
 
 	// Runs
 	root.Type(&v1.Run{}).FinalizeFunc(v1.RunFinalizer, runs.DeleteRunState)
 	root.Type(&v1.Run{}).HandlerFunc(runs.DeleteFinished)
 	root.Type(&v1.Run{}).HandlerFunc(cleanup.Cleanup)
 	root.Type(&v1.Run{}).HandlerFunc(runs.Resume)
+	root.Type(&v1.Run{}).HandlerFunc(runs.IndexChatMessages)
 
 	// Migrate RunStates
 	root.Type(&v1.RunState{}).HandlerFunc(runstates.Migrate)
@@ -136,6 +147,7 @@ func (c *Controller) setupRoutes() {
 	root.Type(&v1.WorkflowExecution{}).HandlerFunc(cleanup.Cleanup)
 	root.Type(&v1.WorkflowExecution{}).HandlerFunc(workflowExecution.Run)
 	root.Type(&v1.WorkflowExecution{}).HandlerFunc(workflowExecution.UpdateRun)
+	root.Type(&v1.WorkflowExecution{}).HandlerFunc(notifications.NotifyWorkflowExecution)
 	root.Type(&v1.WorkflowExecution{}).HandlerFunc(workflowExecution.ReassignThread)
 
 	// Agents
@@ -236,6 +248,12 @@ func (c *Controller) setupRoutes() {
 
 	// Skill
 	root.Type(&v1.Skill{}).HandlerFunc(cleanup.Cleanup)
+	root.Type(&v1.Skill{}).HandlerFunc(searchIndex.IndexSkill)
+	root.Type(&v1.Skill{}).FinalizeFunc(v1.SkillFinalizer, searchIndex.RemoveSkill)
+
+	// PublishedArtifact
+	root.Type(&v1.PublishedArtifact{}).HandlerFunc(searchIndex.IndexPublishedArtifact)
+	root.Type(&v1.PublishedArtifact{}).FinalizeFunc(v1.PublishedArtifactFinalizer, searchIndex.RemovePublishedArtifact)
 
 	// MCPServerCatalogEntry
 	root.Type(&v1.MCPServerCatalogEntry{}).HandlerFunc(cleanup.Cleanup)
@@ -262,6 +280,7 @@ func (c *Controller) setupRoutes() {
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.EnsureMCPServerSecretInfo)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.EnsureCompositeComponents)
 	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.ShutdownIdleServers)
+	root.Type(&v1.MCPServer{}).HandlerFunc(mcpserver.ProbeHealth)
 	root.Type(&v1.MCPServer{}).FinalizeFunc(v1.MCPServerFinalizer, credentialCleanup.RemoveMCPCredentials)
 
 	// MCPServerInstance
@@ -339,6 +358,9 @@ func (c *Controller) setupRoutes() {
 	// AuditLogExport
 	root.Type(&v1.AuditLogExport{}).HandlerFunc(auditLogExportHandler.ExportAuditLogs)
 
+	// UserDataExport
+	root.Type(&v1.UserDataExport{}).HandlerFunc(userDataExportHandler.Export)
+
 	// ScheduledAuditLogExport
 	root.Type(&v1.ScheduledAuditLogExport{}).HandlerFunc(scheduledAuditLogExportHandler.ScheduleExports)
 
@@ -351,6 +373,9 @@ func (c *Controller) setupRoutes() {
 		root.Type(&v1.NanobotAgent{}).HandlerFunc(nanobotAgentHandler.DeleteMCPServer)
 	}
 
+	// Events
+	root.Type(&eventsv1.Event{}).HandlerFunc(recorder.DeleteExpired)
+
 	c.toolRefHandler = toolRef
 	c.mcpCatalogHandler = mcpCatalog
 	c.adminWorkspaceHandler = adminWorkspaceHandler
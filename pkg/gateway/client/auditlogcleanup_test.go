@@ -2,6 +2,7 @@ package client
 
 import (
 	"context"
+	"fmt"
 	"testing"
 	"time"
 
@@ -165,3 +166,81 @@ func TestRunAuditLogCleanupDisabled(t *testing.T) {
 		t.Errorf("expected 2 audit logs (cleanup disabled), got %d", got)
 	}
 }
+
+func TestDeletePolicyAuditLogs(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	for _, entry := range []types.MCPAuditLog{
+		{CreatedAt: now.AddDate(0, 0, -10), RetentionDays: 7},    // past its policy retention - should be deleted
+		{CreatedAt: now.AddDate(0, 0, -3), RetentionDays: 7},     // recent - should be kept
+		{CreatedAt: now.AddDate(0, 0, -100), RetentionDays: 365}, // longer policy retention - should be kept
+		{CreatedAt: now.AddDate(0, 0, -100)},                     // default retention is handled by deleteOldAuditLogs - should be kept
+	} {
+		if err := c.db.WithContext(ctx).Create(&entry).Error; err != nil {
+			t.Fatalf("failed to insert audit log: %v", err)
+		}
+	}
+
+	if err := c.deletePolicyAuditLogs(ctx, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := countAuditLogs(t, c); got != 3 {
+		t.Errorf("expected 3 audit logs after cleanup, got %d", got)
+	}
+
+	if err := c.deleteOldAuditLogs(ctx, now, 90); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := countAuditLogs(t, c); got != 2 {
+		t.Errorf("expected 2 audit logs after default cleanup, got %d", got)
+	}
+}
+
+func TestDeleteExpiredMCPCallRollups(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	now := time.Now().UTC()
+	for i, rollup := range []types.MCPCallRollup{
+		{BucketStart: now.AddDate(0, 0, -100).Truncate(time.Hour), UserID: "1"},                   // past the default retention - should be deleted
+		{BucketStart: now.AddDate(0, 0, -1).Truncate(time.Hour), UserID: "1"},                     // recent - should be kept
+		{BucketStart: now.AddDate(0, 0, -10).Truncate(time.Hour), UserID: "1", RetentionDays: 7},  // past its policy retention - should be deleted
+		{BucketStart: now.AddDate(0, 0, -10).Truncate(time.Hour), UserID: "2", RetentionDays: 30}, // longer policy retention - should be kept
+	} {
+		rollup.MCPID = fmt.Sprintf("ms1%d", i)
+		rollup.CallCount = 1
+		if err := c.db.WithContext(ctx).Create(&rollup).Error; err != nil {
+			t.Fatalf("failed to insert rollup: %v", err)
+		}
+	}
+
+	if err := c.deleteOldAuditLogs(ctx, now, 90); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := c.deletePolicyAuditLogs(ctx, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	rollups, err := c.MCPCallRollupsForUser(ctx, "1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(rollups) != 1 || rollups[0].MCPID != "ms11" {
+		t.Errorf("expected only the recent rollup of user 1 to be kept, got %+v", rollups)
+	}
+
+	if deleted, err := c.DeleteMCPCallRollupsForUser(ctx, "2"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if deleted != 1 {
+		t.Errorf("expected 1 rollup of user 2 to be deleted, got %d", deleted)
+	}
+	if rollups, err = c.MCPCallRollupsForUser(ctx, "1"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if len(rollups) != 1 {
+		t.Errorf("expected the rollups of other users to be kept, got %d", len(rollups))
+	}
+}
@@ -3,10 +3,13 @@ package client
 import (
 	"context"
 	"errors"
+	"fmt"
+	"slices"
 	"time"
 
 	"github.com/obot-platform/obot/logger"
 	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/legalhold"
 )
 
 var log = logger.Package()
@@ -89,22 +92,121 @@ func (c *Client) deleteOldAuditLogs(ctx context.Context, now time.Time, retentio
 
 	cutoff := now.Truncate(24*time.Hour).AddDate(0, 0, -retentionDays)
 
+	holdConditions, holdArgs, err := c.legalHoldConditions(ctx)
+	if err != nil {
+		return err
+	}
+
+	// Audit logs recorded under a policy with its own retention are deleted by deletePolicyAuditLogs.
+	return c.deleteExpiredAuditRows(ctx, cutoff, 0, holdConditions, holdArgs)
+}
+
+// runPolicyAuditLogCleanup deletes audit logs that were recorded under an audit log policy with its own retention.
+// It runs regardless of the default retention, which may be disabled.
+func (c *Client) runPolicyAuditLogCleanup(ctx context.Context) {
+	err := c.deletePolicyAuditLogs(ctx, time.Now().UTC())
+	if err != nil && !errors.Is(err, context.Canceled) {
+		log.Errorf("Failed to delete audit logs past their policy retention: %v", err)
+	}
+
+	ticker := time.NewTicker(c.auditLogCleanupInterval)
+	defer ticker.Stop()
+
 	for {
-		if ctx.Err() != nil {
-			return ctx.Err()
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			err = c.deletePolicyAuditLogs(ctx, time.Now().UTC())
+			if err != nil && !errors.Is(err, context.Canceled) {
+				log.Errorf("Failed to delete audit logs past their policy retention: %v", err)
+			}
 		}
+	}
+}
+
+func (c *Client) deletePolicyAuditLogs(ctx context.Context, now time.Time) error {
+	var retentions []int
+	for _, model := range []any{&types.MCPAuditLog{}, &types.MCPCallRollup{}} {
+		var modelRetentions []int
+		if err := c.db.WithContext(ctx).Model(model).
+			Where("retention_days > 0").
+			Distinct("retention_days").
+			Pluck("retention_days", &modelRetentions).Error; err != nil {
+			return err
+		}
+		retentions = append(retentions, modelRetentions...)
+	}
+	slices.Sort(retentions)
 
-		result := c.db.WithContext(ctx).Exec(
-			"DELETE FROM mcp_audit_logs WHERE id IN (SELECT id FROM mcp_audit_logs WHERE created_at < ? LIMIT ?)",
-			cutoff, c.auditLogDeleteBatchSize,
-		)
-		if result.Error != nil {
-			return result.Error
+	holdConditions, holdArgs, err := c.legalHoldConditions(ctx)
+	if err != nil {
+		return err
+	}
+
+	for _, retentionDays := range slices.Compact(retentions) {
+		cutoff := now.Truncate(24*time.Hour).AddDate(0, 0, -retentionDays)
+		if err := c.deleteExpiredAuditRows(ctx, cutoff, retentionDays, holdConditions, holdArgs); err != nil {
+			return err
 		}
-		if result.RowsAffected < int64(c.auditLogDeleteBatchSize) {
-			return nil
+	}
+
+	return nil
+}
+
+// deleteExpiredAuditRows deletes, in batches, the audit logs and usage rollups that were recorded under the retention
+// before the cutoff, except for those excluded by the hold conditions.
+func (c *Client) deleteExpiredAuditRows(ctx context.Context, cutoff time.Time, retentionDays int, holdConditions string, holdArgs []any) error {
+	for _, table := range []struct{ name, timeColumn string }{
+		{"mcp_audit_logs", "created_at"},
+		{"mcp_call_rollups", "bucket_start"},
+	} {
+		for {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			result := c.db.WithContext(ctx).Exec(
+				fmt.Sprintf("DELETE FROM %[1]s WHERE id IN (SELECT id FROM %[1]s WHERE %[2]s < ? AND retention_days = ?%[3]s LIMIT ?)", table.name, table.timeColumn, holdConditions),
+				append(append([]any{cutoff, retentionDays}, holdArgs...), c.auditLogDeleteBatchSize)...,
+			)
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected < int64(c.auditLogDeleteBatchSize) {
+				break
+			}
 		}
 	}
+
+	return nil
+}
+
+// legalHoldConditions returns the SQL conditions, and their arguments, that exclude the audit logs of users and MCP
+// servers under an active legal hold from deletion.
+func (c *Client) legalHoldConditions(ctx context.Context) (string, []any, error) {
+	if c.storageClient == nil {
+		return "", nil, nil
+	}
+
+	holds, err := legalhold.Active(ctx, c.storageClient)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to list legal holds: %w", err)
+	}
+
+	var (
+		conditions string
+		args       []any
+	)
+	if userIDs := holds.UserIDs(); len(userIDs) > 0 {
+		conditions += " AND user_id NOT IN ?"
+		args = append(args, userIDs)
+	}
+	if mcpServerIDs := holds.MCPServerIDs(); len(mcpServerIDs) > 0 {
+		conditions += " AND mcp_id NOT IN ?"
+		args = append(args, mcpServerIDs)
+	}
+	return conditions, args, nil
 }
 
 func (c *Client) persistAuditLogs() error {
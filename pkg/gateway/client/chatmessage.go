@@ -0,0 +1,103 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+)
+
+const defaultChatMessageSearchLimit = 50
+
+type ChatMessageSearchOptions struct {
+	Query        string
+	ProjectNames []string // Only messages in these projects are searched
+	ThreadName   string
+	Limit        int
+}
+
+// HasChatMessages returns whether the messages of the given run have been indexed.
+func (c *Client) HasChatMessages(ctx context.Context, runName string) (bool, error) {
+	var count int64
+	if err := c.db.WithContext(ctx).Model(&types.ChatMessage{}).Where("run_name = ?", runName).Count(&count).Error; err != nil {
+		return false, fmt.Errorf("failed to check chat messages of run %s: %w", runName, err)
+	}
+	return count > 0, nil
+}
+
+// ReplaceChatMessages replaces the indexed messages of a run with the given messages.
+func (c *Client) ReplaceChatMessages(ctx context.Context, runName string, messages []types.ChatMessage) error {
+	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("run_name = ?", runName).Delete(&types.ChatMessage{}).Error; err != nil {
+			return fmt.Errorf("failed to delete chat messages of run %s: %w", runName, err)
+		}
+		if len(messages) == 0 {
+			return nil
+		}
+		if err := tx.CreateInBatches(messages, 100).Error; err != nil {
+			return fmt.Errorf("failed to insert chat messages of run %s: %w", runName, err)
+		}
+		return nil
+	})
+}
+
+func (c *Client) DeleteChatMessages(ctx context.Context, runName string) error {
+	if err := c.db.WithContext(ctx).Where("run_name = ?", runName).Delete(&types.ChatMessage{}).Error; err != nil {
+		return fmt.Errorf("failed to delete chat messages of run %s: %w", runName, err)
+	}
+	return nil
+}
+
+// SearchChatMessages returns the chat messages matching the query, best matches first. PostgreSQL interprets the
+// query as a web search (quoted phrases, "or" and "-" exclusions), SQLite requires all terms to match.
+func (c *Client) SearchChatMessages(ctx context.Context, opts ChatMessageSearchOptions) ([]types.ChatMessageSearchResult, error) {
+	if strings.TrimSpace(opts.Query) == "" || len(opts.ProjectNames) == 0 {
+		return nil, nil
+	}
+
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultChatMessageSearchLimit
+	}
+
+	db := c.db.WithContext(ctx)
+	if db.Name() == "postgres" {
+		db = db.Table("chat_messages AS m, websearch_to_tsquery('english', ?) AS q", opts.Query).
+			Select("m.*, ts_headline('english', m.content, q, ?) AS snippet, ts_rank(to_tsvector('english', m.content), q) AS rank",
+				fmt.Sprintf("StartSel=%s, StopSel=%s, MaxFragments=2, MinWords=10, MaxWords=30", types.ChatMessageHighlightStart, types.ChatMessageHighlightEnd)).
+			Where("to_tsvector('english', m.content) @@ q")
+	} else {
+		query := sqliteMatchQuery(opts.Query)
+		if query == "" {
+			return nil, nil
+		}
+		db = db.Table("chat_messages_fts").
+			Select("m.*, snippet(chat_messages_fts, 0, ?, ?, '…', 24) AS snippet, -bm25(chat_messages_fts) AS rank",
+				types.ChatMessageHighlightStart, types.ChatMessageHighlightEnd).
+			Joins("JOIN chat_messages AS m ON m.id = chat_messages_fts.rowid").
+			Where("chat_messages_fts MATCH ?", query)
+	}
+
+	db = db.Where("m.project_name IN ?", opts.ProjectNames)
+	if opts.ThreadName != "" {
+		db = db.Where("m.thread_name = ?", opts.ThreadName)
+	}
+
+	var results []types.ChatMessageSearchResult
+	if err := db.Order("rank DESC").Order("m.created_at DESC").Limit(limit).Scan(&results).Error; err != nil {
+		return nil, fmt.Errorf("failed to search chat messages: %w", err)
+	}
+	return results, nil
+}
+
+// sqliteMatchQuery turns a user's query into an FTS5 query matching all of its terms. Each term is quoted so that FTS5
+// operators and column filters in the input are searched for literally.
+func sqliteMatchQuery(query string) string {
+	terms := strings.Fields(query)
+	for i, term := range terms {
+		terms[i] = `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+	}
+	return strings.Join(terms, " ")
+}
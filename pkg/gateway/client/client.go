@@ -64,6 +64,9 @@ func New(ctx context.Context, db *db.DB, storageClient kclient.Client, encryptio
 	go c.runPendingStateCleanup(ctx)
 	go c.runAPIKeyCacheCleanup(ctx)
 	go c.runAuditLogCleanup(ctx, auditLogRetentionDays)
+	go c.runPolicyAuditLogCleanup(ctx)
+	go c.runRevokedOAuthGrantCleanup(ctx)
+	go c.runWebhookShadowDecisionCleanup(ctx)
 	return c
 }
 
@@ -27,3 +27,11 @@ type ExplicitRoleError struct {
 func (e *ExplicitRoleError) Error() string {
 	return e.email + " has a role that was explicitly set"
 }
+
+type GroupNotFoundError struct {
+	id string
+}
+
+func (e *GroupNotFoundError) Error() string {
+	return "group " + e.id + " not found"
+}
@@ -108,10 +108,10 @@ func (c *Client) GetGroupRoleAssignmentsForGroups(ctx context.Context, groupName
 // ResolveUserEffectiveRole computes the effective role for a user by combining:
 // 1. Individual role from users table
 // 2. Group-based roles from GroupRoleAssignments
-// Returns the highest base role plus orthogonal add-on roles (if present).
+// Returns the highest base role plus orthogonal add-on roles (if present), capped for service accounts.
 func (c *Client) ResolveUserEffectiveRole(ctx context.Context, user *types.User, authGroupIDs []string) (types2.Role, error) {
 	// Start with user's individual role
-	effectiveRole := user.Role
+	effectiveRole := capServiceAccountRole(user, user.Role)
 
 	if len(authGroupIDs) == 0 {
 		return effectiveRole, nil
@@ -132,7 +132,19 @@ func (c *Client) ResolveUserEffectiveRole(ctx context.Context, user *types.User,
 	}
 
 	// Normalize to keep only the highest base role + add-on roles
-	return normalizeToHighestRole(effectiveRole), nil
+	return capServiceAccountRole(user, normalizeToHighestRole(effectiveRole)), nil
+}
+
+// capServiceAccountRole removes the owner, admin and user impersonation roles from the role of a service account, so
+// that they can't be gained through groups either. Service accounts with them are left with the power user plus role.
+func capServiceAccountRole(user *types.User, role types2.Role) types2.Role {
+	if !user.ServiceAccount {
+		return role
+	}
+	if role.HasRole(types2.RoleAdmin) {
+		role = role.SwitchBaseRole(types2.RolePowerUserPlus)
+	}
+	return role &^ types2.RoleUserImpersonation
 }
 
 // normalizeToHighestRole takes a combined role bitmap and returns only the highest
@@ -201,7 +213,7 @@ func (c *Client) ResolveUserEffectiveRolesBulk(ctx context.Context, users []type
 	// If no groups at all, just return individual roles
 	if len(uniqueGroupIDs) == 0 {
 		for _, user := range users {
-			effectiveRoles[user.ID] = user.Role
+			effectiveRoles[user.ID] = capServiceAccountRole(&user, user.Role)
 		}
 		return effectiveRoles, nil
 	}
@@ -211,7 +223,7 @@ func (c *Client) ResolveUserEffectiveRolesBulk(ctx context.Context, users []type
 	if err != nil {
 		// Don't fail - fall back to individual roles
 		for _, user := range users {
-			effectiveRoles[user.ID] = user.Role
+			effectiveRoles[user.ID] = capServiceAccountRole(&user, user.Role)
 		}
 		return effectiveRoles, nil
 	}
@@ -236,7 +248,7 @@ func (c *Client) ResolveUserEffectiveRolesBulk(ctx context.Context, users []type
 		}
 
 		// Normalize to keep only the highest base role + add-on roles
-		effectiveRoles[user.ID] = normalizeToHighestRole(effectiveRole)
+		effectiveRoles[user.ID] = capServiceAccountRole(&user, normalizeToHighestRole(effectiveRole))
 	}
 
 	return effectiveRoles, nil
@@ -1,9 +1,11 @@
 package client
 
 import (
+	"context"
 	"testing"
 
 	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/types"
 )
 
 func TestNormalizeToHighestRole(t *testing.T) {
@@ -103,3 +105,44 @@ func TestNormalizeToHighestRole(t *testing.T) {
 		})
 	}
 }
+
+func TestResolveServiceAccountEffectiveRole(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	if _, err := c.CreateGroupRoleAssignment(ctx, "github/admins", types2.RoleAdmin|types2.RoleAuditor|types2.RoleUserImpersonation, ""); err != nil {
+		t.Fatalf("failed to create group role assignment: %v", err)
+	}
+
+	serviceAccount := &types.User{ID: 1, Role: types2.RoleBasic, ServiceAccount: true}
+	person := &types.User{ID: 2, Role: types2.RoleBasic}
+	groupIDs := []string{"github/admins"}
+
+	// The group doesn't make the service account an admin, but it keeps the other roles of the group.
+	if role, err := c.ResolveUserEffectiveRole(ctx, serviceAccount, groupIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if role != types2.RolePowerUserPlus|types2.RoleAuditor {
+		t.Errorf("expected the service account role to be capped at power user plus with auditor, got %d", role)
+	}
+	if role, err := c.ResolveUserEffectiveRole(ctx, person, groupIDs); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if role != types2.RoleAdmin|types2.RoleAuditor|types2.RoleUserImpersonation {
+		t.Errorf("expected the person to get the group's roles, got %d", role)
+	}
+
+	// A role set directly on the account is capped too.
+	owner := &types.User{ID: 3, Role: types2.RoleOwner, ServiceAccount: true}
+	if role, err := c.ResolveUserEffectiveRole(ctx, owner, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	} else if role != types2.RolePowerUserPlus {
+		t.Errorf("expected the service account role to be capped at power user plus, got %d", role)
+	}
+
+	roles, err := c.ResolveUserEffectiveRolesBulk(ctx, []types.User{*serviceAccount, *person}, map[uint][]string{1: groupIDs, 2: groupIDs})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if roles[1] != types2.RolePowerUserPlus|types2.RoleAuditor || roles[2] != types2.RoleAdmin|types2.RoleAuditor|types2.RoleUserImpersonation {
+		t.Errorf("unexpected bulk roles %v", roles)
+	}
+}
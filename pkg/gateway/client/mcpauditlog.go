@@ -49,6 +49,14 @@ func (c *Client) insertMCPAuditLogs(ctx context.Context, logs []types.MCPAuditLo
 		}
 	}
 
+	// Completed calls are added to the usage rollups
+	completed := make([]types.MCPAuditLog, 0, len(logs))
+	for _, log := range toInsert {
+		if log.ResponseReceived {
+			completed = append(completed, log)
+		}
+	}
+
 	// Use a transaction to ensure atomicity
 	return c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Insert request-only and complete logs in batches
@@ -107,18 +115,36 @@ func (c *Client) insertMCPAuditLogs(ctx context.Context, logs []types.MCPAuditLo
 				if err := tx.Model(&existingLog).Updates(updates).Error; err != nil {
 					return fmt.Errorf("failed to update audit log with response data: %w", err)
 				}
+
+				completedLog := existingLog
+				if responseLog.ResponseStatus != 0 {
+					completedLog.ResponseStatus = responseLog.ResponseStatus
+				}
+				if responseLog.Error != "" {
+					completedLog.Error = responseLog.Error
+				}
+				completedLog.ProcessingTimeMs = updates["processing_time_ms"].(int64)
+				if completedLog.UserID == "" {
+					completedLog.UserID = responseLog.UserID
+				}
+				if completedLog.ClientName == "" {
+					completedLog.ClientName = responseLog.ClientName
+				}
+				completed = append(completed, completedLog)
 			} else if errors.Is(err, gorm.ErrRecordNotFound) {
 				// No matching request found - insert as new record
 				if err := tx.Create(&responseLog).Error; err != nil {
 					return fmt.Errorf("failed to insert orphaned response audit log: %w", err)
 				}
+
+				completed = append(completed, responseLog)
 			} else {
 				// Database error
 				return fmt.Errorf("failed to query for existing audit log: %w", err)
 			}
 		}
 
-		return nil
+		return addMCPCallRollups(tx, completed)
 	})
 }
 
@@ -0,0 +1,371 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	MCPUsageIntervalHour = "hour"
+	MCPUsageIntervalDay  = "day"
+	MCPUsageIntervalWeek = "week"
+
+	MCPUsageGroupByServer = "server"
+	MCPUsageGroupByTool   = "tool"
+	MCPUsageGroupByUser   = "user"
+	MCPUsageGroupByClient = "client"
+	MCPUsageGroupByAPIKey = "api_key"
+)
+
+var mcpUsageGroupByColumns = map[string][]string{
+	"":                    nil,
+	MCPUsageGroupByServer: {"mcp_id", "mcp_server_display_name"},
+	MCPUsageGroupByTool:   {"mcp_id", "mcp_server_display_name", "call_identifier"},
+	MCPUsageGroupByUser:   {"user_id"},
+	MCPUsageGroupByClient: {"client_name"},
+	MCPUsageGroupByAPIKey: {"api_key"},
+}
+
+var mcpCallRollupKeyColumns = []clause.Column{
+	{Name: "bucket_start"},
+	{Name: "mcp_id"},
+	{Name: "power_user_workspace_id"},
+	{Name: "mcp_server_display_name"},
+	{Name: "mcp_server_catalog_entry_name"},
+	{Name: "call_type"},
+	{Name: "call_identifier"},
+	{Name: "user_id"},
+	{Name: "client_name"},
+	{Name: "api_key"},
+	{Name: "response_status"},
+	{Name: "latency_bucket"},
+	{Name: "retention_days"},
+}
+
+type MCPUsageTimeSeriesOptions struct {
+	StartTime                  time.Time
+	EndTime                    time.Time
+	Interval                   string
+	GroupBy                    string
+	MCPID                      string
+	PowerUserWorkspaceID       []string // Workspace filtering support (same as audit logs)
+	OwnServerMCPIDs            []string // MCPIDs for user's own servers (union with PowerUserWorkspaceID)
+	UserIDs                    []string
+	MCPServerDisplayNames      []string
+	MCPServerCatalogEntryNames []string
+	CallTypes                  []string
+	CallIdentifiers            []string
+	ClientNames                []string
+}
+
+// MCPUsageSeries is the usage of a group. Only the fields of the grouping are set.
+type MCPUsageSeries struct {
+	MCPID                string
+	MCPServerDisplayName string
+	ToolName             string
+	UserID               string
+	ClientName           string
+	APIKey               string
+	Points               []MCPUsagePoint
+}
+
+type MCPUsagePoint struct {
+	Time                time.Time
+	CallCount           int64
+	ErrorCount          int64
+	AvgProcessingTimeMs float64
+	P50ProcessingTimeMs float64
+	P95ProcessingTimeMs float64
+	P99ProcessingTimeMs float64
+	ResponseStatuses    []MCPUsageStatusCount
+}
+
+type MCPUsageStatusCount struct {
+	ResponseStatus int
+	Count          int64
+}
+
+// addMCPCallRollups adds completed calls to the hourly usage rollups.
+func addMCPCallRollups(tx *gorm.DB, logs []types.MCPAuditLog) error {
+	if len(logs) == 0 {
+		return nil
+	}
+
+	type rollupKey struct {
+		bucketStart                                          time.Time
+		mcpID, workspaceID, displayName, catalogEntryName    string
+		callType, callIdentifier, userID, clientName, apiKey string
+		responseStatus, latencyBucket, retentionDays         int
+	}
+
+	merged := make(map[rollupKey]*types.MCPCallRollup, len(logs))
+	for _, log := range logs {
+		rollup := types.NewMCPCallRollup(log)
+		key := rollupKey{
+			rollup.BucketStart, rollup.MCPID, rollup.PowerUserWorkspaceID, rollup.MCPServerDisplayName, rollup.MCPServerCatalogEntryName,
+			rollup.CallType, rollup.CallIdentifier, rollup.UserID, rollup.ClientName, rollup.APIKey,
+			rollup.ResponseStatus, rollup.LatencyBucket, rollup.RetentionDays,
+		}
+		if existing, ok := merged[key]; ok {
+			existing.CallCount += rollup.CallCount
+			existing.ErrorCount += rollup.ErrorCount
+			existing.TotalProcessingTimeMs += rollup.TotalProcessingTimeMs
+		} else {
+			merged[key] = &rollup
+		}
+	}
+
+	rollups := make([]types.MCPCallRollup, 0, len(merged))
+	for _, rollup := range merged {
+		rollups = append(rollups, *rollup)
+	}
+
+	if err := tx.Clauses(clause.OnConflict{
+		Columns: mcpCallRollupKeyColumns,
+		DoUpdates: clause.Assignments(map[string]any{
+			"call_count":               gorm.Expr("mcp_call_rollups.call_count + excluded.call_count"),
+			"error_count":              gorm.Expr("mcp_call_rollups.error_count + excluded.error_count"),
+			"total_processing_time_ms": gorm.Expr("mcp_call_rollups.total_processing_time_ms + excluded.total_processing_time_ms"),
+		}),
+	}).CreateInBatches(rollups, 100).Error; err != nil {
+		return fmt.Errorf("failed to update MCP usage rollups: %w", err)
+	}
+
+	return nil
+}
+
+// MCPCallRollupsForUser returns the usage rollups of the user's calls, oldest first.
+func (c *Client) MCPCallRollupsForUser(ctx context.Context, userID string) ([]types.MCPCallRollup, error) {
+	var rollups []types.MCPCallRollup
+	return rollups, c.db.WithContext(ctx).Where("user_id = ?", userID).Order("bucket_start, id").Find(&rollups).Error
+}
+
+// DeleteMCPCallRollupsForUser deletes the usage rollups of the user's calls.
+func (c *Client) DeleteMCPCallRollupsForUser(ctx context.Context, userID string) (int64, error) {
+	result := c.db.WithContext(ctx).Where("user_id = ?", userID).Delete(&types.MCPCallRollup{})
+	return result.RowsAffected, result.Error
+}
+
+// GetMCPUsageTimeSeries returns call counts, error rates and processing time percentiles of MCP calls per interval,
+// with a series per group. Usage is rolled up by hour, so the time range is extended to whole hours.
+func (c *Client) GetMCPUsageTimeSeries(ctx context.Context, opts MCPUsageTimeSeriesOptions) ([]MCPUsageSeries, error) {
+	groupColumns, ok := mcpUsageGroupByColumns[opts.GroupBy]
+	if !ok {
+		return nil, fmt.Errorf("invalid group by %q", opts.GroupBy)
+	}
+
+	db := c.db.WithContext(ctx).Model(&types.MCPCallRollup{}).
+		Where("bucket_start >= ? AND bucket_start < ?", opts.StartTime.UTC().Truncate(time.Hour), opts.EndTime.UTC())
+
+	if opts.MCPID != "" {
+		db = db.Where("mcp_id = ?", opts.MCPID)
+	}
+	// Apply scope filtering (union of workspace servers OR own servers)
+	if len(opts.PowerUserWorkspaceID) > 0 || len(opts.OwnServerMCPIDs) > 0 {
+		var (
+			conditions []string
+			args       []any
+		)
+		if len(opts.PowerUserWorkspaceID) > 0 {
+			conditions = append(conditions, "power_user_workspace_id IN (?)")
+			args = append(args, opts.PowerUserWorkspaceID)
+		}
+		if len(opts.OwnServerMCPIDs) > 0 {
+			conditions = append(conditions, "mcp_id IN (?)")
+			args = append(args, opts.OwnServerMCPIDs)
+		}
+		db = db.Where(strings.Join(conditions, " OR "), args...)
+	}
+	if len(opts.UserIDs) > 0 {
+		db = db.Where("user_id IN (?)", opts.UserIDs)
+	}
+	if len(opts.MCPServerDisplayNames) > 0 {
+		db = db.Where("mcp_server_display_name IN (?)", opts.MCPServerDisplayNames)
+	}
+	if len(opts.MCPServerCatalogEntryNames) > 0 {
+		db = db.Where("mcp_server_catalog_entry_name IN (?)", opts.MCPServerCatalogEntryNames)
+	}
+	if opts.GroupBy == MCPUsageGroupByTool {
+		db = db.Where("call_type = ?", "tools/call")
+	} else if len(opts.CallTypes) > 0 {
+		db = db.Where("call_type IN (?)", opts.CallTypes)
+	}
+	if len(opts.CallIdentifiers) > 0 {
+		db = db.Where("call_identifier IN (?)", opts.CallIdentifiers)
+	}
+	if len(opts.ClientNames) > 0 {
+		db = db.Where("client_name IN (?)", opts.ClientNames)
+	}
+
+	columns := append([]string{"bucket_start", "response_status", "latency_bucket"}, groupColumns...)
+
+	var rows []types.MCPCallRollup
+	if err := db.Select(strings.Join(columns, ", ") +
+		", SUM(call_count) AS call_count, SUM(error_count) AS error_count, SUM(total_processing_time_ms) AS total_processing_time_ms").
+		Group(strings.Join(columns, ", ")).
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to get MCP usage rollups: %w", err)
+	}
+
+	return mcpUsageSeries(rows, opts.Interval, opts.GroupBy)
+}
+
+type mcpUsageAccumulator struct {
+	callCount, errorCount, totalProcessingTimeMs int64
+	histogram                                    []int64
+	statuses                                     map[int]int64
+}
+
+// mcpUsageSeries aggregates rollups into a series per group with a point per interval.
+func mcpUsageSeries(rows []types.MCPCallRollup, interval, groupBy string) ([]MCPUsageSeries, error) {
+	truncate, err := mcpUsageIntervalStart(interval)
+	if err != nil {
+		return nil, err
+	}
+
+	type seriesAccumulator struct {
+		series MCPUsageSeries
+		points map[time.Time]*mcpUsageAccumulator
+	}
+
+	series := make(map[string]*seriesAccumulator)
+	for _, row := range rows {
+		group := mcpUsageGroup(row, groupBy)
+		key := group.key()
+		s, ok := series[key]
+		if !ok {
+			s = &seriesAccumulator{series: group, points: make(map[time.Time]*mcpUsageAccumulator)}
+			series[key] = s
+		}
+
+		t := truncate(row.BucketStart.UTC())
+		point, ok := s.points[t]
+		if !ok {
+			point = &mcpUsageAccumulator{
+				histogram: make([]int64, len(types.MCPUsageLatencyBucketBoundsMs)+1),
+				statuses:  make(map[int]int64),
+			}
+			s.points[t] = point
+		}
+
+		point.callCount += row.CallCount
+		point.errorCount += row.ErrorCount
+		point.totalProcessingTimeMs += row.TotalProcessingTimeMs
+		if row.LatencyBucket >= 0 && row.LatencyBucket < len(point.histogram) {
+			point.histogram[row.LatencyBucket] += row.CallCount
+		}
+		point.statuses[row.ResponseStatus] += row.CallCount
+	}
+
+	result := make([]MCPUsageSeries, 0, len(series))
+	for _, key := range slices.Sorted(maps.Keys(series)) {
+		s := series[key]
+		usageSeries := s.series
+		usageSeries.Points = make([]MCPUsagePoint, 0, len(s.points))
+		for _, t := range slices.SortedFunc(maps.Keys(s.points), time.Time.Compare) {
+			acc := s.points[t]
+			point := MCPUsagePoint{
+				Time:                t,
+				CallCount:           acc.callCount,
+				ErrorCount:          acc.errorCount,
+				P50ProcessingTimeMs: mcpUsagePercentile(acc.histogram, 0.50),
+				P95ProcessingTimeMs: mcpUsagePercentile(acc.histogram, 0.95),
+				P99ProcessingTimeMs: mcpUsagePercentile(acc.histogram, 0.99),
+			}
+			if acc.callCount > 0 {
+				point.AvgProcessingTimeMs = float64(acc.totalProcessingTimeMs) / float64(acc.callCount)
+			}
+			for _, status := range slices.Sorted(maps.Keys(acc.statuses)) {
+				point.ResponseStatuses = append(point.ResponseStatuses, MCPUsageStatusCount{ResponseStatus: status, Count: acc.statuses[status]})
+			}
+			usageSeries.Points = append(usageSeries.Points, point)
+		}
+		result = append(result, usageSeries)
+	}
+
+	return result, nil
+}
+
+func mcpUsageIntervalStart(interval string) (func(time.Time) time.Time, error) {
+	switch interval {
+	case MCPUsageIntervalHour:
+		return func(t time.Time) time.Time { return t.Truncate(time.Hour) }, nil
+	case "", MCPUsageIntervalDay:
+		return func(t time.Time) time.Time {
+			return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+		}, nil
+	case MCPUsageIntervalWeek:
+		return func(t time.Time) time.Time {
+			// Weeks start on Monday
+			day := time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+			return day.AddDate(0, 0, -(int(day.Weekday())+6)%7)
+		}, nil
+	default:
+		return nil, fmt.Errorf("invalid interval %q", interval)
+	}
+}
+
+func (s MCPUsageSeries) key() string {
+	return strings.Join([]string{s.MCPID, s.MCPServerDisplayName, s.ToolName, s.UserID, s.ClientName, s.APIKey}, "\x00")
+}
+
+func mcpUsageGroup(row types.MCPCallRollup, groupBy string) MCPUsageSeries {
+	switch groupBy {
+	case MCPUsageGroupByServer:
+		return MCPUsageSeries{MCPID: row.MCPID, MCPServerDisplayName: row.MCPServerDisplayName}
+	case MCPUsageGroupByTool:
+		return MCPUsageSeries{MCPID: row.MCPID, MCPServerDisplayName: row.MCPServerDisplayName, ToolName: row.CallIdentifier}
+	case MCPUsageGroupByUser:
+		return MCPUsageSeries{UserID: row.UserID}
+	case MCPUsageGroupByClient:
+		return MCPUsageSeries{ClientName: row.ClientName}
+	case MCPUsageGroupByAPIKey:
+		return MCPUsageSeries{APIKey: row.APIKey}
+	default:
+		return MCPUsageSeries{}
+	}
+}
+
+// mcpUsagePercentile estimates a percentile of the processing time from a latency histogram, interpolating linearly
+// within the bucket containing the percentile. Percentiles in the last, unbounded bucket are reported as its lower bound.
+func mcpUsagePercentile(histogram []int64, percentile float64) float64 {
+	var total int64
+	for _, count := range histogram {
+		total += count
+	}
+	if total == 0 {
+		return 0
+	}
+
+	rank := percentile * float64(total)
+	var cumulative int64
+	for i, count := range histogram {
+		if count == 0 || float64(cumulative+count) < rank {
+			cumulative += count
+			continue
+		}
+
+		var lower float64
+		if i > 0 {
+			lower = float64(types.MCPUsageLatencyBucketBoundsMs[i-1])
+		}
+		if i >= len(types.MCPUsageLatencyBucketBoundsMs) {
+			return lower
+		}
+
+		upper := float64(types.MCPUsageLatencyBucketBoundsMs[i])
+		fraction := math.Max(0, rank-float64(cumulative)) / float64(count)
+		return lower + (upper-lower)*fraction
+	}
+
+	return 0
+}
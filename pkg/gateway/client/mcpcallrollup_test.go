@@ -0,0 +1,72 @@
+package client
+
+import (
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+func TestMCPUsagePercentile(t *testing.T) {
+	histogram := make([]int64, len(types.MCPUsageLatencyBucketBoundsMs)+1)
+	histogram[0] = 50 // <= 5ms
+	histogram[4] = 50 // 50ms - 100ms
+
+	if p := mcpUsagePercentile(histogram, 0.5); p != 5 {
+		t.Errorf("expected p50 of 5ms, got %v", p)
+	}
+	if p := mcpUsagePercentile(histogram, 0.95); p != 95 {
+		t.Errorf("expected p95 of 95ms, got %v", p)
+	}
+
+	unbounded := make([]int64, len(histogram))
+	unbounded[len(unbounded)-1] = 3
+	if p := mcpUsagePercentile(unbounded, 0.99); p != float64(types.MCPUsageLatencyBucketBoundsMs[len(types.MCPUsageLatencyBucketBoundsMs)-1]) {
+		t.Errorf("expected p99 at the last bound, got %v", p)
+	}
+
+	if p := mcpUsagePercentile(make([]int64, len(histogram)), 0.5); p != 0 {
+		t.Errorf("expected 0 without calls, got %v", p)
+	}
+}
+
+func TestMCPUsageSeries(t *testing.T) {
+	// Wednesday
+	hour := time.Date(2026, 10, 14, 10, 0, 0, 0, time.UTC)
+	rows := []types.MCPCallRollup{
+		{BucketStart: hour, MCPID: "ms1", CallIdentifier: "search", UserID: "u1", ResponseStatus: 200, LatencyBucket: 2, CallCount: 3, TotalProcessingTimeMs: 60},
+		{BucketStart: hour.Add(time.Hour), MCPID: "ms1", CallIdentifier: "search", UserID: "u2", ResponseStatus: 500, LatencyBucket: 0, CallCount: 1, ErrorCount: 1, TotalProcessingTimeMs: 4},
+		{BucketStart: hour.AddDate(0, 0, 1), MCPID: "ms1", CallIdentifier: "create", UserID: "u1", ResponseStatus: 200, LatencyBucket: 5, CallCount: 2, TotalProcessingTimeMs: 400},
+	}
+
+	series, err := mcpUsageSeries(rows, MCPUsageIntervalWeek, MCPUsageGroupByUser)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 2 || series[0].UserID != "u1" || series[1].UserID != "u2" {
+		t.Fatalf("expected a series for u1 and u2, got %+v", series)
+	}
+
+	points := series[0].Points
+	if len(points) != 1 || !points[0].Time.Equal(time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC)) {
+		t.Fatalf("expected a single point for the week starting Monday, got %+v", points)
+	}
+	if points[0].CallCount != 5 || points[0].AvgProcessingTimeMs != 92 {
+		t.Errorf("expected 5 calls averaging 92ms, got %+v", points[0])
+	}
+
+	series, err = mcpUsageSeries(rows, MCPUsageIntervalHour, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(series) != 1 || len(series[0].Points) != 3 {
+		t.Fatalf("expected a single series with 3 points, got %+v", series)
+	}
+	if statuses := series[0].Points[1].ResponseStatuses; len(statuses) != 1 || statuses[0].ResponseStatus != 500 || series[0].Points[1].ErrorCount != 1 {
+		t.Errorf("expected a single failed call, got %+v", series[0].Points[1])
+	}
+
+	if _, err = mcpUsageSeries(rows, "month", ""); err == nil {
+		t.Error("expected an error for an invalid interval")
+	}
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+const (
+	webhookShadowDecisionCleanupInterval = time.Hour
+	webhookShadowDecisionRetention       = 7 * 24 * time.Hour
+)
+
+// LogMCPWebhookShadowDecision records a decision of a MCP webhook validation in shadow mode.
+func (c *Client) LogMCPWebhookShadowDecision(ctx context.Context, d *types.MCPWebhookShadowDecision) error {
+	if d.CreatedAt.IsZero() {
+		d.CreatedAt = time.Now()
+	}
+	d.CreatedAt = d.CreatedAt.UTC()
+
+	if err := c.db.WithContext(ctx).Create(d).Error; err != nil {
+		return fmt.Errorf("failed to insert webhook shadow decision: %w", err)
+	}
+	return nil
+}
+
+// GetMCPWebhookShadowDecisions returns the most recent shadow decisions of the MCP webhook validation, newest first.
+func (c *Client) GetMCPWebhookShadowDecisions(ctx context.Context, validationName string, limit int) ([]types.MCPWebhookShadowDecision, error) {
+	var decisions []types.MCPWebhookShadowDecision
+	db := c.db.WithContext(ctx).Where("validation_name = ?", validationName).Order("created_at DESC, id DESC")
+	if limit > 0 {
+		db = db.Limit(limit)
+	}
+	if err := db.Find(&decisions).Error; err != nil {
+		return nil, fmt.Errorf("failed to list webhook shadow decisions: %w", err)
+	}
+	return decisions, nil
+}
+
+// DeleteMCPWebhookShadowDecisions deletes the shadow decisions of the MCP webhook validation.
+func (c *Client) DeleteMCPWebhookShadowDecisions(ctx context.Context, validationName string) error {
+	return c.db.WithContext(ctx).Where("validation_name = ?", validationName).Delete(&types.MCPWebhookShadowDecision{}).Error
+}
+
+// CleanupExpiredMCPWebhookShadowDecisions deletes the shadow decisions that are older than the retention.
+func (c *Client) CleanupExpiredMCPWebhookShadowDecisions(ctx context.Context) error {
+	return c.db.WithContext(ctx).Delete(&types.MCPWebhookShadowDecision{}, "created_at < ?", time.Now().Add(-webhookShadowDecisionRetention).UTC()).Error
+}
+
+func (c *Client) runWebhookShadowDecisionCleanup(ctx context.Context) {
+	timer := time.NewTimer(webhookShadowDecisionCleanupInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if err := c.CleanupExpiredMCPWebhookShadowDecisions(ctx); err != nil {
+			log.Errorf("Failed to cleanup expired webhook shadow decisions: %v", err)
+		}
+
+		timer.Reset(webhookShadowDecisionCleanupInterval)
+	}
+}
@@ -0,0 +1,47 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+func TestMCPWebhookShadowDecisions(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	for _, d := range []types.MCPWebhookShadowDecision{
+		{ValidationName: "filter1", Decision: "accepted", CreatedAt: time.Now().Add(-time.Minute)},
+		{ValidationName: "filter1", Decision: "rejected"},
+		{ValidationName: "filter2", Decision: "accepted"},
+		{ValidationName: "filter1", Decision: "rejected", CreatedAt: time.Now().Add(-8 * 24 * time.Hour)},
+	} {
+		if err := c.LogMCPWebhookShadowDecision(ctx, &d); err != nil {
+			t.Fatalf("failed to log shadow decision: %v", err)
+		}
+	}
+
+	decisions, err := c.GetMCPWebhookShadowDecisions(ctx, "filter1", 2)
+	if err != nil {
+		t.Fatalf("failed to get shadow decisions: %v", err)
+	}
+	if len(decisions) != 2 || decisions[0].Decision != "rejected" || decisions[1].Decision != "accepted" {
+		t.Errorf("expected the two newest decisions of filter1, got %+v", decisions)
+	}
+
+	if err = c.CleanupExpiredMCPWebhookShadowDecisions(ctx); err != nil {
+		t.Fatalf("failed to cleanup shadow decisions: %v", err)
+	}
+	if decisions, err = c.GetMCPWebhookShadowDecisions(ctx, "filter1", 0); err != nil || len(decisions) != 2 {
+		t.Errorf("expected the expired decision to be cleaned up, got %d, %v", len(decisions), err)
+	}
+
+	if err = c.DeleteMCPWebhookShadowDecisions(ctx, "filter1"); err != nil {
+		t.Fatalf("failed to delete shadow decisions: %v", err)
+	}
+	if decisions, err = c.GetMCPWebhookShadowDecisions(ctx, "filter2", 0); err != nil || len(decisions) != 1 {
+		t.Errorf("expected the decisions of filter2 to be kept, got %d, %v", len(decisions), err)
+	}
+}
@@ -114,6 +114,22 @@ func (c *Client) GetMessagePolicyViolation(ctx context.Context, id uint) (*types
 	return &v, nil
 }
 
+// MessagePolicyViolationsForUser returns all policy violations of the user, decrypted, oldest first.
+func (c *Client) MessagePolicyViolationsForUser(ctx context.Context, userID string) ([]types.MessagePolicyViolation, error) {
+	var violations []types.MessagePolicyViolation
+	if err := c.db.WithContext(ctx).Where("user_id = ?", userID).Order("created_at ASC").Find(&violations).Error; err != nil {
+		return nil, err
+	}
+
+	for i := range violations {
+		if err := c.decryptMessagePolicyViolation(ctx, &violations[i]); err != nil {
+			return nil, fmt.Errorf("failed to decrypt policy violation: %w", err)
+		}
+	}
+
+	return violations, nil
+}
+
 // GetMessagePolicyViolationFilterOptions returns distinct values for a given filter field.
 func (c *Client) GetMessagePolicyViolationFilterOptions(ctx context.Context, option string, opts MessagePolicyViolationOptions) ([]string, error) {
 	db := c.db.WithContext(ctx).Model(&types.MessagePolicyViolation{}).Distinct(option)
@@ -0,0 +1,61 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const revokedOAuthGrantCleanupInterval = time.Hour
+
+// RevokeOAuthGrant marks the OAuth grant as revoked until expiresAt, which must be after all access tokens issued for
+// the grant expire.
+func (c *Client) RevokeOAuthGrant(ctx context.Context, grantID string, expiresAt time.Time) error {
+	return c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "grant_id"}},
+		DoUpdates: clause.AssignmentColumns([]string{"expires_at"}),
+	}).Create(&types.RevokedOAuthGrant{
+		GrantID:   grantID,
+		ExpiresAt: expiresAt,
+	}).Error
+}
+
+// IsOAuthGrantRevoked returns whether the OAuth grant was revoked.
+func (c *Client) IsOAuthGrantRevoked(ctx context.Context, grantID string) (bool, error) {
+	var grant types.RevokedOAuthGrant
+	if err := c.db.WithContext(ctx).Where("grant_id = ?", grantID).First(&grant).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+// CleanupExpiredRevokedOAuthGrants deletes the revocations that no longer have any unexpired access tokens.
+func (c *Client) CleanupExpiredRevokedOAuthGrants(ctx context.Context) error {
+	return c.db.WithContext(ctx).Delete(&types.RevokedOAuthGrant{}, "expires_at < ?", time.Now()).Error
+}
+
+func (c *Client) runRevokedOAuthGrantCleanup(ctx context.Context) {
+	timer := time.NewTimer(revokedOAuthGrantCleanupInterval)
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		if err := c.CleanupExpiredRevokedOAuthGrants(ctx); err != nil {
+			log.Errorf("Failed to cleanup expired revoked OAuth grants: %v", err)
+		}
+
+		timer.Reset(revokedOAuthGrantCleanupInterval)
+	}
+}
@@ -0,0 +1,42 @@
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestRevokeOAuthGrant(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	if revoked, err := c.IsOAuthGrantRevoked(ctx, "grant1"); err != nil || revoked {
+		t.Fatalf("expected grant1 to not be revoked, got %v, %v", revoked, err)
+	}
+
+	if err := c.RevokeOAuthGrant(ctx, "grant1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to revoke grant1: %v", err)
+	}
+	// Revoking a grant twice is fine.
+	if err := c.RevokeOAuthGrant(ctx, "grant1", time.Now().Add(time.Hour)); err != nil {
+		t.Fatalf("failed to revoke grant1 again: %v", err)
+	}
+	if err := c.RevokeOAuthGrant(ctx, "grant2", time.Now().Add(-time.Minute)); err != nil {
+		t.Fatalf("failed to revoke grant2: %v", err)
+	}
+
+	if revoked, err := c.IsOAuthGrantRevoked(ctx, "grant1"); err != nil || !revoked {
+		t.Fatalf("expected grant1 to be revoked, got %v, %v", revoked, err)
+	}
+
+	if err := c.CleanupExpiredRevokedOAuthGrants(ctx); err != nil {
+		t.Fatalf("failed to cleanup revoked grants: %v", err)
+	}
+
+	if revoked, err := c.IsOAuthGrantRevoked(ctx, "grant1"); err != nil || !revoked {
+		t.Errorf("expected grant1 to still be revoked, got %v, %v", revoked, err)
+	}
+	if revoked, err := c.IsOAuthGrantRevoked(ctx, "grant2"); err != nil || revoked {
+		t.Errorf("expected the expired revocation of grant2 to be cleaned up, got %v, %v", revoked, err)
+	}
+}
@@ -0,0 +1,48 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GetSearchDocument returns the search document with the given ID, or nil if the document hasn't been indexed.
+func (c *Client) GetSearchDocument(ctx context.Context, id string) (*types.SearchDocument, error) {
+	var doc types.SearchDocument
+	if err := c.db.WithContext(ctx).Where("id = ?", id).First(&doc).Error; errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get search document %s: %w", id, err)
+	}
+	return &doc, nil
+}
+
+// ListSearchDocuments returns the search documents of the given kind.
+func (c *Client) ListSearchDocuments(ctx context.Context, kind string) ([]types.SearchDocument, error) {
+	var docs []types.SearchDocument
+	if err := c.db.WithContext(ctx).Where("kind = ?", kind).Find(&docs).Error; err != nil {
+		return nil, fmt.Errorf("failed to list %s search documents: %w", kind, err)
+	}
+	return docs, nil
+}
+
+func (c *Client) UpsertSearchDocument(ctx context.Context, doc *types.SearchDocument) error {
+	if err := c.db.WithContext(ctx).Clauses(clause.OnConflict{
+		Columns:   []clause.Column{{Name: "id"}},
+		UpdateAll: true,
+	}).Create(doc).Error; err != nil {
+		return fmt.Errorf("failed to upsert search document %s: %w", doc.ID, err)
+	}
+	return nil
+}
+
+func (c *Client) DeleteSearchDocument(ctx context.Context, id string) error {
+	if err := c.db.WithContext(ctx).Where("id = ?", id).Delete(&types.SearchDocument{}).Error; err != nil {
+		return fmt.Errorf("failed to delete search document %s: %w", id, err)
+	}
+	return nil
+}
@@ -0,0 +1,155 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/hash"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ServiceAccountUsernamePrefix is the prefix of the usernames of service accounts. Auth providers never return usernames
+// with it, so service accounts can't collide with people.
+const ServiceAccountUsernamePrefix = "serviceaccount:"
+
+// ErrNotServiceAccount is returned when a service account operation is done on a user that isn't one.
+var ErrNotServiceAccount = errors.New("user is not a service account")
+
+// CreateServiceAccount creates a service account user that is a member of the given groups.
+// Service accounts don't have identities, so their group memberships are never synced from an auth provider.
+func (c *Client) CreateServiceAccount(ctx context.Context, name, displayName string, role types2.Role, groupIDs []string) (*types.User, error) {
+	username := ServiceAccountUsernamePrefix + name
+	user := &types.User{
+		Username:       username,
+		HashedUsername: hash.String(username),
+		DisplayName:    displayName,
+		Role:           role,
+		ServiceAccount: true,
+	}
+
+	if err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("hashed_username = ? AND deleted_at IS NULL", user.HashedUsername).First(new(types.User)).Error; err == nil {
+			return &AlreadyExistsError{name: fmt.Sprintf("service account %q", name)}
+		} else if !errors.Is(err, gorm.ErrRecordNotFound) {
+			return err
+		}
+
+		// Create a copy so that the caller gets the unencrypted values.
+		u := *user
+		if err := c.encryptUser(ctx, &u); err != nil {
+			return fmt.Errorf("failed to encrypt user: %w", err)
+		}
+		if err := tx.Create(&u).Error; err != nil {
+			return err
+		}
+		user.ID = u.ID
+		user.CreatedAt = u.CreatedAt
+
+		_, _, err := setGroupMemberships(tx, user.ID, groupIDs)
+		return err
+	}); err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+// UpdateServiceAccount updates the display name, role and group memberships of the service account.
+// It returns the updated user, and whether the service account joined or left any groups, and whether it left any.
+func (c *Client) UpdateServiceAccount(ctx context.Context, userID uint, displayName string, role types2.Role, groupIDs []string) (*types.User, bool, bool, error) {
+	var (
+		user                          = new(types.User)
+		membershipsChanged, groupLost bool
+	)
+
+	if err := c.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("id = ? AND deleted_at IS NULL", userID).First(user).Error; err != nil {
+			return err
+		}
+		if !user.ServiceAccount {
+			return ErrNotServiceAccount
+		}
+
+		if err := c.decryptUser(ctx, user); err != nil {
+			return fmt.Errorf("failed to decrypt user: %w", err)
+		}
+
+		user.DisplayName = displayName
+		user.Role = role
+
+		u := *user
+		if err := c.encryptUser(ctx, &u); err != nil {
+			return fmt.Errorf("failed to encrypt user: %w", err)
+		}
+		if err := tx.Save(&u).Error; err != nil {
+			return err
+		}
+
+		var err error
+		membershipsChanged, groupLost, err = setGroupMemberships(tx, user.ID, groupIDs)
+		return err
+	}); err != nil {
+		return nil, false, false, err
+	}
+
+	return user, membershipsChanged, groupLost, nil
+}
+
+// setGroupMemberships makes the user a member of exactly the given groups, which must already exist.
+// It returns whether any memberships were added or removed, and whether any were removed.
+func setGroupMemberships(tx *gorm.DB, userID uint, groupIDs []string) (bool, bool, error) {
+	slices.Sort(groupIDs)
+	groupIDs = slices.Compact(groupIDs)
+
+	if len(groupIDs) > 0 {
+		var existingGroupIDs []string
+		if err := tx.Model(new(types.Group)).Where("id IN ?", groupIDs).Distinct().Pluck("id", &existingGroupIDs).Error; err != nil {
+			return false, false, fmt.Errorf("failed to list groups: %w", err)
+		}
+		for _, groupID := range groupIDs {
+			if !slices.Contains(existingGroupIDs, groupID) {
+				return false, false, &GroupNotFoundError{id: groupID}
+			}
+		}
+	}
+
+	var currentGroupIDs []string
+	if err := tx.Model(new(types.GroupMemberships)).Where("user_id = ?", userID).Pluck("group_id", &currentGroupIDs).Error; err != nil {
+		return false, false, fmt.Errorf("failed to list group memberships: %w", err)
+	}
+
+	var toDelete []string
+	for _, groupID := range currentGroupIDs {
+		if !slices.Contains(groupIDs, groupID) {
+			toDelete = append(toDelete, groupID)
+		}
+	}
+
+	var toInsert []types.GroupMemberships
+	for _, groupID := range groupIDs {
+		if !slices.Contains(currentGroupIDs, groupID) {
+			toInsert = append(toInsert, types.GroupMemberships{
+				UserID:  userID,
+				GroupID: groupID,
+			})
+		}
+	}
+
+	if len(toDelete) > 0 {
+		if err := tx.Where("user_id = ? AND group_id IN ?", userID, toDelete).Delete(new(types.GroupMemberships)).Error; err != nil {
+			return false, false, fmt.Errorf("failed to delete group memberships: %w", err)
+		}
+	}
+	if len(toInsert) > 0 {
+		if err := tx.Clauses(clause.OnConflict{DoNothing: true}).Create(&toInsert).Error; err != nil {
+			return false, false, fmt.Errorf("failed to create group memberships: %w", err)
+		}
+	}
+
+	return len(toInsert) > 0 || len(toDelete) > 0, len(toDelete) > 0, nil
+}
@@ -0,0 +1,67 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"testing"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+)
+
+func TestServiceAccountGroups(t *testing.T) {
+	c := newTestClient(t)
+	ctx := context.Background()
+
+	for _, id := range []string{"github/team-a", "github/team-b"} {
+		if err := c.db.WithContext(ctx).Create(&types.Group{ID: id, AuthProviderName: "github-auth-provider", AuthProviderNamespace: "default", Name: id}).Error; err != nil {
+			t.Fatalf("failed to create group %s: %v", id, err)
+		}
+	}
+
+	if _, err := c.CreateServiceAccount(ctx, "ci-bot", "CI Bot", types2.RoleBasic, []string{"github/team-c"}); !errors.As(err, new(*GroupNotFoundError)) {
+		t.Fatalf("expected a group not found error, got %v", err)
+	}
+
+	user, err := c.CreateServiceAccount(ctx, "ci-bot", "CI Bot", types2.RoleBasic, []string{"github/team-a", "github/team-a"})
+	if err != nil {
+		t.Fatalf("failed to create service account: %v", err)
+	}
+	if user.Username != ServiceAccountUsernamePrefix+"ci-bot" || !user.ServiceAccount {
+		t.Errorf("unexpected service account user: %+v", user)
+	}
+	if groupIDs, err := c.ListGroupIDsForUser(ctx, user.ID); err != nil || !slices.Equal(groupIDs, []string{"github/team-a"}) {
+		t.Errorf("expected service account to be a member of github/team-a, got %v, %v", groupIDs, err)
+	}
+
+	if _, err := c.CreateServiceAccount(ctx, "ci-bot", "CI Bot", types2.RoleBasic, nil); !errors.As(err, new(*AlreadyExistsError)) {
+		t.Errorf("expected an already exists error, got %v", err)
+	}
+
+	user, changed, lost, err := c.UpdateServiceAccount(ctx, user.ID, "Deploy Bot", types2.RolePowerUser, []string{"github/team-b"})
+	if err != nil {
+		t.Fatalf("failed to update service account: %v", err)
+	}
+	if !changed || !lost {
+		t.Errorf("expected memberships to have changed and a group to be lost, got %v, %v", changed, lost)
+	}
+	if user.DisplayName != "Deploy Bot" || user.Role != types2.RolePowerUser {
+		t.Errorf("unexpected updated service account user: %+v", user)
+	}
+	if groupIDs, err := c.ListGroupIDsForUser(ctx, user.ID); err != nil || !slices.Equal(groupIDs, []string{"github/team-b"}) {
+		t.Errorf("expected service account to be a member of github/team-b, got %v, %v", groupIDs, err)
+	}
+
+	if _, changed, lost, err = c.UpdateServiceAccount(ctx, user.ID, "Deploy Bot", types2.RolePowerUser, []string{"github/team-b", "github/team-a"}); err != nil || !changed || lost {
+		t.Errorf("expected a group to be joined without losing any, got %v, %v, %v", changed, lost, err)
+	}
+
+	person := types.User{Username: "person", HashedUsername: "person", Role: types2.RoleBasic}
+	if err := c.db.WithContext(ctx).Create(&person).Error; err != nil {
+		t.Fatalf("failed to create user: %v", err)
+	}
+	if _, _, _, err = c.UpdateServiceAccount(ctx, person.ID, "Person", types2.RoleBasic, nil); !errors.Is(err, ErrNotServiceAccount) {
+		t.Errorf("expected a not a service account error, got %v", err)
+	}
+}
@@ -0,0 +1,41 @@
+package db
+
+import (
+	"fmt"
+
+	"gorm.io/gorm"
+)
+
+// addChatMessagesFullTextSearch adds the full-text index used to search chat history. PostgreSQL indexes the content
+// column directly, while SQLite uses an FTS5 table that is kept in sync with chat_messages by triggers.
+func addChatMessagesFullTextSearch(tx *gorm.DB) error {
+	var statements []string
+	if tx.Name() == "postgres" {
+		statements = []string{
+			`CREATE INDEX IF NOT EXISTS idx_chat_messages_content_fts ON chat_messages USING GIN (to_tsvector('english', content))`,
+		}
+	} else {
+		statements = []string{
+			`CREATE VIRTUAL TABLE IF NOT EXISTS chat_messages_fts USING fts5(content, content='chat_messages', content_rowid='id')`,
+			`CREATE TRIGGER IF NOT EXISTS chat_messages_fts_insert AFTER INSERT ON chat_messages BEGIN
+				INSERT INTO chat_messages_fts(rowid, content) VALUES (new.id, new.content);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS chat_messages_fts_delete AFTER DELETE ON chat_messages BEGIN
+				INSERT INTO chat_messages_fts(chat_messages_fts, rowid, content) VALUES ('delete', old.id, old.content);
+			END`,
+			`CREATE TRIGGER IF NOT EXISTS chat_messages_fts_update AFTER UPDATE ON chat_messages BEGIN
+				INSERT INTO chat_messages_fts(chat_messages_fts, rowid, content) VALUES ('delete', old.id, old.content);
+				INSERT INTO chat_messages_fts(rowid, content) VALUES (new.id, new.content);
+			END`,
+			`INSERT INTO chat_messages_fts(chat_messages_fts) VALUES ('rebuild')`,
+		}
+	}
+
+	for _, statement := range statements {
+		if err := tx.Exec(statement).Error; err != nil {
+			return fmt.Errorf("failed to add chat message full-text search: %w", err)
+		}
+	}
+
+	return nil
+}
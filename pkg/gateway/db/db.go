@@ -111,10 +111,19 @@ func (db *DB) AutoMigrate() (err error) {
 		types.Property{},
 		types.APIKey{},
 		types.MessagePolicyViolation{},
+		types.SearchDocument{},
+		types.ChatMessage{},
+		types.MCPCallRollup{},
+		types.RevokedOAuthGrant{},
+		types.MCPWebhookShadowDecision{},
 	); err != nil {
 		return fmt.Errorf("failed to auto migrate gateway types: %w", err)
 	}
 
+	if err = migrateIfEntryNotFoundInMigrationsTable(tx, "chat_messages_full_text_search", addChatMessagesFullTextSearch); err != nil {
+		return fmt.Errorf("failed to add chat message full-text search: %w", err)
+	}
+
 	// MIGRATION: replace mcp_server_instance with mcp_id as the new primary key.
 	// First, check to se if the mcp_server_instance column still exists.
 	if exists := tx.Migrator().HasColumn(&types.MCPOAuthToken{}, "mcp_server_instance"); exists {
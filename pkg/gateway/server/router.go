@@ -48,6 +48,12 @@ func (s *Server) AddRoutes(mux *server.Server) {
 	mux.HandleFunc("DELETE /api/users/{user_id}", wrap(s.deleteUser))
 	mux.HandleFunc("GET /api/active-users", wrap(s.activeUsers))
 
+	mux.HandleFunc("GET /api/service-accounts", wrap(s.listServiceAccounts))
+	mux.HandleFunc("POST /api/service-accounts", wrap(s.createServiceAccount))
+	mux.HandleFunc("GET /api/service-accounts/{user_id}", wrap(s.getServiceAccount))
+	mux.HandleFunc("PUT /api/service-accounts/{user_id}", wrap(s.updateServiceAccount))
+	mux.HandleFunc("DELETE /api/service-accounts/{user_id}", wrap(s.deleteServiceAccount))
+
 	mux.HandleFunc("GET /api/token-usage", wrap(s.systemTokenUsageByUser))
 	mux.HandleFunc("GET /api/total-token-usage", wrap(s.totalSystemTokenUsage))
 
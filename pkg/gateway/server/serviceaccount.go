@@ -0,0 +1,239 @@
+package server
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/api"
+	"github.com/obot-platform/obot/pkg/gateway/client"
+	"github.com/obot-platform/obot/pkg/gateway/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	"gorm.io/gorm"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var serviceAccountNameRegex = regexp.MustCompile(`^[a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// listServiceAccounts returns all service accounts.
+func (s *Server) listServiceAccounts(apiContext api.Context) error {
+	users, err := apiContext.GatewayClient.Users(apiContext.Context(), types.UserQuery{ServiceAccount: true})
+	if err != nil {
+		return fmt.Errorf("failed to list service accounts: %v", err)
+	}
+
+	userIDs := make([]uint, 0, len(users))
+	for _, user := range users {
+		userIDs = append(userIDs, user.ID)
+	}
+
+	groupMemberships, err := apiContext.GatewayClient.GetUserGroupMemberships(apiContext.Context(), userIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get service account group memberships: %v", err)
+	}
+
+	items := make([]types2.ServiceAccount, 0, len(users))
+	for _, user := range users {
+		items = append(items, convertServiceAccount(&user, groupMemberships[user.ID]))
+	}
+
+	return apiContext.Write(types2.ServiceAccountList{Items: items})
+}
+
+// getServiceAccount returns a specific service account.
+func (s *Server) getServiceAccount(apiContext api.Context) error {
+	user, err := serviceAccountFromPath(apiContext)
+	if err != nil {
+		return err
+	}
+
+	groupIDs, err := apiContext.GatewayClient.ListGroupIDsForUser(apiContext.Context(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get service account group memberships: %v", err)
+	}
+
+	return apiContext.Write(convertServiceAccount(user, groupIDs))
+}
+
+// createServiceAccount creates a new service account.
+func (s *Server) createServiceAccount(apiContext api.Context) error {
+	var manifest types2.ServiceAccountManifest
+	if err := apiContext.Read(&manifest); err != nil {
+		return types2.NewErrBadRequest("invalid request body: %v", err)
+	}
+
+	if !serviceAccountNameRegex.MatchString(manifest.Name) {
+		return types2.NewErrBadRequest("name must be 1 to 63 lowercase alphanumeric characters or dashes, and start and end with an alphanumeric character")
+	}
+	if manifest.DisplayName == "" {
+		manifest.DisplayName = manifest.Name
+	}
+	if manifest.Role == types2.RoleUnknown {
+		manifest.Role = types2.RoleBasic
+	}
+	if err := validateServiceAccountRole(apiContext, types2.RoleUnknown, manifest.Role); err != nil {
+		return err
+	}
+
+	user, err := apiContext.GatewayClient.CreateServiceAccount(apiContext.Context(), manifest.Name, manifest.DisplayName, manifest.Role, manifest.GroupIDs)
+	if err != nil {
+		return serviceAccountError("create", err)
+	}
+	pkgLog.Infof("Created service account: userID=%d name=%s role=%d", user.ID, manifest.Name, user.Role)
+
+	// Trigger reconciliation so that the role of the service account's groups is applied.
+	if err = createUserRoleChange(apiContext, user.ID); err != nil {
+		return err
+	}
+
+	groupIDs, err := apiContext.GatewayClient.ListGroupIDsForUser(apiContext.Context(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get service account group memberships: %v", err)
+	}
+
+	return apiContext.WriteCreated(convertServiceAccount(user, groupIDs))
+}
+
+// updateServiceAccount updates the display name, role and group memberships of a service account.
+func (s *Server) updateServiceAccount(apiContext api.Context) error {
+	existing, err := serviceAccountFromPath(apiContext)
+	if err != nil {
+		return err
+	}
+
+	var manifest types2.ServiceAccountManifest
+	if err := apiContext.Read(&manifest); err != nil {
+		return types2.NewErrBadRequest("invalid request body: %v", err)
+	}
+
+	if manifest.DisplayName == "" {
+		manifest.DisplayName = existing.DisplayName
+	}
+	if manifest.Role == types2.RoleUnknown {
+		manifest.Role = existing.Role
+	}
+	if err := validateServiceAccountRole(apiContext, existing.Role, manifest.Role); err != nil {
+		return err
+	}
+
+	user, membershipsChanged, groupsLost, err := apiContext.GatewayClient.UpdateServiceAccount(apiContext.Context(), existing.ID, manifest.DisplayName, manifest.Role, manifest.GroupIDs)
+	if err != nil {
+		return serviceAccountError("update", err)
+	}
+	pkgLog.Infof("Updated service account: userID=%d role=%d membershipsChanged=%v", user.ID, user.Role, membershipsChanged)
+
+	if existing.Role != user.Role || membershipsChanged {
+		if err = createUserRoleChange(apiContext, user.ID); err != nil {
+			return err
+		}
+	}
+	if groupsLost {
+		// Clean up the MCP servers that the service account can no longer access through its groups.
+		if err = apiContext.Create(&v1.UserGroupChange{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: system.UserGroupChangePrefix,
+				Namespace:    apiContext.Namespace(),
+			},
+			Spec: v1.UserGroupChangeSpec{
+				UserID: user.ID,
+			},
+		}); err != nil {
+			return fmt.Errorf("failed to create user group change event: %v", err)
+		}
+	}
+
+	groupIDs, err := apiContext.GatewayClient.ListGroupIDsForUser(apiContext.Context(), user.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get service account group memberships: %v", err)
+	}
+
+	return apiContext.Write(convertServiceAccount(user, groupIDs))
+}
+
+// deleteServiceAccount deletes a service account the same way as any other user is deleted.
+func (s *Server) deleteServiceAccount(apiContext api.Context) error {
+	if _, err := serviceAccountFromPath(apiContext); err != nil {
+		return err
+	}
+
+	return s.deleteUser(apiContext)
+}
+
+func serviceAccountFromPath(apiContext api.Context) (*types.User, error) {
+	userID := apiContext.PathValue("user_id")
+	if _, err := strconv.ParseUint(userID, 10, 64); err != nil {
+		return nil, types2.NewErrBadRequest("invalid service account ID: %s", userID)
+	}
+
+	user, err := apiContext.GatewayClient.UserByID(apiContext.Context(), userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || err == nil && !user.ServiceAccount {
+		return nil, types2.NewErrNotFound("service account %s not found", userID)
+	} else if err != nil {
+		return nil, fmt.Errorf("failed to get service account: %v", err)
+	}
+
+	return user, nil
+}
+
+// validateServiceAccountRole checks that the role can be given to a service account by the requester.
+// Service accounts are never owners or admins, so that every admin action can be attributed to a person.
+func validateServiceAccountRole(apiContext api.Context, existingRole, role types2.Role) error {
+	if role.HasRole(types2.RoleAdmin) || role.HasUserImpersonationRole() {
+		return types2.NewErrBadRequest("service accounts can't have the owner, admin or user impersonation roles")
+	}
+	if existingRole.HasAuditorRole() != role.HasAuditorRole() && !apiContext.UserIsOwner() {
+		pkgLog.Infof("Denied service account role update: requestedRole=%d reason=auditor_role_change_requires_owner", role)
+		return types2.NewErrHTTP(http.StatusForbidden, "only owner can add or remove auditor role")
+	}
+
+	return nil
+}
+
+func serviceAccountError(action string, err error) error {
+	status := http.StatusInternalServerError
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		status = http.StatusNotFound
+	} else if ae := (*client.AlreadyExistsError)(nil); errors.As(err, &ae) {
+		status = http.StatusConflict
+	} else if gnf := (*client.GroupNotFoundError)(nil); errors.As(err, &gnf) {
+		status = http.StatusBadRequest
+	}
+	return types2.NewErrHTTP(status, fmt.Sprintf("failed to %s service account: %v", action, err))
+}
+
+func createUserRoleChange(apiContext api.Context, userID uint) error {
+	if err := apiContext.Create(&v1.UserRoleChange{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: system.UserRoleChangePrefix,
+			Namespace:    apiContext.Namespace(),
+		},
+		Spec: v1.UserRoleChangeSpec{
+			UserID: userID,
+		},
+	}); err != nil {
+		return fmt.Errorf("failed to create user role change event: %v", err)
+	}
+
+	return nil
+}
+
+func convertServiceAccount(user *types.User, groupIDs []string) types2.ServiceAccount {
+	return types2.ServiceAccount{
+		Metadata: types2.Metadata{
+			ID:      fmt.Sprint(user.ID),
+			Created: *types2.NewTime(user.CreatedAt),
+		},
+		ServiceAccountManifest: types2.ServiceAccountManifest{
+			Name:        strings.TrimPrefix(user.Username, client.ServiceAccountUsernamePrefix),
+			DisplayName: user.DisplayName,
+			Role:        user.Role,
+			GroupIDs:    groupIDs,
+		},
+		Username: user.Username,
+	}
+}
@@ -206,6 +206,15 @@ func (s *Server) updateUser(apiContext api.Context) error {
 		}
 	}
 
+	if originalUser.ServiceAccount {
+		if user.Username != "" && user.Username != originalUser.Username {
+			return types2.NewErrHTTP(http.StatusBadRequest, "the username of a service account can't be changed")
+		}
+		if user.Role.HasRole(types2.RoleAdmin) || user.Role.HasUserImpersonationRole() {
+			return types2.NewErrHTTP(http.StatusBadRequest, "service accounts can't have the owner, admin or user impersonation roles")
+		}
+	}
+
 	if user.Role.HasUserImpersonationRole() && !user.Role.HasRole(types2.RoleAdmin) && !user.Role.HasRole(types2.RoleOwner) {
 		return types2.NewErrHTTP(http.StatusBadRequest, "user impersonation role can only be combined with admin or owner")
 	}
@@ -0,0 +1,35 @@
+package types
+
+import "time"
+
+const (
+	ChatMessageRoleUser      = "user"
+	ChatMessageRoleAssistant = "assistant"
+	ChatMessageRoleTool      = "tool"
+)
+
+// ChatMessage is a user-visible message or tool call of a chat run, stored for full-text search of chat history.
+type ChatMessage struct {
+	ID          uint      `json:"id" gorm:"primaryKey"`
+	RunName     string    `json:"runName" gorm:"index"`
+	ThreadName  string    `json:"threadName" gorm:"index"`
+	ProjectName string    `json:"projectName" gorm:"index"`
+	UserID      string    `json:"userID" gorm:"index"`
+	Role        string    `json:"role"`
+	ToolName    string    `json:"toolName,omitempty"`
+	Content     string    `json:"content"`
+	CreatedAt   time.Time `json:"createdAt" gorm:"index"`
+}
+
+// ChatMessageSearchResult is a chat message that matched a search, with a snippet of its content. Matching terms in the
+// snippet are wrapped in ChatMessageHighlightStart and ChatMessageHighlightEnd.
+type ChatMessageSearchResult struct {
+	ChatMessage
+	Snippet string  `json:"snippet"`
+	Rank    float64 `json:"rank"`
+}
+
+const (
+	ChatMessageHighlightStart = "\x02"
+	ChatMessageHighlightEnd   = "\x03"
+)
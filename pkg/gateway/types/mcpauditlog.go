@@ -38,6 +38,10 @@ type MCPAuditLog struct {
 	RequestHeaders  json.RawMessage `json:"requestHeaders,omitempty"`
 	ResponseHeaders json.RawMessage `json:"responseHeaders,omitempty"`
 
+	// RetentionDays is the retention of the audit log policy that applied when the call was recorded.
+	// If zero, the server's default retention applies.
+	RetentionDays int `json:"retentionDays,omitempty" gorm:"index"`
+
 	ResponseReceived bool `json:"responseReceived"`
 	Encrypted        bool `json:"encrypted"`
 }
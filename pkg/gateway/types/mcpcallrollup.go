@@ -0,0 +1,69 @@
+package types
+
+import "time"
+
+// MCPCallRollup counts the completed MCP calls of an hour that share the same server, call, user, client, API key,
+// response status and latency bucket. Rollups are updated as audit logs are persisted so that usage trends can be
+// queried without scanning the audit logs.
+type MCPCallRollup struct {
+	ID                        uint      `json:"id" gorm:"primaryKey"`
+	BucketStart               time.Time `json:"bucketStart" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	MCPID                     string    `json:"mcpID" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	PowerUserWorkspaceID      string    `json:"powerUserWorkspaceID,omitempty" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	MCPServerDisplayName      string    `json:"mcpServerDisplayName" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	MCPServerCatalogEntryName string    `json:"mcpServerCatalogEntryName" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	CallType                  string    `json:"callType" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	CallIdentifier            string    `json:"callIdentifier,omitempty" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	UserID                    string    `json:"userID" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	ClientName                string    `json:"clientName" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	APIKey                    string    `json:"apiKey,omitempty" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	ResponseStatus            int       `json:"responseStatus" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	// LatencyBucket is the index into MCPUsageLatencyBucketBoundsMs of the calls' processing time.
+	LatencyBucket int `json:"latencyBucket" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+	// RetentionDays is the retention of the audit log policy that applied when the calls were recorded, so that the
+	// rollups are deleted with the audit logs. If zero, the server's default retention applies.
+	RetentionDays int `json:"retentionDays,omitempty" gorm:"uniqueIndex:idx_mcp_call_rollups_key"`
+
+	CallCount             int64 `json:"callCount"`
+	ErrorCount            int64 `json:"errorCount"`
+	TotalProcessingTimeMs int64 `json:"totalProcessingTimeMs"`
+}
+
+// MCPUsageLatencyBucketBoundsMs are the inclusive upper bounds of the processing time histogram buckets. Calls slower
+// than the last bound are counted in an additional bucket.
+var MCPUsageLatencyBucketBoundsMs = []int64{5, 10, 25, 50, 100, 250, 500, 1000, 2500, 5000, 10000, 30000, 60000, 120000}
+
+// MCPUsageLatencyBucket returns the histogram bucket of a processing time.
+func MCPUsageLatencyBucket(processingTimeMs int64) int {
+	for i, bound := range MCPUsageLatencyBucketBoundsMs {
+		if processingTimeMs <= bound {
+			return i
+		}
+	}
+	return len(MCPUsageLatencyBucketBoundsMs)
+}
+
+// NewMCPCallRollup returns the rollup of a single completed call.
+func NewMCPCallRollup(log MCPAuditLog) MCPCallRollup {
+	rollup := MCPCallRollup{
+		BucketStart:               log.CreatedAt.UTC().Truncate(time.Hour),
+		MCPID:                     log.MCPID,
+		PowerUserWorkspaceID:      log.PowerUserWorkspaceID,
+		MCPServerDisplayName:      log.MCPServerDisplayName,
+		MCPServerCatalogEntryName: log.MCPServerCatalogEntryName,
+		CallType:                  log.CallType,
+		CallIdentifier:            log.CallIdentifier,
+		UserID:                    log.UserID,
+		ClientName:                log.ClientName,
+		APIKey:                    log.APIKey,
+		ResponseStatus:            log.ResponseStatus,
+		LatencyBucket:             MCPUsageLatencyBucket(log.ProcessingTimeMs),
+		RetentionDays:             log.RetentionDays,
+		CallCount:                 1,
+		TotalProcessingTimeMs:     log.ProcessingTimeMs,
+	}
+	if log.Error != "" || log.ResponseStatus >= 400 {
+		rollup.ErrorCount = 1
+	}
+	return rollup
+}
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"fmt"
+	"time"
+
+	types2 "github.com/obot-platform/obot/apiclient/types"
+)
+
+// MCPWebhookShadowDecision records a decision of a MCP webhook validation in shadow mode, which isn't enforced.
+type MCPWebhookShadowDecision struct {
+	ID                uint      `json:"id" gorm:"primaryKey"`
+	CreatedAt         time.Time `json:"createdAt" gorm:"index"`
+	ValidationName    string    `json:"validationName" gorm:"index"`
+	MCPID             string    `json:"mcpID" gorm:"index"`
+	UserID            string    `json:"userID"`
+	CallType          string    `json:"callType"`
+	CallIdentifier    string    `json:"callIdentifier,omitempty"`
+	Decision          string    `json:"decision"`
+	Message           string    `json:"message,omitempty"`
+	LatencyMs         int64     `json:"latencyMs"`
+	WebhookStatusCode int       `json:"webhookStatusCode,omitempty"`
+}
+
+func ConvertMCPWebhookShadowDecision(d MCPWebhookShadowDecision) types2.MCPWebhookShadowDecision {
+	return types2.MCPWebhookShadowDecision{
+		ID:                fmt.Sprint(d.ID),
+		Created:           *types2.NewTime(d.CreatedAt),
+		ValidationName:    d.ValidationName,
+		MCPID:             d.MCPID,
+		UserID:            d.UserID,
+		CallType:          d.CallType,
+		CallIdentifier:    d.CallIdentifier,
+		Decision:          types2.MCPWebhookDecision(d.Decision),
+		Message:           d.Message,
+		LatencyMs:         d.LatencyMs,
+		WebhookStatusCode: d.WebhookStatusCode,
+	}
+}
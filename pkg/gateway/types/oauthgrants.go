@@ -0,0 +1,11 @@
+package types
+
+import "time"
+
+// RevokedOAuthGrant records an OAuth authorization that was revoked. Access tokens are JWTs that can't be deleted, so
+// the tokens that were issued for the grant are rejected until they would have expired anyway.
+type RevokedOAuthGrant struct {
+	GrantID   string    `json:"grantID" gorm:"primaryKey"`
+	ExpiresAt time.Time `json:"expiresAt" gorm:"index"`
+	CreatedAt time.Time `json:"createdAt"`
+}
@@ -0,0 +1,19 @@
+package types
+
+import "time"
+
+// SearchDocument is the indexed content and embedding of a searchable resource, like a skill or published artifact.
+type SearchDocument struct {
+	// ID is the kind and resource name of the document, like "skill/sk1abc".
+	ID         string    `json:"id" gorm:"primaryKey"`
+	Kind       string    `json:"kind" gorm:"index"`
+	ResourceID string    `json:"resourceID"`
+	Body       string    `json:"body"`
+	UpdatedAt  time.Time `json:"updatedAt"`
+	// ContentHash is the hash of the content that was embedded, used to skip re-embedding unchanged documents.
+	ContentHash string `json:"contentHash"`
+	// EmbeddingModel is the model that produced the embedding. It is empty if the document couldn't be embedded.
+	EmbeddingModel string `json:"embeddingModel"`
+	// Embedding is the document's vector, encoded as little-endian float32 values.
+	Embedding []byte `json:"-"`
+}
@@ -32,6 +32,9 @@ type User struct {
 	DailyPromptTokensLimit     int       `json:"dailyPromptTokensLimit"`
 	DailyCompletionTokensLimit int       `json:"dailyCompletionTokensLimit"`
 	Encrypted                  bool      `json:"encrypted"`
+	// ServiceAccount indicates that the user is a non-human identity that authenticates with the client credentials of
+	// the OAuth clients bound to it, rather than through an auth provider.
+	ServiceAccount bool `json:"serviceAccount" gorm:"default:false"`
 	// Soft delete fields
 	DeletedAt        *time.Time `json:"deletedAt,omitempty"`
 	OriginalEmail    string     `json:"-"`
@@ -74,6 +77,7 @@ func ConvertUserWithEffectiveRole(u *User, roleFixed bool, authProviderName stri
 		DailyCompletionTokensLimit: u.DailyCompletionTokensLimit,
 		OriginalEmail:              u.OriginalEmail,
 		OriginalUsername:           u.OriginalUsername,
+		ServiceAccount:             u.ServiceAccount,
 	}
 
 	if u.DeletedAt != nil {
@@ -88,6 +92,7 @@ type UserQuery struct {
 	Email          string
 	Role           types2.Role
 	IncludeDeleted bool
+	ServiceAccount bool
 }
 
 func NewUserQuery(u url.Values) UserQuery {
@@ -101,6 +106,7 @@ func NewUserQuery(u url.Values) UserQuery {
 		Email:          u.Get("email"),
 		Role:           types2.Role(role),
 		IncludeDeleted: u.Get("includeDeleted") == "true",
+		ServiceAccount: u.Get("serviceAccount") == "true",
 	}
 }
 
@@ -114,6 +120,9 @@ func (q UserQuery) Scope(db *gorm.DB) *gorm.DB {
 	if q.Role != 0 {
 		db = db.Where("role = ?", q.Role)
 	}
+	if q.ServiceAccount {
+		db = db.Where("service_account = ?", true)
+	}
 
 	// Filter out soft-deleted users by default
 	if !q.IncludeDeleted {
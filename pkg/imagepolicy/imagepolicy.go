@@ -0,0 +1,186 @@
+package imagepolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/distribution/reference"
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ViolationError is returned when an image is not allowed by the container image policy.
+type ViolationError struct {
+	Image  string
+	Reason string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("image %q is not allowed by the container image policy: %s", e.Image, e.Reason)
+}
+
+// Get returns the container image policy. If one hasn't been configured, then an empty policy that allows all images is returned.
+func Get(ctx context.Context, client kclient.Client) (types.ContainerImagePolicyManifest, error) {
+	var policy v1.ContainerImagePolicy
+	if err := client.Get(ctx, kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: system.ContainerImagePolicyName}, &policy); apierrors.IsNotFound(err) {
+		return types.ContainerImagePolicyManifest{}, nil
+	} else if err != nil {
+		return types.ContainerImagePolicyManifest{}, fmt.Errorf("failed to get container image policy: %w", err)
+	}
+
+	return policy.Spec.ContainerImagePolicyManifest, nil
+}
+
+// Validate returns an error if the policy is not valid.
+func Validate(policy types.ContainerImagePolicyManifest) error {
+	var errs []error
+	for _, allowed := range policy.AllowedImages {
+		if _, err := parsePattern(allowed); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	if policy.RequireSignature && len(policy.SignaturePublicKeys) == 0 {
+		errs = append(errs, errors.New("at least one signature public key is required when signatures are required"))
+	}
+
+	for i, key := range policy.SignaturePublicKeys {
+		if _, err := parsePublicKey(key); err != nil {
+			errs = append(errs, fmt.Errorf("invalid signature public key %d: %w", i, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check returns a [ViolationError] if the image's repository is not allowed or it isn't pinned by digest when required.
+// Signatures are verified separately by a [Verifier] because it requires contacting the image's registry.
+func Check(policy types.ContainerImagePolicyManifest, image string) error {
+	if len(policy.AllowedImages) == 0 && !policy.RequireDigest && !policy.RequireSignature {
+		return nil
+	}
+
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(image))
+	if err != nil {
+		return &ViolationError{Image: image, Reason: fmt.Sprintf("invalid image reference: %v", err)}
+	}
+
+	if len(policy.AllowedImages) > 0 && !slices.ContainsFunc(policy.AllowedImages, func(allowed string) bool {
+		p, err := parsePattern(allowed)
+		return err == nil && p.matches(named)
+	}) {
+		return &ViolationError{Image: image, Reason: fmt.Sprintf("repository %s is not in the list of allowed images", named.Name())}
+	}
+
+	if _, ok := named.(reference.Canonical); policy.RequireDigest && !ok {
+		return &ViolationError{Image: image, Reason: "the image must be pinned by digest"}
+	}
+
+	return nil
+}
+
+// CheckServerManifest checks the images of the server, including those of a composite server's components.
+// Images that reference environment variables are skipped because they can only be checked after they are expanded at deploy time.
+func CheckServerManifest(policy types.ContainerImagePolicyManifest, manifest types.MCPServerManifest) error {
+	switch {
+	case manifest.Runtime == types.RuntimeContainerized && manifest.ContainerizedConfig != nil:
+		return checkManifestImage(policy, manifest.ContainerizedConfig.Image)
+	case manifest.Runtime == types.RuntimeComposite && manifest.CompositeConfig != nil:
+		for _, component := range manifest.CompositeConfig.ComponentServers {
+			if err := CheckServerManifest(policy, component.Manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckCatalogEntryManifest checks the images of the catalog entry, including those of a composite entry's components.
+// Like [CheckServerManifest], images that reference environment variables are skipped.
+func CheckCatalogEntryManifest(policy types.ContainerImagePolicyManifest, manifest types.MCPServerCatalogEntryManifest) error {
+	switch {
+	case manifest.Runtime == types.RuntimeContainerized && manifest.ContainerizedConfig != nil:
+		return checkManifestImage(policy, manifest.ContainerizedConfig.Image)
+	case manifest.Runtime == types.RuntimeComposite && manifest.CompositeConfig != nil:
+		for _, component := range manifest.CompositeConfig.ComponentServers {
+			if err := CheckCatalogEntryManifest(policy, component.Manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+func checkManifestImage(policy types.ContainerImagePolicyManifest, image string) error {
+	if strings.Contains(image, "${") {
+		return nil
+	}
+	return Check(policy, image)
+}
+
+// pattern is a parsed entry of a policy's allowed images.
+type pattern struct {
+	value string
+	// registry indicates that all repositories in the registry are allowed.
+	registry bool
+	// prefix indicates that all repositories under value are allowed.
+	prefix bool
+}
+
+func parsePattern(allowed string) (pattern, error) {
+	allowed = strings.TrimSpace(allowed)
+	if allowed == "" {
+		return pattern{}, errors.New("allowed image entries cannot be empty")
+	}
+
+	value, prefix := strings.CutSuffix(allowed, "/*")
+	if isRegistry(value) {
+		return pattern{value: value, registry: true}, nil
+	}
+
+	named, err := reference.ParseNormalizedNamed(value)
+	if err != nil {
+		return pattern{}, fmt.Errorf("invalid allowed image %q: %w", allowed, err)
+	}
+	if !reference.IsNameOnly(named) {
+		return pattern{}, fmt.Errorf("allowed image %q must not include a tag or digest", allowed)
+	}
+
+	return pattern{value: named.Name(), prefix: prefix}, nil
+}
+
+func (p pattern) matches(named reference.Named) bool {
+	switch {
+	case p.registry:
+		return reference.Domain(named) == p.value
+	case p.prefix:
+		return strings.HasPrefix(named.Name(), p.value+"/")
+	default:
+		return named.Name() == p.value
+	}
+}
+
+// isRegistry returns true if the value is a registry host, using the same rules as image references.
+// A value with a colon is only a registry if it is followed by a port, so that "nginx:latest" isn't mistaken for one.
+func isRegistry(value string) bool {
+	if strings.Contains(value, "/") {
+		return false
+	}
+
+	host, port, hasPort := strings.Cut(value, ":")
+	if hasPort {
+		_, err := strconv.ParseUint(port, 10, 16)
+		return err == nil
+	}
+
+	return strings.Contains(host, ".") || host == "localhost"
+}
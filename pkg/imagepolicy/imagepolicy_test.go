@@ -0,0 +1,225 @@
+package imagepolicy
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testDigest = "sha256:0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+func TestCheck(t *testing.T) {
+	tests := []struct {
+		name    string
+		policy  types.ContainerImagePolicyManifest
+		image   string
+		allowed bool
+	}{
+		{
+			name:    "empty policy allows everything",
+			image:   "evil.example.com/miner:latest",
+			allowed: true,
+		},
+		{
+			name:    "registry entry",
+			policy:  types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io"}},
+			image:   "ghcr.io/obot-platform/mcp:v1",
+			allowed: true,
+		},
+		{
+			name:   "registry entry does not match other registries",
+			policy: types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io"}},
+			image:  "docker.io/obot-platform/mcp:v1",
+		},
+		{
+			name:    "prefix entry",
+			policy:  types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io/obot-platform/*"}},
+			image:   "ghcr.io/obot-platform/servers/github",
+			allowed: true,
+		},
+		{
+			name:   "prefix entry does not match similarly named organizations",
+			policy: types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io/obot-platform/*"}},
+			image:  "ghcr.io/obot-platform-fork/github",
+		},
+		{
+			name:    "docker hub short names are normalized",
+			policy:  types.ContainerImagePolicyManifest{AllowedImages: []string{"nginx"}},
+			image:   "docker.io/library/nginx:1.27",
+			allowed: true,
+		},
+		{
+			name:   "repository entry is exact",
+			policy: types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io/obot-platform/mcp"}},
+			image:  "ghcr.io/obot-platform/mcp-other",
+		},
+		{
+			name:   "digest required",
+			policy: types.ContainerImagePolicyManifest{RequireDigest: true},
+			image:  "ghcr.io/obot-platform/mcp:v1",
+		},
+		{
+			name:    "digest provided",
+			policy:  types.ContainerImagePolicyManifest{RequireDigest: true},
+			image:   "ghcr.io/obot-platform/mcp@" + testDigest,
+			allowed: true,
+		},
+		{
+			name:   "invalid image",
+			policy: types.ContainerImagePolicyManifest{AllowedImages: []string{"ghcr.io"}},
+			image:  "Not A Valid Image",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.policy, tt.image)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				var violation *ViolationError
+				assert.ErrorAs(t, err, &violation)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	key, _ := testKey(t)
+
+	assert.NoError(t, Validate(types.ContainerImagePolicyManifest{
+		AllowedImages:       []string{"ghcr.io", "ghcr.io/obot-platform/*", "nginx", "localhost:5000/*"},
+		RequireDigest:       true,
+		RequireSignature:    true,
+		SignaturePublicKeys: []string{key},
+	}))
+
+	assert.Error(t, Validate(types.ContainerImagePolicyManifest{AllowedImages: []string{""}}))
+	assert.Error(t, Validate(types.ContainerImagePolicyManifest{AllowedImages: []string{"nginx:latest"}}))
+	assert.Error(t, Validate(types.ContainerImagePolicyManifest{RequireSignature: true}))
+	assert.Error(t, Validate(types.ContainerImagePolicyManifest{SignaturePublicKeys: []string{"not a key"}}))
+}
+
+func TestVerifySignature(t *testing.T) {
+	key, privateKey := testKey(t)
+	_, otherPrivateKey := testKey(t)
+
+	payload := fmt.Appendf(nil, `{"critical":{"identity":{"docker-reference":"example"},"image":{"docker-manifest-digest":%q},"type":"cosign container image signature"}}`, testDigest)
+	payloadDigest := fmt.Sprintf("sha256:%x", sha256.Sum256(payload))
+
+	newRegistry := func(signer *ecdsa.PrivateKey) *httptest.Server {
+		sum := sha256.Sum256(payload)
+		signature, err := ecdsa.SignASN1(rand.Reader, signer, sum[:])
+		require.NoError(t, err)
+
+		sigManifest, err := json.Marshal(map[string]any{
+			"layers": []map[string]any{{
+				"digest":      payloadDigest,
+				"annotations": map[string]string{signatureAnnotation: base64.StdEncoding.EncodeToString(signature)},
+			}},
+		})
+		require.NoError(t, err)
+
+		return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			switch r.URL.Path {
+			case "/v2/org/server/manifests/v1":
+				w.Header().Set("Docker-Content-Digest", testDigest)
+				_, _ = w.Write([]byte(`{}`))
+			case "/v2/org/server/manifests/" + strings.Replace(testDigest, ":", "-", 1) + ".sig":
+				_, _ = w.Write(sigManifest)
+			case "/v2/org/server/blobs/" + payloadDigest:
+				_, _ = w.Write(payload)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	}
+
+	policy := types.ContainerImagePolicyManifest{
+		RequireSignature:    true,
+		SignaturePublicKeys: []string{key},
+	}
+
+	t.Run("trusted signature", func(t *testing.T) {
+		server := newRegistry(privateKey)
+		defer server.Close()
+
+		v := NewVerifier()
+		v.plainHTTP = true
+		host := strings.TrimPrefix(server.URL, "http://")
+		image, err := v.Verify(context.Background(), policy, host+"/org/server:v1")
+		require.NoError(t, err)
+		// The verified digest is deployed instead of the tag.
+		assert.Equal(t, host+"/org/server@"+testDigest, image)
+	})
+
+	t.Run("pinned after the tag is pushed again", func(t *testing.T) {
+		server := newRegistry(privateKey)
+		host := strings.TrimPrefix(server.URL, "http://")
+
+		v := NewVerifier()
+		v.plainHTTP = true
+		image, err := v.Verify(context.Background(), policy, host+"/org/server:v1")
+		require.NoError(t, err)
+
+		// The cached verification still pins the image to the digest that was verified.
+		server.Config.Handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Docker-Content-Digest", "sha256:"+strings.Repeat("0", 64))
+			_, _ = w.Write([]byte(`{}`))
+		})
+		defer server.Close()
+
+		cached, err := v.Verify(context.Background(), policy, host+"/org/server:v1")
+		require.NoError(t, err)
+		assert.Equal(t, image, cached)
+		assert.Equal(t, host+"/org/server@"+testDigest, cached)
+	})
+
+	t.Run("untrusted signature", func(t *testing.T) {
+		server := newRegistry(otherPrivateKey)
+		defer server.Close()
+
+		v := NewVerifier()
+		v.plainHTTP = true
+		var violation *ViolationError
+		_, err := v.Verify(context.Background(), policy, strings.TrimPrefix(server.URL, "http://")+"/org/server:v1")
+		assert.ErrorAs(t, err, &violation)
+	})
+
+	t.Run("unsigned image", func(t *testing.T) {
+		server := newRegistry(privateKey)
+		defer server.Close()
+
+		v := NewVerifier()
+		v.plainHTTP = true
+		_, err := v.Verify(context.Background(), policy, strings.TrimPrefix(server.URL, "http://")+"/org/unsigned:v1")
+		assert.Error(t, err)
+	})
+}
+
+func testKey(t *testing.T) (string, *ecdsa.PrivateKey) {
+	t.Helper()
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+
+	der, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	require.NoError(t, err)
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: der})), privateKey
+}
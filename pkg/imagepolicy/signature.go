@@ -0,0 +1,397 @@
+package imagepolicy
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/distribution/reference"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/hash"
+)
+
+const (
+	// signatureAnnotation is the annotation on a cosign signature layer that contains the base64 encoded signature.
+	signatureAnnotation = "dev.cosignproject.cosign/signature"
+	// verifiedTTL is how long a successful signature verification is cached.
+	verifiedTTL = time.Hour
+	// maxRegistryResponseSize limits the size of manifests and signature payloads read from a registry.
+	maxRegistryResponseSize = 4 << 20
+)
+
+var manifestMediaTypes = []string{
+	"application/vnd.oci.image.index.v1+json",
+	"application/vnd.oci.image.manifest.v1+json",
+	"application/vnd.docker.distribution.manifest.list.v2+json",
+	"application/vnd.docker.distribution.manifest.v2+json",
+}
+
+// Verifier enforces the container image policy, including signature verification, before an image is deployed.
+type Verifier struct {
+	client    *http.Client
+	plainHTTP bool
+
+	lock     sync.Mutex
+	verified map[string]verifiedImage
+}
+
+// verifiedImage is a cached successful signature verification.
+type verifiedImage struct {
+	pinned string
+	at     time.Time
+}
+
+func NewVerifier() *Verifier {
+	return &Verifier{
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		verified: make(map[string]verifiedImage),
+	}
+}
+
+// Verify returns a [ViolationError] if the image is not allowed by the policy or, when the policy requires it,
+// the image doesn't have a cosign signature that can be verified with one of the policy's public keys.
+// The image to deploy is returned. When the signature is verified, this is the image pinned to the verified digest,
+// so that a tag that is pushed again after the verification can't be used to deploy an image that isn't signed.
+func (v *Verifier) Verify(ctx context.Context, policy types.ContainerImagePolicyManifest, image string) (string, error) {
+	if err := Check(policy, image); err != nil {
+		return "", err
+	}
+	if !policy.RequireSignature {
+		return image, nil
+	}
+
+	cacheKey := hash.String([]any{image, policy.SignaturePublicKeys})
+	v.lock.Lock()
+	verified, ok := v.verified[cacheKey]
+	v.lock.Unlock()
+	if ok && time.Since(verified.at) < verifiedTTL {
+		return verified.pinned, nil
+	}
+
+	keys := make([]crypto.PublicKey, 0, len(policy.SignaturePublicKeys))
+	for _, k := range policy.SignaturePublicKeys {
+		key, err := parsePublicKey(k)
+		if err != nil {
+			return "", &ViolationError{Image: image, Reason: fmt.Sprintf("invalid signature public key: %v", err)}
+		}
+		keys = append(keys, key)
+	}
+
+	named, err := reference.ParseNormalizedNamed(strings.TrimSpace(image))
+	if err != nil {
+		return "", &ViolationError{Image: image, Reason: fmt.Sprintf("invalid image reference: %v", err)}
+	}
+
+	digest, err := v.verifySignature(ctx, named, keys)
+	if err != nil {
+		return "", &ViolationError{Image: image, Reason: err.Error()}
+	}
+
+	pinned, err := reference.ParseNormalizedNamed(reference.TrimNamed(named).String() + "@" + digest)
+	if err != nil {
+		return "", &ViolationError{Image: image, Reason: fmt.Sprintf("invalid image digest: %v", err)}
+	}
+
+	verified = verifiedImage{
+		pinned: reference.FamiliarString(pinned),
+		at:     time.Now(),
+	}
+	v.lock.Lock()
+	v.verified[cacheKey] = verified
+	v.lock.Unlock()
+
+	return verified.pinned, nil
+}
+
+// verifySignature verifies the signature of the image, and returns the digest that was verified.
+func (v *Verifier) verifySignature(ctx context.Context, named reference.Named, keys []crypto.PublicKey) (string, error) {
+	r := v.newRegistry(named)
+
+	var digest string
+	if canonical, ok := named.(reference.Canonical); ok {
+		digest = canonical.Digest().String()
+	} else {
+		tag := "latest"
+		if tagged, ok := named.(reference.Tagged); ok {
+			tag = tagged.Tag()
+		}
+
+		var err error
+		if digest, _, err = r.manifest(ctx, tag); err != nil {
+			return "", fmt.Errorf("failed to resolve image digest: %w", err)
+		}
+	}
+
+	// Cosign stores the signatures of an image in a manifest tagged with the image's digest.
+	_, body, err := r.manifest(ctx, strings.Replace(digest, ":", "-", 1)+".sig")
+	if errors.Is(err, errNotFound) {
+		return "", fmt.Errorf("no signature found for digest %s", digest)
+	} else if err != nil {
+		return "", fmt.Errorf("failed to get image signatures: %w", err)
+	}
+
+	var signatures struct {
+		Layers []struct {
+			Digest      string            `json:"digest"`
+			Annotations map[string]string `json:"annotations"`
+		} `json:"layers"`
+	}
+	if err := json.Unmarshal(body, &signatures); err != nil {
+		return "", fmt.Errorf("failed to decode image signatures: %w", err)
+	}
+
+	for _, layer := range signatures.Layers {
+		signature, err := base64.StdEncoding.DecodeString(layer.Annotations[signatureAnnotation])
+		if err != nil || len(signature) == 0 {
+			continue
+		}
+
+		payload, err := r.blob(ctx, layer.Digest)
+		if err != nil {
+			return "", fmt.Errorf("failed to get signature payload: %w", err)
+		}
+
+		if verifyPayload(keys, payload, signature, digest) {
+			return digest, nil
+		}
+	}
+
+	return "", fmt.Errorf("no signature for digest %s could be verified with the trusted public keys", digest)
+}
+
+// verifyPayload returns true if the signature of the payload can be verified by one of the keys,
+// and the payload is for the given image digest.
+func verifyPayload(keys []crypto.PublicKey, payload, signature []byte, digest string) bool {
+	var simpleSigning struct {
+		Critical struct {
+			Image struct {
+				DockerManifestDigest string `json:"docker-manifest-digest"`
+			} `json:"image"`
+		} `json:"critical"`
+	}
+	if err := json.Unmarshal(payload, &simpleSigning); err != nil || simpleSigning.Critical.Image.DockerManifestDigest != digest {
+		return false
+	}
+
+	sum := sha256.Sum256(payload)
+	for _, key := range keys {
+		switch k := key.(type) {
+		case *ecdsa.PublicKey:
+			if ecdsa.VerifyASN1(k, sum[:], signature) {
+				return true
+			}
+		case *rsa.PublicKey:
+			if rsa.VerifyPKCS1v15(k, crypto.SHA256, sum[:], signature) == nil {
+				return true
+			}
+		case ed25519.PublicKey:
+			if ed25519.Verify(k, payload, signature) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func parsePublicKey(key string) (crypto.PublicKey, error) {
+	block, _ := pem.Decode([]byte(strings.TrimSpace(key)))
+	if block == nil {
+		return nil, errors.New("key is not PEM encoded")
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+
+	switch publicKey.(type) {
+	case *ecdsa.PublicKey, *rsa.PublicKey, ed25519.PublicKey:
+		return publicKey, nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %T", publicKey)
+	}
+}
+
+var errNotFound = errors.New("not found")
+
+// registry is a minimal, anonymous client for the OCI distribution API.
+type registry struct {
+	client     *http.Client
+	baseURL    string
+	repository string
+	token      string
+}
+
+func (v *Verifier) newRegistry(named reference.Named) *registry {
+	host := reference.Domain(named)
+	if host == "docker.io" {
+		host = "registry-1.docker.io"
+	}
+
+	scheme := "https"
+	if v.plainHTTP {
+		scheme = "http"
+	}
+
+	return &registry{
+		client:     v.client,
+		baseURL:    fmt.Sprintf("%s://%s/v2/%s", scheme, host, reference.Path(named)),
+		repository: reference.Path(named),
+	}
+}
+
+// manifest returns the digest and contents of the manifest with the given tag or digest.
+func (r *registry) manifest(ctx context.Context, ref string) (string, []byte, error) {
+	resp, err := r.get(ctx, "/manifests/"+ref, strings.Join(manifestMediaTypes, ","))
+	if err != nil {
+		return "", nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+	if err != nil {
+		return "", nil, err
+	}
+
+	digest := resp.Header.Get("Docker-Content-Digest")
+	if digest == "" {
+		digest = fmt.Sprintf("sha256:%x", sha256.Sum256(body))
+	}
+
+	return digest, body, nil
+}
+
+// blob returns the contents of the blob with the given digest, after verifying that it matches the digest.
+func (r *registry) blob(ctx context.Context, digest string) ([]byte, error) {
+	resp, err := r.get(ctx, "/blobs/"+digest, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxRegistryResponseSize))
+	if err != nil {
+		return nil, err
+	}
+
+	if fmt.Sprintf("sha256:%x", sha256.Sum256(body)) != digest {
+		return nil, fmt.Errorf("blob does not match digest %s", digest)
+	}
+
+	return body, nil
+}
+
+func (r *registry) get(ctx context.Context, path, accept string) (*http.Response, error) {
+	for attempt := 0; ; attempt++ {
+		req, err := http.NewRequestWithContext(ctx, http.MethodGet, r.baseURL+path, nil)
+		if err != nil {
+			return nil, err
+		}
+		if accept != "" {
+			req.Header.Set("Accept", accept)
+		}
+		if r.token != "" {
+			req.Header.Set("Authorization", "Bearer "+r.token)
+		}
+
+		resp, err := r.client.Do(req)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case resp.StatusCode == http.StatusOK:
+			return resp, nil
+		case resp.StatusCode == http.StatusUnauthorized && attempt == 0:
+			challenge := resp.Header.Get("WWW-Authenticate")
+			resp.Body.Close()
+			if err := r.authenticate(ctx, challenge); err != nil {
+				return nil, err
+			}
+		case resp.StatusCode == http.StatusNotFound:
+			resp.Body.Close()
+			return nil, errNotFound
+		default:
+			resp.Body.Close()
+			return nil, fmt.Errorf("unexpected status from registry for %s: %s", path, resp.Status)
+		}
+	}
+}
+
+// authenticate gets an anonymous token for pulling from the repository using the registry's bearer challenge.
+func (r *registry) authenticate(ctx context.Context, challenge string) error {
+	scheme, params, _ := strings.Cut(challenge, " ")
+	if !strings.EqualFold(scheme, "Bearer") {
+		return fmt.Errorf("registry requires unsupported authentication: %q", challenge)
+	}
+
+	values := make(map[string]string)
+	for _, param := range strings.Split(params, ",") {
+		k, v, ok := strings.Cut(strings.TrimSpace(param), "=")
+		if ok {
+			values[k] = strings.Trim(v, `"`)
+		}
+	}
+
+	if values["realm"] == "" {
+		return fmt.Errorf("registry authentication challenge is missing a realm: %q", challenge)
+	}
+
+	query := url.Values{}
+	if values["service"] != "" {
+		query.Set("service", values["service"])
+	}
+	if values["scope"] != "" {
+		query.Set("scope", values["scope"])
+	} else {
+		query.Set("scope", fmt.Sprintf("repository:%s:pull", r.repository))
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, values["realm"]+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+
+	resp, err := r.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to get registry token: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to get registry token: %s", resp.Status)
+	}
+
+	var token struct {
+		Token       string `json:"token"`
+		AccessToken string `json:"access_token"`
+	}
+	if err := json.NewDecoder(io.LimitReader(resp.Body, maxRegistryResponseSize)).Decode(&token); err != nil {
+		return fmt.Errorf("failed to decode registry token: %w", err)
+	}
+
+	r.token = token.Token
+	if r.token == "" {
+		r.token = token.AccessToken
+	}
+
+	return nil
+}
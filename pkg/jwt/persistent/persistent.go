@@ -45,6 +45,9 @@ func NewTokenService(serverURL string, gatewayClient *client.Client, credOnlyGPT
 	return t, nil
 }
 
+// ErrTokenRevoked is returned when decoding a token that was issued for a revoked OAuth grant.
+var ErrTokenRevoked = errors.New("token has been revoked")
+
 type TokenType string
 
 const (
@@ -153,6 +156,10 @@ type TokenContext struct {
 	AuthProviderUserID    string
 
 	MCPID string
+	// GrantID identifies the OAuth authorization that the token was issued for, so that the token can be revoked.
+	GrantID string
+	// OAuthClientID is the ID of the OAuth client that the token was issued to.
+	OAuthClientID string
 
 	// The following fields are for runs
 	Namespace         string
@@ -271,6 +278,14 @@ func (t *TokenService) DecodeToken(ctx context.Context, token string) (*TokenCon
 		return nil, err
 	}
 
+	if grantID, _ := claims["GrantID"].(string); grantID != "" {
+		if revoked, err := t.gatewayClient.IsOAuthGrantRevoked(ctx, grantID); err != nil {
+			return nil, fmt.Errorf("failed to check whether token was revoked: %w", err)
+		} else if revoked {
+			return nil, ErrTokenRevoked
+		}
+	}
+
 	var groups []string
 	if userGroups, ok := claims["UserGroups"].(string); ok {
 		groups = strings.Split(userGroups, ",")
@@ -306,6 +321,8 @@ func (t *TokenService) DecodeToken(ctx context.Context, token string) (*TokenCon
 		AuthProviderNamespace: getStringClaim("AuthProviderNamespace"),
 		AuthProviderUserID:    getStringClaim("AuthProviderUserID"),
 		MCPID:                 getStringClaim("MCPID"),
+		GrantID:               getStringClaim("GrantID"),
+		OAuthClientID:         getStringClaim("client_id"),
 		Namespace:             getStringClaim("Namespace"),
 		RunID:                 getStringClaim("RunID"),
 		ThreadID:              getStringClaim("ThreadID"),
@@ -345,6 +362,8 @@ func (t *TokenService) NewToken(ctx context.Context, context TokenContext) (stri
 		"AuthProviderNamespace": context.AuthProviderNamespace,
 		"AuthProviderUserID":    context.AuthProviderUserID,
 		"MCPID":                 context.MCPID,
+		"GrantID":               context.GrantID,
+		"client_id":             context.OAuthClientID,
 		"Namespace":             context.Namespace,
 		"RunID":                 context.RunID,
 		"ThreadID":              context.ThreadID,
@@ -0,0 +1,112 @@
+package legalhold
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"strings"
+
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Holds is the set of users, projects and MCP servers covered by the active legal holds.
+type Holds struct {
+	users, projects, mcpServers map[string]struct{}
+}
+
+// Active returns the users, projects and MCP servers covered by the legal holds that haven't been released.
+func Active(ctx context.Context, c kclient.Client) (*Holds, error) {
+	var list v1.LegalHoldList
+	if err := c.List(ctx, &list, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return nil, err
+	}
+
+	return newHolds(list.Items), nil
+}
+
+func newHolds(legalHolds []v1.LegalHold) *Holds {
+	h := &Holds{
+		users:      map[string]struct{}{},
+		projects:   map[string]struct{}{},
+		mcpServers: map[string]struct{}{},
+	}
+	for _, hold := range legalHolds {
+		if hold.Spec.Released {
+			continue
+		}
+		for _, id := range hold.Spec.Manifest.UserIDs {
+			h.users[id] = struct{}{}
+		}
+		for _, id := range hold.Spec.Manifest.ProjectIDs {
+			// Projects are threads, referred to by their project ID in the API.
+			h.projects[strings.Replace(id, system.ProjectPrefix, system.ThreadPrefix, 1)] = struct{}{}
+		}
+		for _, id := range hold.Spec.Manifest.MCPServerIDs {
+			h.mcpServers[id] = struct{}{}
+		}
+	}
+	return h
+}
+
+func (h *Holds) Empty() bool {
+	return len(h.users) == 0 && len(h.projects) == 0 && len(h.mcpServers) == 0
+}
+
+func (h *Holds) HoldsUser(userID string) bool {
+	_, ok := h.users[userID]
+	return ok
+}
+
+func (h *Holds) HoldsMCPServer(id string) bool {
+	_, ok := h.mcpServers[id]
+	return ok
+}
+
+// HoldsThread returns whether the thread, or the project it belongs to, is held.
+func (h *Holds) HoldsThread(thread *v1.Thread) bool {
+	if h.HoldsUser(thread.Spec.UserID) {
+		return true
+	}
+	if _, ok := h.projects[thread.Name]; ok && thread.Spec.Project {
+		return true
+	}
+	_, ok := h.projects[thread.Spec.ParentThreadName]
+	return ok && thread.Spec.ParentThreadName != ""
+}
+
+// PreventsDeletion returns whether deleting the thread would delete held data. Deleting a project deletes the threads
+// in it, which may belong to held users other than the project's owner.
+func (h *Holds) PreventsDeletion(ctx context.Context, c kclient.Client, thread *v1.Thread) (bool, error) {
+	if h.Empty() {
+		return false, nil
+	}
+	if h.HoldsThread(thread) {
+		return true, nil
+	}
+	if !thread.Spec.Project || len(h.users) == 0 {
+		return false, nil
+	}
+
+	var threads v1.ThreadList
+	if err := c.List(ctx, &threads, kclient.InNamespace(thread.Namespace), kclient.MatchingFields{
+		"spec.parentThreadName": thread.Name,
+	}); err != nil {
+		return false, err
+	}
+
+	return slices.ContainsFunc(threads.Items, func(thread v1.Thread) bool {
+		return h.HoldsUser(thread.Spec.UserID)
+	}), nil
+}
+
+// UserIDs returns the held users, sorted.
+func (h *Holds) UserIDs() []string {
+	return slices.Sorted(maps.Keys(h.users))
+}
+
+// MCPServerIDs returns the held MCP servers, sorted.
+func (h *Holds) MCPServerIDs() []string {
+	return slices.Sorted(maps.Keys(h.mcpServers))
+}
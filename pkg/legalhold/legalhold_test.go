@@ -0,0 +1,82 @@
+package legalhold
+
+import (
+	"context"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	storagescheme "github.com/obot-platform/obot/pkg/storage/scheme"
+	"github.com/obot-platform/obot/pkg/system"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func thread(name, userID, parent string, project bool) *v1.Thread {
+	return &v1.Thread{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: system.DefaultNamespace},
+		Spec: v1.ThreadSpec{
+			UserID:           userID,
+			ParentThreadName: parent,
+			Project:          project,
+		},
+	}
+}
+
+func TestHolds(t *testing.T) {
+	holds := newHolds([]v1.LegalHold{
+		{Spec: v1.LegalHoldSpec{Manifest: types.LegalHoldManifest{
+			UserIDs:      []string{"2"},
+			ProjectIDs:   []string{"p1held"},
+			MCPServerIDs: []string{"ms1held"},
+		}}},
+		{Spec: v1.LegalHoldSpec{
+			Manifest: types.LegalHoldManifest{UserIDs: []string{"3"}, MCPServerIDs: []string{"ms1released"}},
+			Released: true,
+		}},
+	})
+
+	assert.Equal(t, []string{"2"}, holds.UserIDs())
+	assert.Equal(t, []string{"ms1held"}, holds.MCPServerIDs())
+
+	assert.True(t, holds.HoldsThread(thread("t1chat", "2", "t1other", false)))
+	assert.True(t, holds.HoldsThread(thread("t1held", "1", "", true)))
+	assert.True(t, holds.HoldsThread(thread("t1chat", "1", "t1held", false)))
+	assert.False(t, holds.HoldsThread(thread("t1chat", "3", "t1other", false)))
+	assert.False(t, holds.HoldsThread(thread("t1other", "1", "", true)))
+
+	assert.True(t, holds.HoldsMCPServer("ms1held"))
+	assert.False(t, holds.HoldsMCPServer("ms1released"))
+
+	assert.True(t, newHolds(nil).Empty())
+}
+
+func TestPreventsDeletion(t *testing.T) {
+	ctx := context.Background()
+	c := fake.NewClientBuilder().
+		WithScheme(storagescheme.Scheme).
+		WithObjects(
+			thread("t1shared", "1", "", true),
+			thread("t1chat", "2", "t1shared", false),
+			thread("t1other", "1", "", true),
+			thread("t1mine", "1", "t1other", false),
+		).
+		WithIndex(&v1.Thread{}, "spec.parentThreadName", func(obj kclient.Object) []string {
+			return []string{obj.(*v1.Thread).Spec.ParentThreadName}
+		}).
+		Build()
+
+	holds := newHolds([]v1.LegalHold{{Spec: v1.LegalHoldSpec{Manifest: types.LegalHoldManifest{UserIDs: []string{"2"}}}}})
+
+	// The project belongs to another user, but deleting it would delete the held user's thread.
+	held, err := holds.PreventsDeletion(ctx, c, thread("t1shared", "1", "", true))
+	require.NoError(t, err)
+	assert.True(t, held)
+
+	held, err = holds.PreventsDeletion(ctx, c, thread("t1other", "1", "", true))
+	require.NoError(t, err)
+	assert.False(t, held)
+}
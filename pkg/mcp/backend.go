@@ -29,7 +29,13 @@ type backend interface {
 	streamServerLogs(ctx context.Context, id string) (io.ReadCloser, error)
 	getServerDetails(ctx context.Context, id string) (types.MCPServerDetails, error)
 	restartServer(ctx context.Context, server ServerConfig) error
+	// probeServer checks that an already deployed server is running, without deploying it, and returns the config to
+	// connect to it
+	probeServer(ctx context.Context, server ServerConfig) (ServerConfig, error)
 	shutdownServer(ctx context.Context, id string, hardShutdown bool) error
+	// warmImages pre-pulls the images so that servers using them start faster. Images that were pre-pulled before, but
+	// aren't in the list anymore, are no longer kept warm.
+	warmImages(ctx context.Context, images []string) error
 	transformObotHostname(url string) string
 }
 
@@ -49,6 +55,8 @@ var (
 	ErrPodSchedulingFailed    = errors.New("pod could not be scheduled")
 	ErrPodConfigurationFailed = errors.New("pod configuration is invalid")
 	ErrInsufficientCapacity   = errors.New("insufficient cluster capacity to deploy MCP server")
+	ErrServerQuotaExceeded    = errors.New("maximum number of running MCP servers reached")
+	ErrColdStartTimeout       = errors.New("timed out waiting for MCP server to start")
 )
 
 func ensureServerReady(ctx context.Context, url string, server ServerConfig) error {
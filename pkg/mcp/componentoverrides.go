@@ -0,0 +1,311 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// ComponentOverrideSet applies the prompt and resource overrides of a component server to the messages that the MCP
+// gateway forwards between the composite server and the component server. The composite server then publishes the
+// prompts and resources as they are exposed by the component.
+type ComponentOverrideSet struct {
+	namespace string
+
+	// prompts are the prompt overrides keyed by the original prompt names. They are nil if all prompts are exposed.
+	prompts map[string]types.PromptOverride
+	// exposedPrompts maps the exposed names of the enabled prompts to their original names.
+	exposedPrompts map[string]string
+
+	// resources are the resource overrides keyed by the original URIs. They are nil if all resources are exposed.
+	resources map[string]types.ResourceOverride
+	// exposedResources maps the exposed URIs of the enabled resources to their original URIs.
+	exposedResources map[string]string
+	// templates match the original URIs of the resources of the enabled resource templates.
+	templates []*regexp.Regexp
+}
+
+// NewComponentOverrideSet returns the prompt and resource overrides of the component server, or nil if there are none.
+func NewComponentOverrideSet(component types.ComponentServer) *ComponentOverrideSet {
+	if component.Namespace == "" && len(component.PromptOverrides) == 0 && len(component.ResourceOverrides) == 0 {
+		return nil
+	}
+
+	set := &ComponentOverrideSet{
+		namespace: component.Namespace,
+	}
+
+	if len(component.PromptOverrides) > 0 {
+		set.prompts = make(map[string]types.PromptOverride, len(component.PromptOverrides))
+		set.exposedPrompts = make(map[string]string, len(component.PromptOverrides))
+		for _, prompt := range component.PromptOverrides {
+			set.prompts[prompt.Name] = prompt
+			if prompt.Enabled {
+				set.exposedPrompts[prompt.ExposedName(set.namespace)] = prompt.Name
+			}
+		}
+	}
+
+	if len(component.ResourceOverrides) > 0 {
+		set.resources = make(map[string]types.ResourceOverride, len(component.ResourceOverrides))
+		set.exposedResources = make(map[string]string, len(component.ResourceOverrides))
+		for _, resource := range component.ResourceOverrides {
+			set.resources[resource.URI] = resource
+			if !resource.Enabled {
+				continue
+			}
+			if resource.IsTemplate() {
+				set.templates = append(set.templates, uriTemplateRegexp(resource.URI))
+			} else {
+				set.exposedResources[resource.ExposedURI(set.namespace)] = resource.URI
+			}
+		}
+	}
+
+	return set
+}
+
+var uriTemplateExpressionRegex = regexp.MustCompile(`\{[^}]*\}`)
+
+// uriTemplateRegexp returns a regular expression that matches the URIs that the URI template expands to.
+func uriTemplateRegexp(template string) *regexp.Regexp {
+	var (
+		pattern strings.Builder
+		last    int
+	)
+	pattern.WriteString("^")
+	for _, loc := range uriTemplateExpressionRegex.FindAllStringIndex(template, -1) {
+		pattern.WriteString(regexp.QuoteMeta(template[last:loc[0]]))
+		pattern.WriteString(".*")
+		last = loc[1]
+	}
+	pattern.WriteString(regexp.QuoteMeta(template[last:]))
+	pattern.WriteString("$")
+	return regexp.MustCompile(pattern.String())
+}
+
+// originalPromptName returns the name that the component server uses for the exposed prompt name.
+func (s *ComponentOverrideSet) originalPromptName(name string) (string, bool) {
+	if s.prompts != nil {
+		original, ok := s.exposedPrompts[name]
+		return original, ok
+	}
+	if s.namespace == "" {
+		return name, true
+	}
+	return strings.CutPrefix(name, s.namespace+"_")
+}
+
+// originalResourceURI returns the URI that the component server uses for the exposed resource URI.
+func (s *ComponentOverrideSet) originalResourceURI(uri string) (string, bool) {
+	if original, ok := s.exposedResources[uri]; ok {
+		return original, true
+	}
+
+	original := uri
+	if s.namespace != "" {
+		var ok bool
+		if original, ok = strings.CutPrefix(uri, s.namespace+"+"); !ok {
+			return "", false
+		}
+	}
+
+	if s.resources == nil {
+		return original, true
+	}
+	if resource, ok := s.resources[original]; ok {
+		// Renamed resources are only available with their exposed URIs.
+		return original, resource.Enabled && resource.OverrideURI == ""
+	}
+	for _, template := range s.templates {
+		if template.MatchString(original) {
+			return original, true
+		}
+	}
+	return "", false
+}
+
+// exposedResourceURI returns the URI that is exposed for a resource URI of the component server.
+func (s *ComponentOverrideSet) exposedResourceURI(uri string) string {
+	if resource, ok := s.resources[uri]; ok && resource.OverrideURI != "" {
+		return resource.OverrideURI
+	}
+	return types.NamespacedResourceURI(s.namespace, uri)
+}
+
+// RewriteParams maps the exposed prompt names and resource URIs in the params of a request to those of the component
+// server. An error is returned if the prompt or resource isn't exposed.
+func (s *ComponentOverrideSet) RewriteParams(method string, params json.RawMessage) (json.RawMessage, error) {
+	switch method {
+	case "prompts/get":
+		return rewriteStringField(params, "name", func(name string) (string, error) {
+			if original, ok := s.originalPromptName(name); ok {
+				return original, nil
+			}
+			return "", fmt.Errorf("unknown prompt: %s", name)
+		})
+	case "resources/read", "resources/subscribe", "resources/unsubscribe":
+		return rewriteStringField(params, "uri", func(uri string) (string, error) {
+			if original, ok := s.originalResourceURI(uri); ok {
+				return original, nil
+			}
+			return "", fmt.Errorf("unknown resource: %s", uri)
+		})
+	case "completion/complete":
+		var complete map[string]json.RawMessage
+		if err := json.Unmarshal(params, &complete); err != nil {
+			return nil, fmt.Errorf("invalid completion parameters: %w", err)
+		}
+
+		var ref struct {
+			Type string `json:"type"`
+		}
+		if err := json.Unmarshal(complete["ref"], &ref); err != nil {
+			return nil, fmt.Errorf("invalid completion reference: %w", err)
+		}
+
+		var (
+			rewritten json.RawMessage
+			err       error
+		)
+		switch ref.Type {
+		case "ref/prompt":
+			rewritten, err = s.RewriteParams("prompts/get", complete["ref"])
+		case "ref/resource":
+			rewritten, err = s.RewriteParams("resources/read", complete["ref"])
+		default:
+			return params, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		complete["ref"] = rewritten
+		return json.Marshal(complete)
+	}
+	return params, nil
+}
+
+// RewriteResult applies the overrides to the prompts, resources and resource templates in the result of a list request,
+// and to the URIs of the contents of a read resource. It returns the original result and false if nothing was changed.
+func (s *ComponentOverrideSet) RewriteResult(method string, result json.RawMessage) (json.RawMessage, bool) {
+	switch method {
+	case "prompts/list":
+		return rewriteListResult(result, "prompts", func(prompt map[string]json.RawMessage) bool {
+			var name string
+			if err := json.Unmarshal(prompt["name"], &name); err != nil {
+				return true
+			}
+
+			exposed := types.NamespacedPromptName(s.namespace, name)
+			if s.prompts != nil {
+				override, ok := s.prompts[name]
+				if !ok || !override.Enabled {
+					return false
+				}
+				exposed = override.ExposedName(s.namespace)
+				if override.OverrideDescription != "" {
+					prompt["description"], _ = json.Marshal(override.OverrideDescription)
+				}
+			}
+			prompt["name"], _ = json.Marshal(exposed)
+			return true
+		})
+	case "resources/list":
+		return rewriteListResult(result, "resources", s.rewriteResource("uri"))
+	case "resources/templates/list":
+		return rewriteListResult(result, "resourceTemplates", s.rewriteResource("uriTemplate"))
+	case "resources/read":
+		return rewriteListResult(result, "contents", func(content map[string]json.RawMessage) bool {
+			var uri string
+			if err := json.Unmarshal(content["uri"], &uri); err == nil {
+				content["uri"], _ = json.Marshal(s.exposedResourceURI(uri))
+			}
+			return true
+		})
+	}
+	return result, false
+}
+
+// rewriteResource returns a function that filters and rewrites the resources or resource templates in a list result,
+// which have their URIs in the given field.
+func (s *ComponentOverrideSet) rewriteResource(field string) func(map[string]json.RawMessage) bool {
+	return func(resource map[string]json.RawMessage) bool {
+		var uri string
+		if err := json.Unmarshal(resource[field], &uri); err != nil {
+			return true
+		}
+
+		if s.resources != nil {
+			override, ok := s.resources[uri]
+			if !ok || !override.Enabled {
+				return false
+			}
+			if override.OverrideDescription != "" {
+				resource["description"], _ = json.Marshal(override.OverrideDescription)
+			}
+		}
+		resource[field], _ = json.Marshal(s.exposedResourceURI(uri))
+		return true
+	}
+}
+
+// rewriteStringField replaces the string field in the params of a request with the value returned by rewrite.
+func rewriteStringField(params json.RawMessage, field string, rewrite func(string) (string, error)) (json.RawMessage, error) {
+	var p map[string]json.RawMessage
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, fmt.Errorf("invalid parameters: %w", err)
+	}
+
+	var value string
+	if err := json.Unmarshal(p[field], &value); err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", field, err)
+	}
+
+	rewritten, err := rewrite(value)
+	if err != nil {
+		return nil, err
+	}
+	if rewritten == value {
+		return params, nil
+	}
+
+	if p[field], err = json.Marshal(rewritten); err != nil {
+		return nil, err
+	}
+	return json.Marshal(p)
+}
+
+// rewriteListResult rewrites the objects in the list under the key of the result. Objects for which rewrite returns
+// false are removed. It returns the original result and false if the result has no such list.
+func rewriteListResult(result json.RawMessage, key string, rewrite func(map[string]json.RawMessage) bool) (json.RawMessage, bool) {
+	var r map[string]json.RawMessage
+	if err := json.Unmarshal(result, &r); err != nil {
+		return result, false
+	}
+
+	var items []map[string]json.RawMessage
+	if err := json.Unmarshal(r[key], &items); err != nil || items == nil {
+		return result, false
+	}
+
+	rewritten := make([]map[string]json.RawMessage, 0, len(items))
+	for _, item := range items {
+		if rewrite(item) {
+			rewritten = append(rewritten, item)
+		}
+	}
+
+	b, err := json.Marshal(rewritten)
+	if err != nil {
+		return result, false
+	}
+	r[key] = b
+
+	if b, err = json.Marshal(r); err != nil {
+		return result, false
+	}
+	return b, true
+}
@@ -0,0 +1,95 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewComponentOverrideSet(t *testing.T) {
+	assert.Nil(t, NewComponentOverrideSet(types.ComponentServer{
+		ToolOverrides: []types.ToolOverride{{Name: "search", Enabled: true}},
+	}))
+	assert.NotNil(t, NewComponentOverrideSet(types.ComponentServer{Namespace: "runbooks"}))
+}
+
+func TestComponentOverrideSet_Namespace(t *testing.T) {
+	set := NewComponentOverrideSet(types.ComponentServer{Namespace: "runbooks"})
+	require.NotNil(t, set)
+
+	result, changed := set.RewriteResult("prompts/list", json.RawMessage(`{"prompts":[{"name":"restart","description":"Restart a service"}]}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"prompts":[{"name":"runbooks_restart","description":"Restart a service"}]}`, string(result))
+
+	result, changed = set.RewriteResult("resources/templates/list", json.RawMessage(`{"resourceTemplates":[{"uriTemplate":"file:///{path}","name":"files"}]}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"resourceTemplates":[{"uriTemplate":"runbooks+file:///{path}","name":"files"}]}`, string(result))
+
+	params, err := set.RewriteParams("prompts/get", json.RawMessage(`{"name":"runbooks_restart","arguments":{"service":"api"}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"restart","arguments":{"service":"api"}}`, string(params))
+
+	params, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"runbooks+file:///restart.md"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri":"file:///restart.md"}`, string(params))
+
+	result, changed = set.RewriteResult("resources/read", json.RawMessage(`{"contents":[{"uri":"file:///restart.md","text":"..."}]}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"contents":[{"uri":"runbooks+file:///restart.md","text":"..."}]}`, string(result))
+
+	_, err = set.RewriteParams("prompts/get", json.RawMessage(`{"name":"restart"}`))
+	assert.EqualError(t, err, "unknown prompt: restart")
+
+	_, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"file:///restart.md"}`))
+	assert.EqualError(t, err, "unknown resource: file:///restart.md")
+}
+
+func TestComponentOverrideSet_Overrides(t *testing.T) {
+	set := NewComponentOverrideSet(types.ComponentServer{
+		PromptOverrides: []types.PromptOverride{
+			{Name: "restart", OverrideName: "restart-service", OverrideDescription: "Restart one of our services", Enabled: true},
+			{Name: "delete", Enabled: false},
+		},
+		ResourceOverrides: []types.ResourceOverride{
+			{URI: "file:///readme.md", OverrideURI: "docs://readme", Enabled: true},
+			{URI: "file:///runbooks/{name}", Enabled: true},
+		},
+	})
+	require.NotNil(t, set)
+
+	result, changed := set.RewriteResult("prompts/list", json.RawMessage(`{"prompts":[{"name":"restart"},{"name":"delete"},{"name":"other"}]}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"prompts":[{"name":"restart-service","description":"Restart one of our services"}]}`, string(result))
+
+	result, changed = set.RewriteResult("resources/list", json.RawMessage(`{"resources":[{"uri":"file:///readme.md","name":"readme"},{"uri":"file:///secrets.txt","name":"secrets"}]}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"resources":[{"uri":"docs://readme","name":"readme"}]}`, string(result))
+
+	params, err := set.RewriteParams("prompts/get", json.RawMessage(`{"name":"restart-service"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"restart"}`, string(params))
+
+	params, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"docs://readme"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri":"file:///readme.md"}`, string(params))
+
+	params, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"file:///runbooks/restart.md"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"uri":"file:///runbooks/restart.md"}`, string(params))
+
+	params, err = set.RewriteParams("completion/complete", json.RawMessage(`{"ref":{"type":"ref/prompt","name":"restart-service"},"argument":{"name":"service","value":"a"}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"ref":{"type":"ref/prompt","name":"restart"},"argument":{"name":"service","value":"a"}}`, string(params))
+
+	_, err = set.RewriteParams("prompts/get", json.RawMessage(`{"name":"delete"}`))
+	assert.EqualError(t, err, "unknown prompt: delete")
+
+	_, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"file:///readme.md"}`))
+	assert.EqualError(t, err, "unknown resource: file:///readme.md")
+
+	_, err = set.RewriteParams("resources/read", json.RawMessage(`{"uri":"file:///secrets.txt"}`))
+	assert.EqualError(t, err, "unknown resource: file:///secrets.txt")
+}
@@ -3,13 +3,18 @@ package mcp
 import (
 	"context"
 	"crypto/rand"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io"
 	"maps"
 	"net"
+	"net/http"
+	"net/netip"
 	"os"
 	"path"
 	"regexp"
+	"slices"
 	"sort"
 	"strconv"
 	"strings"
@@ -28,6 +33,7 @@ import (
 	"github.com/moby/moby/api/types/volume"
 	"github.com/moby/moby/client"
 	otypes "github.com/obot-platform/obot/apiclient/types"
+	"k8s.io/apimachinery/pkg/api/resource"
 )
 
 var localhostURLRegexp = regexp.MustCompile(`^http://localhost(:\d+)?`)
@@ -47,6 +53,15 @@ type dockerBackend struct {
 	deploymentCache               map[string]*dockerDeploymentCacheEntry
 	fileSyncMu                    sync.RWMutex
 	syncedFilesHash               map[string]string
+	egressMu                      sync.Mutex
+	egressProxyURL                string
+
+	// capacityMu makes capacity checks and container creation atomic, so that concurrent deployments can't exceed the
+	// budget or quota together.
+	capacityMu        sync.Mutex
+	containerLimits   dockerResources
+	budget            dockerResources
+	maxServersPerUser int
 }
 
 type dockerDeploymentCacheEntry struct {
@@ -76,6 +91,22 @@ func newDockerBackend(ctx context.Context, exposedPort int, opts Options) (backe
 		if err != nil {
 			return nil, fmt.Errorf("failed to detect current IP: %w", err)
 		}
+		log.Warnf("Obot isn't running in a container, so MCP servers with the allow egress mode can reach metadata endpoints")
+	}
+
+	containerLimits, err := parseDockerResources(opts.MCPDockerContainerMemoryLimit, opts.MCPDockerContainerCPULimit)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MCP container limits: %w", err)
+	}
+	budget, err := parseDockerResources(opts.MCPDockerMemoryBudget, opts.MCPDockerCPUBudget)
+	if err != nil {
+		return nil, fmt.Errorf("invalid MCP budget: %w", err)
+	}
+	if budget.memory > 0 && containerLimits.memory == 0 {
+		return nil, fmt.Errorf("the MCP memory budget requires a container memory limit")
+	}
+	if budget.nanoCPUs > 0 && containerLimits.nanoCPUs == 0 {
+		return nil, fmt.Errorf("the MCP CPU budget requires a container CPU limit")
 	}
 
 	d := &dockerBackend{
@@ -90,6 +121,9 @@ func newDockerBackend(ctx context.Context, exposedPort int, opts Options) (backe
 		auditLogsFlushIntervalSeconds: opts.MCPAuditLogPersistIntervalSeconds,
 		deploymentCache:               map[string]*dockerDeploymentCacheEntry{},
 		syncedFilesHash:               map[string]string{},
+		containerLimits:               containerLimits,
+		budget:                        budget,
+		maxServersPerUser:             opts.MCPDockerMaxServersPerUser,
 	}
 
 	if err = d.cleanupDeprecatedContainers(ctx); err != nil {
@@ -180,6 +214,7 @@ func (d *dockerBackend) cleanupDeprecatedContainers(ctx context.Context) error {
 // deployServer will deploy the underlying container for the server. It will not deploy any shims or webhooks.
 // This is only to give users the opportunity to view logs and debug the server they are trying to deploy.
 func (d *dockerBackend) deployServer(ctx context.Context, server ServerConfig, _ []Webhook) error {
+	server = d.enforceableEgress(server)
 	configHash := clientID(server)
 	// Check if container already exists
 	existing, err := d.getContainer(ctx, server.MCPServerName)
@@ -192,7 +227,17 @@ func (d *dockerBackend) deployServer(ctx context.Context, server ServerConfig, _
 	return err
 }
 
+// enforceableEgress drops the allow mode egress policy of the server if Obot doesn't run in a container. The egress proxy
+// that blocks metadata endpoints requires it, and servers that are allowed to reach anything shouldn't fail to deploy.
+func (d *dockerBackend) enforceableEgress(server ServerConfig) ServerConfig {
+	if !d.containerEnv && server.EgressPolicy != nil && server.EgressPolicy.Mode == otypes.MCPEgressModeAllow {
+		server.EgressPolicy = nil
+	}
+	return server
+}
+
 func (d *dockerBackend) ensureServerDeployment(ctx context.Context, server ServerConfig, webhooks []Webhook) (ServerConfig, error) {
+	server = d.enforceableEgress(server)
 	serverName := server.MCPServerName
 	serverConfigHash := hash.Digest(map[string]any{"server": server, "webhooks": webhooks})
 	var err error
@@ -214,11 +259,16 @@ func (d *dockerBackend) ensureServerDeployment(ctx context.Context, server Serve
 
 	mcpServerName := server.MCPServerName
 	if server.Runtime != otypes.RuntimeRemote {
+		restricted := server.EgressPolicy != nil
+
 		// For non-remote runtimes, we deploy the real MCP server first.
 		server, err = d.ensureDeployment(ctx, server, mcpServerName, d.containerEnv || server.NanobotAgentName == "", nil)
 		if err != nil {
 			return ServerConfig{}, err
 		}
+
+		// The shim must also be attached to the egress network to reach a restricted server.
+		server.egressPeer = restricted
 		expectedContainers[server.MCPServerName] = server.Scope
 
 		// If this is a server for a nanobot agent, return the config pointing to the real server without deploying the shim.
@@ -273,6 +323,18 @@ func (d *dockerBackend) ensureDeployment(ctx context.Context, server ServerConfi
 		configHash += hash.Digest(webhooks)
 	}
 
+	desiredNetwork := d.network
+	if server.EgressPolicy != nil {
+		proxyURL, err := d.ensureEgressProxy(ctx)
+		if err != nil {
+			return ServerConfig{}, err
+		}
+
+		// Include the proxy URL in the config hash so that containers are recreated if Obot's address changes.
+		configHash += hash.Digest(proxyURL)
+		desiredNetwork = dockerEgressNetwork
+	}
+
 	// Check if container already exists
 	existing, err := d.getContainer(ctx, server.MCPServerName)
 	if err == nil && existing != nil {
@@ -285,7 +347,8 @@ func (d *dockerBackend) ensureDeployment(ctx context.Context, server ServerConfi
 		if existing.Labels["mcp.config.hash"] != configHash ||
 			currentFileEnvKeysHash != desiredFileEnvKeysHash ||
 			existing.NetworkSettings == nil ||
-			existing.NetworkSettings.Networks[d.network] == nil ||
+			existing.NetworkSettings.Networks[desiredNetwork] == nil ||
+			server.egressPeer && existing.NetworkSettings.Networks[dockerEgressNetwork] == nil ||
 			desiredImage != "" && existing.Image != desiredImage {
 			// Clear the state. The below logic will remove and recreate the container.
 			existing.State = ""
@@ -536,6 +599,30 @@ func (d *dockerBackend) restartServer(ctx context.Context, server ServerConfig)
 	return nil
 }
 
+func (d *dockerBackend) probeServer(ctx context.Context, server ServerConfig) (ServerConfig, error) {
+	c, err := d.getContainer(ctx, server.MCPServerName)
+	if err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to get container %s: %w", server.MCPServerName, err)
+	}
+	if c == nil {
+		return ServerConfig{}, ErrServerNotRunning
+	}
+	if c.State != container.StateRunning {
+		return ServerConfig{}, fmt.Errorf("%w: container %s is %s", ErrHealthCheckFailed, server.MCPServerName, c.State)
+	}
+
+	// Connect through the shim, if there is one, like the clients of the server do.
+	config, err := d.transformConfig(ctx, server)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+	if config == nil {
+		return ServerConfig{}, fmt.Errorf("%w: the shim of container %s isn't running", ErrHealthCheckFailed, server.MCPServerName)
+	}
+
+	return *config, nil
+}
+
 func applyServerConfigToContainerConfig(config *container.Config, server ServerConfig) {
 	if config == nil || server.ContainerImage == "" {
 		return
@@ -579,6 +666,18 @@ func (d *dockerBackend) shutdownServer(ctx context.Context, id string, hardShutd
 	return nil
 }
 
+// warmImages pulls the images. Images that are no longer in the list are kept, because Docker doesn't remove them either
+// when the servers using them are removed.
+func (d *dockerBackend) warmImages(ctx context.Context, images []string) error {
+	var errs []error
+	for _, image := range images {
+		if err := d.pullImage(ctx, image, false); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
 func (d *dockerBackend) removeObjectsForContainer(ctx context.Context, c *container.Summary, id string, includeVolumes bool) error {
 	if includeVolumes {
 		var volumeNames []string
@@ -724,9 +823,9 @@ func (d *dockerBackend) buildServerConfig(server ServerConfig, c *container.Summ
 			return ServerConfig{}, fmt.Errorf("container %s not found or has no network settings", c.ID)
 		}
 
-		n, ok := c.NetworkSettings.Networks[d.network]
+		n, ok := c.NetworkSettings.Networks[d.containerNetwork(c)]
 		if !ok || n.IPAddress == "" {
-			return ServerConfig{}, fmt.Errorf("container %s is not connected to %s network", c.ID, d.network)
+			return ServerConfig{}, fmt.Errorf("container %s is not connected to %s network", c.ID, d.containerNetwork(c))
 		}
 
 		host = n.IPAddress
@@ -970,14 +1069,19 @@ func (d *dockerBackend) createAndStartContainer(ctx context.Context, server Serv
 		}
 	}
 
-	// Host config with port bindings and volume mounts
+	// Host config with port bindings, volume mounts and resource limits
 	hostConfig := &container.HostConfig{
 		PortBindings: map[nat.Port][]nat.PortBinding{nat.Port(containerPortStr): {{HostIP: "127.0.0.1"}}},
 		Mounts:       volumeMounts,
 		RestartPolicy: container.RestartPolicy{
 			Name: "unless-stopped",
 		},
+		Resources: container.Resources{
+			Memory:   d.containerLimits.memory,
+			NanoCPUs: d.containerLimits.nanoCPUs,
+		},
 	}
+	d.containerLimits.setLabels(config.Labels)
 
 	if err := d.pullImage(ctx, image, false); err != nil {
 		return "", 0, fmt.Errorf("failed to ensure image exists: %w", err)
@@ -991,6 +1095,44 @@ func (d *dockerBackend) createAndStartContainer(ctx context.Context, server Serv
 		}
 	}
 
+	if server.EgressPolicy != nil {
+		proxyURL, err := d.ensureEgressProxy(ctx)
+		if err != nil {
+			return "", 0, err
+		}
+
+		policy, err := json.Marshal(server.EgressPolicy)
+		if err != nil {
+			return "", 0, fmt.Errorf("failed to marshal egress policy: %w", err)
+		}
+		config.Labels[egressPolicyContainerLabel] = string(policy)
+
+		// Outbound requests go through the egress proxy, which enforces the policy. The container is only attached
+		// to the internal egress network, so it can't bypass the proxy.
+		config.Env = append(config.Env,
+			"HTTP_PROXY="+proxyURL, "HTTPS_PROXY="+proxyURL, "http_proxy="+proxyURL, "https_proxy="+proxyURL,
+			"NO_PROXY=localhost,127.0.0.1", "no_proxy=localhost,127.0.0.1",
+		)
+		networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{
+			dockerEgressNetwork: {},
+		}
+	} else if server.egressPeer {
+		if _, err := d.ensureEgressProxy(ctx); err != nil {
+			return "", 0, err
+		}
+		if networkingConfig.EndpointsConfig == nil {
+			networkingConfig.EndpointsConfig = map[string]*network.EndpointSettings{}
+		}
+		networkingConfig.EndpointsConfig[dockerEgressNetwork] = &network.EndpointSettings{}
+	}
+
+	d.capacityMu.Lock()
+	defer d.capacityMu.Unlock()
+
+	if err := d.CheckCapacity(ctx, server, mcpServerName); err != nil {
+		return "", 0, err
+	}
+
 	var containerID string
 	// There seems to be a race condition in the Docker API where creating the container fails with a conflict,
 	// but getting the container with the name returns no results.
@@ -1072,9 +1214,9 @@ func (d *dockerBackend) ensureServerReady(ctx context.Context, c *container.Summ
 			return fmt.Errorf("container %s not found or has no network settings", server.MCPServerName)
 		}
 
-		n, ok := c.NetworkSettings.Networks[d.network]
+		n, ok := c.NetworkSettings.Networks[d.containerNetwork(c)]
 		if !ok || n.IPAddress == "" {
-			return fmt.Errorf("container %s is not connected to %s network", server.MCPServerName, d.network)
+			return fmt.Errorf("container %s is not connected to %s network", server.MCPServerName, d.containerNetwork(c))
 		}
 
 		host = n.IPAddress
@@ -1415,3 +1557,284 @@ func (d *dockerBackend) prepareMCPServerNanobotConfig(ctx context.Context, serve
 
 	return volumeName, nil
 }
+
+const (
+	// dockerEgressNetwork is the internal network that servers with a restricted egress policy are attached to. It has
+	// no route outside of Docker, so these servers can only reach other containers on it, including Obot's egress proxy.
+	dockerEgressNetwork = "obot-mcp-egress"
+	// dockerEgressProxyPort is the port of the egress proxy on Obot's address in dockerEgressNetwork.
+	dockerEgressProxyPort = 8099
+	// egressPolicyContainerLabel holds the JSON egress policy of a server's container. The egress proxy reads it to
+	// find the policy of its clients, so that it keeps working for existing containers after Obot restarts.
+	egressPolicyContainerLabel = "mcp.egress.policy"
+)
+
+// ensureEgressProxy creates the egress network, connects Obot's container to it and starts the egress proxy on it, if
+// that hasn't been done yet. It returns the URL of the proxy. Obot must run in a container, since otherwise the servers
+// on the internal network can't reach it.
+func (d *dockerBackend) ensureEgressProxy(ctx context.Context) (string, error) {
+	d.egressMu.Lock()
+	defer d.egressMu.Unlock()
+
+	if d.egressProxyURL != "" {
+		return d.egressProxyURL, nil
+	}
+	if !d.containerEnv {
+		return "", fmt.Errorf("restricting the egress of MCP servers with the Docker backend requires Obot to run in a container")
+	}
+
+	if _, err := d.client.NetworkInspect(ctx, dockerEgressNetwork, network.InspectOptions{}); cerrdefs.IsNotFound(err) {
+		if _, err := d.client.NetworkCreate(ctx, dockerEgressNetwork, network.CreateOptions{
+			Driver:   "bridge",
+			Internal: true,
+			Labels:   map[string]string{"mcp.egress": "true"},
+		}); err != nil && !cerrdefs.IsConflict(err) && !cerrdefs.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create egress network: %w", err)
+		}
+	} else if err != nil {
+		return "", fmt.Errorf("failed to inspect egress network: %w", err)
+	}
+
+	self, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %w", err)
+	}
+
+	inspect, err := d.client.ContainerInspect(ctx, self)
+	if err != nil {
+		return "", fmt.Errorf("failed to inspect Obot container: %w", err)
+	}
+	if inspect.NetworkSettings == nil || inspect.NetworkSettings.Networks[dockerEgressNetwork] == nil {
+		if err := d.client.NetworkConnect(ctx, dockerEgressNetwork, self, nil); err != nil {
+			return "", fmt.Errorf("failed to connect Obot container to egress network: %w", err)
+		}
+		if inspect, err = d.client.ContainerInspect(ctx, self); err != nil {
+			return "", fmt.Errorf("failed to inspect Obot container: %w", err)
+		}
+	}
+
+	settings := inspect.NetworkSettings.Networks[dockerEgressNetwork]
+	if settings == nil || settings.IPAddress == "" {
+		return "", fmt.Errorf("obot container has no address on the egress network")
+	}
+
+	// Only listen on the egress network, so that the proxy isn't reachable from anywhere else.
+	address := net.JoinHostPort(settings.IPAddress, strconv.Itoa(dockerEgressProxyPort))
+	listener, err := net.Listen("tcp", address)
+	if err != nil {
+		return "", fmt.Errorf("failed to start egress proxy: %w", err)
+	}
+
+	server := &http.Server{
+		Handler: &egressProxy{
+			lookup:   d.egressPolicyForAddress,
+			resolver: net.DefaultResolver,
+		},
+		ReadHeaderTimeout: 30 * time.Second,
+	}
+	go func() {
+		if err := server.Serve(listener); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			log.Errorf("Egress proxy stopped: %v", err)
+		}
+	}()
+
+	d.egressProxyURL = "http://" + address
+	return d.egressProxyURL, nil
+}
+
+// egressPolicyForAddress returns the egress policy of the container with the address on the egress network.
+func (d *dockerBackend) egressPolicyForAddress(ctx context.Context, addr netip.Addr) (*otypes.MCPEgressPolicy, error) {
+	containers, err := d.client.ContainerList(ctx, container.ListOptions{
+		Filters: filters.NewArgs(
+			filters.Arg("label", egressPolicyContainerLabel),
+			filters.Arg("network", dockerEgressNetwork),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list containers: %w", err)
+	}
+
+	for _, c := range containers {
+		if c.NetworkSettings == nil {
+			continue
+		}
+		settings := c.NetworkSettings.Networks[dockerEgressNetwork]
+		if settings == nil || settings.IPAddress != addr.String() {
+			continue
+		}
+
+		var policy otypes.MCPEgressPolicy
+		if err := json.Unmarshal([]byte(c.Labels[egressPolicyContainerLabel]), &policy); err != nil {
+			return nil, fmt.Errorf("failed to parse egress policy of container %s: %w", c.ID, err)
+		}
+		return &policy, nil
+	}
+
+	return nil, nil
+}
+
+// containerNetwork returns the network that Obot reaches the container on.
+func (d *dockerBackend) containerNetwork(c *container.Summary) string {
+	if c.Labels[egressPolicyContainerLabel] != "" {
+		return dockerEgressNetwork
+	}
+	return d.network
+}
+
+const (
+	dockerMemoryLimitLabel = "mcp.memory.limit"
+	dockerCPULimitLabel    = "mcp.cpu.limit"
+)
+
+// dockerResources are memory and CPU limits of MCP server containers. Zero values mean that there is no limit.
+type dockerResources struct {
+	// memory is in bytes.
+	memory int64
+	// nanoCPUs is in units of 10^-9 CPUs.
+	nanoCPUs int64
+}
+
+// parseDockerResources parses memory and CPU quantities in the Kubernetes format, such as 512Mi and 500m.
+func parseDockerResources(memory, cpu string) (dockerResources, error) {
+	var r dockerResources
+	if memory != "" {
+		q, err := resource.ParseQuantity(memory)
+		if err != nil {
+			return r, fmt.Errorf("invalid memory %q: %w", memory, err)
+		}
+		r.memory = q.Value()
+	}
+	if cpu != "" {
+		q, err := resource.ParseQuantity(cpu)
+		if err != nil {
+			return r, fmt.Errorf("invalid CPU %q: %w", cpu, err)
+		}
+		r.nanoCPUs = q.MilliValue() * 1_000_000
+	}
+	if r.memory < 0 || r.nanoCPUs < 0 {
+		return r, fmt.Errorf("resources must not be negative")
+	}
+	return r, nil
+}
+
+// setLabels records the limits in the labels of a container, so that the capacity checks don't have to inspect every
+// container.
+func (r dockerResources) setLabels(labels map[string]string) {
+	if r.memory > 0 {
+		labels[dockerMemoryLimitLabel] = strconv.FormatInt(r.memory, 10)
+	}
+	if r.nanoCPUs > 0 {
+		labels[dockerCPULimitLabel] = strconv.FormatInt(r.nanoCPUs, 10)
+	}
+}
+
+// dockerCapacityUsage is the capacity used by MCP server containers.
+type dockerCapacityUsage struct {
+	// limits is the sum of the limits of the containers.
+	limits dockerResources
+	// deployments maps the IDs of the deployments to the IDs of the users that own them. A deployment consists of the
+	// MCP server container and its shim container.
+	deployments map[string]string
+}
+
+// newDockerCapacityUsage adds up the capacity used by the containers, except for those that are stopped and the one with
+// the excluded name, which is about to be replaced.
+func newDockerCapacityUsage(containers []container.Summary, excludedName string) dockerCapacityUsage {
+	usage := dockerCapacityUsage{
+		deployments: make(map[string]string, len(containers)),
+	}
+	for _, c := range containers {
+		if c.State == container.StateExited || c.State == container.StateDead || slices.Contains(c.Names, "/"+excludedName) {
+			continue
+		}
+
+		deploymentID := c.Labels["mcp.deployment.id"]
+		if deploymentID == "" {
+			continue
+		}
+		usage.deployments[deploymentID] = c.Labels["mcp.user.id"]
+
+		if memory, err := strconv.ParseInt(c.Labels[dockerMemoryLimitLabel], 10, 64); err == nil {
+			usage.limits.memory += memory
+		}
+		if nanoCPUs, err := strconv.ParseInt(c.Labels[dockerCPULimitLabel], 10, 64); err == nil {
+			usage.limits.nanoCPUs += nanoCPUs
+		}
+	}
+	return usage
+}
+
+// userDeployments returns the number of deployments of the user, not counting the given deployment.
+func (u dockerCapacityUsage) userDeployments(userID, excludedDeploymentID string) int {
+	var count int
+	for deploymentID, owner := range u.deployments {
+		if owner == userID && deploymentID != excludedDeploymentID {
+			count++
+		}
+	}
+	return count
+}
+
+// checkDockerCapacity returns an error if a container with the limits would exceed the budget, or if the deployment would
+// exceed the maximum number of running servers of its user.
+func checkDockerCapacity(usage dockerCapacityUsage, limits, budget dockerResources, maxServersPerUser int, userID, deploymentID string) error {
+	if budget.memory > 0 && usage.limits.memory+limits.memory > budget.memory ||
+		budget.nanoCPUs > 0 && usage.limits.nanoCPUs+limits.nanoCPUs > budget.nanoCPUs {
+		return ErrInsufficientCapacity
+	}
+	if maxServersPerUser > 0 && userID != "" && usage.userDeployments(userID, deploymentID) >= maxServersPerUser {
+		return ErrServerQuotaExceeded
+	}
+	return nil
+}
+
+// listMCPContainers lists the MCP server containers, including their shim containers.
+func (d *dockerBackend) listMCPContainers(ctx context.Context) ([]container.Summary, error) {
+	containers, err := d.client.ContainerList(ctx, container.ListOptions{
+		All:     true,
+		Filters: filters.NewArgs(filters.Arg("label", "mcp.deployment.id")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list MCP containers: %w", err)
+	}
+	return containers, nil
+}
+
+// CheckCapacity checks if a container for the server can be created without exceeding the memory and CPU budget, or
+// the maximum number of running servers of the server's user. Returns ErrInsufficientCapacity or ErrServerQuotaExceeded
+// if not. The caller must hold capacityMu until the container is created.
+func (d *dockerBackend) CheckCapacity(ctx context.Context, server ServerConfig, mcpServerName string) error {
+	if d.budget == (dockerResources{}) && (d.maxServersPerUser <= 0 || server.OwnerUserID == "") {
+		return nil
+	}
+
+	containers, err := d.listMCPContainers(ctx)
+	if err != nil {
+		return err
+	}
+
+	return checkDockerCapacity(newDockerCapacityUsage(containers, server.MCPServerName), d.containerLimits, d.budget, d.maxServersPerUser, server.OwnerUserID, mcpServerName)
+}
+
+// GetCapacityInfo returns capacity information for the MCP server containers.
+// Used by the admin capacity endpoint.
+func (d *dockerBackend) GetCapacityInfo(ctx context.Context) otypes.MCPCapacityInfo {
+	info := otypes.MCPCapacityInfo{
+		Source:      otypes.CapacitySourceDocker,
+		CPULimit:    formatCPU(*resource.NewScaledQuantity(d.budget.nanoCPUs, resource.Nano)),
+		MemoryLimit: formatMemory(*resource.NewQuantity(d.budget.memory, resource.BinarySI)),
+	}
+
+	containers, err := d.listMCPContainers(ctx)
+	if err != nil {
+		info.Error = "failed to list containers"
+		return info
+	}
+
+	usage := newDockerCapacityUsage(containers, "")
+	info.CPURequested = formatCPU(*resource.NewScaledQuantity(usage.limits.nanoCPUs, resource.Nano))
+	info.MemoryRequested = formatMemory(*resource.NewQuantity(usage.limits.memory, resource.BinarySI))
+	info.ActiveDeployments = len(usage.deployments)
+
+	return info
+}
@@ -141,3 +141,74 @@ func TestApplyServerConfigToContainerConfigNoImageNoChanges(t *testing.T) {
 		t.Fatalf("did not expect mcp.file.env.keys.hash label to be set")
 	}
 }
+
+func TestParseDockerResources(t *testing.T) {
+	resources, err := parseDockerResources("512Mi", "500m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resources.memory != 512*1024*1024 {
+		t.Fatalf("expected 512Mi of memory, got %d", resources.memory)
+	}
+	if resources.nanoCPUs != 500_000_000 {
+		t.Fatalf("expected 0.5 CPUs, got %d nano CPUs", resources.nanoCPUs)
+	}
+
+	if resources, err = parseDockerResources("", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if resources.memory != 0 || resources.nanoCPUs != 0 {
+		t.Fatalf("expected no limits, got %+v", resources)
+	}
+
+	if _, err = parseDockerResources("lots", ""); err == nil {
+		t.Fatal("expected an error for an invalid memory quantity")
+	}
+}
+
+func TestCheckDockerCapacity(t *testing.T) {
+	mcpContainer := func(name, deploymentID, userID, state string) container.Summary {
+		return container.Summary{
+			Names: []string{"/" + name},
+			State: container.ContainerState(state),
+			Labels: map[string]string{
+				"mcp.deployment.id":    deploymentID,
+				"mcp.user.id":          userID,
+				dockerMemoryLimitLabel: "1024",
+				dockerCPULimitLabel:    "1000",
+			},
+		}
+	}
+
+	usage := newDockerCapacityUsage([]container.Summary{
+		mcpContainer("a", "a", "user1", string(container.StateRunning)),
+		mcpContainer("a-shim", "a", "user1", string(container.StateRunning)),
+		mcpContainer("b", "b", "user2", string(container.StateRunning)),
+		mcpContainer("c", "c", "user1", string(container.StateExited)),
+		mcpContainer("d", "d", "user1", string(container.StateRunning)),
+	}, "d")
+
+	if usage.limits.memory != 3072 || usage.limits.nanoCPUs != 3000 {
+		t.Fatalf("expected the limits of three containers, got %+v", usage.limits)
+	}
+	if len(usage.deployments) != 2 {
+		t.Fatalf("expected two deployments, got %d", len(usage.deployments))
+	}
+
+	limits := dockerResources{memory: 1024, nanoCPUs: 1000}
+	if err := checkDockerCapacity(usage, limits, dockerResources{memory: 4096}, 0, "user1", "e"); err != nil {
+		t.Fatalf("expected enough memory, got %v", err)
+	}
+	if err := checkDockerCapacity(usage, limits, dockerResources{nanoCPUs: 3500}, 0, "user1", "e"); err != ErrInsufficientCapacity {
+		t.Fatalf("expected insufficient capacity, got %v", err)
+	}
+	if err := checkDockerCapacity(usage, limits, dockerResources{}, 1, "user1", "e"); err != ErrServerQuotaExceeded {
+		t.Fatalf("expected the server quota to be exceeded, got %v", err)
+	}
+	if err := checkDockerCapacity(usage, limits, dockerResources{}, 1, "user1", "a"); err != nil {
+		t.Fatalf("expected a redeploy to be within the server quota, got %v", err)
+	}
+	if err := checkDockerCapacity(usage, limits, dockerResources{}, 1, "user3", "e"); err != nil {
+		t.Fatalf("expected another user to be within the server quota, got %v", err)
+	}
+}
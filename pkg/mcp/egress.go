@@ -0,0 +1,254 @@
+package mcp
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httputil"
+	"net/netip"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	otypes "github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// egressBlockedPrefixes are never reachable from servers with an egress policy, whatever the policy allows.
+// They include the link-local ranges that cloud metadata endpoints are served on.
+var egressBlockedPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),
+	netip.MustParsePrefix("127.0.0.0/8"),
+	netip.MustParsePrefix("169.254.0.0/16"),
+	netip.MustParsePrefix("::1/128"),
+	netip.MustParsePrefix("fe80::/10"),
+	netip.MustParsePrefix("fd00:ec2::/32"),
+}
+
+// egressAllowAll are the rules of the allow mode. Like any other rule, they never allow the blocked prefixes.
+var egressAllowAll = []otypes.MCPEgressRule{{CIDR: "0.0.0.0/0"}, {CIDR: "::/0"}}
+
+// egressPolicy returns the egress policy of the server's deployment, or nil if its egress isn't restricted. The policy
+// of the server's catalog entry is used if it has one, and the default egress mode otherwise. Only servers that run
+// code from outside of Obot are restricted. Remote shims, nanobot agents and system servers are not. Servers with the
+// allow mode get a policy that allows everything but the blocked prefixes, so that they can't reach metadata endpoints.
+func (sm *SessionManager) egressPolicy(ctx context.Context, server ServerConfig) (*otypes.MCPEgressPolicy, error) {
+	switch server.Runtime {
+	case otypes.RuntimeUVX, otypes.RuntimeNPX, otypes.RuntimeContainerized:
+	default:
+		return nil, nil
+	}
+	if server.NanobotAgentName != "" || server.SystemMCPServer || server.ProjectMCPServer {
+		return nil, nil
+	}
+
+	policy := otypes.MCPEgressPolicy{Mode: sm.defaultEgressMode}
+	if server.MCPCatalogEntryName != "" {
+		var entry v1.MCPServerCatalogEntry
+		if err := sm.storageClient.Get(ctx, kclient.ObjectKey{Namespace: server.MCPServerNamespace, Name: server.MCPCatalogEntryName}, &entry); err != nil && !apierrors.IsNotFound(err) {
+			return nil, fmt.Errorf("failed to get catalog entry %s: %w", server.MCPCatalogEntryName, err)
+		} else if err == nil && entry.Spec.Manifest.EgressPolicy != nil {
+			policy = *entry.Spec.Manifest.EgressPolicy
+		}
+	}
+
+	if policy.Mode == otypes.MCPEgressModeAllow {
+		policy.Allow = egressAllowAll
+	}
+	return &policy, nil
+}
+
+func egressBlocked(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if addr.IsUnspecified() || addr.IsMulticast() {
+		return true
+	}
+	for _, prefix := range egressBlockedPrefixes {
+		if prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+func egressPortAllowed(rule otypes.MCPEgressRule, port int) bool {
+	return len(rule.Ports) == 0 || slices.Contains(rule.Ports, port)
+}
+
+// allowedEgressAddress returns an address of host that the policy allows connecting to on port. A hostname is allowed if
+// it matches a hostname rule, or if it resolves to an address in a CIDR rule. Blocked addresses are never returned, so
+// that an allowed hostname can't be pointed at a metadata endpoint.
+func allowedEgressAddress(ctx context.Context, resolver *net.Resolver, policy otypes.MCPEgressPolicy, host string, port int) (netip.Addr, error) {
+	host = strings.TrimSuffix(host, ".")
+
+	var hostnameAllowed, hasCIDRRules bool
+	for _, rule := range policy.Allow {
+		if rule.CIDR != "" {
+			hasCIDRRules = true
+		} else if strings.EqualFold(rule.Hostname, host) && egressPortAllowed(rule, port) {
+			hostnameAllowed = true
+		}
+	}
+
+	var addrs []netip.Addr
+	if addr, err := netip.ParseAddr(host); err == nil {
+		addrs = []netip.Addr{addr}
+	} else if hostnameAllowed || hasCIDRRules {
+		addrs, err = resolver.LookupNetIP(ctx, "ip", host)
+		if err != nil {
+			return netip.Addr{}, fmt.Errorf("failed to resolve %s: %w", host, err)
+		}
+	}
+
+	for _, addr := range addrs {
+		addr = addr.Unmap()
+		if egressBlocked(addr) {
+			continue
+		}
+		if hostnameAllowed {
+			return addr, nil
+		}
+		for _, rule := range policy.Allow {
+			if rule.CIDR == "" || !egressPortAllowed(rule, port) {
+				continue
+			}
+			if prefix, err := netip.ParsePrefix(rule.CIDR); err == nil && prefix.Contains(addr) {
+				return addr, nil
+			}
+		}
+	}
+
+	return netip.Addr{}, fmt.Errorf("egress to %s on port %d is not allowed", host, port)
+}
+
+// egressProxy is an HTTP proxy that only forwards the requests that its client's egress policy allows. Clients are
+// identified by their address, so they must be on a network that the proxy controls.
+type egressProxy struct {
+	// lookup returns the egress policy of the client with the address, or nil if the client is unknown.
+	lookup   func(ctx context.Context, addr netip.Addr) (*otypes.MCPEgressPolicy, error)
+	resolver *net.Resolver
+	dialer   net.Dialer
+}
+
+func (p *egressProxy) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	clientAddr, err := netip.ParseAddrPort(r.RemoteAddr)
+	if err != nil {
+		http.Error(w, "unknown client", http.StatusForbidden)
+		return
+	}
+
+	policy, err := p.lookup(r.Context(), clientAddr.Addr().Unmap())
+	if err != nil {
+		log.Errorf("Failed to get egress policy of %s: %v", clientAddr.Addr(), err)
+		http.Error(w, "failed to get egress policy", http.StatusInternalServerError)
+		return
+	} else if policy == nil {
+		http.Error(w, "unknown client", http.StatusForbidden)
+		return
+	}
+
+	host, port, err := egressProxyTarget(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	addr, err := allowedEgressAddress(r.Context(), p.resolver, *policy, host, port)
+	if err != nil {
+		log.Infof("Blocked egress from %s: %v", clientAddr.Addr(), err)
+		http.Error(w, err.Error(), http.StatusForbidden)
+		return
+	}
+
+	target := net.JoinHostPort(addr.String(), strconv.Itoa(port))
+	if r.Method == http.MethodConnect {
+		p.tunnel(w, r, target)
+		return
+	}
+
+	(&httputil.ReverseProxy{
+		Rewrite: func(*httputil.ProxyRequest) {},
+		Transport: &http.Transport{
+			// Always dial the address that was checked, rather than resolving the host again.
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				return p.dialer.DialContext(ctx, "tcp", target)
+			},
+			DisableKeepAlives: true,
+		},
+	}).ServeHTTP(w, r)
+}
+
+// egressProxyTarget returns the host and port that the proxy request is for.
+func egressProxyTarget(r *http.Request) (string, int, error) {
+	if r.Method == http.MethodConnect {
+		host, port, err := net.SplitHostPort(r.Host)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid CONNECT target %q: %w", r.Host, err)
+		}
+		portNum, err := strconv.Atoi(port)
+		if err != nil {
+			return "", 0, fmt.Errorf("invalid CONNECT target %q: %w", r.Host, err)
+		}
+		return host, portNum, nil
+	}
+
+	if r.URL.Scheme != "http" || r.URL.Host == "" {
+		return "", 0, errors.New("only absolute http URLs and CONNECT requests can be proxied")
+	}
+
+	port := 80
+	if p := r.URL.Port(); p != "" {
+		var err error
+		if port, err = strconv.Atoi(p); err != nil {
+			return "", 0, fmt.Errorf("invalid port in %q: %w", r.URL.Host, err)
+		}
+	}
+	return r.URL.Hostname(), port, nil
+}
+
+func (p *egressProxy) tunnel(w http.ResponseWriter, r *http.Request, target string) {
+	upstream, err := p.dialer.DialContext(r.Context(), "tcp", target)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("failed to connect to %s: %v", r.Host, err), http.StatusBadGateway)
+		return
+	}
+
+	hijacker, ok := w.(http.Hijacker)
+	if !ok {
+		upstream.Close()
+		http.Error(w, "connection can't be hijacked", http.StatusInternalServerError)
+		return
+	}
+
+	conn, buf, err := hijacker.Hijack()
+	if err != nil {
+		upstream.Close()
+		http.Error(w, fmt.Sprintf("failed to hijack connection: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if _, err := conn.Write([]byte("HTTP/1.1 200 Connection Established\r\n\r\n")); err != nil {
+		upstream.Close()
+		conn.Close()
+		return
+	}
+
+	var once sync.Once
+	closeBoth := func() {
+		upstream.Close()
+		conn.Close()
+	}
+	go func() {
+		// The buffered reader may hold bytes that the client sent after the request.
+		_, _ = io.Copy(upstream, buf)
+		once.Do(closeBoth)
+	}()
+	_, _ = io.Copy(conn, upstream)
+	once.Do(closeBoth)
+}
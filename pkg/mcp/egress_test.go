@@ -0,0 +1,193 @@
+package mcp
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"slices"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	networkingv1 "k8s.io/api/networking/v1"
+)
+
+func TestAllowedEgressAddress(t *testing.T) {
+	policy := types.MCPEgressPolicy{
+		Mode: types.MCPEgressModeDeny,
+		Allow: []types.MCPEgressRule{
+			{CIDR: "203.0.113.0/24", Ports: []int{443}},
+			{CIDR: "0.0.0.0/0", Ports: []int{8443}},
+			{Hostname: "localhost"},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		host    string
+		port    int
+		allowed bool
+	}{
+		{name: "address in allowed range", host: "203.0.113.10", port: 443, allowed: true},
+		{name: "address in allowed range on other port", host: "203.0.113.10", port: 80},
+		{name: "address outside of allowed ranges", host: "198.51.100.1", port: 443},
+		{name: "any address on allowed port", host: "198.51.100.1", port: 8443, allowed: true},
+		{name: "metadata endpoint is blocked", host: "169.254.169.254", port: 8443},
+		{name: "loopback is blocked", host: "127.0.0.1", port: 8443},
+		{name: "IPv6 metadata endpoint is blocked", host: "fd00:ec2::254", port: 8443},
+		{name: "allowed hostname that resolves to loopback is blocked", host: "localhost", port: 80},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			addr, err := allowedEgressAddress(context.Background(), net.DefaultResolver, policy, tt.host, tt.port)
+			if tt.allowed {
+				if err != nil {
+					t.Fatalf("expected egress to %s:%d to be allowed, got %v", tt.host, tt.port, err)
+				}
+				if addr.String() != tt.host {
+					t.Errorf("expected address %s, got %s", tt.host, addr)
+				}
+			} else if err == nil {
+				t.Errorf("expected egress to %s:%d to be blocked, got address %s", tt.host, tt.port, addr)
+			}
+		})
+	}
+}
+
+func TestAllowedEgressAddressDoesNotResolveWithoutRules(t *testing.T) {
+	// With no matching hostname and no CIDR rules, the hostname must be rejected without a DNS lookup.
+	_, err := allowedEgressAddress(context.Background(), nil, types.MCPEgressPolicy{Mode: types.MCPEgressModeDeny}, "example.com", 443)
+	if err == nil {
+		t.Fatal("expected egress to be blocked")
+	}
+}
+
+func TestEgressProxyRejectsUnknownClients(t *testing.T) {
+	proxy := &egressProxy{
+		lookup: func(context.Context, netip.Addr) (*types.MCPEgressPolicy, error) {
+			return nil, nil
+		},
+	}
+
+	req := httptest.NewRequest(http.MethodConnect, "http://203.0.113.10:443", nil)
+	req.Host = "203.0.113.10:443"
+	req.RemoteAddr = "172.18.0.5:51234"
+	rec := httptest.NewRecorder()
+	proxy.ServeHTTP(rec, req)
+
+	if rec.Code != http.StatusForbidden {
+		t.Errorf("expected status %d, got %d", http.StatusForbidden, rec.Code)
+	}
+}
+
+func TestEgressProxyTarget(t *testing.T) {
+	connect := httptest.NewRequest(http.MethodConnect, "http://api.example.com:443", nil)
+	connect.Host = "api.example.com:443"
+	if host, port, err := egressProxyTarget(connect); err != nil || host != "api.example.com" || port != 443 {
+		t.Errorf("unexpected CONNECT target %s:%d, %v", host, port, err)
+	}
+
+	get := httptest.NewRequest(http.MethodGet, "http://api.example.com/path", nil)
+	if host, port, err := egressProxyTarget(get); err != nil || host != "api.example.com" || port != 80 {
+		t.Errorf("unexpected GET target %s:%d, %v", host, port, err)
+	}
+
+	relative := httptest.NewRequest(http.MethodGet, "/path", nil)
+	relative.URL.Scheme = ""
+	relative.URL.Host = ""
+	if _, _, err := egressProxyTarget(relative); err == nil {
+		t.Error("expected relative request to be rejected")
+	}
+}
+
+func TestEgressNetworkPolicy(t *testing.T) {
+	k := &kubernetesBackend{mcpNamespace: "obot-mcp", serviceNamespace: "obot-system"}
+	server := ServerConfig{
+		MCPServerName: "ms1abc",
+		EgressPolicy: &types.MCPEgressPolicy{
+			Mode: types.MCPEgressModeDeny,
+			Allow: []types.MCPEgressRule{
+				{CIDR: "0.0.0.0/0", Ports: []int{443}},
+				{CIDR: "203.0.113.7/32"},
+			},
+		},
+	}
+
+	policy, err := k.egressNetworkPolicy(context.Background(), net.DefaultResolver, server, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if policy.Namespace != "obot-mcp" || policy.Spec.PodSelector.MatchLabels["app"] != "ms1abc" {
+		t.Errorf("unexpected policy target %s/%v", policy.Namespace, policy.Spec.PodSelector.MatchLabels)
+	}
+	if !slices.Equal(policy.Spec.PolicyTypes, []networkingv1.PolicyType{networkingv1.PolicyTypeEgress, networkingv1.PolicyTypeIngress}) {
+		t.Errorf("unexpected policy types %v", policy.Spec.PolicyTypes)
+	}
+
+	// DNS, Obot and the two allow rules.
+	if len(policy.Spec.Egress) != 4 {
+		t.Fatalf("expected 4 egress rules, got %d", len(policy.Spec.Egress))
+	}
+
+	if ns := policy.Spec.Egress[1].To[0].NamespaceSelector.MatchLabels["kubernetes.io/metadata.name"]; ns != "obot-system" {
+		t.Errorf("expected egress to the obot-system namespace, got %q", ns)
+	}
+
+	anywhere := policy.Spec.Egress[2]
+	if anywhere.To[0].IPBlock.CIDR != "0.0.0.0/0" || anywhere.Ports[0].Port.IntValue() != 443 {
+		t.Errorf("unexpected rule %+v", anywhere)
+	}
+	if !slices.Contains(anywhere.To[0].IPBlock.Except, "169.254.0.0/16") || !slices.Contains(anywhere.To[0].IPBlock.Except, "127.0.0.0/8") {
+		t.Errorf("expected blocked ranges to be excepted, got %v", anywhere.To[0].IPBlock.Except)
+	}
+
+	single := policy.Spec.Egress[3]
+	if single.To[0].IPBlock.CIDR != "203.0.113.7/32" || len(single.To[0].IPBlock.Except) != 0 || len(single.Ports) != 0 {
+		t.Errorf("unexpected rule %+v", single)
+	}
+}
+
+func TestAllowModeBlocksMetadataEndpoints(t *testing.T) {
+	sm := &SessionManager{defaultEgressMode: types.MCPEgressModeAllow}
+	policy, err := sm.egressPolicy(context.Background(), ServerConfig{Runtime: types.RuntimeNPX})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policy == nil || policy.Mode != types.MCPEgressModeAllow {
+		t.Fatalf("expected an allow mode policy, got %+v", policy)
+	}
+
+	for host, allowed := range map[string]bool{
+		"198.51.100.1":    true,
+		"2001:db8::1":     true,
+		"169.254.169.254": false,
+		"fd00:ec2::254":   false,
+		"127.0.0.1":       false,
+	} {
+		if _, err := allowedEgressAddress(context.Background(), net.DefaultResolver, *policy, host, 80); allowed && err != nil {
+			t.Errorf("expected egress to %s to be allowed, got %v", host, err)
+		} else if !allowed && err == nil {
+			t.Errorf("expected egress to %s to be blocked", host)
+		}
+	}
+
+	k := &kubernetesBackend{mcpNamespace: "obot-mcp"}
+	networkPolicy, err := k.egressNetworkPolicy(context.Background(), net.DefaultResolver, ServerConfig{MCPServerName: "ms1abc", EgressPolicy: policy}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// DNS, and all IPv4 and IPv6 addresses but the blocked ones.
+	if len(networkPolicy.Spec.Egress) != 3 {
+		t.Fatalf("expected 3 egress rules, got %d", len(networkPolicy.Spec.Egress))
+	}
+	if ipv4 := networkPolicy.Spec.Egress[1].To[0].IPBlock; ipv4.CIDR != "0.0.0.0/0" || !slices.Contains(ipv4.Except, "169.254.0.0/16") {
+		t.Errorf("expected the IPv4 link-local range to be excepted, got %+v", ipv4)
+	}
+	if ipv6 := networkPolicy.Spec.Egress[2].To[0].IPBlock; ipv6.CIDR != "::/0" || !slices.Contains(ipv6.Except, "fe80::/10") || !slices.Contains(ipv6.Except, "fd00:ec2::/32") {
+		t.Errorf("expected the IPv6 link-local and metadata ranges to be excepted, got %+v", ipv6)
+	}
+}
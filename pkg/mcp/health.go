@@ -0,0 +1,47 @@
+package mcp
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	nmcp "github.com/nanobot-ai/nanobot/pkg/mcp"
+)
+
+const (
+	// healthProbeTimeout is how long a single health probe waits for a server to respond. Probes run on the controller's
+	// workers, so this is kept short.
+	healthProbeTimeout = 5 * time.Second
+	// healthProbeClientScope is the scope of the sessions used by health probes, so that they aren't shared with users.
+	healthProbeClientScope = "health-probe"
+)
+
+// ProbeServer checks that an already deployed MCP server responds to an MCP ping. Servers that don't answer pings are
+// probed by initializing a new session instead.
+// Unlike the other methods on the SessionManager, this will not deploy the server if it isn't running.
+// Instead, [ErrServerNotRunning] is returned.
+func (sm *SessionManager) ProbeServer(ctx context.Context, server ServerConfig) error {
+	ctx, cancel := context.WithTimeout(ctx, healthProbeTimeout)
+	defer cancel()
+
+	config, err := sm.backend.probeServer(ctx, server)
+	if err != nil {
+		return err
+	}
+
+	clientOpts := nmcp.ClientOption{ClientName: "Obot Health Probe"}
+	if client, err := sm.loadSession(ctx, config, healthProbeClientScope, clientOpts); err == nil {
+		if _, err = client.Ping(ctx); err == nil {
+			return nil
+		}
+
+		// The session may be stale after the server restarted, or the server may not answer pings.
+		sm.closeClient(config, healthProbeClientScope)
+	}
+
+	if _, err = sm.loadSession(ctx, config, healthProbeClientScope, clientOpts); err != nil {
+		return fmt.Errorf("%w: failed to initialize MCP session: %v", ErrHealthCheckFailed, err)
+	}
+
+	return nil
+}
@@ -7,6 +7,8 @@ import (
 	"fmt"
 	"io"
 	"maps"
+	"net"
+	"net/netip"
 	"reflect"
 	"sort"
 	"strconv"
@@ -24,6 +26,7 @@ import (
 	"github.com/obot-platform/obot/pkg/wait"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -42,9 +45,12 @@ type kubernetesBackend struct {
 	client                        kclient.WithWatch
 	baseImage                     string
 	remoteShimBaseImage           string
+	warmPoolPullerImage           string
 	mcpNamespace                  string
 	mcpClusterDomain              string
 	serviceFQDN                   string
+	serviceNamespace              string
+	networkPolicyEnabled          bool
 	imagePullSecrets              []string
 	auditLogsBatchSize            int
 	auditLogsFlushIntervalSeconds int
@@ -69,9 +75,12 @@ func newKubernetesBackend(clientset *kubernetes.Clientset, client kclient.WithWa
 		client:                        client,
 		baseImage:                     opts.MCPBaseImage,
 		remoteShimBaseImage:           opts.MCPRemoteShimBaseImage,
+		warmPoolPullerImage:           opts.MCPWarmPoolPullerImage,
 		mcpNamespace:                  opts.MCPNamespace,
 		mcpClusterDomain:              opts.MCPClusterDomain,
 		serviceFQDN:                   serviceFQDN,
+		serviceNamespace:              opts.ServiceNamespace,
+		networkPolicyEnabled:          opts.MCPNetworkPolicyEnabled,
 		imagePullSecrets:              opts.MCPImagePullSecrets,
 		auditLogsBatchSize:            opts.MCPAuditLogsPersistBatchSize,
 		auditLogsFlushIntervalSeconds: opts.MCPAuditLogPersistIntervalSeconds,
@@ -99,12 +108,15 @@ func (k *kubernetesBackend) deployServerObjects(ctx context.Context, server Serv
 	// Cleanup old deployments if it exists. Notice the server.Scope as the owner sub-context,
 	// which means that only objects with the same scope will be pruned.
 	if err := apply.New(k.client).WithNamespace(k.mcpNamespace).WithOwnerSubContext(server.Scope).WithPruneTypes(
-		new(corev1.Secret), new(appsv1.Deployment), new(corev1.Service), new(corev1.PersistentVolumeClaim),
+		new(corev1.Secret), new(appsv1.Deployment), new(corev1.Service), new(corev1.PersistentVolumeClaim), new(networkingv1.NetworkPolicy),
 	).Apply(ctx, nil, nil); err != nil && !apierrors.IsNotFound(err) {
 		return fmt.Errorf("failed to cleanup old MCP deployment %s: %w", server.MCPServerName, err)
 	}
 
-	if err := apply.New(k.client).WithNamespace(k.mcpNamespace).WithOwnerSubContext(server.MCPServerName).Apply(ctx, nil, objs...); err != nil {
+	// The network policy is pruned when the server's egress is no longer restricted.
+	if err := apply.New(k.client).WithNamespace(k.mcpNamespace).WithOwnerSubContext(server.MCPServerName).WithPruneTypes(
+		new(networkingv1.NetworkPolicy),
+	).Apply(ctx, nil, objs...); err != nil {
 		return fmt.Errorf("failed to create MCP deployment %s: %w", server.MCPServerName, err)
 	}
 
@@ -355,7 +367,7 @@ func (k *kubernetesBackend) transformObotHostname(url string) string {
 }
 
 func (k *kubernetesBackend) shutdownServer(ctx context.Context, id string, hardShutdown bool) error {
-	prunedTypes := []kclient.Object{new(corev1.Secret), new(appsv1.Deployment), new(corev1.Service)}
+	prunedTypes := []kclient.Object{new(corev1.Secret), new(appsv1.Deployment), new(corev1.Service), new(networkingv1.NetworkPolicy)}
 	if hardShutdown {
 		prunedTypes = append(prunedTypes, new(corev1.PersistentVolumeClaim))
 	}
@@ -368,6 +380,94 @@ func (k *kubernetesBackend) shutdownServer(ctx context.Context, id string, hardS
 	return nil
 }
 
+const warmPoolName = "mcp-warm-pool"
+
+// warmImages runs a DaemonSet with an init container for each image, so that every node that MCP servers are scheduled
+// on pulls the images. The init containers run a busybox binary that is copied from the puller image, because the
+// images may not have any commands of their own that exit successfully. The DaemonSet is removed if there are no images.
+func (k *kubernetesBackend) warmImages(ctx context.Context, images []string) error {
+	var objs []kclient.Object
+	if len(images) > 0 {
+		k8sSettings, err := k.getK8sSettings(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to get k8s settings: %w", err)
+		}
+
+		var (
+			psaLevel   = GetPSAEnforceLevelFromSpec(k8sSettings)
+			podLabels  = map[string]string{"app": warmPoolName}
+			mounts     = []corev1.VolumeMount{{Name: "bin", MountPath: "/warm-pool"}}
+			resources  = corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("1m"), corev1.ResourceMemory: resource.MustParse("8Mi")}}
+			containers = make([]corev1.Container, 0, len(images)+1)
+		)
+
+		containers = append(containers, corev1.Container{
+			Name:            "busybox",
+			Image:           k.warmPoolPullerImage,
+			Command:         []string{"cp", "/bin/busybox", "/warm-pool/busybox"},
+			Resources:       resources,
+			SecurityContext: getContainerSecurityContext(psaLevel),
+			VolumeMounts:    mounts,
+		})
+		for i, image := range images {
+			containers = append(containers, corev1.Container{
+				Name:            fmt.Sprintf("image-%d", i),
+				Image:           image,
+				ImagePullPolicy: corev1.PullAlways,
+				Command:         []string{"/warm-pool/busybox", "true"},
+				Resources:       resources,
+				SecurityContext: getContainerSecurityContext(psaLevel),
+				VolumeMounts:    mounts,
+			})
+		}
+
+		ds := &appsv1.DaemonSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      warmPoolName,
+				Namespace: k.mcpNamespace,
+				Labels:    podLabels,
+			},
+			Spec: appsv1.DaemonSetSpec{
+				Selector: &metav1.LabelSelector{MatchLabels: podLabels},
+				Template: corev1.PodTemplateSpec{
+					ObjectMeta: metav1.ObjectMeta{Labels: podLabels},
+					Spec: corev1.PodSpec{
+						Affinity:         k8sSettings.Affinity,
+						Tolerations:      k8sSettings.Tolerations,
+						RuntimeClassName: k8sSettings.RuntimeClassName,
+						SecurityContext:  getPodSecurityContext(psaLevel),
+						InitContainers:   containers,
+						Containers: []corev1.Container{{
+							Name:            "pause",
+							Image:           k.warmPoolPullerImage,
+							Command:         []string{"sleep", "2147483647"},
+							Resources:       resources,
+							SecurityContext: getContainerSecurityContext(psaLevel),
+						}},
+						Volumes: []corev1.Volume{{
+							Name: "bin",
+							VolumeSource: corev1.VolumeSource{
+								EmptyDir: &corev1.EmptyDirVolumeSource{},
+							},
+						}},
+					},
+				},
+			},
+		}
+		for _, secret := range k.imagePullSecrets {
+			ds.Spec.Template.Spec.ImagePullSecrets = append(ds.Spec.Template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: secret})
+		}
+
+		objs = append(objs, ds)
+	}
+
+	if err := apply.New(k.client).WithNamespace(k.mcpNamespace).WithOwnerSubContext(warmPoolName).WithPruneTypes(new(appsv1.DaemonSet)).Apply(ctx, nil, objs...); err != nil {
+		return fmt.Errorf("failed to apply MCP warm pool: %w", err)
+	}
+
+	return nil
+}
+
 func (k *kubernetesBackend) k8sObjects(ctx context.Context, server ServerConfig, webhooks []Webhook) ([]kclient.Object, error) {
 	var (
 		command  []string
@@ -806,6 +906,19 @@ func (k *kubernetesBackend) k8sObjects(ctx context.Context, server ServerConfig,
 
 	objs = append(objs, dep)
 
+	// The chart's network policy blocks metadata endpoints, and private ranges too, so servers with the allow mode only
+	// need their own when it isn't there. Network policies are additive, so theirs would allow what the chart's blocks.
+	if server.EgressPolicy != nil && (server.EgressPolicy.Mode != types.MCPEgressModeAllow || !k.networkPolicyEnabled) {
+		networkPolicy, err := k.egressNetworkPolicy(ctx, net.DefaultResolver, server, annotations)
+		if err != nil {
+			return nil, err
+		}
+		objs = append(objs, networkPolicy)
+
+		dep.Labels[egressPolicyLabel] = string(server.EgressPolicy.Mode)
+		dep.Spec.Template.Labels[egressPolicyLabel] = string(server.EgressPolicy.Mode)
+	}
+
 	if server.Runtime != types.RuntimeContainerized {
 		// Setup the MCP server nanobot config (nanobot.yaml that configures how nanobot proxies
 		// to the underlying MCP server) and mount it into the last container in the deployment.
@@ -889,6 +1002,103 @@ func (k *kubernetesBackend) k8sObjects(ctx context.Context, server ServerConfig,
 	return objs, nil
 }
 
+// egressPolicyLabel is set on the pods of servers with a restricted egress policy. Network policies are additive, so the
+// namespace-wide network policy of the Helm chart must not select these pods for their own policy to take effect.
+const egressPolicyLabel = "obot.ai/egress-policy"
+
+// egressNetworkPolicy returns the network policy that limits the egress of the server's pods to DNS, Obot and the hosts
+// that its egress policy allows. Network policies only support IP ranges, so hostnames are resolved when the policy is
+// created.
+func (k *kubernetesBackend) egressNetworkPolicy(ctx context.Context, resolver *net.Resolver, server ServerConfig, annotations map[string]string) (*networkingv1.NetworkPolicy, error) {
+	var (
+		udp         = corev1.ProtocolUDP
+		tcp         = corev1.ProtocolTCP
+		dnsPort     = intstr.FromInt(53)
+		policyTypes = []networkingv1.PolicyType{networkingv1.PolicyTypeEgress}
+		ingress     []networkingv1.NetworkPolicyIngressRule
+		egressDNS   = networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{NamespaceSelector: &metav1.LabelSelector{}}},
+			Ports: []networkingv1.NetworkPolicyPort{
+				{Protocol: &udp, Port: &dnsPort},
+				{Protocol: &tcp, Port: &dnsPort},
+			},
+		}
+		egress = []networkingv1.NetworkPolicyEgressRule{egressDNS}
+	)
+
+	if k.serviceNamespace != "" {
+		obotPeers := []networkingv1.NetworkPolicyPeer{{
+			NamespaceSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"kubernetes.io/metadata.name": k.serviceNamespace},
+			},
+		}}
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{To: obotPeers})
+
+		// The pods aren't selected by the chart's policy anymore, so ingress is limited to Obot here too.
+		policyTypes = append(policyTypes, networkingv1.PolicyTypeIngress)
+		ingress = []networkingv1.NetworkPolicyIngressRule{{From: obotPeers}}
+	}
+
+	for _, rule := range server.EgressPolicy.Allow {
+		var prefixes []netip.Prefix
+		if rule.CIDR != "" {
+			prefix, err := netip.ParsePrefix(rule.CIDR)
+			if err != nil {
+				return nil, fmt.Errorf("invalid egress cidr %q: %w", rule.CIDR, err)
+			}
+			prefixes = append(prefixes, prefix.Masked())
+		} else {
+			addrs, err := resolver.LookupNetIP(ctx, "ip", rule.Hostname)
+			if err != nil {
+				return nil, fmt.Errorf("failed to resolve egress hostname %s: %w", rule.Hostname, err)
+			}
+			for _, addr := range addrs {
+				if addr = addr.Unmap(); !egressBlocked(addr) {
+					prefixes = append(prefixes, netip.PrefixFrom(addr, addr.BitLen()))
+				}
+			}
+		}
+
+		peers := make([]networkingv1.NetworkPolicyPeer, 0, len(prefixes))
+		for _, prefix := range prefixes {
+			block := &networkingv1.IPBlock{CIDR: prefix.String()}
+			for _, blocked := range egressBlockedPrefixes {
+				if blocked.Bits() > prefix.Bits() && prefix.Contains(blocked.Addr()) {
+					block.Except = append(block.Except, blocked.String())
+				}
+			}
+			peers = append(peers, networkingv1.NetworkPolicyPeer{IPBlock: block})
+		}
+		if len(peers) == 0 {
+			continue
+		}
+
+		ports := make([]networkingv1.NetworkPolicyPort, 0, len(rule.Ports))
+		for _, port := range rule.Ports {
+			p := intstr.FromInt(port)
+			ports = append(ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+		}
+
+		egress = append(egress, networkingv1.NetworkPolicyEgressRule{To: peers, Ports: ports})
+	}
+
+	return &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name.SafeConcatName(server.MCPServerName, "egress"),
+			Namespace:   k.mcpNamespace,
+			Annotations: annotations,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": server.MCPServerName},
+			},
+			PolicyTypes: policyTypes,
+			Ingress:     ingress,
+			Egress:      egress,
+		},
+	}, nil
+}
+
 // getNewestPod finds and returns the most recently created pod from the list.
 func getNewestPod(pods []corev1.Pod) (*corev1.Pod, error) {
 	if len(pods) == 0 {
@@ -1178,6 +1388,61 @@ func (k *kubernetesBackend) restartServer(ctx context.Context, server ServerConf
 	return fmt.Errorf("failed to fully apply K8s settings to deployment %s after %d attempts", id, maxPatchRetries)
 }
 
+func (k *kubernetesBackend) probeServer(ctx context.Context, server ServerConfig) (ServerConfig, error) {
+	id := server.MCPServerName
+
+	var deployment appsv1.Deployment
+	if err := k.client.Get(ctx, kclient.ObjectKey{Name: id, Namespace: k.mcpNamespace}, &deployment); apierrors.IsNotFound(err) {
+		return ServerConfig{}, ErrServerNotRunning
+	} else if err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to get deployment %s: %w", id, err)
+	}
+
+	var pods corev1.PodList
+	if err := k.client.List(ctx, &pods, &kclient.ListOptions{
+		Namespace: k.mcpNamespace,
+		LabelSelector: labels.SelectorFromSet(map[string]string{
+			"app": id,
+		}),
+	}); err != nil {
+		return ServerConfig{}, fmt.Errorf("failed to list MCP pods: %w", err)
+	}
+	if len(pods.Items) == 0 {
+		return ServerConfig{}, fmt.Errorf("%w: deployment %s has no pods", ErrHealthCheckFailed, id)
+	}
+
+	newestPod, err := getNewestPod(pods.Items)
+	if err != nil {
+		return ServerConfig{}, err
+	}
+	// Surface permanent pod failures, like CrashLoopBackOff, rather than a failed probe.
+	if shouldRetry, podErr := analyzePodStatus(newestPod); !shouldRetry {
+		return ServerConfig{}, podErr
+	}
+
+	u := fmt.Sprintf("http://%s.%s.svc.%s", id, k.mcpNamespace, k.mcpClusterDomain)
+	if server.NanobotAgentName != "" {
+		u = fmt.Sprintf("%s:8080", u)
+	}
+
+	// The Kubernetes backend always runs nanobot in front of the server, so connect to it as a remote server.
+	return ServerConfig{
+		URL:                  fmt.Sprintf("%s/%s", u, strings.TrimPrefix(server.ContainerPath, "/")),
+		MCPServerName:        id,
+		Audiences:            server.Audiences,
+		MCPServerNamespace:   server.MCPServerNamespace,
+		MCPServerDisplayName: server.MCPServerDisplayName,
+		Scope:                newestPod.Name,
+		UserID:               server.UserID,
+		OwnerUserID:          server.OwnerUserID,
+		Runtime:              types.RuntimeRemote,
+		Issuer:               server.Issuer,
+		ContainerPort:        server.ContainerPort,
+		ContainerPath:        server.ContainerPath,
+		NanobotAgentName:     server.NanobotAgentName,
+	}, nil
+}
+
 // patchDeploymentWithK8sSettings applies the K8s settings patch to the deployment
 // Note: This does NOT update the hash annotation - that's done separately via patchDeploymentHash
 // after verification passes, ensuring the hash only reflects successfully applied settings.
@@ -8,13 +8,17 @@ import (
 	"net/url"
 	"slices"
 	"sync"
+	"time"
 
 	"github.com/gptscript-ai/gptscript/pkg/hash"
 	"github.com/gptscript-ai/gptscript/pkg/types"
 	otypes "github.com/obot-platform/obot/apiclient/types"
 	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/imagepolicy"
+	"github.com/obot-platform/obot/pkg/packagepolicy"
 	"github.com/obot-platform/obot/pkg/storage"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"golang.org/x/sync/singleflight"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
@@ -36,6 +40,23 @@ type Options struct {
 	SingleUserIdleServerShutdownHours int      `usage:"The interval in hours to check for idle MCP servers designated to a single user and shut them down, set to -1 to disable shutdown" default:"24"`
 	MultiUserIdleServerShutdownHours  int      `usage:"The interval in hours to check for idle multi-user MCP servers and shut them down, set to -1 to disable" default:"168"`
 	IdleAgentShutdownHours            int      `usage:"The interval in hours to check for idle agents and shut them down, set to -1 to disable" default:"72"`
+	MCPDefaultEgressMode              string   `usage:"The egress mode for MCP servers whose catalog entry doesn't set an egress policy: allow or deny" default:"allow"`
+	MCPNetworkPolicyEnabled           bool     `usage:"Whether the Helm chart's network policy restricts the egress of MCP servers in Kubernetes, so that servers with the allow egress mode don't need their own"`
+
+	// Scale-to-zero settings. The intervals in minutes override the intervals in hours when they are set.
+	SingleUserIdleServerShutdownMinutes int    `usage:"The interval in minutes to check for idle MCP servers designated to a single user and shut them down, set to -1 to disable shutdown"`
+	MultiUserIdleServerShutdownMinutes  int    `usage:"The interval in minutes to check for idle multi-user MCP servers and shut them down, set to -1 to disable"`
+	IdleAgentShutdownMinutes            int    `usage:"The interval in minutes to check for idle agents and shut them down, set to -1 to disable"`
+	MCPColdStartTimeoutSeconds          int    `usage:"The time in seconds that requests to the MCP gateway wait for an MCP server to start" default:"300"`
+	MCPWarmPoolSize                     int    `usage:"The number of the most used catalog entries whose images are pre-pulled so that their servers start faster, set to 0 to disable" default:"0"`
+	MCPWarmPoolPullerImage              string `usage:"The image that provides the busybox binary used to pre-pull images with the kubernetes backend" default:"busybox:1.37"`
+
+	// Docker resource settings. The budgets require the container limits, because they are enforced by adding those up.
+	MCPDockerContainerMemoryLimit string `usage:"The memory limit of each MCP server container with the docker backend (e.g., 512Mi), unlimited if not set"`
+	MCPDockerContainerCPULimit    string `usage:"The CPU limit of each MCP server container with the docker backend (e.g., 500m or 1), unlimited if not set"`
+	MCPDockerMemoryBudget         string `usage:"The total memory limit of all MCP server containers with the docker backend (e.g., 8Gi), unlimited if not set"`
+	MCPDockerCPUBudget            string `usage:"The total CPU limit of all MCP server containers with the docker backend (e.g., 4), unlimited if not set"`
+	MCPDockerMaxServersPerUser    int    `usage:"The maximum number of running MCP servers for each user with the docker backend, set to 0 for no limit"`
 
 	// Kubernetes settings from Helm
 	MCPK8sSettingsAffinity             string `usage:"Affinity rules for MCP server pods (JSON)"`
@@ -74,7 +95,15 @@ type SessionManager struct {
 	baseURL           string
 	allowLocalhostMCP bool
 
-	webhookHelper *WebhookHelper
+	webhookHelper     *WebhookHelper
+	storageClient     storage.Client
+	imageVerifier     *imagepolicy.Verifier
+	defaultEgressMode otypes.MCPEgressMode
+
+	// launches makes concurrent launches of the same server wait for a single deployment.
+	launches         singleflight.Group
+	coldStartTimeout time.Duration
+	warmPool         warmPool
 }
 
 const streamableHTTPHealthcheckBody string = `{
@@ -92,6 +121,14 @@ const streamableHTTPHealthcheckBody string = `{
 }`
 
 func NewSessionManager(ctx context.Context, tokenService TokenService, baseURL string, httpListenPort int, opts Options, webhookHelper *WebhookHelper, localK8sConfig *rest.Config, obotStorageClient storage.Client) (*SessionManager, error) {
+	defaultEgressMode := otypes.MCPEgressMode(opts.MCPDefaultEgressMode)
+	if defaultEgressMode == "" {
+		defaultEgressMode = otypes.MCPEgressModeAllow
+	}
+	if err := (otypes.MCPEgressPolicy{Mode: defaultEgressMode}).Validate(); err != nil {
+		return nil, fmt.Errorf("invalid default MCP egress mode: %w", err)
+	}
+
 	var backend backend
 
 	switch opts.MCPRuntimeBackend {
@@ -151,6 +188,14 @@ func NewSessionManager(ctx context.Context, tokenService TokenService, baseURL s
 		backend:           backend,
 		baseURL:           baseURL,
 		allowLocalhostMCP: !opts.DisallowLocalhostMCP,
+		storageClient:     obotStorageClient,
+		imageVerifier:     imagepolicy.NewVerifier(),
+		defaultEgressMode: defaultEgressMode,
+		coldStartTimeout:  time.Duration(opts.MCPColdStartTimeoutSeconds) * time.Second,
+		warmPool: warmPool{
+			size:   opts.MCPWarmPoolSize,
+			images: []string{opts.MCPBaseImage, opts.MCPRemoteShimBaseImage},
+		},
 	}, nil
 }
 
@@ -242,14 +287,38 @@ func (sm *SessionManager) closeClient(server ServerConfig, clientScope string) {
 	}
 }
 
-// LaunchServer will ensure that the server is deployed
+// LaunchServer will ensure that the server is deployed and return its URL.
+// Concurrent launches of the same server wait for a single deployment, which isn't canceled with the requests that wait
+// for it. This lets the first requests to an idle server wait for it to start, up to the cold start timeout.
 func (sm *SessionManager) LaunchServer(ctx context.Context, serverConfig ServerConfig) (string, error) {
 	if serverConfig.ProjectMCPServer {
 		return "", errors.New("cannot launch project MCP server")
 	}
 
-	c, err := sm.ensureDeployment(ctx, serverConfig, true)
-	return c.URL, err
+	result := sm.launches.DoChan(serverConfig.MCPServerName+"/"+hash.Digest(serverConfig), func() (any, error) {
+		ctx := context.WithoutCancel(ctx)
+		if sm.coldStartTimeout > 0 {
+			var cancel context.CancelFunc
+			ctx, cancel = context.WithTimeout(ctx, sm.coldStartTimeout)
+			defer cancel()
+		}
+
+		c, err := sm.ensureDeployment(ctx, serverConfig, true)
+		if err != nil && errors.Is(ctx.Err(), context.DeadlineExceeded) {
+			return "", fmt.Errorf("%w: %w", ErrColdStartTimeout, err)
+		}
+		return c.URL, err
+	})
+
+	select {
+	case <-ctx.Done():
+		return "", ctx.Err()
+	case r := <-result:
+		if r.Err != nil {
+			return "", r.Err
+		}
+		return r.Val.(string), nil
+	}
 }
 
 // ShutdownServer will close the connections to the MCP server and remove all of the resources.
@@ -353,9 +422,68 @@ func (sm *SessionManager) ensureDeployment(ctx context.Context, server ServerCon
 		}
 	}
 
+	if sm.storageClient != nil {
+		var err error
+		if server, err = sm.applyPolicies(ctx, server); err != nil {
+			return ServerConfig{}, err
+		}
+	}
+
 	return sm.backend.ensureServerDeployment(ctx, server, webhooks)
 }
 
+// applyPolicies enforces the container image and package policies on the server. These are checked here, after any
+// environment variables have been expanded, so that they are enforced for every backend.
+func (sm *SessionManager) applyPolicies(ctx context.Context, server ServerConfig) (ServerConfig, error) {
+	switch server.Runtime {
+	case otypes.RuntimeContainerized:
+		policy, err := imagepolicy.Get(ctx, sm.storageClient)
+		if err != nil {
+			return server, err
+		}
+
+		image, err := sm.imageVerifier.Verify(ctx, policy, server.ContainerImage)
+		if err != nil {
+			return server, err
+		}
+		// Deploy the image that was verified, so that the tag can't be moved to another image before it is pulled.
+		server.ContainerImage = image
+	case otypes.RuntimeNPX, otypes.RuntimeUVX:
+		policy, err := packagepolicy.Get(ctx, sm.storageClient)
+		if err != nil {
+			return server, err
+		}
+
+		if err := packagepolicy.Check(policy, server.Runtime, serverPackage(server)); err != nil {
+			return server, err
+		}
+
+		// Registries and indexes configured by the server's environment are removed, so that packages are only installed
+		// from the mirror, or the public registry if there is no mirror.
+		server.Env = append(packagepolicy.StripRegistryEnv(policy, server.Runtime, server.Env), packagepolicy.MirrorEnv(policy, server.Runtime)...)
+	}
+
+	policy, err := sm.egressPolicy(ctx, server)
+	if err != nil {
+		return server, err
+	}
+	server.EgressPolicy = policy
+
+	return server, nil
+}
+
+// serverPackage returns the package that an npx or uvx server runs.
+func serverPackage(server ServerConfig) string {
+	args := server.Args
+	if server.Runtime == otypes.RuntimeUVX && len(args) > 1 && args[0] == "--from" {
+		args = args[1:]
+	}
+	if len(args) == 0 {
+		return ""
+	}
+	return args[0]
+}
+
 func clientID(server ServerConfig) string {
 	// The user ID is not part of the client ID.
 	server.UserID = ""
@@ -404,11 +532,14 @@ func (sm *SessionManager) GenerateToolPreviews(ctx context.Context, tempMCPServe
 	return ConvertTools(tools.Tools, []string{"*"}, nil)
 }
 
-// GetCapacityInfo returns capacity information for the MCP namespace.
-// Only available when using the Kubernetes backend.
+// GetCapacityInfo returns capacity information for the MCP namespace, or for the MCP server containers with the Docker
+// backend.
 func (sm *SessionManager) GetCapacityInfo(ctx context.Context) (otypes.MCPCapacityInfo, error) {
-	if k8sBackend, ok := sm.backend.(*kubernetesBackend); ok {
-		return k8sBackend.GetCapacityInfo(ctx), nil
+	switch b := sm.backend.(type) {
+	case *kubernetesBackend:
+		return b.GetCapacityInfo(ctx), nil
+	case *dockerBackend:
+		return b.GetCapacityInfo(ctx), nil
 	}
-	return otypes.MCPCapacityInfo{}, &ErrNotSupportedByBackend{Feature: "capacity info", Backend: "docker"}
+	return otypes.MCPCapacityInfo{}, &ErrNotSupportedByBackend{Feature: "capacity info", Backend: "unknown"}
 }
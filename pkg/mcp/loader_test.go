@@ -70,3 +70,21 @@ func TestClientIDIncludesFileEnvKeys(t *testing.T) {
 		t.Fatalf("expected different client IDs when file env key changes")
 	}
 }
+
+func TestServerPackage(t *testing.T) {
+	tests := []struct {
+		server ServerConfig
+		want   string
+	}{
+		{ServerConfig{Runtime: "npx", Command: "npx", Args: []string{"@scope/server@1.0.0", "--flag"}}, "@scope/server@1.0.0"},
+		{ServerConfig{Runtime: "uvx", Command: "uvx", Args: []string{"mcp-server-fetch==1.0.0"}}, "mcp-server-fetch==1.0.0"},
+		{ServerConfig{Runtime: "uvx", Command: "uvx", Args: []string{"--from", "mcp-tools==1.0.0", "fetch"}}, "mcp-tools==1.0.0"},
+		{ServerConfig{Runtime: "npx", Command: "npx"}, ""},
+	}
+
+	for _, tt := range tests {
+		if got := serverPackage(tt.server); got != tt.want {
+			t.Fatalf("expected package %q for args %v, got %q", tt.want, tt.server.Args, got)
+		}
+	}
+}
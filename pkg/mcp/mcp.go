@@ -142,6 +142,10 @@ func findSpecialError(err error, mcpServerDisplayName string) (bool, error) {
 			return true, fmt.Errorf("MCP server %s pod could not be scheduled", mcpServerDisplayName)
 		case unwrappedErr == ErrPodConfigurationFailed:
 			return true, fmt.Errorf("MCP server %s has invalid configuration", mcpServerDisplayName)
+		case unwrappedErr == ErrInsufficientCapacity:
+			return true, fmt.Errorf("insufficient capacity to deploy MCP server %s", mcpServerDisplayName)
+		case unwrappedErr == ErrServerQuotaExceeded:
+			return true, fmt.Errorf("cannot deploy MCP server %s, the maximum number of running MCP servers was reached", mcpServerDisplayName)
 		default:
 			switch e := unwrappedErr.(type) {
 			case nmcp.AuthRequiredErr:
@@ -0,0 +1,285 @@
+package mcp
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// ToolOverrideSet applies tool overrides to the tools/list results and tools/call requests that the MCP gateway
+// forwards for a server.
+type ToolOverrideSet struct {
+	// exposed are the overrides of the enabled tools, keyed by the tool names exposed to clients.
+	exposed map[string]types.ToolOverride
+	// original are all the overrides, keyed by the tool names of the server. They are only set if the set filters and
+	// renames the tools, which the nanobot configuration of composite servers already does for their components.
+	original map[string]types.ToolOverride
+}
+
+// NewToolOverrideSet returns the tool overrides that the MCP gateway applies for the server, or nil if there are none.
+// The tool overrides of single servers filter, rename and rewrite the arguments of their tools. For composite servers,
+// only the arguments are rewritten here.
+func NewToolOverrideSet(config ServerConfig) *ToolOverrideSet {
+	if config.Runtime == types.RuntimeComposite {
+		exposed := make(map[string]types.ToolOverride)
+		for _, component := range config.Components {
+			for _, tool := range component.Tools {
+				if tool.Enabled && len(tool.Arguments) > 0 {
+					exposed[exposedToolName(tool)] = tool
+				}
+			}
+		}
+		if len(exposed) == 0 {
+			return nil
+		}
+		return &ToolOverrideSet{exposed: exposed}
+	}
+
+	if len(config.ToolOverrides) == 0 {
+		return nil
+	}
+
+	set := &ToolOverrideSet{
+		exposed:  make(map[string]types.ToolOverride, len(config.ToolOverrides)),
+		original: make(map[string]types.ToolOverride, len(config.ToolOverrides)),
+	}
+	for _, tool := range config.ToolOverrides {
+		set.original[tool.Name] = tool
+		if tool.Enabled {
+			set.exposed[exposedToolName(tool)] = tool
+		}
+	}
+	return set
+}
+
+func exposedToolName(tool types.ToolOverride) string {
+	if tool.OverrideName != "" {
+		return tool.OverrideName
+	}
+	return tool.Name
+}
+
+// RewriteParams applies the overrides to the params of a tools/call request. The params of other requests are returned
+// unchanged.
+func (s *ToolOverrideSet) RewriteParams(method string, params json.RawMessage) (json.RawMessage, error) {
+	if method != "tools/call" {
+		return params, nil
+	}
+	return s.RewriteCall(params)
+}
+
+// RewriteResult applies the overrides to the result of a tools/list request. The results of other requests are
+// returned unchanged.
+func (s *ToolOverrideSet) RewriteResult(method string, result json.RawMessage) (json.RawMessage, bool) {
+	if method != "tools/list" {
+		return result, false
+	}
+	return s.RewriteListResult(result)
+}
+
+// RewriteCall applies the overrides to the params of a tools/call request. It sets fixed and default arguments, drops
+// removed arguments, and maps the tool name back to the server's name. An error is returned if the tool isn't exposed,
+// or if an argument has a value that isn't allowed.
+func (s *ToolOverrideSet) RewriteCall(params json.RawMessage) (json.RawMessage, error) {
+	var call map[string]json.RawMessage
+	if err := json.Unmarshal(params, &call); err != nil {
+		return nil, fmt.Errorf("invalid tool call parameters: %w", err)
+	}
+
+	var name string
+	if err := json.Unmarshal(call["name"], &name); err != nil {
+		return nil, fmt.Errorf("invalid tool name: %w", err)
+	}
+
+	override, ok := s.exposed[name]
+	if !ok {
+		if s.original != nil {
+			return nil, fmt.Errorf("unknown tool: %s", name)
+		}
+		return params, nil
+	}
+
+	if s.original != nil && override.Name != name {
+		b, err := json.Marshal(override.Name)
+		if err != nil {
+			return nil, err
+		}
+		call["name"] = b
+	}
+
+	if len(override.Arguments) > 0 {
+		var arguments map[string]json.RawMessage
+		if len(call["arguments"]) > 0 {
+			if err := json.Unmarshal(call["arguments"], &arguments); err != nil {
+				return nil, fmt.Errorf("invalid arguments for tool %s: %w", name, err)
+			}
+		}
+		if arguments == nil {
+			arguments = make(map[string]json.RawMessage, len(override.Arguments))
+		}
+
+		for _, argument := range override.Arguments {
+			value, set := arguments[argument.Name]
+			switch {
+			case len(argument.Value) > 0:
+				arguments[argument.Name] = argument.Value
+			case argument.Removed:
+				delete(arguments, argument.Name)
+			case !set && len(argument.Default) > 0:
+				arguments[argument.Name] = argument.Default
+			case set && !argument.Allows(value):
+				return nil, fmt.Errorf("value of argument %s for tool %s is not allowed", argument.Name, name)
+			}
+		}
+
+		b, err := json.Marshal(arguments)
+		if err != nil {
+			return nil, err
+		}
+		call["arguments"] = b
+	}
+
+	return json.Marshal(call)
+}
+
+// RewriteListResult applies the overrides to the tools in the result of a tools/list request.
+// It returns the original result and false if nothing was changed.
+func (s *ToolOverrideSet) RewriteListResult(result json.RawMessage) (json.RawMessage, bool) {
+	var list map[string]json.RawMessage
+	if err := json.Unmarshal(result, &list); err != nil {
+		return result, false
+	}
+
+	var tools []map[string]json.RawMessage
+	if err := json.Unmarshal(list["tools"], &tools); err != nil || tools == nil {
+		return result, false
+	}
+
+	var changed bool
+	rewritten := make([]map[string]json.RawMessage, 0, len(tools))
+	for _, tool := range tools {
+		var name string
+		if err := json.Unmarshal(tool["name"], &name); err != nil {
+			rewritten = append(rewritten, tool)
+			continue
+		}
+
+		var override types.ToolOverride
+		if s.original != nil {
+			var ok bool
+			if override, ok = s.original[name]; !ok || !override.Enabled {
+				changed = true
+				continue
+			}
+			if override.OverrideName != "" {
+				tool["name"], _ = json.Marshal(override.OverrideName)
+				changed = true
+			}
+			if override.OverrideDescription != "" {
+				tool["description"], _ = json.Marshal(override.OverrideDescription)
+				changed = true
+			}
+		} else {
+			override = s.exposed[name]
+		}
+
+		if len(override.Arguments) > 0 {
+			if schema, ok := rewriteInputSchema(tool["inputSchema"], override.Arguments); ok {
+				tool["inputSchema"] = schema
+				changed = true
+			}
+		}
+
+		rewritten = append(rewritten, tool)
+	}
+
+	if !changed {
+		return result, false
+	}
+
+	b, err := json.Marshal(rewritten)
+	if err != nil {
+		return result, false
+	}
+	list["tools"] = b
+
+	b, err = json.Marshal(list)
+	if err != nil {
+		return result, false
+	}
+	return b, true
+}
+
+// rewriteInputSchema removes the fixed and removed arguments from the input schema of a tool, and sets the defaults
+// and enums of the others. Arguments with defaults are no longer required.
+func rewriteInputSchema(inputSchema json.RawMessage, arguments []types.ToolArgumentOverride) (json.RawMessage, bool) {
+	var schema map[string]json.RawMessage
+	if err := json.Unmarshal(inputSchema, &schema); err != nil {
+		return inputSchema, false
+	}
+
+	var properties map[string]map[string]json.RawMessage
+	if len(schema["properties"]) > 0 {
+		if err := json.Unmarshal(schema["properties"], &properties); err != nil {
+			return inputSchema, false
+		}
+	}
+
+	var required []string
+	if len(schema["required"]) > 0 {
+		if err := json.Unmarshal(schema["required"], &required); err != nil {
+			return inputSchema, false
+		}
+	}
+
+	for _, argument := range arguments {
+		if len(argument.Value) > 0 || argument.Removed {
+			delete(properties, argument.Name)
+			required = slices.DeleteFunc(required, func(name string) bool { return name == argument.Name })
+			continue
+		}
+
+		property, ok := properties[argument.Name]
+		if !ok {
+			continue
+		}
+		if property == nil {
+			property = make(map[string]json.RawMessage, 2)
+			properties[argument.Name] = property
+		}
+		if len(argument.Default) > 0 {
+			property["default"] = argument.Default
+			required = slices.DeleteFunc(required, func(name string) bool { return name == argument.Name })
+		}
+		if len(argument.Enum) > 0 {
+			property["enum"], _ = json.Marshal(argument.Enum)
+		}
+	}
+
+	if properties != nil {
+		b, err := json.Marshal(properties)
+		if err != nil {
+			return inputSchema, false
+		}
+		schema["properties"] = b
+	}
+	if _, ok := schema["required"]; ok {
+		if len(required) == 0 {
+			delete(schema, "required")
+		} else {
+			b, err := json.Marshal(required)
+			if err != nil {
+				return inputSchema, false
+			}
+			schema["required"] = b
+		}
+	}
+
+	b, err := json.Marshal(schema)
+	if err != nil {
+		return inputSchema, false
+	}
+	return b, true
+}
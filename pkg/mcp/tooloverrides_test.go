@@ -0,0 +1,99 @@
+package mcp
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var searchIssuesArguments = []types.ToolArgumentOverride{
+	{Name: "owner", Value: json.RawMessage(`"obot-platform"`)},
+	{Name: "repo", Value: json.RawMessage(`"obot"`)},
+	{Name: "state", Default: json.RawMessage(`"open"`), Enum: []json.RawMessage{[]byte(`"open"`), []byte(`"closed"`)}},
+	{Name: "sort", Removed: true},
+}
+
+func TestNewToolOverrideSet(t *testing.T) {
+	assert.Nil(t, NewToolOverrideSet(ServerConfig{Runtime: types.RuntimeRemote}))
+	assert.Nil(t, NewToolOverrideSet(ServerConfig{
+		Runtime: types.RuntimeComposite,
+		Components: []ComponentServer{{
+			Name:  "github",
+			Tools: []types.ToolOverride{{Name: "search_issues", OverrideName: "search", Enabled: true}},
+		}},
+	}))
+
+	set := NewToolOverrideSet(ServerConfig{
+		Runtime: types.RuntimeComposite,
+		Components: []ComponentServer{{
+			Name:  "github",
+			Tools: []types.ToolOverride{{Name: "search_issues", OverrideName: "search", Enabled: true, Arguments: searchIssuesArguments}},
+		}},
+	})
+	require.NotNil(t, set)
+	assert.Contains(t, set.exposed, "search")
+	assert.Nil(t, set.original)
+}
+
+func TestToolOverrideSet_RewriteCall(t *testing.T) {
+	set := NewToolOverrideSet(ServerConfig{
+		Runtime: types.RuntimeRemote,
+		ToolOverrides: []types.ToolOverride{
+			{Name: "search_issues", OverrideName: "search", Enabled: true, Arguments: searchIssuesArguments},
+			{Name: "get_me", Enabled: true},
+			{Name: "delete_repository"},
+		},
+	})
+	require.NotNil(t, set)
+
+	params, err := set.RewriteCall(json.RawMessage(`{"name":"search","arguments":{"query":"bug","owner":"someone-else","sort":"created"},"_meta":{"progressToken":1}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"search_issues","arguments":{"query":"bug","owner":"obot-platform","repo":"obot","state":"open"},"_meta":{"progressToken":1}}`, string(params))
+
+	params, err = set.RewriteCall(json.RawMessage(`{"name":"search","arguments":{"state":"closed"}}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"search_issues","arguments":{"owner":"obot-platform","repo":"obot","state":"closed"}}`, string(params))
+
+	params, err = set.RewriteCall(json.RawMessage(`{"name":"get_me"}`))
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"name":"get_me"}`, string(params))
+
+	_, err = set.RewriteCall(json.RawMessage(`{"name":"search","arguments":{"state":"all"}}`))
+	assert.EqualError(t, err, "value of argument state for tool search is not allowed")
+
+	_, err = set.RewriteCall(json.RawMessage(`{"name":"search_issues"}`))
+	assert.EqualError(t, err, "unknown tool: search_issues")
+
+	_, err = set.RewriteCall(json.RawMessage(`{"name":"delete_repository"}`))
+	assert.EqualError(t, err, "unknown tool: delete_repository")
+}
+
+func TestToolOverrideSet_RewriteListResult(t *testing.T) {
+	set := NewToolOverrideSet(ServerConfig{
+		Runtime: types.RuntimeRemote,
+		ToolOverrides: []types.ToolOverride{
+			{Name: "search_issues", OverrideName: "search", OverrideDescription: "Search our issues", Enabled: true, Arguments: searchIssuesArguments},
+			{Name: "get_me", Enabled: true},
+		},
+	})
+	require.NotNil(t, set)
+
+	result, changed := set.RewriteListResult(json.RawMessage(`{"tools":[
+		{"name":"search_issues","description":"Search issues","inputSchema":{"type":"object","properties":{"query":{"type":"string"},"owner":{"type":"string"},"repo":{"type":"string"},"state":{"type":"string"},"sort":{"type":"string"}},"required":["query","owner","repo","state"]}},
+		{"name":"get_me","inputSchema":{"type":"object"}},
+		{"name":"delete_repository","inputSchema":{"type":"object"}}
+	],"nextCursor":"abc"}`))
+	assert.True(t, changed)
+	assert.JSONEq(t, `{"tools":[
+		{"name":"search","description":"Search our issues","inputSchema":{"type":"object","properties":{"query":{"type":"string"},"state":{"type":"string","default":"open","enum":["open","closed"]}},"required":["query"]}},
+		{"name":"get_me","inputSchema":{"type":"object"}}
+	],"nextCursor":"abc"}`, string(result))
+
+	original := json.RawMessage(`{"content":[{"type":"text","text":"hello"}]}`)
+	result, changed = set.RewriteListResult(original)
+	assert.False(t, changed)
+	assert.Equal(t, original, result)
+}
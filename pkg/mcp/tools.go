@@ -4,6 +4,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"maps"
 	"slices"
 
 	"github.com/google/jsonschema-go/jsonschema"
@@ -66,6 +67,7 @@ func ConvertTools(tools []mcp.Tool, allowedTools, unsupportedTools []string) ([]
 
 // ApplyToolOverrides applies ToolOverrides to a component's tool array,
 // filtering out disabled tools and applying name/description overrides.
+// The parameters of fixed and removed arguments are dropped.
 // If overrides are present, they act as an allowlist - only tools explicitly listed are included.
 func ApplyToolOverrides(tools []otypes.MCPServerTool, toolOverrides []otypes.ToolOverride) []otypes.MCPServerTool {
 	// Build lookup map: toolName -> ToolOverride
@@ -98,6 +100,14 @@ func ApplyToolOverrides(tools []otypes.MCPServerTool, toolOverrides []otypes.Too
 			if override.OverrideDescription != "" {
 				tool.Description = override.OverrideDescription
 			}
+
+			// Fixed and removed arguments are not published to clients.
+			for _, argument := range override.Arguments {
+				if _, ok := tool.Params[argument.Name]; ok && (len(argument.Value) > 0 || argument.Removed) {
+					tool.Params = maps.Clone(tool.Params)
+					delete(tool.Params, argument.Name)
+				}
+			}
 		}
 
 		transformedTools = append(transformedTools, tool)
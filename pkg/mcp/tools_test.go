@@ -1,6 +1,7 @@
 package mcp
 
 import (
+	"encoding/json"
 	"testing"
 
 	"github.com/obot-platform/obot/apiclient/types"
@@ -151,6 +152,40 @@ func TestApplyToolOverrides(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "fixed and removed arguments - params dropped",
+			tools: []types.MCPServerTool{
+				{
+					Name:        "search_issues",
+					Description: "Searches issues",
+					Params: map[string]string{
+						"owner": "Repository owner",
+						"query": "Search query",
+						"sort":  "Sort order",
+					},
+				},
+			},
+			toolOverrides: []types.ToolOverride{
+				{
+					Name:    "search_issues",
+					Enabled: true,
+					Arguments: []types.ToolArgumentOverride{
+						{Name: "owner", Value: json.RawMessage(`"obot-platform"`)},
+						{Name: "sort", Removed: true},
+						{Name: "query", Default: json.RawMessage(`"is:open"`)},
+					},
+				},
+			},
+			expected: []types.MCPServerTool{
+				{
+					Name:        "search_issues",
+					Description: "Searches issues",
+					Params: map[string]string{
+						"query": "Search query",
+					},
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
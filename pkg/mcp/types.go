@@ -47,6 +47,10 @@ type ServerConfig struct {
 	// Composite configuration.
 	Components []ComponentServer `json:"components"`
 
+	// ToolOverrides are applied by the MCP gateway to the messages of a single server, so they are left out of the
+	// configuration hash to avoid redeploying the server when they change.
+	ToolOverrides []types.ToolOverride `json:"-"`
+
 	Scope                string `json:"scope"`
 	UserID               string `json:"userID"`
 	OwnerUserID          string `json:"ownerUserID"`
@@ -58,6 +62,14 @@ type ServerConfig struct {
 	NanobotAgentName     string `json:"nanobotAgentName"`
 	ProjectMCPServer     bool   `json:"projectMCPServer"`
 	ComponentMCPServer   bool   `json:"componentMCPServer"`
+	SystemMCPServer      bool   `json:"systemMCPServer,omitempty"`
+
+	// EgressPolicy restricts the outbound network access of the server's deployment. It is set from the catalog entry,
+	// or the default egress mode, when the server is deployed. Nil means that egress is not restricted.
+	EgressPolicy *types.MCPEgressPolicy `json:"egressPolicy,omitempty"`
+	// egressPeer is set on the Docker shim of a server with a restricted egress policy, so that it is attached to the
+	// egress network.
+	egressPeer bool
 
 	Issuer    string   `json:"issuer"`
 	Audiences []string `json:"audiences"`
@@ -149,6 +161,7 @@ func CompositeServerToServerConfig(mcpServer v1.MCPServer, components []v1.MCPSe
 					OverrideName:        tool.OverrideName,
 					OverrideDescription: tool.OverrideDescription,
 					Enabled:             tool.Enabled,
+					Arguments:           tool.Arguments,
 				})
 			}
 		}
@@ -174,6 +187,7 @@ func CompositeServerToServerConfig(mcpServer v1.MCPServer, components []v1.MCPSe
 					OverrideName:        tool.OverrideName,
 					OverrideDescription: tool.OverrideDescription,
 					Enabled:             tool.Enabled,
+					Arguments:           tool.Arguments,
 				})
 			}
 		}
@@ -236,6 +250,7 @@ func ServerToServerConfig(mcpServer v1.MCPServer, audiences []string, issuer, us
 		AuthorizeEndpoint:         fmt.Sprintf("%s/oauth/authorize", issuer),
 		ComponentMCPServer:        mcpServer.Spec.CompositeName != "",
 		NanobotAgentName:          mcpServer.Spec.NanobotAgentID,
+		ToolOverrides:             mcpServer.Spec.Manifest.ToolOverrides,
 	}
 
 	if mcpServer.Spec.CompositeName == "" {
@@ -394,6 +409,7 @@ func SystemServerToServerConfig(systemServer v1.SystemMCPServer, audiences []str
 		MCPServerNamespace:        systemServer.Namespace,
 		MCPServerName:             systemServer.Name,
 		MCPServerDisplayName:      displayName,
+		SystemMCPServer:           true,
 		Runtime:                   systemServer.Spec.Manifest.Runtime,
 		Scope:                     fmt.Sprintf("%s-system", systemServer.Name),
 		Issuer:                    issuer,
@@ -0,0 +1,92 @@
+package mcp
+
+import (
+	"cmp"
+	"context"
+	"slices"
+	"time"
+
+	otypes "github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// warmPoolInterval is how often the warm pool is updated with the images of the most used catalog entries.
+const warmPoolInterval = time.Hour
+
+type warmPool struct {
+	// size is the number of the most used catalog entries whose images are kept warm.
+	size int
+	// images are the base images, which are always kept warm when the warm pool is enabled.
+	images []string
+}
+
+// RunWarmPool keeps the images of the most used catalog entries warm until the context is canceled, so that their
+// servers don't wait for the images to be pulled when they start after being shut down for being idle. Only the images
+// are kept warm. Pods aren't created ahead of time, because they carry the configuration of the server that they run.
+func (sm *SessionManager) RunWarmPool(ctx context.Context) {
+	if sm.warmPool.size <= 0 || sm.storageClient == nil {
+		// Stop keeping images warm if the warm pool was enabled before.
+		if err := sm.backend.warmImages(ctx, nil); err != nil {
+			log.Warnf("failed to clean up MCP server warm pool: %v", err)
+		}
+		return
+	}
+
+	for {
+		if err := sm.warmImages(ctx); err != nil {
+			log.Warnf("failed to update MCP server warm pool: %v", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(warmPoolInterval):
+		}
+	}
+}
+
+func (sm *SessionManager) warmImages(ctx context.Context) error {
+	var entries v1.MCPServerCatalogEntryList
+	if err := sm.storageClient.List(ctx, &entries, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return err
+	}
+
+	var servers v1.MCPServerList
+	if err := sm.storageClient.List(ctx, &servers, kclient.InNamespace(system.DefaultNamespace)); err != nil {
+		return err
+	}
+
+	return sm.backend.warmImages(ctx, warmPoolImages(entries.Items, servers.Items, sm.warmPool))
+}
+
+// warmPoolImages returns the base images of the warm pool, followed by the images of the containerized catalog entries
+// among the pool's size of the catalog entries with the most servers.
+func warmPoolImages(entries []v1.MCPServerCatalogEntry, servers []v1.MCPServer, pool warmPool) []string {
+	serverCounts := make(map[string]int, len(entries))
+	for _, server := range servers {
+		if server.Spec.MCPServerCatalogEntryName != "" && !server.Spec.Template && server.DeletionTimestamp.IsZero() {
+			serverCounts[server.Spec.MCPServerCatalogEntryName]++
+		}
+	}
+
+	entries = slices.DeleteFunc(slices.Clone(entries), func(entry v1.MCPServerCatalogEntry) bool {
+		return serverCounts[entry.Name] == 0
+	})
+	slices.SortFunc(entries, func(a, b v1.MCPServerCatalogEntry) int {
+		if c := cmp.Compare(serverCounts[b.Name], serverCounts[a.Name]); c != 0 {
+			return c
+		}
+		return cmp.Compare(a.Name, b.Name)
+	})
+
+	images := slices.Clone(pool.images)
+	for _, entry := range entries[:min(pool.size, len(entries))] {
+		manifest := entry.Spec.Manifest
+		if manifest.Runtime == otypes.RuntimeContainerized && manifest.ContainerizedConfig != nil && manifest.ContainerizedConfig.Image != "" && !slices.Contains(images, manifest.ContainerizedConfig.Image) {
+			images = append(images, manifest.ContainerizedConfig.Image)
+		}
+	}
+	return images
+}
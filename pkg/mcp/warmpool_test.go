@@ -0,0 +1,57 @@
+package mcp
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestWarmPoolImages(t *testing.T) {
+	entry := func(name string, runtime types.Runtime, image string) v1.MCPServerCatalogEntry {
+		e := v1.MCPServerCatalogEntry{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		e.Spec.Manifest.Runtime = runtime
+		if image != "" {
+			e.Spec.Manifest.ContainerizedConfig = &types.ContainerizedRuntimeConfig{Image: image}
+		}
+		return e
+	}
+	servers := func(entryName string, count int) []v1.MCPServer {
+		result := make([]v1.MCPServer, count)
+		for i := range result {
+			result[i].Spec.MCPServerCatalogEntryName = entryName
+		}
+		return result
+	}
+
+	entries := []v1.MCPServerCatalogEntry{
+		entry("github", types.RuntimeContainerized, "github-mcp:latest"),
+		entry("slack", types.RuntimeContainerized, "slack-mcp:latest"),
+		entry("fetch", types.RuntimeUVX, ""),
+		entry("jira", types.RuntimeContainerized, "jira-mcp:latest"),
+		entry("unused", types.RuntimeContainerized, "unused-mcp:latest"),
+	}
+
+	var allServers []v1.MCPServer
+	allServers = append(allServers, servers("github", 3)...)
+	allServers = append(allServers, servers("fetch", 5)...)
+	allServers = append(allServers, servers("jira", 1)...)
+	allServers = append(allServers, servers("slack", 1)...)
+
+	template := servers("jira", 5)
+	for i := range template {
+		template[i].Spec.Template = true
+	}
+	allServers = append(allServers, template...)
+
+	pool := warmPool{size: 3, images: []string{"base", "shim"}}
+	assert.Equal(t, []string{"base", "shim", "github-mcp:latest", "jira-mcp:latest"}, warmPoolImages(entries, allServers, pool))
+
+	pool.size = 10
+	assert.Equal(t, []string{"base", "shim", "github-mcp:latest", "jira-mcp:latest", "slack-mcp:latest"}, warmPoolImages(entries, allServers, pool))
+
+	pool.size = 0
+	assert.Equal(t, []string{"base", "shim"}, warmPoolImages(entries, allServers, pool))
+}
@@ -28,64 +28,93 @@ type Webhook struct {
 }
 
 func (wh *WebhookHelper) GetWebhooksForMCPServer(serverConfig ServerConfig) ([]Webhook, error) {
-	var result []Webhook
-	webhookSeen := make(map[string]struct{})
+	validations, err := wh.getValidationsForMCPServer(serverConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]Webhook, 0, len(validations))
+	for _, res := range validations {
+		if res.Spec.Manifest.RunsInGateway() {
+			continue
+		}
+
+		displayName := res.Spec.Manifest.Name
+		if displayName == "" {
+			displayName = res.Name
+		}
+
+		result = append(result, Webhook{
+			Name:        res.Name,
+			DisplayName: displayName,
+			URL:         system.MCPConnectURL(wh.baseURL, system.SystemMCPServerPrefix+res.Name),
+			Definitions: res.Spec.Manifest.Selectors.Strings(),
+		})
+	}
+
+	return result, nil
+}
+
+// GetGatewayValidationsForMCPServer returns the enabled validations that apply to the MCP server and are run by the
+// MCP gateway instead of through the nanobot hooks. These are the built-in validations and the ones in shadow mode.
+func (wh *WebhookHelper) GetGatewayValidationsForMCPServer(serverConfig ServerConfig) ([]*v1.MCPWebhookValidation, error) {
+	validations, err := wh.getValidationsForMCPServer(serverConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return slices.DeleteFunc(validations, func(res *v1.MCPWebhookValidation) bool {
+		return !res.Spec.Manifest.RunsInGateway()
+	}), nil
+}
+
+func (wh *WebhookHelper) getValidationsForMCPServer(serverConfig ServerConfig) ([]*v1.MCPWebhookValidation, error) {
+	var result []*v1.MCPWebhookValidation
+	seen := make(map[string]struct{})
 
 	objs, err := wh.indexer.ByIndex("server-names", serverConfig.MCPServerName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhooks from MCP server index: %w", err)
 	}
 
-	result = wh.appendWebhooks(serverConfig.MCPServerNamespace, objs, webhookSeen, result)
+	result = appendValidations(serverConfig.MCPServerNamespace, objs, seen, result)
 
 	objs, err = wh.indexer.ByIndex("catalog-entry-names", serverConfig.MCPCatalogEntryName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhooks from catalog entry index: %w", err)
 	}
 
-	result = wh.appendWebhooks(serverConfig.MCPServerNamespace, objs, webhookSeen, result)
+	result = appendValidations(serverConfig.MCPServerNamespace, objs, seen, result)
 
 	objs, err = wh.indexer.ByIndex("selectors", "*")
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhooks from selector index: %w", err)
 	}
 
-	result = wh.appendWebhooks(serverConfig.MCPServerNamespace, objs, webhookSeen, result)
+	result = appendValidations(serverConfig.MCPServerNamespace, objs, seen, result)
 
 	objs, err = wh.indexer.ByIndex("catalog-names", serverConfig.MCPCatalogName)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get webhooks from catalog index: %w", err)
 	}
 
-	result = wh.appendWebhooks(serverConfig.MCPServerNamespace, objs, webhookSeen, result)
+	result = appendValidations(serverConfig.MCPServerNamespace, objs, seen, result)
 
 	return result, nil
 }
 
-func (wh *WebhookHelper) appendWebhooks(namespace string, objs []any, seen map[string]struct{}, result []Webhook) []Webhook {
+func appendValidations(namespace string, objs []any, seen map[string]struct{}, result []*v1.MCPWebhookValidation) []*v1.MCPWebhookValidation {
 	result = slices.Grow(result, len(objs))
 
 	for _, mwv := range objs {
 		res, ok := mwv.(*v1.MCPWebhookValidation)
 		if ok && res.Namespace == namespace && !res.Spec.Manifest.Disabled {
-			url := system.MCPConnectURL(wh.baseURL, system.SystemMCPServerPrefix+res.Name)
-			if _, seen := seen[url]; seen {
+			if _, seen := seen[res.Name]; seen {
 				continue
 			}
 
-			seen[url] = struct{}{}
-
-			displayName := res.Spec.Manifest.Name
-			if displayName == "" {
-				displayName = res.Name
-			}
-
-			result = append(result, Webhook{
-				Name:        res.Name,
-				DisplayName: displayName,
-				URL:         url,
-				Definitions: res.Spec.Manifest.Selectors.Strings(),
-			})
+			seen[res.Name] = struct{}{}
+			result = append(result, res)
 		}
 	}
 
@@ -0,0 +1,52 @@
+package notification
+
+import (
+	"bytes"
+	"fmt"
+	"mime"
+	"net"
+	"net/smtp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+const defaultSMTPPort = 587
+
+func (s *Sender) sendEmail(config *types.EmailNotificationConfig, password string, n Notification) error {
+	if config == nil {
+		return fmt.Errorf("email channel is missing its configuration")
+	}
+
+	port := config.Port
+	if port == 0 {
+		port = defaultSMTPPort
+	}
+
+	var auth smtp.Auth
+	if config.Username != "" {
+		// PlainAuth refuses to send credentials over an unencrypted connection, except to localhost.
+		auth = smtp.PlainAuth("", config.Username, password, config.Host)
+	}
+
+	if err := s.sendMail(net.JoinHostPort(config.Host, strconv.Itoa(port)), auth, config.From, config.To, emailMessage(config, n)); err != nil {
+		return fmt.Errorf("failed to send email: %w", err)
+	}
+
+	return nil
+}
+
+func emailMessage(config *types.EmailNotificationConfig, n Notification) []byte {
+	var msg bytes.Buffer
+	fmt.Fprintf(&msg, "From: %s\r\n", config.From)
+	fmt.Fprintf(&msg, "To: %s\r\n", strings.Join(config.To, ", "))
+	fmt.Fprintf(&msg, "Subject: %s\r\n", mime.QEncoding.Encode("utf-8", "[Obot] "+n.Subject()))
+	fmt.Fprintf(&msg, "Date: %s\r\n", time.Now().UTC().Format(time.RFC1123Z))
+	msg.WriteString("MIME-Version: 1.0\r\n")
+	msg.WriteString("Content-Type: text/plain; charset=\"utf-8\"\r\n")
+	msg.WriteString("\r\n")
+	msg.WriteString(strings.ReplaceAll(n.Text(), "\n", "\r\n"))
+	return msg.Bytes()
+}
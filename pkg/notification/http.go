@@ -0,0 +1,82 @@
+package notification
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+const (
+	// SignatureHeader carries the hex encoded HMAC-SHA256 of the request body for http channels.
+	SignatureHeader = "X-Obot-Signature-256"
+	// EventHeader carries the notification event for http channels.
+	EventHeader = "X-Obot-Event"
+)
+
+// webhookMessage is accepted by both Slack and Microsoft Teams incoming webhooks.
+type webhookMessage struct {
+	Text string `json:"text"`
+}
+
+func (s *Sender) sendWebhook(ctx context.Context, url string, n Notification) error {
+	body, err := json.Marshal(webhookMessage{Text: n.Text()})
+	if err != nil {
+		return err
+	}
+
+	return s.post(ctx, url, body, nil)
+}
+
+func (s *Sender) sendHTTP(ctx context.Context, url, secret string, n Notification) error {
+	body, err := json.Marshal(n)
+	if err != nil {
+		return err
+	}
+
+	headers := map[string]string{
+		EventHeader: string(n.Event),
+	}
+	if secret != "" {
+		headers[SignatureHeader] = Sign(secret, body)
+	}
+
+	return s.post(ctx, url, body, headers)
+}
+
+func (s *Sender) post(ctx context.Context, url string, body []byte, headers map[string]string) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		req.Header.Set(k, v)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return fmt.Errorf("notification endpoint returned status %d: %s", resp.StatusCode, respBody)
+	}
+
+	return nil
+}
+
+// Sign returns the value of the signature header for the given body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,112 @@
+package notification
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/smtp"
+	"strings"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// maxExcerptLength is the maximum number of characters of run output included in a notification.
+const maxExcerptLength = 1000
+
+// Notification describes the outcome of a task run.
+type Notification struct {
+	Event     types.NotificationEvent `json:"event"`
+	TaskID    string                  `json:"taskID"`
+	TaskName  string                  `json:"taskName"`
+	RunID     string                  `json:"runID"`
+	State     types.WorkflowState     `json:"state"`
+	Output    string                  `json:"output,omitempty"`
+	Error     string                  `json:"error,omitempty"`
+	Warning   string                  `json:"warning,omitempty"`
+	Link      string                  `json:"link,omitempty"`
+	StartTime time.Time               `json:"startTime"`
+	EndTime   time.Time               `json:"endTime,omitzero"`
+}
+
+// Subject returns a one line summary of the notification.
+func (n Notification) Subject() string {
+	name := n.TaskName
+	if name == "" {
+		name = n.TaskID
+	}
+
+	switch n.Event {
+	case types.NotificationEventFailure:
+		return fmt.Sprintf("Task %q failed", name)
+	case types.NotificationEventWarning:
+		return fmt.Sprintf("Task %q completed with warnings", name)
+	default:
+		return fmt.Sprintf("Task %q completed successfully", name)
+	}
+}
+
+// Text returns a plain text rendering of the notification suitable for email and chat messages.
+func (n Notification) Text() string {
+	var sb strings.Builder
+	sb.WriteString(n.Subject())
+	sb.WriteString("\n\n")
+	fmt.Fprintf(&sb, "Run: %s\n", n.RunID)
+	if !n.StartTime.IsZero() {
+		fmt.Fprintf(&sb, "Started: %s\n", n.StartTime.UTC().Format(time.RFC1123))
+	}
+	if !n.EndTime.IsZero() {
+		fmt.Fprintf(&sb, "Finished: %s\n", n.EndTime.UTC().Format(time.RFC1123))
+	}
+	if n.Error != "" {
+		fmt.Fprintf(&sb, "\nError:\n%s\n", n.Error)
+	}
+	if n.Warning != "" {
+		fmt.Fprintf(&sb, "\nWarning:\n%s\n", n.Warning)
+	}
+	if n.Output != "" {
+		fmt.Fprintf(&sb, "\nOutput:\n%s\n", n.Output)
+	}
+	if n.Link != "" {
+		fmt.Fprintf(&sb, "\nView run: %s\n", n.Link)
+	}
+	return sb.String()
+}
+
+// Excerpt truncates s so that it can be included in a notification.
+func Excerpt(s string) string {
+	s = strings.TrimSpace(s)
+	runes := []rune(s)
+	if len(runes) <= maxExcerptLength {
+		return s
+	}
+	return string(runes[:maxExcerptLength]) + "..."
+}
+
+// Sender delivers notifications to notification channels.
+type Sender struct {
+	httpClient *http.Client
+	sendMail   func(addr string, a smtp.Auth, from string, to []string, msg []byte) error
+}
+
+func NewSender() *Sender {
+	return &Sender{
+		httpClient: &http.Client{Timeout: 30 * time.Second},
+		sendMail:   smtp.SendMail,
+	}
+}
+
+// Send delivers the notification to the channel. The secret is the SMTP password for email channels
+// and the signing secret for http channels.
+func (s *Sender) Send(ctx context.Context, channel types.NotificationChannelManifest, secret string, n Notification) error {
+	switch channel.ChannelType {
+	case types.NotificationChannelTypeEmail:
+		return s.sendEmail(channel.Email, secret, n)
+	case types.NotificationChannelTypeWebhook:
+		return s.sendWebhook(ctx, channel.URL, n)
+	case types.NotificationChannelTypeHTTP:
+		return s.sendHTTP(ctx, channel.URL, secret, n)
+	default:
+		return fmt.Errorf("unsupported notification channel type %q", channel.ChannelType)
+	}
+}
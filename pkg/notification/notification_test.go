@@ -0,0 +1,128 @@
+package notification
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/smtp"
+	"strings"
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExcerpt(t *testing.T) {
+	assert.Equal(t, "short", Excerpt("  short\n"))
+
+	long := strings.Repeat("é", maxExcerptLength+10)
+	excerpt := Excerpt(long)
+	assert.True(t, strings.HasSuffix(excerpt, "..."))
+	assert.Len(t, []rune(excerpt), maxExcerptLength+3)
+}
+
+func TestSubject(t *testing.T) {
+	n := Notification{TaskID: "w1abc", TaskName: "Daily report"}
+
+	n.Event = types.NotificationEventSuccess
+	assert.Equal(t, `Task "Daily report" completed successfully`, n.Subject())
+
+	n.Event = types.NotificationEventFailure
+	assert.Equal(t, `Task "Daily report" failed`, n.Subject())
+
+	n.TaskName = ""
+	n.Event = types.NotificationEventWarning
+	assert.Equal(t, `Task "w1abc" completed with warnings`, n.Subject())
+}
+
+func TestSendHTTPSignsBody(t *testing.T) {
+	var (
+		body      []byte
+		signature string
+		event     string
+	)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ = io.ReadAll(r.Body)
+		signature = r.Header.Get(SignatureHeader)
+		event = r.Header.Get(EventHeader)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	n := Notification{Event: types.NotificationEventFailure, TaskID: "w1abc", RunID: "we1abc", Error: "boom"}
+	err := NewSender().Send(context.Background(), types.NotificationChannelManifest{
+		ChannelType: types.NotificationChannelTypeHTTP,
+		URL:         server.URL,
+	}, "s3cret", n)
+	require.NoError(t, err)
+
+	assert.Equal(t, Sign("s3cret", body), signature)
+	assert.Equal(t, string(types.NotificationEventFailure), event)
+
+	var received Notification
+	require.NoError(t, json.Unmarshal(body, &received))
+	assert.Equal(t, n.RunID, received.RunID)
+	assert.Equal(t, n.Error, received.Error)
+}
+
+func TestSendWebhook(t *testing.T) {
+	var msg webhookMessage
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&msg)
+	}))
+	defer server.Close()
+
+	err := NewSender().Send(context.Background(), types.NotificationChannelManifest{
+		ChannelType: types.NotificationChannelTypeWebhook,
+		URL:         server.URL,
+	}, "", Notification{Event: types.NotificationEventSuccess, TaskName: "Daily report", Link: "https://obot.example.com/o/p1abc?thread=t1abc"})
+	require.NoError(t, err)
+
+	assert.True(t, strings.HasPrefix(msg.Text, `Task "Daily report" completed successfully`))
+	assert.Contains(t, msg.Text, "https://obot.example.com/o/p1abc?thread=t1abc")
+}
+
+func TestSendReturnsErrorOnFailedStatus(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "nope", http.StatusBadGateway)
+	}))
+	defer server.Close()
+
+	err := NewSender().Send(context.Background(), types.NotificationChannelManifest{
+		ChannelType: types.NotificationChannelTypeWebhook,
+		URL:         server.URL,
+	}, "", Notification{})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "502")
+}
+
+func TestSendEmail(t *testing.T) {
+	var (
+		addr string
+		to   []string
+		msg  string
+	)
+	s := NewSender()
+	s.sendMail = func(a string, _ smtp.Auth, _ string, t []string, m []byte) error {
+		addr, to, msg = a, t, string(m)
+		return nil
+	}
+
+	err := s.Send(context.Background(), types.NotificationChannelManifest{
+		ChannelType: types.NotificationChannelTypeEmail,
+		Email: &types.EmailNotificationConfig{
+			Host: "smtp.example.com",
+			From: "obot@example.com",
+			To:   []string{"ops@example.com"},
+		},
+	}, "", Notification{Event: types.NotificationEventFailure, TaskName: "Daily report", Error: "boom"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "smtp.example.com:587", addr)
+	assert.Equal(t, []string{"ops@example.com"}, to)
+	assert.Contains(t, msg, "To: ops@example.com\r\n")
+	assert.Contains(t, msg, "Error:\r\nboom")
+}
@@ -0,0 +1,258 @@
+package packagepolicy
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var (
+	npmNameRegex         = regexp.MustCompile(`^(?:@[a-z0-9-~][a-z0-9-._~]*/)?[a-z0-9-~][a-z0-9-._~]*$`)
+	semverRegex          = regexp.MustCompile(`^v?\d+\.\d+\.\d+(?:-[0-9A-Za-z.-]+)?(?:\+[0-9A-Za-z.-]+)?$`)
+	pypiRequirementRegex = regexp.MustCompile(`^([A-Za-z0-9](?:[A-Za-z0-9._-]*[A-Za-z0-9])?)\s*(?:\[[A-Za-z0-9,._\- ]*\])?\s*(.*)$`)
+	pypiNormalizeRegex   = regexp.MustCompile(`[-_.]+`)
+	pypiVersionRegex     = regexp.MustCompile(`^[A-Za-z0-9.+!-]+$`)
+	errNotARegistryName  = errors.New("only packages from the registry can be run, not URLs, paths, or other sources")
+)
+
+// ViolationError is returned when a package is not allowed by the package policy.
+type ViolationError struct {
+	Package string
+	Reason  string
+}
+
+func (e *ViolationError) Error() string {
+	return fmt.Sprintf("package %q is not allowed by the package policy: %s", e.Package, e.Reason)
+}
+
+// Get returns the package policy. If one hasn't been configured, then an empty policy that allows all packages is returned.
+func Get(ctx context.Context, client kclient.Client) (types.PackagePolicyManifest, error) {
+	var policy v1.PackagePolicy
+	if err := client.Get(ctx, kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: system.PackagePolicyName}, &policy); apierrors.IsNotFound(err) {
+		return types.PackagePolicyManifest{}, nil
+	} else if err != nil {
+		return types.PackagePolicyManifest{}, fmt.Errorf("failed to get package policy: %w", err)
+	}
+
+	return policy.Spec.PackagePolicyManifest, nil
+}
+
+// Validate returns an error if the policy is not valid.
+func Validate(policy types.PackagePolicyManifest) error {
+	return errors.Join(
+		validateRegistryPolicy("npm", policy.NPM),
+		validateRegistryPolicy("pypi", policy.PyPI),
+	)
+}
+
+func validateRegistryPolicy(registry string, policy types.PackageRegistryPolicy) error {
+	var errs []error
+	for _, allowed := range policy.AllowedPackages {
+		if strings.TrimSpace(allowed) == "" {
+			errs = append(errs, fmt.Errorf("%s: allowed package entries cannot be empty", registry))
+		} else if _, err := path.Match(allowed, ""); err != nil {
+			errs = append(errs, fmt.Errorf("%s: invalid allowed package %q: %w", registry, allowed, err))
+		}
+	}
+
+	if policy.MirrorURL != "" {
+		if u, err := url.Parse(policy.MirrorURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			errs = append(errs, fmt.Errorf("%s: mirror URL must be an absolute http or https URL", registry))
+		}
+	}
+
+	return errors.Join(errs...)
+}
+
+// Check returns a [ViolationError] if the package is not allowed by the policy for the runtime.
+// Runtimes other than npx and uvx are always allowed.
+func Check(policy types.PackagePolicyManifest, runtime types.Runtime, pkg string) error {
+	var (
+		registryPolicy types.PackageRegistryPolicy
+		parse          func(string) (string, bool, error)
+	)
+	switch runtime {
+	case types.RuntimeNPX:
+		registryPolicy, parse = policy.NPM, parseNPM
+	case types.RuntimeUVX:
+		registryPolicy, parse = policy.PyPI, parsePyPI
+	default:
+		return nil
+	}
+
+	if len(registryPolicy.AllowedPackages) == 0 && !registryPolicy.RequireExactVersion {
+		return nil
+	}
+
+	name, exact, err := parse(strings.TrimSpace(pkg))
+	if err != nil {
+		return &ViolationError{Package: pkg, Reason: err.Error()}
+	}
+
+	if len(registryPolicy.AllowedPackages) > 0 && !slices.ContainsFunc(registryPolicy.AllowedPackages, func(allowed string) bool {
+		if runtime == types.RuntimeUVX {
+			allowed = normalizePyPI(allowed)
+		}
+		matched, _ := path.Match(strings.TrimSpace(allowed), name)
+		return matched
+	}) {
+		return &ViolationError{Package: pkg, Reason: fmt.Sprintf("%s is not in the list of allowed packages", name)}
+	}
+
+	if registryPolicy.RequireExactVersion && !exact {
+		return &ViolationError{Package: pkg, Reason: "the package must be pinned to an exact version"}
+	}
+
+	return nil
+}
+
+// CheckServerManifest checks the package of the server, including those of a composite server's components.
+func CheckServerManifest(policy types.PackagePolicyManifest, manifest types.MCPServerManifest) error {
+	switch {
+	case manifest.Runtime == types.RuntimeNPX && manifest.NPXConfig != nil:
+		return Check(policy, manifest.Runtime, manifest.NPXConfig.Package)
+	case manifest.Runtime == types.RuntimeUVX && manifest.UVXConfig != nil:
+		return Check(policy, manifest.Runtime, manifest.UVXConfig.Package)
+	case manifest.Runtime == types.RuntimeComposite && manifest.CompositeConfig != nil:
+		for _, component := range manifest.CompositeConfig.ComponentServers {
+			if err := CheckServerManifest(policy, component.Manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// CheckCatalogEntryManifest checks the package of the catalog entry, including those of a composite entry's components.
+func CheckCatalogEntryManifest(policy types.PackagePolicyManifest, manifest types.MCPServerCatalogEntryManifest) error {
+	switch {
+	case manifest.Runtime == types.RuntimeNPX && manifest.NPXConfig != nil:
+		return Check(policy, manifest.Runtime, manifest.NPXConfig.Package)
+	case manifest.Runtime == types.RuntimeUVX && manifest.UVXConfig != nil:
+		return Check(policy, manifest.Runtime, manifest.UVXConfig.Package)
+	case manifest.Runtime == types.RuntimeComposite && manifest.CompositeConfig != nil:
+		for _, component := range manifest.CompositeConfig.ComponentServers {
+			if err := CheckCatalogEntryManifest(policy, component.Manifest); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// MirrorEnv returns the environment variables that point the runtime's package manager at the configured mirror, if there is one.
+func MirrorEnv(policy types.PackagePolicyManifest, runtime types.Runtime) []string {
+	switch {
+	case runtime == types.RuntimeNPX && policy.NPM.MirrorURL != "":
+		return []string{"NPM_CONFIG_REGISTRY=" + policy.NPM.MirrorURL}
+	case runtime == types.RuntimeUVX && policy.PyPI.MirrorURL != "":
+		return []string{"UV_DEFAULT_INDEX=" + policy.PyPI.MirrorURL}
+	}
+	return nil
+}
+
+// StripRegistryEnv removes the environment variables that point the runtime's package manager at another registry or
+// index when the policy restricts the runtime's packages. Otherwise, an allowed package name could be installed from a
+// registry that isn't the mirror, or from an index that publishes a different package with the same name.
+func StripRegistryEnv(policy types.PackagePolicyManifest, runtime types.Runtime, env []string) []string {
+	var registryPolicy types.PackageRegistryPolicy
+	switch runtime {
+	case types.RuntimeNPX:
+		registryPolicy = policy.NPM
+	case types.RuntimeUVX:
+		registryPolicy = policy.PyPI
+	default:
+		return env
+	}
+	if registryPolicy.MirrorURL == "" && len(registryPolicy.AllowedPackages) == 0 {
+		return env
+	}
+
+	return slices.DeleteFunc(slices.Clone(env), func(kv string) bool {
+		key, _, _ := strings.Cut(kv, "=")
+		return isRegistryEnvKey(runtime, key)
+	})
+}
+
+// isRegistryEnvKey returns whether the environment variable configures where the runtime's package manager installs
+// packages from. Keys are compared case-insensitively, because npm reads its configuration in any case.
+func isRegistryEnvKey(runtime types.Runtime, key string) bool {
+	key = strings.ToUpper(key)
+	switch runtime {
+	case types.RuntimeNPX:
+		// This includes scoped registries, like npm_config_@scope:registry.
+		return strings.HasPrefix(key, "NPM_CONFIG_") && strings.HasSuffix(key, "REGISTRY")
+	case types.RuntimeUVX:
+		return strings.HasPrefix(key, "UV_INDEX") ||
+			slices.Contains([]string{"UV_DEFAULT_INDEX", "UV_EXTRA_INDEX_URL", "UV_FIND_LINKS", "PIP_INDEX_URL", "PIP_EXTRA_INDEX_URL", "PIP_FIND_LINKS"}, key)
+	}
+	return false
+}
+
+// parseNPM returns the name of an npm package spec like "@scope/name@1.2.3", and whether it is pinned to an exact version.
+func parseNPM(spec string) (string, bool, error) {
+	name, version := spec, ""
+	if i := strings.LastIndex(spec, "@"); i > 0 {
+		name, version = spec[:i], spec[i+1:]
+	}
+
+	if !npmNameRegex.MatchString(name) {
+		return "", false, errNotARegistryName
+	}
+
+	return name, semverRegex.MatchString(version), nil
+}
+
+// parsePyPI returns the normalized name of a PyPI requirement like "name[extra]==1.2.3" or "name@1.2.3",
+// and whether it is pinned to an exact version.
+func parsePyPI(spec string) (string, bool, error) {
+	match := pypiRequirementRegex.FindStringSubmatch(spec)
+	if match == nil {
+		return "", false, errNotARegistryName
+	}
+
+	name, constraint := normalizePyPI(match[1]), strings.TrimSpace(match[2])
+	var version string
+	switch {
+	case constraint == "":
+		return name, false, nil
+	case strings.HasPrefix(constraint, "==="):
+		version = constraint[3:]
+	case strings.HasPrefix(constraint, "=="):
+		version = constraint[2:]
+	case strings.HasPrefix(constraint, "@"):
+		version = constraint[1:]
+		if strings.Contains(version, "://") || strings.Contains(version, "/") {
+			// A direct reference, like "name @ git+https://...", isn't from the registry.
+			return "", false, errNotARegistryName
+		}
+		if version == "latest" {
+			return name, false, nil
+		}
+	case strings.ContainsAny(constraint[:1], "<>!~="):
+		return name, false, nil
+	default:
+		return "", false, errNotARegistryName
+	}
+
+	version = strings.TrimSpace(version)
+	return name, pypiVersionRegex.MatchString(version) && !strings.Contains(version, "*"), nil
+}
+
+// normalizePyPI normalizes a PyPI package name as described in PEP 503.
+func normalizePyPI(name string) string {
+	return strings.ToLower(pypiNormalizeRegex.ReplaceAllString(strings.TrimSpace(name), "-"))
+}
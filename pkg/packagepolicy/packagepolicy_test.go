@@ -0,0 +1,199 @@
+package packagepolicy
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheck(t *testing.T) {
+	npmPolicy := types.PackagePolicyManifest{
+		NPM: types.PackageRegistryPolicy{
+			AllowedPackages:     []string{"@modelcontextprotocol/*", "mcp-remote"},
+			RequireExactVersion: true,
+		},
+	}
+	pypiPolicy := types.PackagePolicyManifest{
+		PyPI: types.PackageRegistryPolicy{
+			AllowedPackages:     []string{"mcp_server_*", "Obot-MCP"},
+			RequireExactVersion: true,
+		},
+	}
+
+	tests := []struct {
+		name    string
+		policy  types.PackagePolicyManifest
+		runtime types.Runtime
+		pkg     string
+		allowed bool
+	}{
+		{
+			name:    "empty policy allows everything",
+			runtime: types.RuntimeNPX,
+			pkg:     "github:someone/something",
+			allowed: true,
+		},
+		{
+			name:    "other runtimes are not checked",
+			policy:  npmPolicy,
+			runtime: types.RuntimeContainerized,
+			pkg:     "anything",
+			allowed: true,
+		},
+		{
+			name:    "npm scope with exact version",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "@modelcontextprotocol/server-github@2025.4.8",
+			allowed: true,
+		},
+		{
+			name:    "npm exact name",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "mcp-remote@0.1.18",
+			allowed: true,
+		},
+		{
+			name:    "npm typosquatted scope",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "@modelcontextprotoco1/server-github@2025.4.8",
+		},
+		{
+			name:    "npm without version",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "mcp-remote",
+		},
+		{
+			name:    "npm with tag",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "mcp-remote@latest",
+		},
+		{
+			name:    "npm with range",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "mcp-remote@^0.1.0",
+		},
+		{
+			name:    "npm from git",
+			policy:  npmPolicy,
+			runtime: types.RuntimeNPX,
+			pkg:     "github:modelcontextprotocol/servers",
+		},
+		{
+			name:    "pypi normalized name with exact version",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "mcp-server-fetch==2025.1.17",
+			allowed: true,
+		},
+		{
+			name:    "pypi with extras and uvx version syntax",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "obot_mcp[cli]@1.0.0",
+			allowed: true,
+		},
+		{
+			name:    "pypi with range",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "mcp-server-fetch>=2025.1.17",
+		},
+		{
+			name:    "pypi with wildcard version",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "mcp-server-fetch==2025.*",
+		},
+		{
+			name:    "pypi not allowed",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "requests==2.32.0",
+		},
+		{
+			name:    "pypi from git",
+			policy:  pypiPolicy,
+			runtime: types.RuntimeUVX,
+			pkg:     "git+https://github.com/someone/mcp-server-fetch",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := Check(tt.policy, tt.runtime, tt.pkg)
+			if tt.allowed {
+				assert.NoError(t, err)
+			} else {
+				var violation *ViolationError
+				assert.ErrorAs(t, err, &violation)
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, Validate(types.PackagePolicyManifest{
+		NPM:  types.PackageRegistryPolicy{AllowedPackages: []string{"@scope/*"}, MirrorURL: "https://npm.example.com/"},
+		PyPI: types.PackageRegistryPolicy{AllowedPackages: []string{"mcp-*"}, MirrorURL: "https://pypi.example.com/simple"},
+	}))
+
+	assert.Error(t, Validate(types.PackagePolicyManifest{NPM: types.PackageRegistryPolicy{AllowedPackages: []string{"["}}}))
+	assert.Error(t, Validate(types.PackagePolicyManifest{NPM: types.PackageRegistryPolicy{AllowedPackages: []string{" "}}}))
+	assert.Error(t, Validate(types.PackagePolicyManifest{PyPI: types.PackageRegistryPolicy{MirrorURL: "pypi.example.com"}}))
+}
+
+func TestMirrorEnv(t *testing.T) {
+	policy := types.PackagePolicyManifest{
+		NPM:  types.PackageRegistryPolicy{MirrorURL: "https://npm.example.com/"},
+		PyPI: types.PackageRegistryPolicy{MirrorURL: "https://pypi.example.com/simple"},
+	}
+
+	assert.Equal(t, []string{"NPM_CONFIG_REGISTRY=https://npm.example.com/"}, MirrorEnv(policy, types.RuntimeNPX))
+	assert.Equal(t, []string{"UV_DEFAULT_INDEX=https://pypi.example.com/simple"}, MirrorEnv(policy, types.RuntimeUVX))
+	assert.Nil(t, MirrorEnv(policy, types.RuntimeContainerized))
+	assert.Nil(t, MirrorEnv(types.PackagePolicyManifest{}, types.RuntimeNPX))
+}
+
+func TestStripRegistryEnv(t *testing.T) {
+	env := []string{
+		"API_KEY=secret",
+		"npm_config_registry=https://attacker.example.com/",
+		"NPM_CONFIG_@scope:registry=https://attacker.example.com/",
+		"UV_INDEX=https://attacker.example.com/simple",
+		"UV_INDEX_URL=https://attacker.example.com/simple",
+		"UV_EXTRA_INDEX_URL=https://attacker.example.com/simple",
+		"PIP_INDEX_URL=https://attacker.example.com/simple",
+		"PIP_EXTRA_INDEX_URL=https://attacker.example.com/simple",
+	}
+
+	mirrored := types.PackagePolicyManifest{
+		NPM: types.PackageRegistryPolicy{MirrorURL: "https://npm.example.com/"},
+	}
+	assert.Equal(t, []string{
+		"API_KEY=secret",
+		"UV_INDEX=https://attacker.example.com/simple",
+		"UV_INDEX_URL=https://attacker.example.com/simple",
+		"UV_EXTRA_INDEX_URL=https://attacker.example.com/simple",
+		"PIP_INDEX_URL=https://attacker.example.com/simple",
+		"PIP_EXTRA_INDEX_URL=https://attacker.example.com/simple",
+	}, StripRegistryEnv(mirrored, types.RuntimeNPX, env))
+
+	allowlisted := types.PackagePolicyManifest{
+		PyPI: types.PackageRegistryPolicy{AllowedPackages: []string{"mcp-server-fetch"}},
+	}
+	assert.Equal(t, []string{
+		"API_KEY=secret",
+		"npm_config_registry=https://attacker.example.com/",
+		"NPM_CONFIG_@scope:registry=https://attacker.example.com/",
+	}, StripRegistryEnv(allowlisted, types.RuntimeUVX, env))
+
+	assert.Equal(t, env, StripRegistryEnv(mirrored, types.RuntimeUVX, env), "the environment is kept when the runtime isn't restricted")
+	assert.Len(t, env, 8, "the environment passed in isn't modified")
+}
@@ -0,0 +1,175 @@
+package search
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"net/http"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/obot/apiclient/types"
+	"github.com/obot-platform/obot/pkg/alias"
+	"github.com/obot-platform/obot/pkg/gateway/server/dispatcher"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/obot-platform/obot/pkg/system"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// embedder turns text into vectors.
+type embedder interface {
+	// model returns the name of the model the embeddings are produced with.
+	model(ctx context.Context) (string, error)
+	embed(ctx context.Context, texts []string) (string, [][]float32, error)
+}
+
+// modelProviderEmbedder embeds text with the model of the text-embedding default model alias.
+type modelProviderEmbedder struct {
+	client     kclient.Client
+	dispatcher *dispatcher.Dispatcher
+	gptClient  *gptscript.GPTScript
+}
+
+func (e *modelProviderEmbedder) model(ctx context.Context) (string, error) {
+	model, err := e.resolveModel(ctx)
+	if err != nil {
+		return "", err
+	}
+	return model.Spec.Manifest.TargetModel, nil
+}
+
+// resolveModel resolves the text-embedding alias to its model.
+func (e *modelProviderEmbedder) resolveModel(ctx context.Context) (*v1.Model, error) {
+	aliasType := types.DefaultModelAliasTypeTextEmbedding
+	m, err := alias.GetFromScope(ctx, e.client, "Model", system.DefaultNamespace, string(aliasType))
+	if err != nil {
+		return nil, fmt.Errorf("failed to resolve %s alias: %w", aliasType, err)
+	}
+
+	var model *v1.Model
+	switch resolved := m.(type) {
+	case *v1.DefaultModelAlias:
+		if resolved.Spec.Manifest.Model == "" {
+			return nil, fmt.Errorf("default model alias %q is not configured", aliasType)
+		}
+		var mdl v1.Model
+		if err := alias.Get(ctx, e.client, &mdl, system.DefaultNamespace, resolved.Spec.Manifest.Model); err != nil {
+			return nil, fmt.Errorf("failed to get model from alias: %w", err)
+		}
+		model = &mdl
+	case *v1.Model:
+		model = resolved
+	default:
+		return nil, fmt.Errorf("unexpected type %T when resolving %s", m, aliasType)
+	}
+
+	if !model.Spec.Manifest.Active {
+		return nil, fmt.Errorf("model %q is not active", model.Spec.Manifest.Name)
+	}
+	return model, nil
+}
+
+type embeddingRequest struct {
+	Model string   `json:"model"`
+	Input []string `json:"input"`
+}
+
+type embeddingResponse struct {
+	Data []struct {
+		Index     int       `json:"index"`
+		Embedding []float32 `json:"embedding"`
+	} `json:"data"`
+}
+
+func (e *modelProviderEmbedder) embed(ctx context.Context, texts []string) (string, [][]float32, error) {
+	model, err := e.resolveModel(ctx)
+	if err != nil {
+		return "", nil, err
+	}
+
+	providerURL, err := e.dispatcher.URLForModelProvider(ctx, e.gptClient, system.DefaultNamespace, model.Spec.Manifest.ModelProvider)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get model provider URL: %w", err)
+	}
+	// only add /v1 if the URL has no path.
+	if providerURL.Path == "" || providerURL.Path == "/" {
+		providerURL.Path = "/v1"
+	}
+
+	var toolRef v1.ToolReference
+	if err := e.client.Get(ctx, kclient.ObjectKey{Namespace: system.DefaultNamespace, Name: model.Spec.Manifest.ModelProvider}, &toolRef); err != nil {
+		return "", nil, fmt.Errorf("failed to get model provider tool reference: %w", err)
+	}
+
+	credEnv, err := dispatcher.CredentialEnvForModelProvider(ctx, e.gptClient, toolRef)
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to get model provider credentials: %w", err)
+	}
+
+	body, err := json.Marshal(embeddingRequest{
+		Model: model.Spec.Manifest.TargetModel,
+		Input: texts,
+	})
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, providerURL.JoinPath("embeddings").String(), bytes.NewReader(body))
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	for k, v := range credEnv {
+		httpReq.Header.Set(fmt.Sprintf("X-Obot-%s", k), v)
+	}
+
+	resp, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return "", nil, fmt.Errorf("embedding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return "", nil, fmt.Errorf("embedding request returned status %d: %s", resp.StatusCode, respBody)
+	}
+
+	var embeddings embeddingResponse
+	if err := json.NewDecoder(resp.Body).Decode(&embeddings); err != nil {
+		return "", nil, fmt.Errorf("failed to decode embedding response: %w", err)
+	}
+
+	vectors := make([][]float32, len(texts))
+	for _, data := range embeddings.Data {
+		if data.Index < 0 || data.Index >= len(vectors) {
+			return "", nil, fmt.Errorf("embedding response has unexpected index %d", data.Index)
+		}
+		vectors[data.Index] = data.Embedding
+	}
+	for i, vector := range vectors {
+		if len(vector) == 0 {
+			return "", nil, fmt.Errorf("embedding response is missing input %d", i)
+		}
+	}
+
+	return model.Spec.Manifest.TargetModel, vectors, nil
+}
+
+func encodeVector(vector []float32) []byte {
+	data := make([]byte, 4*len(vector))
+	for i, v := range vector {
+		binary.LittleEndian.PutUint32(data[4*i:], math.Float32bits(v))
+	}
+	return data
+}
+
+func decodeVector(data []byte) []float32 {
+	vector := make([]float32, len(data)/4)
+	for i := range vector {
+		vector[i] = math.Float32frombits(binary.LittleEndian.Uint32(data[4*i:]))
+	}
+	return vector
+}
@@ -0,0 +1,169 @@
+package search
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/gptscript-ai/go-gptscript"
+	"github.com/obot-platform/obot/logger"
+	"github.com/obot-platform/obot/pkg/gateway/server/dispatcher"
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/obot-platform/obot/pkg/hash"
+	kclient "sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+var log = logger.Package()
+
+const (
+	KindSkill             = "skill"
+	KindPublishedArtifact = "published-artifact"
+
+	// maxBodyLen is the maximum length of a document body that is indexed.
+	maxBodyLen = 8 * 1024
+)
+
+// Document is a searchable resource.
+type Document struct {
+	Kind        string
+	ID          string
+	Name        string
+	DisplayName string
+	Description string
+	// Body is the long-form content of the document, like the body of a SKILL.md. It is only needed when indexing;
+	// when searching, the indexed body is used.
+	Body string
+}
+
+// Result is a document that matched a query, with its relevance score.
+type Result struct {
+	ID    string
+	Score float64
+}
+
+type store interface {
+	GetSearchDocument(ctx context.Context, id string) (*gatewaytypes.SearchDocument, error)
+	ListSearchDocuments(ctx context.Context, kind string) ([]gatewaytypes.SearchDocument, error)
+	UpsertSearchDocument(ctx context.Context, doc *gatewaytypes.SearchDocument) error
+	DeleteSearchDocument(ctx context.Context, id string) error
+}
+
+// Index stores the content and embeddings of searchable resources, and ranks them against queries with a hybrid of
+// keyword and vector search.
+type Index struct {
+	store    store
+	embedder embedder
+}
+
+func NewIndex(client kclient.Client, store store, dispatcher *dispatcher.Dispatcher, gptClient *gptscript.GPTScript) *Index {
+	return &Index{
+		store: store,
+		embedder: &modelProviderEmbedder{
+			client:     client,
+			dispatcher: dispatcher,
+			gptClient:  gptClient,
+		},
+	}
+}
+
+func documentID(kind, id string) string {
+	return kind + "/" + id
+}
+
+// Add indexes the document, embedding it if its content or the embedding model changed since it was last indexed.
+// It returns false if the document couldn't be embedded, in which case it is still searchable by keyword and should
+// be added again later.
+func (i *Index) Add(ctx context.Context, doc Document) (bool, error) {
+	id := documentID(doc.Kind, doc.ID)
+	body := TruncateBody(doc.Body)
+	text := strings.Join([]string{doc.Name, doc.DisplayName, doc.Description, body}, "\n")
+	contentHash := hash.String(text)
+
+	existing, err := i.store.GetSearchDocument(ctx, id)
+	if err != nil {
+		return false, err
+	}
+
+	model, err := i.embedder.model(ctx)
+	if err != nil {
+		log.Debugf("Text embedding model is not available, indexing %s by keyword only: %v", id, err)
+	}
+	if existing != nil && existing.ContentHash == contentHash && (model == "" || existing.EmbeddingModel == model) {
+		return existing.EmbeddingModel != "", nil
+	}
+
+	indexed := &gatewaytypes.SearchDocument{
+		ID:          id,
+		Kind:        doc.Kind,
+		ResourceID:  doc.ID,
+		Body:        body,
+		UpdatedAt:   time.Now(),
+		ContentHash: contentHash,
+	}
+	if model != "" {
+		embeddingModel, vectors, err := i.embedder.embed(ctx, []string{text})
+		if err != nil {
+			log.Warnf("Failed to embed %s, indexing it by keyword only: %v", id, err)
+		} else {
+			indexed.EmbeddingModel = embeddingModel
+			indexed.Embedding = encodeVector(vectors[0])
+		}
+	}
+
+	return indexed.EmbeddingModel != "", i.store.UpsertSearchDocument(ctx, indexed)
+}
+
+// Remove removes the document from the index.
+func (i *Index) Remove(ctx context.Context, kind, id string) error {
+	return i.store.DeleteSearchDocument(ctx, documentID(kind, id))
+}
+
+// Search ranks the candidates, which must all be of the given kind, against the query. Only the candidates that match
+// the query are returned, most relevant first. If the query can't be embedded, the candidates are ranked by keyword
+// only.
+func (i *Index) Search(ctx context.Context, kind, query string, candidates []Document) ([]Result, error) {
+	indexed, err := i.store.ListSearchDocuments(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]gatewaytypes.SearchDocument, len(indexed))
+	for _, doc := range indexed {
+		byID[doc.ResourceID] = doc
+	}
+
+	keywordDocs := make([]Document, len(candidates))
+	for j, candidate := range candidates {
+		if doc, ok := byID[candidate.ID]; ok {
+			candidate.Body = doc.Body
+		}
+		keywordDocs[j] = candidate
+	}
+	keywordScores := keywordScores(query, keywordDocs)
+
+	var vectorScores map[string]float64
+	if len(byID) > 0 {
+		model, vectors, err := i.embedder.embed(ctx, []string{query})
+		if err != nil {
+			log.Debugf("Failed to embed search query, searching by keyword only: %v", err)
+		} else {
+			vectorScores = make(map[string]float64, len(candidates))
+			for _, candidate := range candidates {
+				if doc, ok := byID[candidate.ID]; ok && doc.EmbeddingModel == model && len(doc.Embedding) > 0 {
+					vectorScores[candidate.ID] = cosineSimilarity(vectors[0], decodeVector(doc.Embedding))
+				}
+			}
+		}
+	}
+
+	return fuse(keywordScores, vectorScores), nil
+}
+
+// TruncateBody truncates a body to the length that is indexed, so that resources can store only what search needs.
+func TruncateBody(body string) string {
+	if len(body) <= maxBodyLen {
+		return body
+	}
+	// Drop a partial UTF-8 sequence left at the end.
+	return strings.ToValidUTF8(body[:maxBodyLen], "")
+}
@@ -0,0 +1,184 @@
+package search
+
+import (
+	"context"
+	"errors"
+	"maps"
+	"slices"
+	"testing"
+
+	gatewaytypes "github.com/obot-platform/obot/pkg/gateway/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type memoryStore map[string]gatewaytypes.SearchDocument
+
+func (m memoryStore) GetSearchDocument(_ context.Context, id string) (*gatewaytypes.SearchDocument, error) {
+	doc, ok := m[id]
+	if !ok {
+		return nil, nil
+	}
+	return &doc, nil
+}
+
+func (m memoryStore) ListSearchDocuments(_ context.Context, kind string) ([]gatewaytypes.SearchDocument, error) {
+	var docs []gatewaytypes.SearchDocument
+	for _, doc := range m {
+		if doc.Kind == kind {
+			docs = append(docs, doc)
+		}
+	}
+	return docs, nil
+}
+
+func (m memoryStore) UpsertSearchDocument(_ context.Context, doc *gatewaytypes.SearchDocument) error {
+	m[doc.ID] = *doc
+	return nil
+}
+
+func (m memoryStore) DeleteSearchDocument(_ context.Context, id string) error {
+	delete(m, id)
+	return nil
+}
+
+// topicEmbedder embeds text as a vector of whether it mentions each of a fixed set of topics.
+type topicEmbedder struct {
+	topics [][]string
+	calls  int
+	err    error
+}
+
+func (e *topicEmbedder) model(context.Context) (string, error) {
+	return "topics", e.err
+}
+
+func (e *topicEmbedder) embed(_ context.Context, texts []string) (string, [][]float32, error) {
+	if e.err != nil {
+		return "", nil, e.err
+	}
+	e.calls++
+
+	vectors := make([][]float32, len(texts))
+	for i, text := range texts {
+		tokens := tokenize(text)
+		vectors[i] = make([]float32, len(e.topics))
+		for j, words := range e.topics {
+			for _, token := range tokens {
+				for _, word := range words {
+					if token == word {
+						vectors[i][j] = 1
+					}
+				}
+			}
+		}
+	}
+	return "topics", vectors, nil
+}
+
+func TestIndexSearch(t *testing.T) {
+	ctx := context.Background()
+	store := memoryStore{}
+	embedder := &topicEmbedder{topics: [][]string{
+		{"review", "pr", "diff", "critique"},
+		{"deploy", "release", "ship"},
+		{"spreadsheet", "excel", "csv"},
+	}}
+	index := &Index{store: store, embedder: embedder}
+
+	docs := []Document{
+		{Kind: KindSkill, ID: "code-review", Name: "code-review", Description: "Reviews pull requests", Body: "Read the diff and leave comments."},
+		{Kind: KindSkill, ID: "release", Name: "release", Description: "Ships a new version", Body: "Tag and deploy."},
+		{Kind: KindSkill, ID: "xlsx", Name: "xlsx", Description: "Works with spreadsheets", Body: "Open excel and csv files."},
+	}
+	for _, doc := range docs {
+		embedded, err := index.Add(ctx, doc)
+		require.NoError(t, err)
+		assert.True(t, embedded)
+	}
+	assert.Equal(t, 3, embedder.calls)
+
+	// Adding unchanged documents doesn't embed them again.
+	_, err := index.Add(ctx, docs[0])
+	require.NoError(t, err)
+	assert.Equal(t, 3, embedder.calls)
+
+	// A keyword match in the body is found even though the candidates don't carry the body.
+	candidates := []Document{
+		{Kind: KindSkill, ID: "code-review", Name: "code-review", Description: "Reviews pull requests"},
+		{Kind: KindSkill, ID: "release", Name: "release", Description: "Ships a new version"},
+		{Kind: KindSkill, ID: "xlsx", Name: "xlsx", Description: "Works with spreadsheets"},
+	}
+	results, err := index.Search(ctx, KindSkill, "tag", candidates)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "release", results[0].ID)
+
+	// Vector search finds documents without shared words.
+	results, err = index.Search(ctx, KindSkill, "critique my PR", candidates)
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "code-review", results[0].ID)
+
+	// Documents that match by keyword and by meaning rank first.
+	results, err = index.Search(ctx, KindSkill, "csv review", candidates)
+	require.NoError(t, err)
+	require.Len(t, results, 2)
+
+	// Only candidates are returned.
+	results, err = index.Search(ctx, KindSkill, "excel", candidates[:2])
+	require.NoError(t, err)
+	assert.Empty(t, results)
+
+	require.NoError(t, index.Remove(ctx, KindSkill, "xlsx"))
+	assert.Len(t, store, 2)
+}
+
+func TestIndexWithoutEmbeddingModel(t *testing.T) {
+	ctx := context.Background()
+	index := &Index{store: memoryStore{}, embedder: &topicEmbedder{err: errors.New("default model alias \"text-embedding\" is not configured")}}
+
+	embedded, err := index.Add(ctx, Document{Kind: KindPublishedArtifact, ID: "pa1", Name: "workflow-a", Body: "Summarize the weekly report."})
+	require.NoError(t, err)
+	assert.False(t, embedded)
+
+	results, err := index.Search(ctx, KindPublishedArtifact, "week", []Document{{Kind: KindPublishedArtifact, ID: "pa1", Name: "workflow-a"}})
+	require.NoError(t, err)
+	require.Len(t, results, 1)
+	assert.Equal(t, "pa1", results[0].ID)
+}
+
+func TestKeywordScores(t *testing.T) {
+	scores := keywordScores("code rev", []Document{
+		{ID: "name", Name: "code-review"},
+		{ID: "description", Name: "helper", Description: "Reviews code"},
+		{ID: "none", Name: "release"},
+	})
+	assert.Len(t, scores, 2)
+	assert.Greater(t, scores["name"], scores["description"])
+}
+
+func TestKeywordScoresPartialQueries(t *testing.T) {
+	docs := []Document{
+		{ID: "postgres", Name: "postgres-helper"},
+		{ID: "mysql", Name: "mysql-helper", Description: "Queries MySQL databases"},
+	}
+
+	// Typeahead queries match the start of a token.
+	scores := keywordScores("post", docs)
+	assert.Equal(t, []string{"postgres"}, slices.Collect(maps.Keys(scores)))
+
+	// Queries that only match inside a token fall back to substring matches, as before the index was used.
+	scores = keywordScores("gres", docs)
+	assert.Equal(t, []string{"postgres"}, slices.Collect(maps.Keys(scores)))
+
+	scores = keywordScores("ysq", docs)
+	assert.Equal(t, []string{"mysql"}, slices.Collect(maps.Keys(scores)))
+
+	assert.Empty(t, keywordScores("redis", docs))
+}
+
+func TestVectorEncoding(t *testing.T) {
+	vector := []float32{0.25, -1.5, 3}
+	assert.Equal(t, vector, decodeVector(encodeVector(vector)))
+}
@@ -0,0 +1,38 @@
+package search
+
+import (
+	"github.com/obot-platform/obot/pkg/skillformat"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+)
+
+// PublishedArtifactDocument returns the document for the latest published version of the artifact that isn't yanked.
+// It returns false if there is no such version, in which case the artifact shouldn't be searchable.
+func PublishedArtifactDocument(artifact *v1.PublishedArtifact) (Document, bool) {
+	var latest int
+	description, body := "", ""
+	for _, version := range artifact.Status.Versions {
+		if version.Version <= latest || version.Yanked || !version.IsPublished() {
+			continue
+		}
+		latest = version.Version
+		description, body = version.Description, version.Body
+	}
+	if latest == 0 {
+		return Document{}, false
+	}
+
+	if latest == artifact.Spec.LatestVersion {
+		// The artifact's description can be edited after the version is published, and versions published before
+		// bodies were recorded per version only have a body on the artifact.
+		description, body = artifact.Spec.Description, artifact.Spec.Body
+	}
+
+	return Document{
+		Kind:        KindPublishedArtifact,
+		ID:          artifact.Name,
+		Name:        artifact.Spec.Name,
+		DisplayName: skillformat.DisplayName(artifact.Spec.Name),
+		Description: description,
+		Body:        body,
+	}, true
+}
@@ -0,0 +1,69 @@
+package search
+
+import (
+	"testing"
+
+	"github.com/obot-platform/obot/apiclient/types"
+	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func TestPublishedArtifactDocument(t *testing.T) {
+	artifact := &v1.PublishedArtifact{
+		ObjectMeta: metav1.ObjectMeta{Name: "pa1abc"},
+		Spec: v1.PublishedArtifactSpec{
+			PublishedArtifactManifest: types.PublishedArtifactManifest{
+				Name:        "my-workflow",
+				Description: "edited v2",
+			},
+			LatestVersion: 2,
+			Body:          "v2 body",
+		},
+		Status: v1.PublishedArtifactStatus{
+			Versions: []types.PublishedArtifactVersionEntry{
+				{Version: 1, Description: "v1", Body: "v1 body"},
+				{
+					Version:                       2,
+					Description:                   "v2",
+					Body:                          "v2 body",
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{ReviewState: types.PublishedArtifactReviewStateApproved},
+				},
+				{
+					Version:                       3,
+					Description:                   "v3",
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{ReviewState: types.PublishedArtifactReviewStatePending},
+				},
+				{
+					Version:                       4,
+					Description:                   "v4",
+					PublishedArtifactVersionState: types.PublishedArtifactVersionState{ReviewState: types.PublishedArtifactReviewStateRejected},
+				},
+			},
+		},
+	}
+
+	doc, ok := PublishedArtifactDocument(artifact)
+	require.True(t, ok)
+	assert.Equal(t, Document{
+		Kind:        KindPublishedArtifact,
+		ID:          "pa1abc",
+		Name:        "my-workflow",
+		DisplayName: "My Workflow",
+		Description: "edited v2",
+		Body:        "v2 body",
+	}, doc)
+
+	// Yanking the latest published version falls back to the one before it.
+	artifact.Status.Versions[1].Yanked = true
+	doc, ok = PublishedArtifactDocument(artifact)
+	require.True(t, ok)
+	assert.Equal(t, "v1", doc.Description)
+	assert.Equal(t, "v1 body", doc.Body)
+
+	// Without a published version that isn't yanked, the artifact isn't searchable.
+	artifact.Status.Versions[0].Yanked = true
+	_, ok = PublishedArtifactDocument(artifact)
+	assert.False(t, ok)
+}
@@ -0,0 +1,194 @@
+package search
+
+import (
+	"cmp"
+	"math"
+	"slices"
+	"strings"
+	"unicode"
+)
+
+const (
+	// BM25 parameters.
+	bm25K1 = 1.2
+	bm25B  = 0.75
+
+	// rrfK dampens the difference between the top ranks when fusing the keyword and vector rankings.
+	rrfK = 60
+
+	// minSimilarity is the cosine similarity a document needs to be considered a vector match.
+	minSimilarity = 0.3
+
+	// prefixMatchWeight is how much a term that only prefixes a token counts compared to an exact match.
+	prefixMatchWeight = 0.5
+)
+
+// fieldWeights make matches in names count more than matches in descriptions and bodies.
+var fieldWeights = struct {
+	name, displayName, description, body float64
+}{3, 3, 2, 1}
+
+func tokenize(s string) []string {
+	return strings.FieldsFunc(strings.ToLower(s), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+}
+
+// keywordScores scores the documents against the query with BM25, treating a term that prefixes a token as a
+// partial match. If no document matches any term, then the documents whose names or descriptions contain the query
+// are scored instead, so that partial queries still find what they did before the index was used. Documents that
+// don't match are left out.
+func keywordScores(query string, docs []Document) map[string]float64 {
+	terms := slices.Compact(slices.Sorted(slices.Values(tokenize(query))))
+	if len(terms) == 0 || len(docs) == 0 {
+		return nil
+	}
+
+	termFrequencies := make([]map[string]float64, len(docs))
+	lengths := make([]float64, len(docs))
+	var totalLength float64
+	for i, doc := range docs {
+		frequencies := make(map[string]float64)
+		for _, field := range []struct {
+			text   string
+			weight float64
+		}{
+			{doc.Name, fieldWeights.name},
+			{doc.DisplayName, fieldWeights.displayName},
+			{doc.Description, fieldWeights.description},
+			{doc.Body, fieldWeights.body},
+		} {
+			for _, token := range tokenize(field.text) {
+				frequencies[token] += field.weight
+				lengths[i] += field.weight
+			}
+		}
+		termFrequencies[i] = frequencies
+		totalLength += lengths[i]
+	}
+	averageLength := totalLength / float64(len(docs))
+	if averageLength == 0 {
+		return nil
+	}
+
+	matches := make([][]float64, len(docs))
+	documentFrequencies := make([]int, len(terms))
+	for i, frequencies := range termFrequencies {
+		matches[i] = make([]float64, len(terms))
+		for j, term := range terms {
+			for token, frequency := range frequencies {
+				if token == term {
+					matches[i][j] += frequency
+				} else if len(term) > 1 && strings.HasPrefix(token, term) {
+					matches[i][j] += prefixMatchWeight * frequency
+				}
+			}
+			if matches[i][j] > 0 {
+				documentFrequencies[j]++
+			}
+		}
+	}
+
+	scores := make(map[string]float64)
+	n := float64(len(docs))
+	for i, doc := range docs {
+		var score float64
+		for j := range terms {
+			m := matches[i][j]
+			if m == 0 {
+				continue
+			}
+			df := float64(documentFrequencies[j])
+			idf := math.Log(1 + (n-df+0.5)/(df+0.5))
+			score += idf * m * (bm25K1 + 1) / (m + bm25K1*(1-bm25B+bm25B*lengths[i]/averageLength))
+		}
+		if score > 0 {
+			scores[doc.ID] = score
+		}
+	}
+	if len(scores) == 0 {
+		return substringScores(query, docs)
+	}
+	return scores
+}
+
+// substringScores scores the documents by the weights of the fields that contain the query.
+func substringScores(query string, docs []Document) map[string]float64 {
+	query = strings.ToLower(strings.TrimSpace(query))
+	scores := make(map[string]float64)
+	for _, doc := range docs {
+		var score float64
+		for _, field := range []struct {
+			text   string
+			weight float64
+		}{
+			{doc.Name, fieldWeights.name},
+			{doc.DisplayName, fieldWeights.displayName},
+			{doc.Description, fieldWeights.description},
+		} {
+			if strings.Contains(strings.ToLower(field.text), query) {
+				score += field.weight
+			}
+		}
+		if score > 0 {
+			scores[doc.ID] = score
+		}
+	}
+	return scores
+}
+
+func cosineSimilarity(a, b []float32) float64 {
+	if len(a) != len(b) || len(a) == 0 {
+		return 0
+	}
+
+	var dot, normA, normB float64
+	for i := range a {
+		dot += float64(a[i]) * float64(b[i])
+		normA += float64(a[i]) * float64(a[i])
+		normB += float64(b[i]) * float64(b[i])
+	}
+	if normA == 0 || normB == 0 {
+		return 0
+	}
+	return dot / (math.Sqrt(normA) * math.Sqrt(normB))
+}
+
+// fuse combines the keyword and vector scores with reciprocal rank fusion. Vector scores below minSimilarity are
+// ignored, so that every result matches the query by keyword or by meaning.
+func fuse(keywordScores, vectorScores map[string]float64) []Result {
+	fused := make(map[string]float64, len(keywordScores)+len(vectorScores))
+	addRanks := func(scores map[string]float64, minScore float64) {
+		ids := make([]string, 0, len(scores))
+		for id, score := range scores {
+			if score >= minScore {
+				ids = append(ids, id)
+			}
+		}
+		slices.SortFunc(ids, func(a, b string) int {
+			return compareScores(scores[a], scores[b], a, b)
+		})
+		for rank, id := range ids {
+			fused[id] += 1.0 / float64(rrfK+rank+1)
+		}
+	}
+	addRanks(keywordScores, 0)
+	addRanks(vectorScores, minSimilarity)
+
+	results := make([]Result, 0, len(fused))
+	for id, score := range fused {
+		results = append(results, Result{ID: id, Score: score})
+	}
+	slices.SortFunc(results, func(a, b Result) int {
+		return compareScores(a.Score, b.Score, a.ID, b.ID)
+	})
+	return results
+}
+
+// compareScores orders by descending score, then by ID.
+func compareScores(scoreA, scoreB float64, idA, idB string) int {
+	if c := cmp.Compare(scoreB, scoreA); c != 0 {
+		return c
+	}
+	return strings.Compare(idA, idB)
+}
@@ -51,6 +51,7 @@ import (
 	"github.com/obot-platform/obot/pkg/messagepolicy"
 	"github.com/obot-platform/obot/pkg/modelaccesspolicy"
 	"github.com/obot-platform/obot/pkg/proxy"
+	"github.com/obot-platform/obot/pkg/search"
 	"github.com/obot-platform/obot/pkg/skillaccessrule"
 	"github.com/obot-platform/obot/pkg/storage"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
@@ -200,6 +201,9 @@ type Services struct {
 	// Used for indexed lookups of skill access rules.
 	SkillAccessRuleHelper *skillaccessrule.Helper
 
+	// Used for hybrid keyword and vector search of skills and published artifacts.
+	SearchIndex *search.Index
+
 	WebhookHelper *mcp.WebhookHelper
 
 	// Used for loading and running MCP servers with GPTScript.
@@ -302,6 +306,15 @@ func unmarshalJSONStrict(data []byte, v any) error {
 	return decoder.Decode(v)
 }
 
+// idleShutdownInterval returns the interval after which idle servers are shut down. The interval in minutes takes
+// precedence over the interval in hours when it is set. A negative interval disables the shutdown.
+func idleShutdownInterval(hours, minutes int) time.Duration {
+	if minutes != 0 {
+		return time.Duration(minutes) * time.Minute
+	}
+	return time.Duration(hours) * time.Hour
+}
+
 // parsePSASettingsFromHelm parses Pod Security Admission settings from environment/Helm options.
 // PSA settings are always managed via Helm/environment and cannot be modified via UI.
 func parsePSASettingsFromHelm(opts mcp.Options) (*v1.PodSecurityAdmissionSettings, error) {
@@ -1015,21 +1028,27 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		MCPLoader:                  mcpSessionManager,
 		MCPOAuthTokenStorage:       mcpOAuthTokenStorage,
 		OAuthServerConfig: handlers.OAuthAuthorizationServerConfig{
-			Issuer:                            config.Hostname,
-			AuthorizationEndpoint:             fmt.Sprintf("%s/oauth/authorize", config.Hostname),
-			TokenEndpoint:                     fmt.Sprintf("%s/oauth/token", config.Hostname),
-			RegistrationEndpoint:              fmt.Sprintf("%s/oauth/register", config.Hostname),
-			JWKSURI:                           config.Hostname + "/oauth/jwks.json",
-			ScopesSupported:                   []string{"profile"},
-			ResponseTypesSupported:            []string{"code"},
-			GrantTypesSupported:               []string{"authorization_code", "refresh_token", "urn:ietf:params:oauth:grant-type:token-exchange"},
-			CodeChallengeMethodsSupported:     []string{"S256", "plain"},
-			TokenEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post", "none"},
-			UserInfoEndpoint:                  fmt.Sprintf("%s/oauth/userinfo", config.Hostname),
+			Issuer:                                    config.Hostname,
+			AuthorizationEndpoint:                     fmt.Sprintf("%s/oauth/authorize", config.Hostname),
+			TokenEndpoint:                             fmt.Sprintf("%s/oauth/token", config.Hostname),
+			DeviceAuthorizationEndpoint:               fmt.Sprintf("%s/oauth/device_authorization", config.Hostname),
+			RegistrationEndpoint:                      fmt.Sprintf("%s/oauth/register", config.Hostname),
+			JWKSURI:                                   config.Hostname + "/oauth/jwks.json",
+			ScopesSupported:                           []string{"profile"},
+			ResponseTypesSupported:                    []string{"code"},
+			GrantTypesSupported:                       []string{"authorization_code", "refresh_token", "urn:ietf:params:oauth:grant-type:token-exchange", "urn:ietf:params:oauth:grant-type:device_code", "client_credentials"},
+			CodeChallengeMethodsSupported:             []string{"S256", "plain"},
+			TokenEndpointAuthMethodsSupported:         []string{"client_secret_basic", "client_secret_post", "none"},
+			UserInfoEndpoint:                          fmt.Sprintf("%s/oauth/userinfo", config.Hostname),
+			RevocationEndpoint:                        fmt.Sprintf("%s/oauth/revoke", config.Hostname),
+			RevocationEndpointAuthMethodsSupported:    []string{"client_secret_basic", "client_secret_post", "none"},
+			IntrospectionEndpoint:                     fmt.Sprintf("%s/oauth/introspect", config.Hostname),
+			IntrospectionEndpointAuthMethodsSupported: []string{"client_secret_basic", "client_secret_post"},
 		},
 		AccessControlRuleHelper:              acrHelper,
 		ModelAccessPolicyHelper:              mapHelper,
 		MessagePolicyHelper:                  msgPolicyHelper,
+		SearchIndex:                          search.NewIndex(storageClient, gatewayClient, providerDispatcher, credOnlyGPTscriptClient),
 		SkillAccessRuleHelper:                skillAccessRuleHelper,
 		WebhookHelper:                        webhookHelper,
 		LocalK8sConfig:                       localK8sConfig,
@@ -1042,9 +1061,9 @@ func New(ctx context.Context, config Config) (*Services, error) {
 		MCPRuntimeBackend:                    config.MCPRuntimeBackend,
 		MCPRemoteShimBaseImage:               config.MCPRemoteShimBaseImage,
 		MCPHTTPWebhookBaseImage:              config.MCPHTTPWebhookBaseImage,
-		SingleUserIdleServerShutdownInterval: time.Duration(config.SingleUserIdleServerShutdownHours) * time.Hour,
-		MultiUserIdleServerShutdownInterval:  time.Duration(config.MultiUserIdleServerShutdownHours) * time.Hour,
-		AgentIdleServerShutdownInterval:      time.Duration(config.IdleAgentShutdownHours) * time.Hour,
+		SingleUserIdleServerShutdownInterval: idleShutdownInterval(config.SingleUserIdleServerShutdownHours, config.SingleUserIdleServerShutdownMinutes),
+		MultiUserIdleServerShutdownInterval:  idleShutdownInterval(config.MultiUserIdleServerShutdownHours, config.MultiUserIdleServerShutdownMinutes),
+		AgentIdleServerShutdownInterval:      idleShutdownInterval(config.IdleAgentShutdownHours, config.IdleAgentShutdownMinutes),
 		RegistryNoAuth:                       registryNoAuth,
 		NanobotIntegration:                   config.NanobotIntegration,
 		MessagePoliciesEnabled:               config.EnableMessagePolicies,
@@ -3,6 +3,7 @@ package services
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/obot-platform/obot/pkg/mcp"
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
@@ -403,3 +404,24 @@ func TestPSASettingsIndependentOfPodScheduling(t *testing.T) {
 		t.Error("expected PSA settings to be non-nil")
 	}
 }
+
+func TestIdleShutdownInterval(t *testing.T) {
+	tests := []struct {
+		name           string
+		hours, minutes int
+		expected       time.Duration
+	}{
+		{name: "hours", hours: 24, expected: 24 * time.Hour},
+		{name: "minutes override hours", hours: 24, minutes: 15, expected: 15 * time.Minute},
+		{name: "minutes disable shutdown", hours: 24, minutes: -1, expected: -time.Minute},
+		{name: "hours disable shutdown", hours: -1, expected: -time.Hour},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := idleShutdownInterval(tt.hours, tt.minutes); got != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ContainerImagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ContainerImagePolicySpec   `json:"spec,omitempty"`
+	Status ContainerImagePolicyStatus `json:"status,omitempty"`
+}
+
+type ContainerImagePolicySpec struct {
+	types.ContainerImagePolicyManifest `json:",inline"`
+}
+
+type ContainerImagePolicyStatus struct{}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type ContainerImagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []ContainerImagePolicy `json:"items"`
+}
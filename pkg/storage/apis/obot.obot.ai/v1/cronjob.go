@@ -66,6 +66,11 @@ type CronJobSpec struct {
 type CronJobStatus struct {
 	LastRunStartedAt           *metav1.Time `json:"lastRunStartedAt,omitempty"`
 	LastSuccessfulRunCompleted *metav1.Time `json:"lastSuccessfulRunCompleted,omitempty"`
+	// LastScheduleTime is the last time the schedule was evaluated. Scheduled times before it have either been run
+	// or intentionally skipped, because they were paused, blacked out, or dropped by the catch-up policy.
+	LastScheduleTime *metav1.Time `json:"lastScheduleTime,omitempty"`
+	// PausedAt is set while the task schedule is paused.
+	PausedAt *metav1.Time `json:"pausedAt,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -0,0 +1,40 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type LegalHold struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec LegalHoldSpec `json:"spec,omitempty"`
+}
+
+type LegalHoldSpec struct {
+	Manifest types.LegalHoldManifest `json:"manifest"`
+	// Released is set once the hold has been released. Released holds are kept as a record and can't be changed.
+	Released bool                   `json:"released,omitempty"`
+	History  []types.LegalHoldEvent `json:"history,omitempty"`
+}
+
+func (in *LegalHold) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Released", "Spec.Released"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type LegalHoldList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []LegalHold `json:"items"`
+}
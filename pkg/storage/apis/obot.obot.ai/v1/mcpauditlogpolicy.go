@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPAuditLogPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec MCPAuditLogPolicySpec `json:"spec,omitempty"`
+}
+
+type MCPAuditLogPolicySpec struct {
+	Manifest types.MCPAuditLogPolicyManifest `json:"manifest"`
+}
+
+func (in *MCPAuditLogPolicy) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Resources", "{{len .Spec.Manifest.Resources}}"},
+		{"Retention Days", "{{.Spec.Manifest.RetentionDays}}"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type MCPAuditLogPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []MCPAuditLogPolicy `json:"items"`
+}
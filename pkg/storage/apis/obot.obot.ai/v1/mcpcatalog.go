@@ -17,6 +17,8 @@ type MCPCatalog struct {
 type MCPCatalogSpec struct {
 	DisplayName string   `json:"displayName,omitempty"`
 	SourceURLs  []string `json:"sourceURLs,omitempty"`
+	// ExposePackages includes package descriptors for the catalog's entries in the registry API.
+	ExposePackages bool `json:"exposePackages,omitempty"`
 }
 
 type MCPCatalogStatus struct {
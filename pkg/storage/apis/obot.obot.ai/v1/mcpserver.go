@@ -156,8 +156,14 @@ type MCPServerStatus struct {
 	// OAuthCredentialConfigured indicates whether OAuth credentials have been configured
 	// for this server's catalog entry. Only relevant for remote servers that require static OAuth.
 	OAuthCredentialConfigured bool `json:"oauthCredentialConfigured,omitempty"`
-	// LastRequestTime is the time of the last request to the server, in 15 minute granularity.
+	// LastRequestTime is the time of the last request to the server, in 15 minute granularity, or one minute granularity
+	// when IdleShutdownInterval isn't a whole number of hours.
 	LastRequestTime metav1.Time `json:"lastRequestTime,omitzero"`
+	// IdleShutdownInterval is the interval without requests after which the server is shut down. A negative interval
+	// means that the server isn't shut down.
+	IdleShutdownInterval metav1.Duration `json:"idleShutdownInterval,omitzero"`
+	// Health contains the results of the periodic health probes of the server's deployment.
+	Health *types.MCPServerHealth `json:"health,omitempty"`
 }
 
 type DeploymentCondition struct {
@@ -23,6 +23,7 @@ func (in *MCPWebhookValidation) GetColumns() [][]string {
 		{"Name", "Name"},
 		{"Display Name", "Spec.Manifest.Name"},
 		{"Resources ", "{{len .Spec.Manifest.Resources}}"},
+		{"Type", "{{.Spec.Manifest.ValidationType}}"},
 		{"URL", "{{.Spec.Manifest.URL}}"},
 		{"Disabled", "{{.Spec.Manifest.Disabled}}"},
 	}
@@ -0,0 +1,38 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotificationChannel struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec NotificationChannelSpec `json:"spec,omitempty"`
+}
+
+type NotificationChannelSpec struct {
+	Manifest types.NotificationChannelManifest `json:"manifest"`
+}
+
+func (in *NotificationChannel) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"Display Name", "Spec.Manifest.DisplayName"},
+		{"Type", "Spec.Manifest.ChannelType"},
+		{"Disabled", "{{.Spec.Manifest.Disabled}}"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type NotificationChannelList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []NotificationChannel `json:"items"`
+}
@@ -28,6 +28,10 @@ func (in *OAuthAuthRequest) Get(field string) string {
 		switch field {
 		case "spec.hashedAuthCode":
 			return in.Spec.HashedAuthCode
+		case "spec.hashedDeviceCode":
+			return in.Spec.HashedDeviceCode
+		case "spec.hashedUserCode":
+			return in.Spec.HashedUserCode
 		}
 	}
 
@@ -35,7 +39,7 @@ func (in *OAuthAuthRequest) Get(field string) string {
 }
 
 func (in *OAuthAuthRequest) FieldNames() []string {
-	return []string{"spec.hashedAuthCode"}
+	return []string{"spec.hashedAuthCode", "spec.hashedDeviceCode", "spec.hashedUserCode"}
 }
 
 func (in *OAuthAuthRequest) DeleteRefs() []Ref {
@@ -59,6 +63,14 @@ type OAuthAuthRequestSpec struct {
 	AuthProviderUserID    string `json:"authProviderUserID"`
 	AuthProviderNamespace string `json:"authProviderNamespace"`
 	AuthProviderName      string `json:"authProviderName"`
+
+	// The following fields are only used by device authorization requests.
+	HashedDeviceCode string       `json:"hashedDeviceCode,omitempty"`
+	HashedUserCode   string       `json:"hashedUserCode,omitempty"`
+	Approved         bool         `json:"approved,omitempty"`
+	Denied           bool         `json:"denied,omitempty"`
+	PollInterval     int          `json:"pollInterval,omitempty"`
+	LastPolledAt     *metav1.Time `json:"lastPolledAt,omitempty"`
 }
 
 type OAuthAuthRequestStatus struct {
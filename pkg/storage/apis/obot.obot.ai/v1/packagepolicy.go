@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PackagePolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PackagePolicySpec   `json:"spec,omitempty"`
+	Status PackagePolicyStatus `json:"status,omitempty"`
+}
+
+type PackagePolicySpec struct {
+	types.PackagePolicyManifest `json:",inline"`
+}
+
+type PackagePolicyStatus struct{}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PackagePolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PackagePolicy `json:"items"`
+}
@@ -56,13 +56,18 @@ type PublishedArtifactSpec struct {
 	// AuthorID is the user ID of the artifact's creator (extracted from auth token).
 	AuthorID string `json:"authorID,omitempty"`
 
-	// LatestVersion is the current highest version number for this artifact.
+	// LatestVersion is the highest version that is published, because it was published without review or has been
+	// approved. The description, author email, body, and blob key are those of this version. Versions waiting for
+	// review are only recorded in the status until they are approved.
 	LatestVersion int `json:"latestVersion,omitempty"`
 
 	// LegacyVisibility is retained only so old stored artifacts can be migrated.
 	LegacyVisibility string `json:"visibility,omitempty"`
 
-	// BlobKey is the S3 path to the latest version's ZIP blob.
+	// Body is the start of the latest published version's SKILL.md body, used for search.
+	Body string `json:"body,omitempty"`
+
+	// BlobKey is the S3 path to the latest published version's ZIP blob.
 	// Convention: published-artifacts/{id}/v{N}.zip
 	BlobKey string `json:"blobKey,omitempty"`
 }
@@ -0,0 +1,31 @@
+package v1
+
+import (
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PublishedArtifactReviewPolicy struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PublishedArtifactReviewPolicySpec   `json:"spec,omitempty"`
+	Status PublishedArtifactReviewPolicyStatus `json:"status,omitempty"`
+}
+
+type PublishedArtifactReviewPolicySpec struct {
+	types.PublishedArtifactReviewPolicyManifest `json:",inline"`
+}
+
+type PublishedArtifactReviewPolicyStatus struct{}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type PublishedArtifactReviewPolicyList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []PublishedArtifactReviewPolicy `json:"items"`
+}
@@ -26,6 +26,8 @@ const (
 	AccessControlRuleFinalizer     = "obot.obot.ai/access-control-rule"
 	SystemMCPServerFinalizer       = "obot.obot.ai/system-mcp-server"
 	NanobotAgentFinalizer          = "obot.obot.ai/nanobot-agent"
+	SkillFinalizer                 = "obot.obot.ai/skill"
+	PublishedArtifactFinalizer     = "obot.obot.ai/published-artifact"
 
 	ModelProviderSyncAnnotation         = "obot.ai/model-provider-sync"
 	WorkflowSyncAnnotation              = "obot.ai/workflow-sync"
@@ -113,6 +113,12 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&K8sSettingsList{},
 		&AppPreferences{},
 		&AppPreferencesList{},
+		&ContainerImagePolicy{},
+		&ContainerImagePolicyList{},
+		&PackagePolicy{},
+		&PackagePolicyList{},
+		&PublishedArtifactReviewPolicy{},
+		&PublishedArtifactReviewPolicyList{},
 		&AuditLogExport{},
 		&AuditLogExportList{},
 		&ScheduledAuditLogExport{},
@@ -131,6 +137,14 @@ func AddToSchemeWithGV(scheme *runtime.Scheme, schemeGroupVersion schema.GroupVe
 		&PublishedArtifactList{},
 		&OktaGroupMigration{},
 		&OktaGroupMigrationList{},
+		&NotificationChannel{},
+		&NotificationChannelList{},
+		&MCPAuditLogPolicy{},
+		&MCPAuditLogPolicyList{},
+		&LegalHold{},
+		&LegalHoldList{},
+		&UserDataExport{},
+		&UserDataExportList{},
 	); err != nil {
 		return err
 	}
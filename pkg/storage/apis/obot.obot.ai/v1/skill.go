@@ -69,6 +69,8 @@ type SkillSpec struct {
 	CommitSHA    string `json:"commitSHA,omitempty"`
 	RelativePath string `json:"relativePath,omitempty"`
 	InstallHash  string `json:"installHash,omitempty"`
+	// Body is the start of the SKILL.md body, used for search.
+	Body string `json:"body,omitempty"`
 }
 
 type SkillStatus struct {
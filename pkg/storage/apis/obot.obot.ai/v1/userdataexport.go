@@ -0,0 +1,72 @@
+package v1
+
+import (
+	"slices"
+
+	"github.com/obot-platform/nah/pkg/fields"
+	"github.com/obot-platform/obot/apiclient/types"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+var (
+	_ fields.Fields = (*UserDataExport)(nil)
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type UserDataExport struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   UserDataExportSpec   `json:"spec,omitempty"`
+	Status UserDataExportStatus `json:"status,omitempty"`
+}
+
+func (in *UserDataExport) Has(field string) (exists bool) {
+	return slices.Contains(in.FieldNames(), field)
+}
+
+func (in *UserDataExport) Get(field string) (value string) {
+	switch field {
+	case "spec.userID":
+		return in.Spec.UserID
+	}
+	return ""
+}
+
+func (in *UserDataExport) FieldNames() []string {
+	return []string{"spec.userID"}
+}
+
+func (in *UserDataExport) GetColumns() [][]string {
+	return [][]string{
+		{"Name", "Name"},
+		{"User", "Spec.UserID"},
+		{"Status", "Status.State"},
+		{"Created", "{{ago .CreationTimestamp}}"},
+	}
+}
+
+type UserDataExportSpec struct {
+	UserID      string `json:"userID"`
+	RequestedBy string `json:"requestedBy"`
+}
+
+type UserDataExportStatus struct {
+	State types.UserDataExportState `json:"state,omitempty"`
+	Error string                    `json:"error,omitempty"`
+	// Key is the key of the archive in the artifact blob store.
+	Key         string       `json:"key,omitempty"`
+	Size        int64        `json:"size,omitempty"`
+	StartedAt   *metav1.Time `json:"startedAt,omitempty"`
+	CompletedAt *metav1.Time `json:"completedAt,omitempty"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+type UserDataExportList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+
+	Items []UserDataExport `json:"items"`
+}
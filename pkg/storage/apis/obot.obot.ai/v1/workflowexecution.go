@@ -91,6 +91,8 @@ type WorkflowExecutionStatus struct {
 	WorkflowManifest   *types.WorkflowManifest `json:"workflowManifest,omitempty"`
 	EndTime            *metav1.Time            `json:"endTime,omitempty"`
 	WorkflowGeneration int64                   `json:"workflowGeneration,omitempty"`
+	// Notifications records the notifications sent for the outcome of this execution.
+	Notifications []types.NotificationDelivery `json:"notifications,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -469,6 +469,96 @@ func (in *AuditLogExportStatus) DeepCopy() *AuditLogExportStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicy) DeepCopyInto(out *ContainerImagePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicy.
+func (in *ContainerImagePolicy) DeepCopy() *ContainerImagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerImagePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicyList) DeepCopyInto(out *ContainerImagePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ContainerImagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicyList.
+func (in *ContainerImagePolicyList) DeepCopy() *ContainerImagePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ContainerImagePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicySpec) DeepCopyInto(out *ContainerImagePolicySpec) {
+	*out = *in
+	in.ContainerImagePolicyManifest.DeepCopyInto(&out.ContainerImagePolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicySpec.
+func (in *ContainerImagePolicySpec) DeepCopy() *ContainerImagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ContainerImagePolicyStatus) DeepCopyInto(out *ContainerImagePolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ContainerImagePolicyStatus.
+func (in *ContainerImagePolicyStatus) DeepCopy() *ContainerImagePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ContainerImagePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CronJob) DeepCopyInto(out *CronJob) {
 	*out = *in
@@ -555,6 +645,14 @@ func (in *CronJobStatus) DeepCopyInto(out *CronJobStatus) {
 		in, out := &in.LastSuccessfulRunCompleted, &out.LastSuccessfulRunCompleted
 		*out = (*in).DeepCopy()
 	}
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PausedAt != nil {
+		in, out := &in.PausedAt, &out.PausedAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CronJobStatus.
@@ -1334,6 +1432,161 @@ func (in *KnowledgeSummaryStatus) DeepCopy() *KnowledgeSummaryStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHold) DeepCopyInto(out *LegalHold) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHold.
+func (in *LegalHold) DeepCopy() *LegalHold {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHold)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LegalHold) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldList) DeepCopyInto(out *LegalHoldList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]LegalHold, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldList.
+func (in *LegalHoldList) DeepCopy() *LegalHoldList {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *LegalHoldList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LegalHoldSpec) DeepCopyInto(out *LegalHoldSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+	if in.History != nil {
+		in, out := &in.History, &out.History
+		*out = make([]types.LegalHoldEvent, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LegalHoldSpec.
+func (in *LegalHoldSpec) DeepCopy() *LegalHoldSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LegalHoldSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicy) DeepCopyInto(out *MCPAuditLogPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicy.
+func (in *MCPAuditLogPolicy) DeepCopy() *MCPAuditLogPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPAuditLogPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicyList) DeepCopyInto(out *MCPAuditLogPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]MCPAuditLogPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicyList.
+func (in *MCPAuditLogPolicyList) DeepCopy() *MCPAuditLogPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *MCPAuditLogPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MCPAuditLogPolicySpec) DeepCopyInto(out *MCPAuditLogPolicySpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPAuditLogPolicySpec.
+func (in *MCPAuditLogPolicySpec) DeepCopy() *MCPAuditLogPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MCPAuditLogPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MCPCatalog) DeepCopyInto(out *MCPCatalog) {
 	*out = *in
@@ -1723,6 +1976,12 @@ func (in *MCPServerStatus) DeepCopyInto(out *MCPServerStatus) {
 		}
 	}
 	in.LastRequestTime.DeepCopyInto(&out.LastRequestTime)
+	out.IdleShutdownInterval = in.IdleShutdownInterval
+	if in.Health != nil {
+		in, out := &in.Health, &out.Health
+		*out = new(types.MCPServerHealth)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MCPServerStatus.
@@ -2330,6 +2589,80 @@ func (in *NanobotAgentStatus) DeepCopy() *NanobotAgentStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannel) DeepCopyInto(out *NotificationChannel) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannel.
+func (in *NotificationChannel) DeepCopy() *NotificationChannel {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannel)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationChannel) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelList) DeepCopyInto(out *NotificationChannelList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]NotificationChannel, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelList.
+func (in *NotificationChannelList) DeepCopy() *NotificationChannelList {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *NotificationChannelList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NotificationChannelSpec) DeepCopyInto(out *NotificationChannelSpec) {
+	*out = *in
+	in.Manifest.DeepCopyInto(&out.Manifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NotificationChannelSpec.
+func (in *NotificationChannelSpec) DeepCopy() *NotificationChannelSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NotificationChannelSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NotificationConfig) DeepCopyInto(out *NotificationConfig) {
 	*out = *in
@@ -2520,7 +2853,7 @@ func (in *OAuthAuthRequest) DeepCopyInto(out *OAuthAuthRequest) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -2577,6 +2910,10 @@ func (in *OAuthAuthRequestList) DeepCopyObject() runtime.Object {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *OAuthAuthRequestSpec) DeepCopyInto(out *OAuthAuthRequestSpec) {
 	*out = *in
+	if in.LastPolledAt != nil {
+		in, out := &in.LastPolledAt, &out.LastPolledAt
+		*out = (*in).DeepCopy()
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OAuthAuthRequestSpec.
@@ -2885,6 +3222,96 @@ func (in *OktaGroupMigrationSpec) DeepCopy() *OktaGroupMigrationSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicy) DeepCopyInto(out *PackagePolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicy.
+func (in *PackagePolicy) DeepCopy() *PackagePolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackagePolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicyList) DeepCopyInto(out *PackagePolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PackagePolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicyList.
+func (in *PackagePolicyList) DeepCopy() *PackagePolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PackagePolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicySpec) DeepCopyInto(out *PackagePolicySpec) {
+	*out = *in
+	in.PackagePolicyManifest.DeepCopyInto(&out.PackagePolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicySpec.
+func (in *PackagePolicySpec) DeepCopy() *PackagePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PackagePolicyStatus) DeepCopyInto(out *PackagePolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PackagePolicyStatus.
+func (in *PackagePolicyStatus) DeepCopy() *PackagePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PackagePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PodSecurityAdmissionSettings) DeepCopyInto(out *PodSecurityAdmissionSettings) {
 	*out = *in
@@ -3321,6 +3748,96 @@ func (in *PublishedArtifactList) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReviewPolicy) DeepCopyInto(out *PublishedArtifactReviewPolicy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	out.Status = in.Status
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicy.
+func (in *PublishedArtifactReviewPolicy) DeepCopy() *PublishedArtifactReviewPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReviewPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedArtifactReviewPolicy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReviewPolicyList) DeepCopyInto(out *PublishedArtifactReviewPolicyList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]PublishedArtifactReviewPolicy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicyList.
+func (in *PublishedArtifactReviewPolicyList) DeepCopy() *PublishedArtifactReviewPolicyList {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReviewPolicyList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PublishedArtifactReviewPolicyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReviewPolicySpec) DeepCopyInto(out *PublishedArtifactReviewPolicySpec) {
+	*out = *in
+	in.PublishedArtifactReviewPolicyManifest.DeepCopyInto(&out.PublishedArtifactReviewPolicyManifest)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicySpec.
+func (in *PublishedArtifactReviewPolicySpec) DeepCopy() *PublishedArtifactReviewPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReviewPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PublishedArtifactReviewPolicyStatus) DeepCopyInto(out *PublishedArtifactReviewPolicyStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PublishedArtifactReviewPolicyStatus.
+func (in *PublishedArtifactReviewPolicyStatus) DeepCopy() *PublishedArtifactReviewPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PublishedArtifactReviewPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *PublishedArtifactSpec) DeepCopyInto(out *PublishedArtifactSpec) {
 	*out = *in
@@ -4662,6 +5179,103 @@ func (in *ToolStatus) DeepCopy() *ToolStatus {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExport) DeepCopyInto(out *UserDataExport) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExport.
+func (in *UserDataExport) DeepCopy() *UserDataExport {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExport)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UserDataExport) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExportList) DeepCopyInto(out *UserDataExportList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]UserDataExport, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExportList.
+func (in *UserDataExportList) DeepCopy() *UserDataExportList {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExportList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *UserDataExportList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExportSpec) DeepCopyInto(out *UserDataExportSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExportSpec.
+func (in *UserDataExportSpec) DeepCopy() *UserDataExportSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExportSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UserDataExportStatus) DeepCopyInto(out *UserDataExportStatus) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UserDataExportStatus.
+func (in *UserDataExportStatus) DeepCopy() *UserDataExportStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(UserDataExportStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *UserDefaultRoleSetting) DeepCopyInto(out *UserDefaultRoleSetting) {
 	*out = *in
@@ -5070,6 +5684,13 @@ func (in *WorkflowExecutionStatus) DeepCopyInto(out *WorkflowExecutionStatus) {
 		in, out := &in.EndTime, &out.EndTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Notifications != nil {
+		in, out := &in.Notifications, &out.Notifications
+		*out = make([]types.NotificationDelivery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WorkflowExecutionStatus.
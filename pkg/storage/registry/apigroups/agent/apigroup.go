@@ -10,6 +10,7 @@ import (
 	"github.com/obot-platform/obot/pkg/storage/scheme"
 	"github.com/obot-platform/obot/pkg/storage/services"
 	coordinationv1 "k8s.io/api/coordination/v1"
+	eventsv1 "k8s.io/api/events/v1"
 	"k8s.io/apiserver/pkg/registry/rest"
 	genericapiserver "k8s.io/apiserver/pkg/server"
 	kclient "sigs.k8s.io/controller-runtime/pkg/client"
@@ -56,6 +57,16 @@ func LeasesAPIGroup(services *services.Services) (*genericapiserver.APIGroupInfo
 	}, coordinationv1.SchemeGroupVersion)
 }
 
+func EventsAPIGroup(services *services.Services) (*genericapiserver.APIGroupInfo, error) {
+	store, _, err := generic.NewStore(services.DB, &eventsv1.Event{})
+	if err != nil {
+		return nil, err
+	}
+	return apigroup.ForStores(scheme.AddToScheme, map[string]rest.Storage{
+		"events": store,
+	}, eventsv1.SchemeGroupVersion)
+}
+
 func APIGroup(services *services.Services) (*genericapiserver.APIGroupInfo, error) {
 	stores, err := Stores(services)
 	if err != nil {
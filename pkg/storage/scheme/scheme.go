@@ -5,6 +5,7 @@ import (
 	v1 "github.com/obot-platform/obot/pkg/storage/apis/obot.obot.ai/v1"
 	coordinationv1 "k8s.io/api/coordination/v1"
 	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
 )
 
 //nolint:revive
@@ -12,4 +13,5 @@ var Scheme, Codecs, Parameter, AddToScheme = restconfig.MustBuildScheme(
 	v1.AddToScheme,
 	coordinationv1.AddToScheme,
 	corev1.AddToScheme,
+	eventsv1.AddToScheme,
 )
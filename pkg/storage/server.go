@@ -36,7 +36,7 @@ func Start(ctx context.Context, config sservices.Config) (Client, *rest.Config,
 }
 
 func startMinkServer(ctx context.Context, config sservices.Config, services *sservices.Services) (Client, *rest.Config, error) {
-	apiGroups, err := mserver.BuildAPIGroups(services, agent.APIGroup, agent.LeasesAPIGroup)
+	apiGroups, err := mserver.BuildAPIGroups(services, agent.APIGroup, agent.LeasesAPIGroup, agent.EventsAPIGroup)
 	if err != nil {
 		return nil, nil, err
 	}
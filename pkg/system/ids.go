@@ -57,6 +57,10 @@ const (
 	ProjectV2Prefix               = "pv21"
 	PublishedArtifactPrefix       = "pa1"
 	OktaGroupMigrationPrefix      = "ogm1"
+	NotificationChannelPrefix     = "nc1"
+	MCPAuditLogPolicyPrefix       = "malp1"
+	LegalHoldPrefix               = "lh1"
+	UserDataExportPrefix          = "ude1"
 
 	ObotMCPServerName = SystemMCPServerPrefix + "obot-mcp-server"
 )
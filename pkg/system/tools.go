@@ -24,12 +24,16 @@ const (
 	OpenAIAPIKeyEnvVar    = "OPENAI_API_KEY"
 	AnthropicAPIKeyEnvVar = "ANTHROPIC_API_KEY"
 
-	DefaultNamespace       = "default"
-	DefaultCatalog         = "default"
-	DefaultSkillRepository = "default"
-	DefaultRoleSettingName = "user-default-role-setting"
-	K8sSettingsName        = "k8s-settings"
-	AppPreferencesName     = "app-preferences"
+	DefaultNamespace         = "default"
+	DefaultCatalog           = "default"
+	DefaultSkillRepository   = "default"
+	DefaultRoleSettingName   = "user-default-role-setting"
+	K8sSettingsName          = "k8s-settings"
+	AppPreferencesName       = "app-preferences"
+	ContainerImagePolicyName = "container-image-policy"
+	PackagePolicyName        = "package-policy"
+
+	PublishedArtifactReviewPolicyName = "published-artifact-review-policy"
 
 	ModelProviderCredential = "sys.model.provider.credential"
 
@@ -38,6 +42,7 @@ const (
 	GenericFileScannerProviderCredentialContext = "file-scanner-provider"
 
 	MCPWebhookValidationCredentialContext = "mcp-webhook-context"
+	NotificationChannelCredentialContext  = "notification-channel-context"
 
 	JWKCredentialContext = "jwk"
 )
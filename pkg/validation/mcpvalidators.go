@@ -11,7 +11,11 @@ import (
 	"github.com/obot-platform/obot/apiclient/types"
 )
 
-var hostnameRegex = regexp.MustCompile(`^(?:\*\.)?[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*$`)
+var (
+	hostnameRegex = regexp.MustCompile(`^(?:\*\.)?[a-zA-Z0-9-]+(?:\.[a-zA-Z0-9-]+)*$`)
+	// namespaceRegex matches component namespaces, which become part of the scheme of resource URIs.
+	namespaceRegex = regexp.MustCompile(`^[a-zA-Z][a-zA-Z0-9-]*$`)
+)
 
 // RuntimeValidator defines the interface for validating runtime-specific configurations
 type RuntimeValidator interface {
@@ -447,6 +451,8 @@ func (v CompositeValidator) ValidateConfig(manifest types.MCPServerManifest) err
 
 	// Check for duplicate component servers
 	componentServerIDs := make(map[string]struct{}, len(manifest.CompositeConfig.ComponentServers))
+	exposedPrompts, exposedResources := make(map[string]int), make(map[string]int)
+	components := make([]componentExposure, 0, len(manifest.CompositeConfig.ComponentServers))
 	for i, component := range manifest.CompositeConfig.ComponentServers {
 		// Ensure exactly one of CatalogEntryID or MCPServerID is set
 		hasCatalogEntry, hasServerID := component.CatalogEntryID != "", component.MCPServerID != ""
@@ -479,7 +485,7 @@ func (v CompositeValidator) ValidateConfig(manifest types.MCPServerManifest) err
 		}
 
 		// Validate tool overrides
-		if err := validateToolOverrides(component.ToolOverrides); err != nil {
+		if err := validateToolOverrides(types.RuntimeComposite, component.ToolOverrides); err != nil {
 			return errors.Join(types.RuntimeValidationError{
 				Runtime: types.RuntimeComposite,
 				Field:   fmt.Sprintf("compositeConfig.componentServers[%d]", i),
@@ -487,6 +493,11 @@ func (v CompositeValidator) ValidateConfig(manifest types.MCPServerManifest) err
 			}, err)
 		}
 
+		// Validate prompt and resource overrides
+		if err := validatePromptAndResourceOverrides(i, component.Namespace, component.PromptOverrides, component.ResourceOverrides, exposedPrompts, exposedResources); err != nil {
+			return err
+		}
+
 		componentID := component.ComponentID()
 		if _, ok := componentServerIDs[componentID]; ok {
 			return types.RuntimeValidationError{
@@ -496,9 +507,15 @@ func (v CompositeValidator) ValidateConfig(manifest types.MCPServerManifest) err
 			}
 		}
 		componentServerIDs[componentID] = struct{}{}
+		components = append(components, componentExposure{
+			namespace:  component.Namespace,
+			overridden: len(component.PromptOverrides) > 0 || len(component.ResourceOverrides) > 0,
+			restricted: len(component.PromptOverrides) > 0 && len(component.ResourceOverrides) > 0,
+			disabled:   component.Disabled,
+		})
 	}
 
-	return nil
+	return validateComponentNamespaces(components)
 }
 
 func (v CompositeValidator) ValidateCatalogConfig(manifest types.MCPServerCatalogEntryManifest) error {
@@ -528,6 +545,8 @@ func (v CompositeValidator) ValidateCatalogConfig(manifest types.MCPServerCatalo
 
 	// Check for duplicate component servers
 	componentServerIDs := make(map[string]struct{}, len(manifest.CompositeConfig.ComponentServers))
+	exposedPrompts, exposedResources := make(map[string]int), make(map[string]int)
+	components := make([]componentExposure, 0, len(manifest.CompositeConfig.ComponentServers))
 	for i, component := range manifest.CompositeConfig.ComponentServers {
 		// Ensure exactly one of CatalogEntryID or MCPServerID is set
 		hasCatalogEntry, hasServerID := component.CatalogEntryID != "", component.MCPServerID != ""
@@ -560,7 +579,7 @@ func (v CompositeValidator) ValidateCatalogConfig(manifest types.MCPServerCatalo
 		}
 
 		// Validate tool overrides
-		if err := validateToolOverrides(component.ToolOverrides); err != nil {
+		if err := validateToolOverrides(types.RuntimeComposite, component.ToolOverrides); err != nil {
 			return errors.Join(types.RuntimeValidationError{
 				Runtime: types.RuntimeComposite,
 				Field:   fmt.Sprintf("compositeConfig.componentServers[%d]", i),
@@ -568,6 +587,11 @@ func (v CompositeValidator) ValidateCatalogConfig(manifest types.MCPServerCatalo
 			}, err)
 		}
 
+		// Validate prompt and resource overrides
+		if err := validatePromptAndResourceOverrides(i, component.Namespace, component.PromptOverrides, component.ResourceOverrides, exposedPrompts, exposedResources); err != nil {
+			return err
+		}
+
 		componentID := component.ComponentID()
 		if _, ok := componentServerIDs[componentID]; ok {
 			return types.RuntimeValidationError{
@@ -577,12 +601,17 @@ func (v CompositeValidator) ValidateCatalogConfig(manifest types.MCPServerCatalo
 			}
 		}
 		componentServerIDs[componentID] = struct{}{}
+		components = append(components, componentExposure{
+			namespace:  component.Namespace,
+			overridden: len(component.PromptOverrides) > 0 || len(component.ResourceOverrides) > 0,
+			restricted: len(component.PromptOverrides) > 0 && len(component.ResourceOverrides) > 0,
+		})
 	}
 
-	return nil
+	return validateComponentNamespaces(components)
 }
 
-func validateToolOverrides(overrides []types.ToolOverride) error {
+func validateToolOverrides(runtime types.Runtime, overrides []types.ToolOverride) error {
 	var (
 		toolNames    = make(map[string]struct{}, len(overrides))
 		exposedNames = make(map[string]struct{}, len(overrides))
@@ -591,7 +620,7 @@ func validateToolOverrides(overrides []types.ToolOverride) error {
 	for i, override := range overrides {
 		if override.Name == "" {
 			return types.RuntimeValidationError{
-				Runtime: types.RuntimeComposite,
+				Runtime: runtime,
 				Field:   fmt.Sprintf("toolOverrides[%d].name", i),
 				Message: "original tool name is required",
 			}
@@ -600,13 +629,32 @@ func validateToolOverrides(overrides []types.ToolOverride) error {
 		// Check for duplicate original names
 		if _, ok := toolNames[override.Name]; ok {
 			return types.RuntimeValidationError{
-				Runtime: types.RuntimeComposite,
+				Runtime: runtime,
 				Field:   fmt.Sprintf("toolOverrides[%d].name", i),
 				Message: fmt.Sprintf("duplicate tool name: %s", override.Name),
 			}
 		}
 		toolNames[override.Name] = struct{}{}
 
+		argumentNames := make(map[string]struct{}, len(override.Arguments))
+		for j, argument := range override.Arguments {
+			if err := argument.Validate(); err != nil {
+				return types.RuntimeValidationError{
+					Runtime: runtime,
+					Field:   fmt.Sprintf("toolOverrides[%d].arguments[%d]", i, j),
+					Message: err.Error(),
+				}
+			}
+			if _, ok := argumentNames[argument.Name]; ok {
+				return types.RuntimeValidationError{
+					Runtime: runtime,
+					Field:   fmt.Sprintf("toolOverrides[%d].arguments[%d].name", i, j),
+					Message: fmt.Sprintf("duplicate argument name: %s", argument.Name),
+				}
+			}
+			argumentNames[argument.Name] = struct{}{}
+		}
+
 		// For disabled tools, we don't care about exposed-name conflicts.
 		if !override.Enabled {
 			continue
@@ -621,7 +669,7 @@ func validateToolOverrides(overrides []types.ToolOverride) error {
 		// Check for duplicate exposed names among enabled tools.
 		if _, ok := exposedNames[effectiveName]; ok {
 			return types.RuntimeValidationError{
-				Runtime: types.RuntimeComposite,
+				Runtime: runtime,
 				Field:   fmt.Sprintf("toolOverrides[%d].overrideName", i),
 				Message: fmt.Sprintf("duplicate override name: %s", effectiveName),
 			}
@@ -632,6 +680,164 @@ func validateToolOverrides(overrides []types.ToolOverride) error {
 	return nil
 }
 
+// validatePromptAndResourceOverrides validates the namespace and the prompt and resource overrides of the i-th component
+// server of a composite server. The names of the prompts and the URIs of the resources that the component server exposes
+// are added to exposedPrompts and exposedResources, which map them to the index of the component server that exposes
+// them, so that conflicts with the other component servers are detected.
+func validatePromptAndResourceOverrides(i int, namespace string, prompts []types.PromptOverride, resources []types.ResourceOverride, exposedPrompts, exposedResources map[string]int) error {
+	field := fmt.Sprintf("compositeConfig.componentServers[%d]", i)
+
+	if namespace != "" && !namespaceRegex.MatchString(namespace) {
+		return types.RuntimeValidationError{
+			Runtime: types.RuntimeComposite,
+			Field:   field + ".namespace",
+			Message: "namespace must start with a letter and contain only letters, digits, and hyphens",
+		}
+	}
+
+	promptNames := make(map[string]struct{}, len(prompts))
+	for j, prompt := range prompts {
+		if prompt.Name == "" {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.promptOverrides[%d].name", field, j),
+				Message: "original prompt name is required",
+			}
+		}
+		if _, ok := promptNames[prompt.Name]; ok {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.promptOverrides[%d].name", field, j),
+				Message: fmt.Sprintf("duplicate prompt name: %s", prompt.Name),
+			}
+		}
+		promptNames[prompt.Name] = struct{}{}
+
+		if !prompt.Enabled {
+			continue
+		}
+
+		name := prompt.ExposedName(namespace)
+		if other, ok := exposedPrompts[name]; ok {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.promptOverrides[%d]", field, j),
+				Message: conflictMessage("prompt", name, i, other),
+			}
+		}
+		exposedPrompts[name] = i
+	}
+
+	resourceURIs := make(map[string]struct{}, len(resources))
+	for j, resource := range resources {
+		if resource.URI == "" {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.resourceOverrides[%d].uri", field, j),
+				Message: "original resource URI is required",
+			}
+		}
+		if _, ok := resourceURIs[resource.URI]; ok {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.resourceOverrides[%d].uri", field, j),
+				Message: fmt.Sprintf("duplicate resource URI: %s", resource.URI),
+			}
+		}
+		resourceURIs[resource.URI] = struct{}{}
+
+		if resource.OverrideURI != "" && (resource.IsTemplate() || strings.Contains(resource.OverrideURI, "{")) {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.resourceOverrides[%d].overrideURI", field, j),
+				Message: "resource templates can't be given a different URI template",
+			}
+		}
+
+		if !resource.Enabled {
+			continue
+		}
+
+		uri := resource.ExposedURI(namespace)
+		if other, ok := exposedResources[uri]; ok {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   fmt.Sprintf("%s.resourceOverrides[%d]", field, j),
+				Message: conflictMessage("resource", uri, i, other),
+			}
+		}
+		exposedResources[uri] = i
+	}
+
+	return nil
+}
+
+// componentExposure is what validateComponentNamespaces needs to know about a component server of a composite server.
+type componentExposure struct {
+	namespace string
+	// overridden is whether the component server has prompt or resource overrides, and restricted is whether both
+	// its prompts and its resources are restricted by them.
+	overridden, restricted bool
+	disabled               bool
+}
+
+// validateComponentNamespaces checks that the prompts and resources of the component servers of a composite server can't
+// conflict. Only those restricted by overrides are known in advance, so when more than one component server is included
+// and prompt or resource overrides are used, the component servers that don't restrict both must have a namespace of
+// their own. Composite servers that don't use these overrides are left as they are, so that existing ones stay valid.
+func validateComponentNamespaces(components []componentExposure) error {
+	var (
+		included   int
+		overridden bool
+	)
+	for _, component := range components {
+		if !component.disabled {
+			included++
+			overridden = overridden || component.overridden
+		}
+	}
+	if included < 2 {
+		return nil
+	}
+
+	namespaces := make(map[string]int, len(components))
+	for i, component := range components {
+		if component.disabled {
+			continue
+		}
+
+		field := fmt.Sprintf("compositeConfig.componentServers[%d].namespace", i)
+		if component.namespace == "" {
+			if overridden && !component.restricted {
+				return types.RuntimeValidationError{
+					Runtime: types.RuntimeComposite,
+					Field:   field,
+					Message: "namespace is required when more than one component server is included and prompt or resource overrides are used, unless the prompts and resources of the component server are restricted by overrides",
+				}
+			}
+			continue
+		}
+
+		if other, ok := namespaces[component.namespace]; ok {
+			return types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   field,
+				Message: fmt.Sprintf("namespace %s is also used by component server %d", component.namespace, other),
+			}
+		}
+		namespaces[component.namespace] = i
+	}
+
+	return nil
+}
+
+func conflictMessage(kind, name string, i, other int) string {
+	if i == other {
+		return fmt.Sprintf("duplicate exposed %s: %s", kind, name)
+	}
+	return fmt.Sprintf("%s %s is also exposed by component server %d", kind, name, other)
+}
+
 // getRuntimeValidators returns a map of all available runtime validators
 func getRuntimeValidators() RuntimeValidators {
 	return RuntimeValidators{
@@ -644,6 +850,10 @@ func getRuntimeValidators() RuntimeValidators {
 }
 
 func ValidateServerManifest(manifest types.MCPServerManifest) error {
+	if err := validateServerToolOverrides(manifest.Runtime, manifest.ToolOverrides); err != nil {
+		return err
+	}
+
 	if validator, ok := getRuntimeValidators()[manifest.Runtime]; ok {
 		return validator.ValidateConfig(manifest)
 	}
@@ -656,6 +866,20 @@ func ValidateServerManifest(manifest types.MCPServerManifest) error {
 }
 
 func ValidateCatalogEntryManifest(manifest types.MCPServerCatalogEntryManifest) error {
+	if err := validateServerToolOverrides(manifest.Runtime, manifest.ToolOverrides); err != nil {
+		return err
+	}
+
+	if manifest.EgressPolicy != nil {
+		if err := manifest.EgressPolicy.Validate(); err != nil {
+			return types.RuntimeValidationError{
+				Runtime: manifest.Runtime,
+				Field:   "egressPolicy",
+				Message: err.Error(),
+			}
+		}
+	}
+
 	if validator, ok := getRuntimeValidators()[manifest.Runtime]; ok {
 		return validator.ValidateCatalogConfig(manifest)
 	}
@@ -666,3 +890,19 @@ func ValidateCatalogEntryManifest(manifest types.MCPServerCatalogEntryManifest)
 		Message: "unsupported runtime",
 	}
 }
+
+// validateServerToolOverrides validates the tool overrides of a server, which composite servers set on their component
+// servers instead.
+func validateServerToolOverrides(runtime types.Runtime, overrides []types.ToolOverride) error {
+	if len(overrides) == 0 {
+		return nil
+	}
+	if runtime == types.RuntimeComposite {
+		return types.RuntimeValidationError{
+			Runtime: runtime,
+			Field:   "toolOverrides",
+			Message: "tool overrides of composite servers must be set on their component servers",
+		}
+	}
+	return validateToolOverrides(runtime, overrides)
+}
@@ -1,6 +1,7 @@
 package validation
 
 import (
+	"encoding/json"
 	"errors"
 	"strings"
 	"testing"
@@ -1027,6 +1028,78 @@ func TestCompositeValidator_ValidateCatalogConfig(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "catalog component without namespace fails when prompt overrides are used",
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeCatalogConfig{
+					ComponentServers: []types.CatalogComponentServer{
+						{
+							CatalogEntryID:  "entry-1",
+							Namespace:       "one",
+							Manifest:        types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+							PromptOverrides: []types.PromptOverride{{Name: "prompt-1", Enabled: true}},
+						},
+						{
+							MCPServerID: "server-2",
+							Manifest:    types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+						},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[1].namespace",
+				Message: "namespace is required when more than one component server is included and prompt or resource overrides are used, unless the prompts and resources of the component server are restricted by overrides",
+			},
+		},
+		{
+			name: "catalog component without namespace passes when its prompts and resources are restricted",
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeCatalogConfig{
+					ComponentServers: []types.CatalogComponentServer{
+						{
+							CatalogEntryID: "entry-1",
+							Namespace:      "one",
+							Manifest:       types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+						},
+						{
+							MCPServerID:       "server-2",
+							Manifest:          types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+							PromptOverrides:   []types.PromptOverride{{Name: "prompt-1", Enabled: true}},
+							ResourceOverrides: []types.ResourceOverride{{URI: "file:///one", Enabled: true}},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "catalog components with the same namespace fail",
+			manifest: types.MCPServerCatalogEntryManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeCatalogConfig{
+					ComponentServers: []types.CatalogComponentServer{
+						{
+							CatalogEntryID: "entry-1",
+							Namespace:      "same",
+							Manifest:       types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+						},
+						{
+							MCPServerID: "server-2",
+							Namespace:   "same",
+							Manifest:    types.MCPServerCatalogEntryManifest{Runtime: types.RuntimeRemote},
+						},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[1].namespace",
+				Message: "namespace same is also used by component server 0",
+			},
+		},
 		{
 			name: "remote component with static OAuth not allowed in catalog",
 			manifest: types.MCPServerCatalogEntryManifest{
@@ -1194,11 +1267,186 @@ func TestValidateToolOverrides(t *testing.T) {
 			},
 			expectedError: nil,
 		},
+		{
+			name: "valid argument overrides",
+			overrides: []types.ToolOverride{
+				{
+					Name:    "search_issues",
+					Enabled: true,
+					Arguments: []types.ToolArgumentOverride{
+						{Name: "owner", Value: json.RawMessage(`"obot-platform"`)},
+						{Name: "state", Default: json.RawMessage(`"open"`), Enum: []json.RawMessage{[]byte(`"open"`), []byte(`"closed"`)}},
+						{Name: "sort", Removed: true},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "fixed argument with enum",
+			overrides: []types.ToolOverride{
+				{
+					Name:    "search_issues",
+					Enabled: true,
+					Arguments: []types.ToolArgumentOverride{
+						{Name: "owner", Value: json.RawMessage(`"obot-platform"`), Enum: []json.RawMessage{[]byte(`"obot-platform"`)}},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "toolOverrides[0].arguments[0]",
+				Message: "argument owner has a fixed value and can't also set a default, enum, or be removed",
+			},
+		},
+		{
+			name: "default not in enum",
+			overrides: []types.ToolOverride{
+				{
+					Name:    "search_issues",
+					Enabled: true,
+					Arguments: []types.ToolArgumentOverride{
+						{Name: "state", Default: json.RawMessage(`"all"`), Enum: []json.RawMessage{[]byte(`"open"`)}},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "toolOverrides[0].arguments[0]",
+				Message: "default of argument state is not in its enum",
+			},
+		},
+		{
+			name: "duplicate argument name",
+			overrides: []types.ToolOverride{
+				{
+					Name:    "search_issues",
+					Enabled: true,
+					Arguments: []types.ToolArgumentOverride{
+						{Name: "owner", Value: json.RawMessage(`"obot-platform"`)},
+						{Name: "owner", Removed: true},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "toolOverrides[0].arguments[1].name",
+				Message: "duplicate argument name: owner",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateToolOverrides(types.RuntimeComposite, tt.overrides)
+			require.Equal(t, tt.expectedError, err)
+		})
+	}
+}
+
+func TestValidatePromptAndResourceOverrides(t *testing.T) {
+	type component struct {
+		namespace string
+		prompts   []types.PromptOverride
+		resources []types.ResourceOverride
+	}
+
+	tests := []struct {
+		name          string
+		components    []component
+		expectedError error
+	}{
+		{
+			name: "valid overrides",
+			components: []component{
+				{
+					namespace: "runbooks",
+					prompts:   []types.PromptOverride{{Name: "restart", Enabled: true}},
+					resources: []types.ResourceOverride{{URI: "file:///{path}", Enabled: true}},
+				},
+				{
+					prompts:   []types.PromptOverride{{Name: "restart", OverrideName: "restart-service", Enabled: true}},
+					resources: []types.ResourceOverride{{URI: "file:///readme.md", OverrideURI: "docs://readme", Enabled: true}},
+				},
+			},
+		},
+		{
+			name: "invalid namespace",
+			components: []component{
+				{namespace: "run books"},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[0].namespace",
+				Message: "namespace must start with a letter and contain only letters, digits, and hyphens",
+			},
+		},
+		{
+			name: "prompt conflict between components",
+			components: []component{
+				{prompts: []types.PromptOverride{{Name: "restart", Enabled: true}}},
+				{prompts: []types.PromptOverride{{Name: "reboot", OverrideName: "restart", Enabled: true}}},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[1].promptOverrides[0]",
+				Message: "prompt restart is also exposed by component server 0",
+			},
+		},
+		{
+			name: "no conflict with disabled prompt",
+			components: []component{
+				{prompts: []types.PromptOverride{{Name: "restart", Enabled: true}}},
+				{prompts: []types.PromptOverride{{Name: "restart"}}},
+			},
+		},
+		{
+			name: "resource conflict between components",
+			components: []component{
+				{namespace: "docs", resources: []types.ResourceOverride{{URI: "file:///readme.md", Enabled: true}}},
+				{resources: []types.ResourceOverride{{URI: "file:///other.md", OverrideURI: "docs+file:///readme.md", Enabled: true}}},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[1].resourceOverrides[0]",
+				Message: "resource docs+file:///readme.md is also exposed by component server 0",
+			},
+		},
+		{
+			name: "resource template with override URI",
+			components: []component{
+				{resources: []types.ResourceOverride{{URI: "file:///{path}", OverrideURI: "docs://readme", Enabled: true}}},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[0].resourceOverrides[0].overrideURI",
+				Message: "resource templates can't be given a different URI template",
+			},
+		},
+		{
+			name: "duplicate prompt name",
+			components: []component{
+				{prompts: []types.PromptOverride{{Name: "restart", Enabled: true}, {Name: "restart", OverrideName: "other"}}},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[0].promptOverrides[1].name",
+				Message: "duplicate prompt name: restart",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			err := validateToolOverrides(tt.overrides)
+			var (
+				err                              error
+				exposedPrompts, exposedResources = make(map[string]int), make(map[string]int)
+			)
+			for i, c := range tt.components {
+				if err = validatePromptAndResourceOverrides(i, c.namespace, c.prompts, c.resources, exposedPrompts, exposedResources); err != nil {
+					break
+				}
+			}
 			require.Equal(t, tt.expectedError, err)
 		})
 	}
@@ -1313,6 +1561,70 @@ func TestCompositeValidator_ValidateConfig_StaticOAuth(t *testing.T) {
 				Message: "remote component with static OAuth cannot be included in a composite server",
 			},
 		},
+		{
+			name: "disabled component doesn't require namespaces",
+			manifest: types.MCPServerManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeRuntimeConfig{
+					ComponentServers: []types.ComponentServer{
+						{
+							CatalogEntryID:  "entry-1",
+							Manifest:        types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"}},
+							PromptOverrides: []types.PromptOverride{{Name: "prompt-1", Enabled: true}},
+						},
+						{
+							CatalogEntryID: "entry-2",
+							Disabled:       true,
+							Manifest:       types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://other.example.com/mcp"}},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "included components without namespaces pass without prompt or resource overrides",
+			manifest: types.MCPServerManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeRuntimeConfig{
+					ComponentServers: []types.ComponentServer{
+						{
+							CatalogEntryID: "entry-1",
+							Manifest:       types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"}},
+						},
+						{
+							CatalogEntryID: "entry-2",
+							Manifest:       types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://other.example.com/mcp"}},
+						},
+					},
+				},
+			},
+			expectedError: nil,
+		},
+		{
+			name: "included components without namespaces fail when resource overrides are used",
+			manifest: types.MCPServerManifest{
+				Runtime: types.RuntimeComposite,
+				CompositeConfig: &types.CompositeRuntimeConfig{
+					ComponentServers: []types.ComponentServer{
+						{
+							CatalogEntryID: "entry-1",
+							Manifest:       types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://example.com/mcp"}},
+						},
+						{
+							CatalogEntryID:    "entry-2",
+							Manifest:          types.MCPServerManifest{Runtime: types.RuntimeRemote, RemoteConfig: &types.RemoteRuntimeConfig{URL: "https://other.example.com/mcp"}},
+							ResourceOverrides: []types.ResourceOverride{{URI: "file:///one", Enabled: true}},
+						},
+					},
+				},
+			},
+			expectedError: types.RuntimeValidationError{
+				Runtime: types.RuntimeComposite,
+				Field:   "compositeConfig.componentServers[0].namespace",
+				Message: "namespace is required when more than one component server is included and prompt or resource overrides are used, unless the prompts and resources of the component server are restricted by overrides",
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,322 @@
+package webhookvalidation
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+)
+
+const (
+	// ErrorCodeRejected is the JSON-RPC error code returned for requests that are rejected by a validation.
+	ErrorCodeRejected = -32001
+	// ErrorCodeInvalidParams is the JSON-RPC error code returned for requests with invalid parameters.
+	ErrorCodeInvalidParams = -32602
+)
+
+// ParseRequests parses the JSON-RPC message or batch of messages in the body of a MCP POST request.
+func ParseRequests(body []byte) ([]Request, bool, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '[' {
+		var batch []json.RawMessage
+		if err := json.Unmarshal(body, &batch); err != nil {
+			return nil, true, fmt.Errorf("failed to parse JSON-RPC batch: %w", err)
+		}
+
+		reqs := make([]Request, 0, len(batch))
+		for _, msg := range batch {
+			req, err := parseRequest(msg)
+			if err != nil {
+				return nil, true, err
+			}
+			reqs = append(reqs, req)
+		}
+		return reqs, true, nil
+	}
+
+	req, err := parseRequest(body)
+	if err != nil {
+		return nil, false, err
+	}
+	return []Request{req}, false, nil
+}
+
+func parseRequest(msg json.RawMessage) (Request, error) {
+	var req Request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return req, fmt.Errorf("failed to parse JSON-RPC message: %w", err)
+	}
+	req.Raw = msg
+	return req, nil
+}
+
+// WithParams returns a copy of the request with its params, and the params in its raw message, replaced.
+func (r Request) WithParams(params json.RawMessage) (Request, error) {
+	var msg map[string]json.RawMessage
+	if err := json.Unmarshal(r.Raw, &msg); err != nil {
+		return r, fmt.Errorf("failed to parse JSON-RPC message: %w", err)
+	}
+	msg["params"] = params
+
+	raw, err := json.Marshal(msg)
+	if err != nil {
+		return r, fmt.Errorf("failed to marshal JSON-RPC message: %w", err)
+	}
+
+	r.Params = params
+	r.Raw = raw
+	return r, nil
+}
+
+// EncodeRequests returns the body of a MCP POST request with the raw messages of the requests.
+func EncodeRequests(reqs []Request, batch bool) ([]byte, error) {
+	if !batch {
+		if len(reqs) != 1 {
+			return nil, fmt.Errorf("expected one JSON-RPC message, got %d", len(reqs))
+		}
+		return reqs[0].Raw, nil
+	}
+
+	msgs := make([]json.RawMessage, 0, len(reqs))
+	for _, req := range reqs {
+		msgs = append(msgs, req.Raw)
+	}
+	return json.Marshal(msgs)
+}
+
+type errorResponse struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Error   struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// RejectionResponse returns the JSON-RPC error responses for the requests of a rejected message or batch.
+// Notifications don't get responses, so nil is returned if there are no requests with IDs.
+func RejectionResponse(reqs []Request, batch bool, rejected error) []byte {
+	return ErrorResponse(reqs, batch, ErrorCodeRejected, rejected.Error())
+}
+
+// ErrorResponse returns JSON-RPC error responses with the code and message for the requests of a message or batch.
+// Notifications don't get responses, so nil is returned if there are no requests with IDs.
+func ErrorResponse(reqs []Request, batch bool, code int, message string) []byte {
+	responses := make([]errorResponse, 0, len(reqs))
+	for _, req := range reqs {
+		if len(req.ID) == 0 {
+			continue
+		}
+		resp := errorResponse{
+			JSONRPC: "2.0",
+			ID:      req.ID,
+		}
+		resp.Error.Code = code
+		resp.Error.Message = message
+		responses = append(responses, resp)
+	}
+
+	if len(responses) == 0 {
+		return nil
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if batch {
+		b, err = json.Marshal(responses)
+	} else {
+		b, err = json.Marshal(responses[0])
+	}
+	if err != nil {
+		return nil
+	}
+	return b
+}
+
+// RedactMessage redacts the results of the JSON-RPC response or batch of responses in data with the validators.
+// It returns the redacted data and the validators that redacted anything. Validators in shadow mode don't change the
+// data, but are returned if they would have. The original data is returned if nothing was redacted.
+func RedactMessage(data []byte, validators []*Validator) ([]byte, []*Validator) {
+	if len(validators) == 0 {
+		return data, nil
+	}
+
+	var redactors []*Validator
+	data = RewriteResults(data, func(_, result json.RawMessage) (json.RawMessage, bool) {
+		var changed bool
+		for _, v := range validators {
+			redacted, ok := v.RedactResult(result)
+			if !ok {
+				continue
+			}
+			if !slices.Contains(redactors, v) {
+				redactors = append(redactors, v)
+			}
+			if !v.shadow {
+				result = redacted
+				changed = true
+			}
+		}
+		return result, changed
+	})
+	return data, redactors
+}
+
+// RewriteResults replaces the results of the JSON-RPC response or batch of responses in data with the results returned
+// by rewrite, which is called with the ID and result of each response. The original data is returned if nothing was
+// changed.
+func RewriteResults(data []byte, rewrite func(id, result json.RawMessage) (json.RawMessage, bool)) []byte {
+	trimmed := bytes.TrimSpace(data)
+	if len(trimmed) == 0 {
+		return data
+	}
+
+	var messages []map[string]json.RawMessage
+	batch := trimmed[0] == '['
+	if batch {
+		if err := json.Unmarshal(trimmed, &messages); err != nil {
+			return data
+		}
+	} else {
+		var message map[string]json.RawMessage
+		if err := json.Unmarshal(trimmed, &message); err != nil {
+			return data
+		}
+		messages = append(messages, message)
+	}
+
+	var changed bool
+	for _, message := range messages {
+		result, ok := message["result"]
+		if !ok {
+			continue
+		}
+		if rewritten, ok := rewrite(message["id"], result); ok {
+			message["result"] = rewritten
+			changed = true
+		}
+	}
+
+	if !changed {
+		return data
+	}
+
+	var (
+		b   []byte
+		err error
+	)
+	if batch {
+		b, err = json.Marshal(messages)
+	} else {
+		b, err = json.Marshal(messages[0])
+	}
+	if err != nil {
+		return data
+	}
+	return b
+}
+
+// RedactEventStream returns a body that redacts the JSON-RPC responses in the data of the events of a server-sent event stream
+// as they are read from the given body. The validators that redacted anything, or would have in shadow mode, are
+// passed to report.
+func RedactEventStream(body io.ReadCloser, validators []*Validator, report func([]*Validator)) io.ReadCloser {
+	return RewriteEventStream(body, func(data []byte) []byte {
+		redacted, redactors := RedactMessage(data, validators)
+		if len(redactors) > 0 {
+			report(redactors)
+		}
+		return redacted
+	})
+}
+
+// RewriteEventStream returns a body that replaces the data of each event in a server-sent event stream with the data
+// returned by rewrite as it is read from the given body. An event's data can span multiple data lines, so they are
+// joined with newlines before they are rewritten, like clients join them.
+func RewriteEventStream(body io.ReadCloser, rewrite func(data []byte) []byte) io.ReadCloser {
+	pr, pw := io.Pipe()
+
+	go func() {
+		var (
+			r     = bufio.NewReader(body)
+			event [][]byte
+		)
+		for {
+			line, err := r.ReadBytes('\n')
+			if len(line) > 0 {
+				event = append(event, line)
+			}
+			// An event ends with a blank line, or with the stream.
+			if len(event) > 0 && (err != nil || len(bytes.TrimRight(line, "\r\n")) == 0) {
+				if _, werr := pw.Write(rewriteEvent(event, rewrite)); werr != nil {
+					return
+				}
+				event = event[:0]
+			}
+			if err != nil {
+				if err == io.EOF {
+					err = nil
+				}
+				_ = pw.CloseWithError(err)
+				return
+			}
+		}
+	}()
+
+	return &eventStreamBody{PipeReader: pr, body: body}
+}
+
+// rewriteEvent returns the lines of an event with its data rewritten. The other lines of the event are kept as they
+// are, and the rewritten data lines take the place of the first data line.
+func rewriteEvent(lines [][]byte, rewrite func(data []byte) []byte) []byte {
+	var values [][]byte
+	for _, line := range lines {
+		if value, ok := bytes.CutPrefix(line, []byte("data:")); ok {
+			value = bytes.TrimPrefix(value, []byte(" "))
+			values = append(values, bytes.TrimRight(value, "\r\n"))
+		}
+	}
+
+	data := bytes.Join(values, []byte("\n"))
+	rewritten := data
+	if len(values) > 0 {
+		rewritten = rewrite(data)
+	}
+	if bytes.Equal(rewritten, data) {
+		return bytes.Join(lines, nil)
+	}
+
+	var (
+		out         bytes.Buffer
+		dataWritten bool
+	)
+	for _, line := range lines {
+		if !bytes.HasPrefix(line, []byte("data:")) {
+			out.Write(line)
+			continue
+		}
+		if dataWritten {
+			continue
+		}
+		for _, value := range bytes.Split(rewritten, []byte("\n")) {
+			out.WriteString("data: ")
+			out.Write(value)
+			out.WriteByte('\n')
+		}
+		dataWritten = true
+	}
+	return out.Bytes()
+}
+
+type eventStreamBody struct {
+	*io.PipeReader
+	body io.ReadCloser
+}
+
+func (e *eventStreamBody) Close() error {
+	_ = e.PipeReader.Close()
+	return e.body.Close()
+}
@@ -0,0 +1,422 @@
+package webhookvalidation
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/jsonschema-go/jsonschema"
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+const redacted = "[REDACTED]"
+
+// secretPatterns are used for the secret scanning of responses.
+var secretPatterns = []*regexp.Regexp{
+	// Private keys
+	regexp.MustCompile(`-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`),
+	// AWS access key IDs
+	regexp.MustCompile(`\b(?:AKIA|ASIA)[0-9A-Z]{16}\b`),
+	// GitHub tokens
+	regexp.MustCompile(`\bgh[pousr]_[A-Za-z0-9]{36,}\b`),
+	regexp.MustCompile(`\bgithub_pat_[A-Za-z0-9_]{22,}\b`),
+	// Slack tokens
+	regexp.MustCompile(`\bxox[abprs]-[A-Za-z0-9-]{10,}\b`),
+	// Stripe secret keys
+	regexp.MustCompile(`\b[rs]k_live_[A-Za-z0-9]{20,}\b`),
+	// Google API keys
+	regexp.MustCompile(`\bAIza[0-9A-Za-z_-]{35}\b`),
+	// OpenAI and Anthropic style API keys
+	regexp.MustCompile(`\bsk-[A-Za-z0-9_-]{20,}\b`),
+	// JWTs
+	regexp.MustCompile(`\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\b`),
+}
+
+// RejectedError is returned when a built-in validation rejects a request.
+type RejectedError struct {
+	Validation, Reason string
+}
+
+func (e *RejectedError) Error() string {
+	return fmt.Sprintf("rejected by %s: %s", e.Validation, e.Reason)
+}
+
+// Request is the part of a MCP request that is validated.
+type Request struct {
+	ID     json.RawMessage `json:"id,omitempty"`
+	Method string          `json:"method"`
+	Params json.RawMessage `json:"params,omitempty"`
+	// Raw is the whole JSON-RPC message.
+	Raw json.RawMessage `json:"-"`
+}
+
+type callParams struct {
+	Name      string         `json:"name"`
+	URI       string         `json:"uri"`
+	Arguments map[string]any `json:"arguments"`
+}
+
+// Identifier returns the name of the tool or prompt, or the URI of the resource, that the request is for.
+func (r Request) Identifier() string {
+	var params callParams
+	if len(r.Params) == 0 || json.Unmarshal(r.Params, &params) != nil {
+		return ""
+	}
+	if params.URI != "" {
+		return params.URI
+	}
+	return params.Name
+}
+
+// Validator is a compiled built-in MCPWebhookValidation.
+type Validator struct {
+	name        string
+	displayName string
+	shadow      bool
+	selectors   types.MCPSelectors
+	schema      *jsonschema.Resolved
+	rules       []argumentRule
+	redact      []*regexp.Regexp
+	limiter     *rateLimiter
+}
+
+type argumentRule struct {
+	argument        string
+	allowed, denied []*regexp.Regexp
+}
+
+// New compiles the built-in validation in the manifest of the MCPWebhookValidation with the given name.
+// The display name is used in the reasons of rejections.
+func New(name, displayName string, manifest types.MCPWebhookValidationManifest) (*Validator, error) {
+	if !manifest.ValidationType.IsBuiltin() {
+		return nil, fmt.Errorf("%q is not a built-in validation type", manifest.ValidationType)
+	}
+
+	v := &Validator{
+		name:        name,
+		displayName: displayName,
+		shadow:      manifest.ShadowMode,
+		selectors:   manifest.Selectors,
+	}
+
+	switch manifest.ValidationType {
+	case types.MCPWebhookValidationTypeArgumentSchema:
+		var schema jsonschema.Schema
+		if err := json.Unmarshal(manifest.ArgumentSchema, &schema); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal argument schema: %w", err)
+		}
+		resolved, err := schema.Resolve(nil)
+		if err != nil {
+			return nil, fmt.Errorf("invalid argument schema: %w", err)
+		}
+		v.schema = resolved
+	case types.MCPWebhookValidationTypeArgumentValues:
+		for _, rule := range manifest.ArgumentValues {
+			allowed, err := compileAnchored(rule.Allowed)
+			if err != nil {
+				return nil, err
+			}
+			denied, err := compileAnchored(rule.Denied)
+			if err != nil {
+				return nil, err
+			}
+			v.rules = append(v.rules, argumentRule{
+				argument: rule.Argument,
+				allowed:  allowed,
+				denied:   denied,
+			})
+		}
+	case types.MCPWebhookValidationTypeResponseRedaction:
+		if manifest.ResponseRedaction == nil {
+			return nil, fmt.Errorf("response redaction is required")
+		}
+		for _, pattern := range manifest.ResponseRedaction.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+			}
+			v.redact = append(v.redact, re)
+		}
+		if manifest.ResponseRedaction.SecretScanning {
+			v.redact = append(v.redact, secretPatterns...)
+		}
+	case types.MCPWebhookValidationTypeRateLimit:
+		if manifest.RateLimit == nil || manifest.RateLimit.CallsPerMinute <= 0 {
+			return nil, fmt.Errorf("rate limit requires a positive number of calls per minute")
+		}
+		v.limiter = newRateLimiter(manifest.RateLimit.CallsPerMinute, manifest.RateLimit.PerUser)
+	default:
+		return nil, fmt.Errorf("unknown validation type %q", manifest.ValidationType)
+	}
+
+	return v, nil
+}
+
+func compileAnchored(patterns []string) ([]*regexp.Regexp, error) {
+	result := make([]*regexp.Regexp, 0, len(patterns))
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(`^(?:` + pattern + `)$`)
+		if err != nil {
+			return nil, fmt.Errorf("invalid pattern %q: %w", pattern, err)
+		}
+		result = append(result, re)
+	}
+	return result, nil
+}
+
+// Name returns the name of the MCPWebhookValidation.
+func (v *Validator) Name() string {
+	return v.name
+}
+
+// Shadow returns whether the decisions of the validation are only recorded instead of enforced.
+func (v *Validator) Shadow() bool {
+	return v.shadow
+}
+
+// Matches returns whether the validation applies to the request.
+func (v *Validator) Matches(req Request) bool {
+	return v.selectors.Matches(req.Method, req.Identifier())
+}
+
+// RedactsResponses returns whether the validation redacts the responses of the requests it matches.
+func (v *Validator) RedactsResponses() bool {
+	return len(v.redact) > 0
+}
+
+// CheckRequest returns a *RejectedError if the validation rejects the request of the user.
+// Only tool calls are checked, other requests are always accepted.
+func (v *Validator) CheckRequest(req Request, userID string) error {
+	if req.Method != "tools/call" || !v.Matches(req) {
+		return nil
+	}
+
+	var params callParams
+	if len(req.Params) > 0 {
+		if err := json.Unmarshal(req.Params, &params); err != nil {
+			return v.reject("invalid tool call parameters: %v", err)
+		}
+	}
+
+	switch {
+	case v.schema != nil:
+		arguments := params.Arguments
+		if arguments == nil {
+			arguments = map[string]any{}
+		}
+		if err := v.schema.Validate(arguments); err != nil {
+			return v.reject("invalid arguments for tool %s: %v", params.Name, err)
+		}
+	case len(v.rules) > 0:
+		for _, rule := range v.rules {
+			value, ok := params.Arguments[rule.argument]
+			if !ok {
+				continue
+			}
+			for _, s := range argumentStrings(value) {
+				if reason := rule.check(s); reason != "" {
+					return v.reject("value of argument %s for tool %s %s", rule.argument, params.Name, reason)
+				}
+			}
+		}
+	case v.limiter != nil:
+		if !v.limiter.allow(params.Name, userID, time.Now()) {
+			return v.reject("tool %s has been called too many times, try again later", params.Name)
+		}
+	}
+
+	return nil
+}
+
+func (v *Validator) reject(format string, args ...any) error {
+	return &RejectedError{
+		Validation: v.displayName,
+		Reason:     fmt.Sprintf(format, args...),
+	}
+}
+
+func (r argumentRule) check(value string) string {
+	for _, re := range r.denied {
+		if re.MatchString(value) {
+			return "is denied"
+		}
+	}
+	if len(r.allowed) == 0 {
+		return ""
+	}
+	for _, re := range r.allowed {
+		if re.MatchString(value) {
+			return ""
+		}
+	}
+	return "is not allowed"
+}
+
+// argumentStrings returns the values to check for an argument. Each item of an array is checked separately.
+func argumentStrings(value any) []string {
+	switch v := value.(type) {
+	case nil:
+		return nil
+	case string:
+		return []string{v}
+	case []any:
+		var result []string
+		for _, item := range v {
+			result = append(result, argumentStrings(item)...)
+		}
+		return result
+	default:
+		b, _ := json.Marshal(v)
+		return []string{string(b)}
+	}
+}
+
+// RedactResult replaces the matches of the validation's patterns in all the strings in the result of a response.
+// It returns the original result and false if nothing was redacted.
+func (v *Validator) RedactResult(result json.RawMessage) (json.RawMessage, bool) {
+	if len(v.redact) == 0 || len(result) == 0 {
+		return result, false
+	}
+
+	var data any
+	if err := json.Unmarshal(result, &data); err != nil {
+		return result, false
+	}
+
+	data, changed := v.redactValue(data)
+	if !changed {
+		return result, false
+	}
+
+	b, err := json.Marshal(data)
+	if err != nil {
+		return result, false
+	}
+	return b, true
+}
+
+func (v *Validator) redactValue(value any) (any, bool) {
+	var changed bool
+	switch val := value.(type) {
+	case string:
+		for _, re := range v.redact {
+			if re.MatchString(val) {
+				val = re.ReplaceAllLiteralString(val, redacted)
+				changed = true
+			}
+		}
+		return val, changed
+	case []any:
+		for i, item := range val {
+			var itemChanged bool
+			val[i], itemChanged = v.redactValue(item)
+			changed = changed || itemChanged
+		}
+		return val, changed
+	case map[string]any:
+		for k, item := range val {
+			var itemChanged bool
+			val[k], itemChanged = v.redactValue(item)
+			changed = changed || itemChanged
+		}
+		return val, changed
+	default:
+		return value, false
+	}
+}
+
+// rateLimiter counts the calls of each tool in fixed one minute windows.
+type rateLimiter struct {
+	lock    sync.Mutex
+	limit   int
+	perUser bool
+	windows map[string]*window
+}
+
+type window struct {
+	start time.Time
+	count int
+}
+
+// maxWindows is the number of windows after which expired windows are removed.
+const maxWindows = 10000
+
+func newRateLimiter(limit int, perUser bool) *rateLimiter {
+	return &rateLimiter{
+		limit:   limit,
+		perUser: perUser,
+		windows: make(map[string]*window),
+	}
+}
+
+func (r *rateLimiter) allow(tool, userID string, now time.Time) bool {
+	key := tool
+	if r.perUser {
+		key = strings.Join([]string{tool, userID}, "\x00")
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	w := r.windows[key]
+	if w == nil || now.Sub(w.start) >= time.Minute {
+		if w == nil && len(r.windows) >= maxWindows {
+			for k, existing := range r.windows {
+				if now.Sub(existing.start) >= time.Minute {
+					delete(r.windows, k)
+				}
+			}
+		}
+		w = &window{start: now}
+		r.windows[key] = w
+	}
+
+	if w.count >= r.limit {
+		return false
+	}
+	w.count++
+	return true
+}
+
+// Cache keeps the compiled validators, and their state like rate limit counters, across requests.
+// A validator is compiled again when the generation of its MCPWebhookValidation changes.
+// Validators are keyed by the name of their MCPWebhookValidation, since all of them are in the same namespace.
+type Cache struct {
+	lock       sync.Mutex
+	validators map[string]cachedValidator
+}
+
+type cachedValidator struct {
+	generation int64
+	validator  *Validator
+}
+
+func NewCache() *Cache {
+	return &Cache{
+		validators: make(map[string]cachedValidator),
+	}
+}
+
+// Get returns the compiled validator for the MCPWebhookValidation with the given name and generation.
+func (c *Cache) Get(name string, generation int64, displayName string, manifest types.MCPWebhookValidationManifest) (*Validator, error) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	if cached, ok := c.validators[name]; ok && cached.generation == generation {
+		return cached.validator, nil
+	}
+
+	v, err := New(name, displayName, manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	c.validators[name] = cachedValidator{
+		generation: generation,
+		validator:  v,
+	}
+	return v, nil
+}
@@ -0,0 +1,72 @@
+package webhookvalidation
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/obot-platform/obot/apiclient/types"
+)
+
+// SignatureHeader is the header with the HMAC-SHA256 signature of the payloads sent to webhooks.
+const SignatureHeader = "X-Obot-Signature-256"
+
+// maxWebhookResponseSize is the size after which webhook responses are truncated.
+const maxWebhookResponseSize = 64 * 1024
+
+// WebhookResult is the outcome of sending a MCP message to a webhook.
+type WebhookResult struct {
+	Decision   types.MCPWebhookDecision
+	StatusCode int
+	Response   string
+	Latency    time.Duration
+}
+
+// Sign returns the value of the signature header for the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// CallWebhook sends the MCP message to the webhook the same way that the webhook validation servers do.
+// The message is accepted if the webhook responds with 200 and rejected otherwise.
+func CallWebhook(ctx context.Context, client *http.Client, url, secret string, body []byte) (WebhookResult, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return WebhookResult{}, fmt.Errorf("failed to create webhook request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if secret != "" {
+		req.Header.Set(SignatureHeader, Sign(secret, body))
+	}
+
+	start := time.Now()
+	resp, err := client.Do(req)
+	if err != nil {
+		return WebhookResult{Latency: time.Since(start)}, fmt.Errorf("failed to call webhook: %w", err)
+	}
+	defer resp.Body.Close()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxWebhookResponseSize))
+	result := WebhookResult{
+		Decision:   types.MCPWebhookDecisionRejected,
+		StatusCode: resp.StatusCode,
+		Response:   string(respBody),
+		Latency:    time.Since(start),
+	}
+	if err != nil {
+		return result, fmt.Errorf("failed to read webhook response: %w", err)
+	}
+	if resp.StatusCode == http.StatusOK {
+		result.Decision = types.MCPWebhookDecisionAccepted
+	}
+
+	return result, nil
+}
@@ -16,6 +16,7 @@ export interface MCPCatalogManifest {
 	displayName: string;
 	sourceURLs: string[];
 	allowedUserIDs: string[];
+	exposePackages?: boolean;
 }
 
 export interface MCPCatalog extends MCPCatalogManifest {
@@ -794,7 +795,7 @@ export type GroupRoleAssignmentList = {
 };
 
 // MCP Capacity types
-export type CapacitySource = 'resourceQuota' | 'deployments';
+export type CapacitySource = 'resourceQuota' | 'deployments' | 'docker';
 
 export interface MCPCapacityInfo {
 	source: CapacitySource;
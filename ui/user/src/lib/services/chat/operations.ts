@@ -2155,6 +2155,39 @@ export async function checkCompositeOAuth(
 	return Array.isArray(response) ? response : [];
 }
 
+// OAuth device authorization helpers
+export type DeviceAuthorization = {
+	clientName: string;
+	clientURI?: string;
+	resource?: string;
+	scope?: string;
+	expiresAt: string;
+	redirect_uri?: string;
+	approved?: boolean;
+	denied?: boolean;
+};
+
+export async function getDeviceAuthorization(
+	userCode: string,
+	opts?: { fetch?: Fetcher }
+): Promise<DeviceAuthorization> {
+	return (await doGet(`/oauth/device/${encodeURIComponent(userCode)}`, {
+		...opts,
+		dontLogErrors: true
+	})) as DeviceAuthorization;
+}
+
+export async function verifyDeviceAuthorization(
+	userCode: string,
+	approve: boolean
+): Promise<DeviceAuthorization> {
+	return (await doPost(
+		`/oauth/device/${encodeURIComponent(userCode)}`,
+		{ approve },
+		{ dontLogErrors: true }
+	)) as DeviceAuthorization;
+}
+
 export async function restartWorkspaceCatalogEntryServerDeployment(
 	workspaceID: string,
 	entryID: string,
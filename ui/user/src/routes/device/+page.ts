@@ -0,0 +1,14 @@
+import { redirect } from '@sveltejs/kit';
+import type { PageLoad } from './$types';
+
+export const load: PageLoad = async ({ parent, url }) => {
+	const { profile } = await parent();
+	if (profile?.unauthorized) {
+		// Log in first, then come back to approve the device.
+		throw redirect(303, `/?rd=${encodeURIComponent(url.pathname + url.search)}`);
+	}
+
+	return {
+		userCode: url.searchParams.get('user_code') ?? ''
+	};
+};